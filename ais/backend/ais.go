@@ -11,12 +11,14 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
@@ -30,8 +32,8 @@ import (
 
 // TODO:
 // - include `appliedCfgVer` in the GetInfo* response (to synchronize p._remais, etc.)
-// - periodically refresh remote Smap
-// - use m.remote[uuid].smap to load balance and retry disconnects
+//
+// See also: aisrem.go (endpoints, load balancing, failover, and periodic Smap refresh)
 
 const ua = "aisnode/backend"
 
@@ -39,11 +41,19 @@ const remAisDefunct = "defunct" // uuid configured offline
 
 type (
 	remAis struct {
-		smap *meta.Smap
-		m    *AISbp
-		url  string
-		uuid string
-		bp   api.BaseParams
+		smap      *meta.Smap
+		m         *AISbp
+		cliH      *http.Client
+		cliTLS    *http.Client
+		url       string   // most recently used (reachable) endpoint
+		uuid      string   // remote Smap.UUID
+		lastErr   string   // most recent connectivity error
+		confURLs  []string // as configured
+		eps       []*remEp // configured + discovered
+		lastOK    atomic.Int64
+		failovers atomic.Int64
+		rr        atomic.Uint32 // round-robin
+		mu        sync.RWMutex
 	}
 	AISbp struct {
 		t             core.TargetPut
@@ -51,6 +61,7 @@ type (
 		alias         cos.StrKVs         // alias => UUID
 		mu            sync.RWMutex
 		appliedCfgVer int64
+		hkReg         atomic.Bool
 		base
 	}
 )
@@ -77,12 +88,15 @@ func NewAIS(t core.TargetPut) *AISbp {
 func (r *remAis) String() string {
 	var alias string
 	for a, uuid := range r.m.alias {
-		if uuid == r.smap.UUID {
+		if uuid == r.uuid {
 			alias = a
 			break
 		}
 	}
-	return fmt.Sprintf("remote cluster (%s, %q, %q, %s)", r.url, alias, r.smap.UUID, r.smap)
+	r.mu.RLock()
+	s := fmt.Sprintf("remote cluster (%s, %q, %q, %s)", r.url, alias, r.uuid, r.smap)
+	r.mu.RUnlock()
+	return s
 }
 
 func unsetUUID(bck *cmn.Bck) { bck.Ns.UUID = "" }
@@ -173,7 +187,9 @@ func (m *AISbp) GetInfoInternal() (res meta.RemAisVec) {
 	m.mu.RLock()
 	res.A = make([]*meta.RemAis, 0, len(m.remote))
 	for uuid, remAis := range m.remote {
-		out := &meta.RemAis{UUID: uuid, URL: remAis.url}
+		out := &meta.RemAis{UUID: uuid}
+		remAis.info(out)
+		out.Smap = nil
 		for a, u := range m.alias {
 			if uuid == u {
 				out.Alias = a
@@ -187,45 +203,23 @@ func (m *AISbp) GetInfoInternal() (res meta.RemAisVec) {
 	return
 }
 
-// At the same time a cluster may have registered both types of remote AIS
-// clusters(HTTP and HTTPS). So, the method must use both kinds of clients and
-// select the correct one at the moment it sends a request.
+// connect to (refresh) all attached remote clusters and return their (current) state and health
 // See also: GetInfoInternal()
 // TODO: ditto
 func (m *AISbp) GetInfo(clusterConf cmn.BackendConfAIS) (res meta.RemAisVec) {
-	var (
-		cfg              = cmn.GCO.Get()
-		cliPlain, cliTLS = remaisClients(&cfg.Client)
-	)
+	// snapshot under lock
 	m.mu.RLock()
+	all := make([]*remAis, 0, len(m.remote))
 	res.A = make([]*meta.RemAis, 0, len(m.remote))
 	for uuid, remAis := range m.remote {
-		var (
-			out    = &meta.RemAis{UUID: uuid, URL: remAis.url}
-			client = cliPlain
-		)
-		if cos.IsHTTPS(remAis.url) {
-			client = cliTLS
-		}
+		out := &meta.RemAis{UUID: uuid}
 		for a, u := range m.alias {
 			if uuid == u {
 				out.Alias = a
 				break
 			}
 		}
-
-		// online?
-		if smap, err := api.GetClusterMap(api.BaseParams{Client: client, URL: remAis.url, UA: ua}); err == nil {
-			if smap.UUID != uuid {
-				nlog.Errorf("%s: UUID has changed %q", remAis, smap.UUID)
-				continue
-			}
-			if smap.Version < remAis.smap.Version {
-				nlog.Errorf("%s: detected older Smap %s - proceeding to override anyway", remAis, smap)
-			}
-			remAis.smap = smap
-		}
-		out.Smap = remAis.smap
+		all = append(all, remAis)
 		res.A = append(res.A, out)
 	}
 	// defunct (cluster config not updated yet locally?)
@@ -239,11 +233,15 @@ func (m *AISbp) GetInfo(clusterConf cmn.BackendConfAIS) (res meta.RemAisVec) {
 		}
 	}
 	m.mu.RUnlock()
-	return
-}
 
-func remaisClients(clientConf *cmn.ClientConf) (client, clientTLS *http.Client) {
-	return cmn.NewDefaultClients(clientConf.Timeout.D())
+	// online? (compare with housekeep)
+	for i, remAis := range all {
+		if err := remAis.refresh(); err != nil {
+			nlog.Warningln("failed to refresh", remAis.String()+":", err)
+		}
+		remAis.info(res.A[i])
+	}
+	return
 }
 
 // A list of remote AIS URLs can contains both HTTP and HTTPS links at the
@@ -254,15 +252,14 @@ func (r *remAis) init(alias string, confURLs []string, cfg *cmn.ClusterConfig) (
 	var (
 		url           string
 		remSmap, smap *meta.Smap
-		cliH, cliTLS  = remaisClients(&cfg.Client)
+		unreachable   = make(cos.StrSet, len(confURLs))
 	)
+	r.cliH, r.cliTLS = remaisClients(&cfg.Client)
+	r.confURLs = confURLs
 	for _, u := range confURLs {
-		client := cliH
-		if cos.IsHTTPS(u) {
-			client = cliTLS
-		}
-		if smap, err = api.GetClusterMap(api.BaseParams{Client: client, URL: u, UA: ua}); err != nil {
+		if smap, err = api.GetClusterMap(r.newEp(u).bp); err != nil {
 			nlog.Warningf("remote cluster failing to reach %q via %s: %v", alias, u, err)
+			unreachable.Add(strings.TrimSuffix(u, "/"))
 			continue
 		}
 		if remSmap == nil {
@@ -284,12 +281,14 @@ func (r *remAis) init(alias string, confURLs []string, cfg *cmn.ClusterConfig) (
 		return
 	}
 	r.smap, r.url = remSmap, url
-	if cos.IsHTTPS(url) {
-		r.bp = api.BaseParams{Client: cliTLS, URL: url, UA: ua}
-	} else {
-		r.bp = api.BaseParams{Client: cliH, URL: url, UA: ua}
-	}
 	r.uuid = remSmap.UUID
+	r.setEps()
+	for _, ep := range r.eps {
+		if unreachable.Contains(ep.bp.URL) {
+			ep.down.Store(true)
+		}
+	}
+	err = nil
 	return
 }
 
@@ -318,7 +317,7 @@ func (m *AISbp) add(newAis *remAis, newAlias string) (err error) {
 		if newAis.url != remAis.url {
 			nlog.Warningf("%s: different new URL %s - overriding", remAis, newAis)
 		}
-		if newAis.smap.Version < remAis.smap.Version {
+		if newAis.smap.Version < remAis.smap.Version { // (remAis: not yet in use)
 			nlog.Errorf("%s: detected older Smap %s - proceeding to override anyway", remAis, newAis)
 		}
 		tag = "updated"
@@ -343,6 +342,9 @@ func (m *AISbp) getRemAis(aliasOrUUID string) (remAis *remAis, err error) {
 	m.mu.RLock()
 	remAis, _, err = m.resolve(aliasOrUUID)
 	m.mu.RUnlock()
+	if err == nil {
+		m.regHK()
+	}
 	return
 }
 
@@ -360,6 +362,7 @@ func (m *AISbp) headRemAis(aliasOrUUID string) (remAis *remAis, alias, uuid stri
 		}
 	}
 	m.mu.RUnlock()
+	m.regHK()
 	return
 }
 
@@ -404,7 +407,11 @@ func (m *AISbp) HeadBucket(_ context.Context, remoteBck *meta.Bck) (bckProps cos
 	debug.Assert(uuid == remAis.uuid)
 	bck := remoteBck.Clone()
 	unsetUUID(&bck)
-	if p, err = api.HeadBucket(remAis.bp, bck, false /*dontAddRemote*/); err != nil {
+	err = remAis.do(true, func(bp api.BaseParams) (err error) {
+		p, err = api.HeadBucket(bp, bck, false /*dontAddRemote*/)
+		return err
+	})
+	if err != nil {
 		ecode, err = extractErrCode(err, remAis.uuid)
 		return
 	}
@@ -418,7 +425,9 @@ func (m *AISbp) HeadBucket(_ context.Context, remoteBck *meta.Bck) (bckProps cos
 	bckProps[apc.HdrBackendProvider] = apc.AIS
 	bckProps[apc.HdrRemAisUUID] = remAis.uuid
 	bckProps[apc.HdrRemAisAlias] = alias
+	remAis.mu.RLock()
 	bckProps[apc.HdrRemAisURL] = remAis.url
+	remAis.mu.RUnlock()
 
 	return
 }
@@ -443,7 +452,11 @@ func (m *AISbp) ListObjects(remoteBck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRe
 	unsetUUID(&bck)
 
 	var lstRes *cmn.LsoRes
-	if lstRes, err = api.ListObjectsPage(remAis.bp, bck, remoteMsg, api.ListArgs{}); err != nil {
+	err = remAis.do(true, func(bp api.BaseParams) (err error) {
+		lstRes, err = api.ListObjectsPage(bp, bck, remoteMsg, api.ListArgs{})
+		return err
+	})
+	if err != nil {
 		ecode, err = extractErrCode(err, remAis.uuid)
 		return
 	}
@@ -500,7 +513,10 @@ func (m *AISbp) blist(uuid string, qbck cmn.QueryBcks) (bcks cmn.Bcks, err error
 	if remAis, err = m.getRemAis(uuid); err != nil {
 		return
 	}
-	bcks, err = api.ListBuckets(remAis.bp, remoteQuery, apc.FltExists)
+	err = remAis.do(true, func(bp api.BaseParams) (err error) {
+		bcks, err = api.ListBuckets(bp, remoteQuery, apc.FltExists)
+		return err
+	})
 	if err != nil {
 		_, err = extractErrCode(err, uuid)
 		return nil, err
@@ -526,7 +542,11 @@ func (m *AISbp) HeadObj(_ context.Context, lom *core.LOM, _ *http.Request) (oa *
 		return
 	}
 	unsetUUID(&remoteBck)
-	if op, err = api.HeadObject(remAis.bp, remoteBck, lom.ObjName, apc.FltPresent, true /*silent*/); err != nil {
+	err = remAis.do(true, func(bp api.BaseParams) (err error) {
+		op, err = api.HeadObject(bp, remoteBck, lom.ObjName, apc.FltPresent, true /*silent*/)
+		return err
+	})
+	if err != nil {
 		ecode, err = extractErrCode(err, remAis.uuid)
 		return
 	}
@@ -547,7 +567,11 @@ func (m *AISbp) GetObj(_ context.Context, lom *core.LOM, owt cmn.OWT, _ *http.Re
 		return
 	}
 	unsetUUID(&remoteBck)
-	if r, size, err = api.GetObjectReader(remAis.bp, remoteBck, lom.ObjName, nil /*api.GetArgs*/); err != nil {
+	err = remAis.do(true, func(bp api.BaseParams) (err error) {
+		r, size, err = api.GetObjectReader(bp, remoteBck, lom.ObjName, nil /*api.GetArgs*/)
+		return err
+	})
+	if err != nil {
 		return extractErrCode(err, remAis.uuid)
 	}
 	params := core.AllocPutParams()
@@ -563,7 +587,7 @@ func (m *AISbp) GetObj(_ context.Context, lom *core.LOM, owt cmn.OWT, _ *http.Re
 	return extractErrCode(err, remAis.uuid)
 }
 
-func (m *AISbp) GetObjReader(_ context.Context, lom *core.LOM, offset, length int64) (res core.GetReaderResult) {
+func (m *AISbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int64) (res core.GetReaderResult) {
 	var (
 		remAis    *remAis
 		op        *cmn.ObjectProps
@@ -583,7 +607,11 @@ func (m *AISbp) GetObjReader(_ context.Context, lom *core.LOM, offset, length in
 			Query:  url.Values{apc.QparamSilent: []string{"true"}},
 		}
 	} else {
-		if op, res.Err = api.HeadObject(remAis.bp, remoteBck, lom.ObjName, apc.FltPresent, true /*silent*/); res.Err != nil {
+		res.Err = remAis.do(true, func(bp api.BaseParams) (err error) {
+			op, err = api.HeadObject(bp, remoteBck, lom.ObjName, apc.FltPresent, true /*silent*/)
+			return err
+		})
+		if res.Err != nil {
 			res.ErrCode, res.Err = extractErrCode(res.Err, remAis.uuid)
 			return
 		}
@@ -594,7 +622,19 @@ func (m *AISbp) GetObjReader(_ context.Context, lom *core.LOM, offset, length in
 		res.ExpCksum = oa.Cksum
 		lom.SetCksum(nil)
 	}
-	res.R, res.Size, res.Err = api.GetObjectReader(remAis.bp, remoteBck, lom.ObjName, args)
+	// custom request headers, if any (see ctxHeader)
+	if hdr := ctxHeader(ctx); len(hdr) > 0 {
+		if args == nil {
+			args = &api.GetArgs{Header: make(http.Header, len(hdr))}
+		}
+		for name, vals := range hdr {
+			args.Header[name] = vals
+		}
+	}
+	res.Err = remAis.do(true, func(bp api.BaseParams) (err error) {
+		res.R, res.Size, err = api.GetObjectReader(bp, remoteBck, lom.ObjName, args)
+		return err
+	})
 	res.ErrCode, res.Err = extractErrCode(res.Err, remAis.uuid)
 	return
 }
@@ -612,14 +652,19 @@ func (m *AISbp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (ecode i
 	unsetUUID(&remoteBck)
 	size := lom.Lsize(true) // _special_ as it's still a workfile at this point
 	args := api.PutArgs{
-		BaseParams: remAis.bp,
-		Bck:        remoteBck,
-		ObjName:    lom.ObjName,
-		Cksum:      lom.Checksum(),
-		Reader:     r.(cos.ReadOpenCloser),
-		Size:       uint64(size),
-	}
-	if oah, err = api.PutObject(&args); err != nil {
+		Bck:     remoteBck,
+		ObjName: lom.ObjName,
+		Cksum:   lom.Checksum(),
+		Reader:  r.(cos.ReadOpenCloser),
+		Size:    uint64(size),
+	}
+	// (not retrying - the reader may have been consumed)
+	err = remAis.do(false, func(bp api.BaseParams) (err error) {
+		args.BaseParams = bp
+		oah, err = api.PutObject(&args)
+		return err
+	})
+	if err != nil {
 		ecode, err = extractErrCode(err, remAis.uuid)
 		return
 	}
@@ -643,6 +688,8 @@ func (m *AISbp) DeleteObj(lom *core.LOM) (ecode int, err error) {
 		return
 	}
 	unsetUUID(&remoteBck)
-	err = api.DeleteObject(remAis.bp, remoteBck, lom.ObjName)
+	err = remAis.do(false, func(bp api.BaseParams) error {
+		return api.DeleteObject(bp, remoteBck, lom.ObjName)
+	})
 	return extractErrCode(err, remAis.uuid)
 }
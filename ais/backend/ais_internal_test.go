@@ -0,0 +1,100 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// remote cluster with three gateways: one configured, two discovered
+func TestRemAisFailover(t *testing.T) {
+	var (
+		smap = &meta.Smap{UUID: "remote-uuid", Version: 10, Pmap: make(meta.NodeMap, 3)}
+		srvs = make([]*httptest.Server, 3)
+	)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != apc.URLPathDae.S || r.URL.Query().Get(apc.QparamWhat) != apc.WhatSmap {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+		json.NewEncoder(w).Encode(smap)
+	}
+	for i := range srvs {
+		srvs[i] = httptest.NewServer(http.HandlerFunc(handler))
+		defer srvs[i].Close()
+		id := "p" + string(rune('1'+i))
+		smap.Pmap[id] = &meta.Snode{DaeID: id, DaeType: apc.Proxy, PubNet: meta.NetInfo{URL: srvs[i].URL}}
+	}
+
+	var (
+		m   = &AISbp{remote: make(map[string]*remAis), alias: make(cos.StrKVs)}
+		r   = &remAis{m: m}
+		cfg = &cmn.ClusterConfig{}
+	)
+	cfg.Client.Timeout = cos.Duration(time.Second)
+	if _, err := r.init("alias", []string{srvs[0].URL}, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.add(r, "alias"); err != nil {
+		t.Fatal(err)
+	}
+
+	// discovered gateways are down until probed
+	var info meta.RemAis
+	r.info(&info)
+	if len(info.URLs) != 3 || len(info.Down) != 2 || info.URLs[0] != srvs[0].URL {
+		t.Fatalf("expected 3 endpoints (2 down), got %+v", info)
+	}
+	if err := r.refresh(); err != nil {
+		t.Fatal(err)
+	}
+	info = meta.RemAis{}
+	r.info(&info)
+	if len(info.Down) != 0 || info.LastOK == 0 {
+		t.Fatalf("expected all endpoints up, got %+v", info)
+	}
+
+	// configured gateway goes away: idempotent requests fail over
+	srvs[0].Close()
+	for range 6 {
+		err := r.do(true, func(bp api.BaseParams) error {
+			_, err := api.GetClusterMap(bp)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	info = meta.RemAis{}
+	r.info(&info)
+	if info.Failovers != 1 || len(info.Down) != 1 || info.Down[0] != srvs[0].URL || info.LastErr == "" {
+		t.Fatalf("expected one failover and %s down, got %+v", srvs[0].URL, info)
+	}
+
+	// non-idempotent: no retries
+	srvs[1].Close()
+	srvs[2].Close()
+	err := r.do(false, func(bp api.BaseParams) error {
+		_, err := api.GetClusterMap(bp)
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if r.failovers.Load() != 1 {
+		t.Fatalf("expected no (additional) failovers, got %d total", r.failovers.Load())
+	}
+}
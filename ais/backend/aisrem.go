@@ -0,0 +1,245 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// remote AIS cluster endpoints:
+// - configured URLs (first), followed by public URLs of the remote gateways (as per remote Smap)
+// - round-robin across reachable endpoints
+// - connectivity error => mark the endpoint down and, if the operation is idempotent, fail over to the next one
+// - periodically refresh remote Smap, rediscover endpoints, and probe those that are down
+
+const (
+	remAisRefreshIval = time.Minute
+	remAisMaxRetries  = 3
+)
+
+type remEp struct {
+	bp   api.BaseParams
+	down atomic.Bool
+}
+
+func remaisClients(clientConf *cmn.ClientConf) (client, clientTLS *http.Client) {
+	return cmn.NewDefaultClients(clientConf.Timeout.D())
+}
+
+func remUnreachable(err error) bool {
+	herr := cmn.Err2HTTPErr(err)
+	if herr == nil {
+		return cos.IsUnreachable(err, 0) || cos.IsRetriableConnErr(err)
+	}
+	switch herr.TypeCode {
+	case "OpError", "DNSError": // no response (api wraps transport errors)
+		return true
+	}
+	return herr.Status == http.StatusBadGateway || herr.Status == http.StatusServiceUnavailable ||
+		herr.Status == http.StatusGatewayTimeout
+}
+
+////////////
+// remAis //
+////////////
+
+func (r *remAis) newEp(u string) *remEp {
+	client := r.cliH
+	if cos.IsHTTPS(u) {
+		client = r.cliTLS
+	}
+	return &remEp{bp: api.BaseParams{Client: client, URL: u, UA: ua}}
+}
+
+// (re)build endpoints while preserving the state of existing ones
+// is called under lock
+func (r *remAis) setEps() {
+	var (
+		eps        = make([]*remEp, 0, len(r.confURLs)+len(r.smap.Pmap))
+		seen       = make(cos.StrSet, cap(eps))
+		discovered = make([]string, 0, len(r.smap.Pmap))
+	)
+	add := func(u string, down bool) {
+		u = strings.TrimSuffix(u, "/")
+		if u == "" || seen.Contains(u) {
+			return
+		}
+		seen.Add(u)
+		for _, ep := range r.eps {
+			if ep.bp.URL == u {
+				eps = append(eps, ep)
+				return
+			}
+		}
+		ep := r.newEp(u)
+		ep.down.Store(down)
+		eps = append(eps, ep)
+	}
+	for _, u := range r.confURLs {
+		add(u, false)
+	}
+	for _, psi := range r.smap.Pmap {
+		if !psi.InMaintOrDecomm() {
+			discovered = append(discovered, psi.URL(cmn.NetPublic))
+		}
+	}
+	sort.Strings(discovered)
+	for _, u := range discovered {
+		add(u, true /*until probed*/)
+	}
+	r.eps = eps
+}
+
+// round-robin across reachable endpoints (and any endpoint when all are down)
+func (r *remAis) nextEp() *remEp {
+	r.mu.RLock()
+	eps := r.eps
+	r.mu.RUnlock()
+	n := uint32(len(eps))
+	i := r.rr.Inc()
+	for j := range n {
+		if ep := eps[(i+j)%n]; !ep.down.Load() {
+			return ep
+		}
+	}
+	return eps[i%n]
+}
+
+// call remote cluster; on connectivity error mark the endpoint down
+// and, if the operation is idempotent, retry via other endpoint(s)
+func (r *remAis) do(idempotent bool, f func(bp api.BaseParams) error) error {
+	ep := r.nextEp()
+	for i := 0; ; i++ {
+		err := f(ep.bp)
+		if err == nil || !remUnreachable(err) {
+			if err == nil || cmn.Err2HTTPErr(err) != nil {
+				r.ok(ep) // responded
+			}
+			return err
+		}
+		r.fail(ep, err)
+		if !idempotent || i >= remAisMaxRetries {
+			return err
+		}
+		next := r.nextEp()
+		if next == ep {
+			return err
+		}
+		r.failovers.Inc()
+		nlog.Warningln(r.String()+": failing over from", ep.bp.URL, "to", next.bp.URL, "err:", err)
+		ep = next
+	}
+}
+
+func (r *remAis) ok(ep *remEp) {
+	ep.down.Store(false)
+	r.lastOK.Store(time.Now().UnixNano())
+	r.mu.Lock()
+	r.url = ep.bp.URL
+	r.mu.Unlock()
+}
+
+func (r *remAis) fail(ep *remEp, err error) {
+	ep.down.Store(true)
+	r.mu.Lock()
+	r.lastErr = ep.bp.URL + ": " + err.Error()
+	r.mu.Unlock()
+}
+
+// refresh remote Smap, rediscover endpoints, and probe those that are down
+func (r *remAis) refresh() error {
+	var smap *meta.Smap
+	err := r.do(true, func(bp api.BaseParams) (err error) {
+		smap, err = api.GetClusterMap(bp)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if smap.UUID != r.uuid {
+		err = fmt.Errorf("%s: UUID has changed %q", r, smap.UUID)
+		r.mu.Lock()
+		r.lastErr = err.Error()
+		r.mu.Unlock()
+		return err
+	}
+	r.mu.Lock()
+	if smap.Version < r.smap.Version {
+		nlog.Errorf("remote cluster %q: detected older Smap %s (have %s) - proceeding to override anyway",
+			r.uuid, smap, r.smap)
+	}
+	r.smap = smap
+	r.setEps()
+	eps := r.eps
+	r.mu.Unlock()
+
+	for _, ep := range eps {
+		if !ep.down.Load() {
+			continue
+		}
+		if smap, err := api.GetClusterMap(ep.bp); err == nil && smap.UUID == r.uuid {
+			ep.down.Store(false)
+		}
+	}
+	return nil
+}
+
+// fill in current state and health
+func (r *remAis) info(out *meta.RemAis) {
+	r.mu.RLock()
+	out.URL = r.url
+	out.Smap = r.smap
+	out.LastErr = r.lastErr
+	out.URLs = make([]string, 0, len(r.eps))
+	for _, ep := range r.eps {
+		out.URLs = append(out.URLs, ep.bp.URL)
+		if ep.down.Load() {
+			out.Down = append(out.Down, ep.bp.URL)
+		}
+	}
+	r.mu.RUnlock()
+	out.LastOK = r.lastOK.Load()
+	out.Failovers = r.failovers.Load()
+}
+
+///////////
+// AISbp //
+///////////
+
+// housekeeping: refresh all attached
+func (m *AISbp) housekeep() time.Duration {
+	m.mu.RLock()
+	all := make([]*remAis, 0, len(m.remote))
+	for _, remAis := range m.remote {
+		all = append(all, remAis)
+	}
+	m.mu.RUnlock()
+	for _, remAis := range all {
+		if err := remAis.refresh(); err != nil {
+			nlog.Warningln("failed to refresh", remAis.String()+":", err)
+		}
+	}
+	return remAisRefreshIval
+}
+
+// (upon first use)
+func (m *AISbp) regHK() {
+	if m.hkReg.CAS(false, true) {
+		hk.Reg(apc.AIS+"-remote"+hk.NameSuffix, m.housekeep, remAisRefreshIval)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	ratomic "sync/atomic"
 	"time"
 
 	aiss3 "github.com/NVIDIA/aistore/ais/s3"
@@ -29,6 +30,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/OneOfOne/xxhash"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -36,6 +38,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
 type (
@@ -46,17 +49,30 @@ type (
 	}
 	sessConf struct {
 		bck    *cmn.Bck
+		ep     *cmn.S3Endpoint // named endpoint (if any)
+		epname string
 		region string
+		digest uint64 // named endpoint's configuration digest
+	}
+
+	// named endpoints and their digests - computed once per config version
+	epCache struct {
+		config  *cmn.Config
+		awsConf *cmn.BackendConfAWS
+		digests map[string]uint64
 	}
 )
 
 var (
 	// map[string]*s3.Client, with one s3.Client a.k.a. "svc"
-	// per (profile, region, endpoint) triplet
+	// per (profile, region, endpoint) triplet, or else
+	// per (named endpoint, region) - see cmn.BackendConfAWS
 	clients sync.Map
 
 	s3Endpoint string
 	awsProfile string
+
+	epc ratomic.Pointer[epCache]
 )
 
 // interface guard
@@ -115,6 +131,7 @@ func (*s3bp) HeadBucket(_ context.Context, bck *meta.Bck) (bckProps cos.StrKVs,
 	if bck.Props != nil {
 		bckProps[apc.HdrS3Endpoint] = bck.Props.Extra.AWS.Endpoint
 	}
+	bckProps[apc.HdrS3EpName] = sessConf.epname
 	versioned, errV := getBucketVersioning(svc, cloudBck)
 	if errV != nil {
 		ecode, err = awsErrorToAISError(errV, cloudBck, "")
@@ -141,7 +158,6 @@ func (s3bp *s3bp) GetBucketInv(bck *meta.Bck, ctx *core.LsoInvCtx) (int, error)
 		return 0, err
 	}
 
-	// one bucket, one inventory, one statically defined name
 	prefix, objName := aiss3.InvPrefObjname(bck.Bucket(), ctx.Name, ctx.ID)
 	lom := core.AllocLOM(objName)
 	if err = lom.InitBck(bck.Bucket()); err != nil {
@@ -154,14 +170,14 @@ func (s3bp *s3bp) GetBucketInv(bck *meta.Bck, ctx *core.LsoInvCtx) (int, error)
 		return 0, err
 	}
 
-	lsV2resp, csv, manifest, ecode, err := s3bp.initInventory(cloudBck, svc, ctx, prefix)
+	inv, ecode, err := s3bp.initInventory(cloudBck, svc, ctx, prefix)
 	if err != nil {
 		lom.Unlock(false)
 		core.FreeLOM(lom)
 		return ecode, err
 	}
 	ctx.Lom = lom
-	mtime, usable := checkInvLom(csv.mtime, ctx)
+	mtime, usable := checkInvLom(inv.manifest.mtime, ctx)
 	if usable {
 		if ctx.Lmfh, err = ctx.Lom.Open(); err != nil {
 			lom.Unlock(false)
@@ -217,9 +233,9 @@ func (s3bp *s3bp) GetBucketInv(bck *meta.Bck, ctx *core.LsoInvCtx) (int, error)
 
 	// still under wlock: cleanup old, read and write as ctx.Lom
 
-	cleanupOldInventory(cloudBck, svc, lsV2resp, csv, manifest)
+	cleanupOldInventory(cloudBck, svc, inv)
 
-	err = s3bp.getInventory(cloudBck, ctx, csv)
+	err = s3bp.getInventory(cloudBck, ctx, inv)
 
 	// wlock --> rlock
 
@@ -434,7 +450,7 @@ func (*s3bp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, ecode int, _ error) {
 // HEAD OBJECT
 //
 
-func (*s3bp) HeadObj(_ context.Context, lom *core.LOM, oreq *http.Request) (oa *cmn.ObjAttrs, ecode int, err error) {
+func (*s3bp) HeadObj(ctx context.Context, lom *core.LOM, oreq *http.Request) (oa *cmn.ObjAttrs, ecode int, err error) {
 	var (
 		svc        *s3.Client
 		headOutput *s3.HeadObjectOutput
@@ -511,7 +527,7 @@ func (*s3bp) HeadObj(_ context.Context, lom *core.LOM, oreq *http.Request) (oa *
 
 exit:
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infoln("[head_object]", cloudBck.Cname(lom.ObjName))
+		nlog.InfolnWith(logFields(ctx), "[head_object]", cloudBck.Cname(lom.ObjName))
 	}
 	return
 }
@@ -551,11 +567,27 @@ finalize:
 	err := s3bp.t.PutObject(lom, params)
 	core.FreePutParams(params)
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infoln("[get_object]", lom.String(), err)
+		nlog.InfolnWith(logFields(ctx), "[get_object]", lom.String(), err)
 	}
 	return 0, err
 }
 
+// custom request headers, if any (see ctxHeader);
+// NOTE: request signing (SigV4) takes precedence over the caller's "Authorization"
+func s3HdrOpts(ctx context.Context) []func(*s3.Options) {
+	hdr := ctxHeader(ctx)
+	if len(hdr) == 0 {
+		return nil
+	}
+	return []func(*s3.Options){func(o *s3.Options) {
+		for name, vals := range hdr {
+			for _, val := range vals {
+				o.APIOptions = append(o.APIOptions, smithyhttp.AddHeaderValue(name, val))
+			}
+		}
+	}}
+}
+
 func (*s3bp) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int64) (res core.GetReaderResult) {
 	var (
 		obj      *s3.GetObjectOutput
@@ -574,7 +606,7 @@ func (*s3bp) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int
 	if length > 0 {
 		rng := cmn.MakeRangeHdr(offset, length)
 		input.Range = aws.String(rng)
-		obj, err = svc.GetObject(ctx, &input)
+		obj, err = svc.GetObject(ctx, &input, s3HdrOpts(ctx)...)
 		if err != nil {
 			res.ErrCode, res.Err = awsErrorToAISError(err, cloudBck, lom.ObjName)
 			if res.ErrCode == http.StatusRequestedRangeNotSatisfiable {
@@ -583,7 +615,7 @@ func (*s3bp) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int
 			return res
 		}
 	} else {
-		obj, err = svc.GetObject(ctx, &input)
+		obj, err = svc.GetObject(ctx, &input, s3HdrOpts(ctx)...)
 		if err != nil {
 			res.ErrCode, res.Err = awsErrorToAISError(err, cloudBck, lom.ObjName)
 			return res
@@ -692,7 +724,7 @@ exit:
 		}
 	}
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infoln("[put_object]", lom.String())
+		nlog.InfolnWith(logFields(reqCtx(oreq)), "[put_object]", lom.String())
 	}
 	cos.Close(r)
 	return
@@ -721,7 +753,7 @@ func (*s3bp) DeleteObj(lom *core.LOM) (ecode int, err error) {
 		return
 	}
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infoln("[delete_object]", lom.String())
+		nlog.InfolnWith(logFields(nil), "[delete_object]", lom.String())
 	}
 	return
 }
@@ -732,6 +764,7 @@ func (*s3bp) DeleteObj(lom *core.LOM) (ecode int, err error) {
 
 // newClient creates new S3 client on a per-region basis or, more precisely,
 // per (region, endpoint) pair - and note that s3 endpoint is per-bucket configurable.
+// Named endpoints (cmn.BackendConfAWS), if configured, take precedence.
 // If the client already exists newClient simply returns it.
 // From S3 SDK:
 // "S3 methods are safe to use concurrently. It is not safe to modify mutate
@@ -741,6 +774,12 @@ func (sessConf *sessConf) s3client(tag string) (*s3.Client, error) {
 		endpoint = s3Endpoint
 		profile  = awsProfile
 	)
+	if err := sessConf.resolve(); err != nil {
+		return nil, err
+	}
+	if sessConf.ep != nil {
+		return sessConf.epclient(tag)
+	}
 	if sessConf.bck != nil && sessConf.bck.Props != nil {
 		if sessConf.region == "" {
 			sessConf.region = sessConf.bck.Props.Extra.AWS.CloudRegion
@@ -800,6 +839,122 @@ func (sessConf *sessConf) options(options *s3.Options) {
 			options.UsePathStyle = cmn.Rom.Features().IsSet(feat.S3UsePathStyle)
 		}
 	}
+	if ep := sessConf.ep; ep != nil {
+		switch ep.Addressing {
+		case cmn.S3AddressingPath:
+			options.UsePathStyle = true
+		case cmn.S3AddressingVirtual:
+			options.UsePathStyle = false
+		}
+	}
+}
+
+// (config updates are copy-on-write - hence, comparing pointers)
+func loadEpCache() *epCache {
+	config := cmn.GCO.Get()
+	if c := epc.Load(); c != nil && c.config == config {
+		return c
+	}
+	c := &epCache{config: config, awsConf: config.Backend.S3Conf()}
+	if c.awsConf != nil {
+		c.digests = make(map[string]uint64, len(c.awsConf.Endpoints))
+		for name, ep := range c.awsConf.Endpoints {
+			b := cos.MustMarshal(ep)
+			b = append(b, cos.MustMarshal(&config.Client)...) // (see loadEpConfig)
+			c.digests[name] = xxhash.Checksum64S(b, cos.MLCG32)
+		}
+	}
+	epc.Store(c) // (benign race)
+	c.evict()
+	return c
+}
+
+// remove cached clients of the named endpoints that were either deleted or updated
+func (c *epCache) evict() {
+	clients.Range(func(k, v any) bool {
+		cid := k.(string)
+		if cid[0] != '@' || c.valid(cid) {
+			return true
+		}
+		clients.Delete(cid)
+		if svc, ok := v.(*s3.Client); ok {
+			if hc, ok := svc.Options().HTTPClient.(*http.Client); ok {
+				hc.CloseIdleConnections()
+			}
+		}
+		if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+			nlog.Infoln("evict s3client for named endpoint:", cid)
+		}
+		return true
+	})
+}
+
+func (c *epCache) valid(cid string) bool {
+	for name, digest := range c.digests {
+		if strings.HasPrefix(cid, "@"+name+"#") && strings.HasSuffix(cid, "#"+strconv.FormatUint(digest, 36)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve named endpoint, if any
+func (sessConf *sessConf) resolve() error {
+	if sessConf.bck == nil || sessConf.ep != nil {
+		return nil
+	}
+	c := loadEpCache()
+	awsConf := c.awsConf
+	if awsConf == nil || len(awsConf.Endpoints) == 0 {
+		return nil
+	}
+	var epname string
+	if sessConf.bck.Props != nil {
+		epname = sessConf.bck.Props.Extra.AWS.EndpointName
+	}
+	name, ep, err := awsConf.Resolve(sessConf.bck.Name, epname)
+	if err != nil {
+		return err
+	}
+	sessConf.ep, sessConf.epname, sessConf.digest = ep, name, c.digests[name]
+	return nil
+}
+
+// s3 client for a named endpoint: same caching rules (see above) except that
+// client ID includes endpoint's configuration digest (in re: runtime updates)
+func (sessConf *sessConf) epclient(tag string) (*s3.Client, error) {
+	ep := sessConf.ep
+	if sessConf.region == "" {
+		if sessConf.bck.Props != nil {
+			sessConf.region = sessConf.bck.Props.Extra.AWS.CloudRegion
+		}
+		if sessConf.region == "" {
+			sessConf.region = ep.Region
+		}
+	}
+	cid := "@" + sessConf.epname + "#" + sessConf.region + "#" + strconv.FormatUint(sessConf.digest, 36)
+	if asvc, loaded := clients.Load(cid); loaded {
+		svc, ok := asvc.(*s3.Client)
+		debug.Assert(ok)
+		return svc, nil
+	}
+
+	cfg, err := loadEpConfig(ep, &cmn.GCO.Get().Client)
+	if err != nil {
+		return nil, fmt.Errorf("s3 endpoint %q: %w", sessConf.epname, err)
+	}
+	svc := s3.NewFromConfig(cfg, sessConf.options)
+	if sessConf.region == "" && tag != gotBucketLocation {
+		if tag != "" && cmn.Rom.FastV(4, cos.SmoduleBackend) {
+			nlog.Warningln(tag, "no region for bucket", sessConf.bck.Cname(""), "endpoint", sessConf.epname)
+		}
+		return svc, nil
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("add s3client for named endpoint:", cid)
+	}
+	clients.Store(cid, svc)
+	return svc, nil
 }
 
 func _cid(profile, region, endpoint string) string {
@@ -835,6 +990,43 @@ func loadConfig(endpoint, profile string) (aws.Config, error) {
 	return cfg, nil
 }
 
+// NOTE: client timeout is part of the endpoint's digest (see loadEpCache)
+func loadEpConfig(ep *cmn.S3Endpoint, clientConf *cmn.ClientConf) (aws.Config, error) {
+	var (
+		cargs  = cmn.TransportArgs{Timeout: clientConf.TimeoutLong.D()} // (long: reading and writing object data)
+		client *http.Client
+	)
+	if ep.CABundle != "" || ep.SkipVerify {
+		tlsConf, err := cmn.NewTLS(cmn.TLSArgs{ClientCA: ep.CABundle, SkipVerify: ep.SkipVerify})
+		if err != nil {
+			return aws.Config{}, err
+		}
+		transport := cmn.NewTransport(cargs)
+		transport.TLSClientConfig = tlsConf
+		client = &http.Client{Transport: transport, Timeout: cargs.Timeout}
+	} else {
+		client = cmn.NewClient(cargs)
+	}
+	opts := []func(*config.LoadOptions) error{
+		config.WithHTTPClient(client),
+		config.WithSharedConfigProfile(ep.Profile),
+	}
+	if ep.AccessKey != "" {
+		creds := aws.Credentials{AccessKeyID: ep.AccessKey, SecretAccessKey: ep.SecretKey, Source: "ais-config"}
+		opts = append(opts, config.WithCredentialsProvider(aws.CredentialsProviderFunc(
+			func(context.Context) (aws.Credentials, error) { return creds, nil },
+		)))
+	}
+	cfg, err := config.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return cfg, err
+	}
+	if ep.URL != "" {
+		cfg.BaseEndpoint = aws.String(ep.URL)
+	}
+	return cfg, nil
+}
+
 func getBucketVersioning(svc *s3.Client, bck *cmn.Bck) (enabled bool, errV error) {
 	input := &s3.GetBucketVersioningInput{Bucket: aws.String(bck.Name)}
 	result, err := svc.GetBucketVersioning(context.Background(), input)
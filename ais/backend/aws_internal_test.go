@@ -0,0 +1,153 @@
+//go:build aws
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func setS3Endpoints(eps map[string]*cmn.S3Endpoint) {
+	config := cmn.GCO.BeginUpdate()
+	config.Backend.Conf = map[string]any{apc.AWS: cmn.BackendConfAWS{Endpoints: eps}}
+	cmn.GCO.CommitUpdate(config)
+}
+
+func TestS3EndpointResolve(t *testing.T) {
+	setS3Endpoints(map[string]*cmn.S3Endpoint{
+		"minio": {URL: "http://minio:9000", Region: "eu-1", Addressing: cmn.S3AddressingPath, Buckets: []string{"abc"}},
+		"ceph":  {URL: "http://ceph:7480", Addressing: cmn.S3AddressingVirtual},
+	})
+	defer setS3Endpoints(nil)
+
+	// listed bucket
+	bck := &cmn.Bck{Name: "abc", Provider: apc.AWS, Props: &cmn.Bprops{}}
+	sc := &sessConf{bck: bck}
+	tassert.CheckFatal(t, sc.resolve())
+	tassert.Fatalf(t, sc.epname == "minio" && sc.ep != nil && sc.digest != 0, "expected minio, got %q", sc.epname)
+	digest := sc.digest
+
+	// named via bucket props
+	bck = &cmn.Bck{Name: "abc", Provider: apc.AWS, Props: &cmn.Bprops{}}
+	bck.Props.Extra.AWS.EndpointName = "ceph"
+	sc = &sessConf{bck: bck}
+	tassert.CheckFatal(t, sc.resolve())
+	tassert.Fatalf(t, sc.epname == "ceph" && sc.digest != digest, "expected ceph, got %q", sc.epname)
+
+	// neither
+	sc = &sessConf{bck: &cmn.Bck{Name: "xyz", Provider: apc.AWS}}
+	tassert.CheckFatal(t, sc.resolve())
+	tassert.Errorf(t, sc.ep == nil && sc.epname == "", "expected no endpoint, got %q", sc.epname)
+
+	// digests: computed once per config version
+	c := loadEpCache()
+	tassert.Errorf(t, loadEpCache() == c, "expected cached endpoints")
+	setS3Endpoints(map[string]*cmn.S3Endpoint{
+		"minio": {URL: "http://minio:9001", Buckets: []string{"abc"}},
+	})
+	tassert.Errorf(t, loadEpCache() != c, "expected endpoints to be reloaded upon config update")
+	sc = &sessConf{bck: &cmn.Bck{Name: "abc", Provider: apc.AWS}}
+	tassert.CheckFatal(t, sc.resolve())
+	tassert.Errorf(t, sc.epname == "minio" && sc.digest != digest, "expected updated minio digest")
+}
+
+func TestS3EndpointOptions(t *testing.T) {
+	bck := &cmn.Bck{Name: "abc", Provider: apc.AWS, Props: &cmn.Bprops{Features: feat.S3UsePathStyle}}
+
+	// bucket's feature flag
+	sc := &sessConf{bck: bck, region: "us-east-2"}
+	opts := &s3.Options{}
+	sc.options(opts)
+	tassert.Errorf(t, opts.UsePathStyle && opts.Region == "us-east-2", "unexpected %v, %q", opts.UsePathStyle, opts.Region)
+
+	// endpoint's addressing overrides the feature flag
+	sc = &sessConf{bck: bck, ep: &cmn.S3Endpoint{Addressing: cmn.S3AddressingVirtual}}
+	opts = &s3.Options{Region: "us-west-1"}
+	sc.options(opts)
+	tassert.Errorf(t, !opts.UsePathStyle, "expected virtual-hosted style")
+	tassert.Errorf(t, sc.region == "us-west-1", "expected region from options, got %q", sc.region)
+
+	sc = &sessConf{bck: &cmn.Bck{Name: "abc", Props: &cmn.Bprops{}}, ep: &cmn.S3Endpoint{Addressing: cmn.S3AddressingPath}}
+	opts = &s3.Options{}
+	sc.options(opts)
+	tassert.Errorf(t, opts.UsePathStyle, "expected path style")
+}
+
+func TestS3EndpointClients(t *testing.T) {
+	t.Setenv("AWS_CA_BUNDLE", "") // (the SDK won't add custom root CAs to a non-buildable http client)
+	config := cmn.GCO.BeginUpdate()
+	config.Client.TimeoutLong = cos.Duration(time.Minute)
+	cmn.GCO.CommitUpdate(config)
+	setS3Endpoints(map[string]*cmn.S3Endpoint{
+		"minio": {URL: "https://minio:9000", Region: "eu-1", SkipVerify: true, Buckets: []string{"abc"}},
+		"ceph":  {URL: "http://ceph:7480", Region: "eu-2", Buckets: []string{"xyz"}},
+	})
+	defer setS3Endpoints(nil)
+
+	epclient := func(bckName string) (*s3.Client, string) {
+		sc := &sessConf{bck: &cmn.Bck{Name: bckName, Provider: apc.AWS, Props: &cmn.Bprops{}}}
+		tassert.CheckFatal(t, sc.resolve())
+		svc, err := sc.epclient("")
+		tassert.CheckFatal(t, err)
+		return svc, "@" + sc.epname + "#" + sc.region + "#"
+	}
+	cached := func(prefix string) (n int) {
+		clients.Range(func(k, _ any) bool {
+			if strings.HasPrefix(k.(string), prefix) {
+				n++
+			}
+			return true
+		})
+		return n
+	}
+
+	// transport: TLS (per endpoint), with configured client timeout
+	svc, minio := epclient("abc")
+	hc, ok := svc.Options().HTTPClient.(*http.Client)
+	tassert.Fatalf(t, ok, "unexpected http client %T", svc.Options().HTTPClient)
+	tassert.Errorf(t, hc.Timeout == time.Minute, "expected timeout %v, got %v", time.Minute, hc.Timeout)
+	transport, ok := hc.Transport.(*http.Transport)
+	tassert.Fatalf(t, ok, "unexpected transport %T", hc.Transport)
+	tassert.Errorf(t, transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify, "expected skip-verify TLS")
+	svc2, _ := epclient("abc")
+	tassert.Errorf(t, svc2 == svc, "expected cached client")
+	_, ceph := epclient("xyz")
+	tassert.Fatalf(t, cached(minio) == 1 && cached(ceph) == 1, "expected cached clients")
+
+	// update one endpoint: only its client gets evicted
+	setS3Endpoints(map[string]*cmn.S3Endpoint{
+		"minio": {URL: "https://minio:9001", Region: "eu-1", SkipVerify: true, Buckets: []string{"abc"}},
+		"ceph":  {URL: "http://ceph:7480", Region: "eu-2", Buckets: []string{"xyz"}},
+	})
+	loadEpCache()
+	tassert.Errorf(t, cached(minio) == 0, "expected updated endpoint's client to be evicted")
+	tassert.Errorf(t, cached(ceph) == 1, "expected unchanged endpoint's client to stay cached")
+	svc2, _ = epclient("abc")
+	tassert.Errorf(t, svc2 != svc, "expected new client")
+
+	// client timeout is part of the digest; removed endpoints get evicted as well
+	config = cmn.GCO.BeginUpdate()
+	config.Client.TimeoutLong = cos.Duration(2 * time.Minute)
+	cmn.GCO.CommitUpdate(config)
+	loadEpCache()
+	tassert.Errorf(t, cached(minio) == 0 && cached(ceph) == 0, "expected all clients to be evicted")
+	svc, _ = epclient("abc")
+	hc = svc.Options().HTTPClient.(*http.Client)
+	tassert.Errorf(t, hc.Timeout == 2*time.Minute, "expected timeout %v, got %v", 2*time.Minute, hc.Timeout)
+	setS3Endpoints(nil)
+	loadEpCache()
+	tassert.Errorf(t, cached("@") == 0, "expected no named-endpoint clients")
+}
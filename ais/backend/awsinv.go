@@ -14,7 +14,8 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -33,11 +34,13 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
-// NOTE currently implemented main assumption/requirement:
-// - one bucket, one inventory (for this same bucket), and one statically defined .csv
-
-// TODO:
-// - LsoMsg.StartAfter (a.k.a. ListObjectsV2Input.StartAfter); see also "expecting to resume" below
+// S3 bucket inventory:
+// - <prefix>/<inventory-ID>/<timestamp>/manifest.json (JSON) lists inventory data files
+//   in one of the supported formats: CSV (gzipped), ORC, or Parquet (see ais/s3/inventory)
+// - the latest manifest gets selected; when the bucket has multiple inventory configurations
+//   the caller must specify one (`apc.HdrInvID`)
+// - all data files listed in the manifest are then downloaded, converted (if need be),
+//   and stored locally as a single .csv
 
 // constant and tunables (see also: ais/s3/inventory)
 const numBlobWorkers = 10
@@ -52,104 +55,125 @@ const (
 
 	invMaxPage = 8 * apc.MaxPageSizeAWS
 	invPageSGL = max(invMaxPage*invMaxLine, 2*cos.MiB)
-)
-
-// NOTE: hardcoding two groups of constants - cannot find any of them in https://github.com/aws/aws-sdk-go-v2
-// Generally, instead of reading inventory manifest line by line (and worrying about duplicated constants)
-// it'd be much nicer to have an official JSON.
 
-const (
-	invManifest = "manifest.json"
-	invSchema   = "fileSchema" // e.g. "fileSchema" : "Bucket, Key, Size, ETag"
-	invKey      = "\"key\""
+	invMaxManifest = 64 * cos.MiB // sanity
 )
 
-// canonical schema
-const (
-	invSchemaBucket = "Bucket" // must be the first field, always present
-	invBucketPos    = 0
-	invSchemaKey    = "Key" // must be the second mandatory field
-	invKeyPos       = 1
+type (
+	invT struct {
+		oname string
+		mtime time.Time
+		size  int64
+	}
+	// selected inventory
+	invInfo struct {
+		mf       *aiss3.InvManifest
+		objs     []types.Object // all objects under the selected inventory's prefix (see cleanup)
+		manifest invT
+	}
 )
 
-type invT struct {
-	oname string
-	mtime time.Time
-	size  int64
-}
-
-// list inventories, read and parse manifest, return schema and unique oname
-func (s3bp *s3bp) initInventory(cloudBck *cmn.Bck, svc *s3.Client, ctx *core.LsoInvCtx, prefix string) (*s3.ListObjectsV2Output,
-	invT, invT, int, error) {
+// list inventories, select the latest manifest, read and parse it (schema => ctx)
+func (s3bp *s3bp) initInventory(cloudBck *cmn.Bck, svc *s3.Client, ctx *core.LsoInvCtx, prefix string) (*invInfo, int, error) {
 	var (
-		csv      invT
-		manifest invT
-		bn       = aws.String(cloudBck.Name)
-		params   = &s3.ListObjectsV2Input{Bucket: bn}
+		objs   []types.Object
+		latest = make(map[string]invT, 1) // by inventory ID
+		pref   = prefix + cos.PathSeparator
+		params = &s3.ListObjectsV2Input{
+			Bucket:  aws.String(cloudBck.Name),
+			Prefix:  aws.String(pref),
+			MaxKeys: aws.Int32(apc.MaxPageSizeAWS),
+		}
 	)
 
-	params.Prefix = aws.String(prefix)
-	params.MaxKeys = aws.Int32(apc.MaxPageSizeAWS) // no more than 1000 manifests
-
-	// 1. ls inventory
-	resp, err := svc.ListObjectsV2(context.Background(), params)
-	if err != nil {
-		ecode, e := awsErrorToAISError(err, cloudBck, "")
-		return nil, csv, manifest, ecode, e
-	}
-	for _, obj := range resp.Contents {
-		name := *obj.Key
-		if cos.Ext(name) == aiss3.InvSrcExt {
-			mtime := *(obj.LastModified)
-			if csv.mtime.IsZero() || mtime.After(csv.mtime) {
-				csv.mtime = mtime
-				csv.oname = name
-				csv.size = *(obj.Size)
-			}
-			continue
+	// 1. ls inventories
+	for {
+		resp, err := svc.ListObjectsV2(context.Background(), params)
+		if err != nil {
+			ecode, e := awsErrorToAISError(err, cloudBck, "")
+			return nil, ecode, e
 		}
-		if filepath.Base(name) == invManifest {
+		objs = append(objs, resp.Contents...)
+		for _, obj := range resp.Contents {
+			name := *obj.Key
+			if path.Base(name) != aiss3.InvManifestName {
+				continue
+			}
+			// when not specified: <inventory-ID>/<timestamp>/manifest.json
+			var id string
+			if ctx.ID == "" {
+				if parts := strings.Split(strings.TrimPrefix(name, pref), cos.PathSeparator); len(parts) == 3 {
+					id = parts[0]
+				}
+			}
 			mtime := *(obj.LastModified)
-			if manifest.mtime.IsZero() || mtime.After(manifest.mtime) {
-				manifest.mtime = mtime
-				manifest.oname = name
+			if m, ok := latest[id]; !ok || mtime.After(m.mtime) {
+				latest[id] = invT{oname: name, mtime: mtime, size: *(obj.Size)}
 			}
 		}
+		if resp.IsTruncated == nil || !*resp.IsTruncated || resp.NextContinuationToken == nil {
+			break
+		}
+		params.ContinuationToken = resp.NextContinuationToken
 	}
-	if csv.oname == "" {
+
+	inv := &invInfo{objs: objs}
+	switch len(latest) {
+	case 0:
 		what := prefix
 		if ctx.ID == "" {
 			what = cos.Either(ctx.Name, aiss3.InvName)
 		}
-		return nil, csv, manifest, http.StatusNotFound, cos.NewErrNotFound(cloudBck, invTag+":"+what)
-	}
-	if csv.mtime.After(manifest.mtime) {
-		a, b := cos.FormatTime(manifest.mtime, cos.StampSec), cos.FormatTime(csv.mtime, cos.StampSec)
-		nlog.Warningln("using an older manifest:", manifest.oname, a, "to parse:", csv.oname, b)
+		return nil, http.StatusNotFound, cos.NewErrNotFound(cloudBck, invTag+":"+what)
+	case 1:
+		for id, manifest := range latest {
+			inv.manifest = manifest
+			if id != "" {
+				// (only the selected inventory)
+				inv.objs = inv.objs[:0]
+				for _, obj := range objs {
+					if strings.HasPrefix(*obj.Key, pref+id+cos.PathSeparator) {
+						inv.objs = append(inv.objs, obj)
+					}
+				}
+			}
+		}
+	default:
+		ids := make([]string, 0, len(latest))
+		for id := range latest {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		err := fmt.Errorf("%s: %s has %d inventory configurations %v - use %q to specify one",
+			invTag, cloudBck.Cname(""), len(ids), ids, apc.HdrInvID)
+		return nil, http.StatusBadRequest, err
 	}
 
-	// 2. read the manifest and extract `fileSchema` --> ctx
-	schema, ecode, err := s3bp._getManifest(cloudBck, svc, manifest.oname, csv.oname)
+	// 2. read and parse the manifest (schema --> ctx)
+	mf, ecode, err := s3bp._getManifest(cloudBck, svc, inv.manifest.oname)
 	if err != nil {
-		return nil, csv, manifest, ecode, err
+		return nil, ecode, err
 	}
-
-	ctx.Schema = schema
-	return resp, csv, manifest, 0, nil
+	inv.mf = mf
+	ctx.Schema = mf.Schema
+	return inv, 0, nil
 }
 
-func cleanupOldInventory(cloudBck *cmn.Bck, svc *s3.Client, lsV2resp *s3.ListObjectsV2Output, csv, manifest invT) {
+// remove older inventories (manifests and data files) - all except the selected one
+func cleanupOldInventory(cloudBck *cmn.Bck, svc *s3.Client, inv *invInfo) {
 	var (
-		num int
-		bn  = aws.String(cloudBck.Name)
+		num  int
+		bn   = aws.String(cloudBck.Name)
+		keep = make(cos.StrSet, len(inv.mf.Files)+1)
 	)
-	for _, obj := range lsV2resp.Contents {
+	keep.Add(inv.manifest.oname)
+	for _, f := range inv.mf.Files {
+		keep.Add(f.Key)
+	}
+	for _, obj := range inv.objs {
 		name := *obj.Key
 		mtime := *(obj.LastModified)
-		if name == csv.oname || name == manifest.oname || csv.mtime.Sub(mtime) < 23*time.Hour {
-			continue
-		}
-		if _sinceAbs(csv.mtime, mtime) < 23*time.Hour {
+		if keep.Contains(name) || inv.manifest.mtime.Sub(mtime) < 23*time.Hour {
 			continue
 		}
 		if _, errN := svc.DeleteObject(context.Background(), &s3.DeleteObjectInput{Bucket: bn, Key: obj.Key}); errN != nil {
@@ -191,72 +215,41 @@ func checkInvLom(latest time.Time, ctx *core.LsoInvCtx) (time.Time, bool) {
 	return mtime, false
 }
 
-// get+unzip and write lom
-func (s3bp *s3bp) getInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, csv invT) error {
-	lom := &core.LOM{ObjName: csv.oname}
-	if err := lom.InitBck(cloudBck); err != nil {
-		return err
-	}
-	lom.SetSize(csv.size)
-
+// get all manifested data files, convert (if need be), and write lom
+func (s3bp *s3bp) getInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, inv *invInfo) error {
 	wfqn := fs.CSM.Gen(ctx.Lom, fs.WorkfileType, "")
 	wfh, err := ctx.Lom.CreateWork(wfqn)
 	if err != nil {
 		return _errInv("create-file", err)
 	}
 
-	var (
-		r = &reader{
-			workCh: make(chan *memsys.SGL, 1),
-			doneCh: make(chan *memsys.SGL, 1),
-		}
-		uzw = &unzipWriter{
-			r:   r,
-			wfh: wfh,
-		}
-		params = &core.BlobParams{
-			Lom:      lom,
-			Msg:      &apc.BlobMsg{NumWorkers: numBlobWorkers},
-			WriteSGL: uzw.writeSGL,
+	for _, file := range inv.mf.Files {
+		if err = s3bp.getInvFile(cloudBck, ctx, inv.mf, file, wfh); err != nil {
+			break
 		}
-		xblob core.Xact
-		gzr   *gzip.Reader
-	)
-	// run x-blob-downloader with default (num-readers, chunk-size) tunables
-	xblob, err = s3bp.t.GetColdBlob(params, lom.ObjAttrs())
+	}
+	wfh.Close()
 	if err == nil {
-		if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-			nlog.Infoln("started", xblob.String(), "->", wfqn)
+		var finfo os.FileInfo
+		if finfo, err = os.Stat(wfqn); err == nil {
+			ctx.Size = finfo.Size()
 		}
-		gzr, err = gzip.NewReader(r)
 	}
-	if err != nil {
-		wfh.Close()
-		cos.RemoveFile(wfqn)
-		return _errInv("blob-gunzip", err)
-	}
-
-	buf, slab := s3bp.mm.AllocSize(memsys.DefaultBuf2Size)
-	ctx.Size, err = cos.CopyBuffer(uzw, gzr, buf)
-
-	slab.Free(buf)
-	wfh.Close()
-	gzr.Close()
 
 	// finalize (NOTE a lighter version of FinalizeObj - no redundancy, no locks)
 	if err == nil {
-		lom := ctx.Lom
+		lom, mtime := ctx.Lom, inv.manifest.mtime
 		if err = lom.RenameFinalize(wfqn); err == nil {
-			if err = os.Chtimes(lom.FQN, csv.mtime, csv.mtime); err == nil {
-				nlog.Infoln("new", invTag+":", lom.Cname(), ctx.Schema)
+			if err = os.Chtimes(lom.FQN, mtime, mtime); err == nil {
+				nlog.Infoln("new", invTag+":", lom.Cname(), inv.mf.FileFormat, ctx.Schema)
 
 				lom.SetSize(ctx.Size)
-				lom.SetAtimeUnix(csv.mtime.UnixNano())
+				lom.SetAtimeUnix(mtime.UnixNano())
 				if errN := lom.PersistMain(); errN != nil {
 					debug.AssertNoErr(errN) // (unlikely)
 					nlog.Errorln("failed to persist", lom.Cname(), "err:", err, "- proceeding anyway...")
 				} else if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-					nlog.Infoln("done", xblob.String(), "->", lom.Cname(), ctx.Size)
+					nlog.Infoln("done", inv.manifest.oname, "->", lom.Cname(), ctx.Size)
 				}
 				return nil
 			}
@@ -267,17 +260,109 @@ func (s3bp *s3bp) getInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, csv invT)
 	if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
 		nlog.Errorf("get-inv (%v), nested fail to remove (%v)", err, nerr)
 	}
-	if abrt := xblob.AbortErr(); abrt != nil {
-		return _errInv("get-inv-abort", abrt)
+	return err
+}
+
+// get one inventory data file and append it (as csv) to the work file;
+// columnar (ORC, Parquet) formats require random access - download them first
+func (s3bp *s3bp) getInvFile(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, mf *aiss3.InvManifest, file aiss3.InvManifestFile,
+	wfh cos.LomWriter) error {
+	lom := &core.LOM{ObjName: file.Key}
+	if err := lom.InitBck(cloudBck); err != nil {
+		return err
+	}
+	lom.SetSize(file.Size)
+
+	if mf.FileFormat == aiss3.InvFormatCSV {
+		_, err := s3bp.blobGet(lom, wfh, true /*gunzip*/)
+		return err
+	}
+
+	rfqn := fs.CSM.Gen(ctx.Lom, fs.WorkfileType, "raw")
+	rfh, err := ctx.Lom.CreateWork(rfqn)
+	if err != nil {
+		return _errInv("create-file", err)
+	}
+	size, err := s3bp.blobGet(lom, rfh, false)
+	rfh.Close()
+
+	if err == nil {
+		var (
+			fh    *os.File
+			nrows int64
+		)
+		if fh, err = os.Open(rfqn); err == nil {
+			nrows, err = aiss3.InvToCSV(mf.FileFormat, fh, size, mf.Columns, wfh)
+			fh.Close()
+			if err != nil {
+				err = _errInv("convert "+lom.Cname(), err)
+			} else if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+				nlog.Infoln("converted", lom.Cname(), mf.FileFormat, "rows:", nrows)
+			}
+		}
+	}
+	if nerr := cos.RemoveFile(rfqn); nerr != nil && !os.IsNotExist(nerr) {
+		nlog.Errorln("failed to remove", rfqn, "err:", nerr)
+	}
+	return err
+}
+
+// run x-blob-downloader with default (num-readers, chunk-size) tunables
+// and write the object (optionally, gunzip-ed) => w
+func (s3bp *s3bp) blobGet(lom *core.LOM, w cos.LomWriter, gunzip bool) (int64, error) {
+	var (
+		r = &reader{
+			workCh: make(chan *memsys.SGL, 1),
+			doneCh: make(chan *memsys.SGL, 1),
+		}
+		uzw = &unzipWriter{
+			r:   r,
+			wfh: w,
+		}
+		params = &core.BlobParams{
+			Lom:      lom,
+			Msg:      &apc.BlobMsg{NumWorkers: numBlobWorkers},
+			WriteSGL: uzw.writeSGL,
+		}
+		src io.Reader = r
+	)
+	xblob, err := s3bp.t.GetColdBlob(params, lom.ObjAttrs())
+	if err != nil {
+		return 0, _errInv("blob-get", err)
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("started", xblob.String(), "for", lom.Cname())
+	}
+	if gunzip {
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return 0, _errInv("blob-gunzip", err)
+		}
+		defer gzr.Close()
+		src = gzr
+	}
+
+	buf, slab := s3bp.mm.AllocSize(memsys.DefaultBuf2Size)
+	n, err := cos.CopyBuffer(uzw, src, buf)
+	slab.Free(buf)
+
+	if err != nil {
+		if abrt := xblob.AbortErr(); abrt != nil {
+			return n, _errInv("get-inv-abort", abrt)
+		}
+		return n, _errInv("get-inv-gzr-uzw-fail", err)
 	}
-	return _errInv("get-inv-gzr-uzw-fail", err)
+	return n, nil
 }
 
 func (*s3bp) listInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, msg *apc.LsoMsg, lst *cmn.LsoRes) (err error) {
 	var (
-		custom cos.StrKVs
-		i      int64
+		custom    cos.StrKVs
+		i         int64
+		bucketPos = aiss3.InvFieldPos(ctx.Schema, aiss3.InvSchemaBucket)
+		keyPos    = aiss3.InvFieldPos(ctx.Schema, aiss3.InvSchemaKey)
 	)
+	debug.Assert(bucketPos >= 0 && keyPos >= 0, ctx.Schema)
 	msg.PageSize = calcPageSize(msg.PageSize, invMaxPage)
 	for j := len(lst.Entries); j < int(msg.PageSize); j++ {
 		lst.Entries = append(lst.Entries, &cmn.LsoEnt{})
@@ -315,9 +400,13 @@ func (*s3bp) listInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, msg *apc.LsoM
 		}
 
 		line := strings.Split(string(lbuf), ",")
-		debug.Assert(strings.Contains(line[invBucketPos], cloudBck.Name), line)
+		if len(line) != len(ctx.Schema) {
+			nlog.Errorln("Warning:", ctx.Lom.String(), "invalid line [", cos.BHead(lbuf, invMaxLine), "]")
+			continue
+		}
+		debug.Assert(strings.Contains(line[bucketPos], cloudBck.Name), line)
 
-		objName := cmn.UnquoteCEV(line[invKeyPos])
+		objName := aiss3.InvObjName(line[keyPos])
 
 		if skip {
 			skip = false
@@ -327,6 +416,11 @@ func (*s3bp) listInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, msg *apc.LsoM
 			}
 		}
 
+		// start-after
+		if msg.StartAfter != "" && objName <= msg.StartAfter {
+			continue
+		}
+
 		// prefix
 		if msg.IsFlagSet(apc.LsNoRecursion) {
 			if _, errN := cmn.HandleNoRecurs(msg.Prefix, objName); errN != nil {
@@ -342,10 +436,14 @@ func (*s3bp) listInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, msg *apc.LsoM
 		entry.Name = objName
 
 		clear(custom)
-		for i := invKeyPos + 1; i < len(ctx.Schema); i++ {
-			switch types.InventoryOptionalField(ctx.Schema[i]) {
+		for i, field := range ctx.Schema {
+			switch types.InventoryOptionalField(field) {
 			case types.InventoryOptionalFieldSize:
 				size := cmn.UnquoteCEV(line[i])
+				if size == "" {
+					entry.Size = 0
+					continue
+				}
 				entry.Size, err = strconv.ParseInt(size, 10, 64)
 				if err != nil {
 					nlog.Errorln(ctx.Lom.String(), "failed to parse size", size, err)
@@ -371,110 +469,52 @@ func (*s3bp) listInventory(cloudBck *cmn.Bck, ctx *core.LsoInvCtx, msg *apc.LsoM
 	lbuf, err = sgl.NextLine(lbuf, false /*advance roff*/)
 	if err == nil {
 		line := strings.Split(string(lbuf), ",")
-		debug.Assert(strings.Contains(line[invBucketPos], cloudBck.Name), line)
-		lst.ContinuationToken = cmn.UnquoteCEV(line[invKeyPos])
+		if keyPos < len(line) {
+			lst.ContinuationToken = aiss3.InvObjName(line[keyPos])
+		}
 	}
 	return err
 }
 
 // GET, parse, and validate inventory manifest
-// (see "hardcoding" comment above)
-// with JSON-tagged manifest structure (that'd include `json:"fileSchema"`)
-// it'd then make sense to additionally validate: format == csv and source bucket == destination bucket == this bucket
-func (s3bp *s3bp) _getManifest(cloudBck *cmn.Bck, svc *s3.Client, mname, csvname string) (schema []string, _ int, _ error) {
+func (*s3bp) _getManifest(cloudBck *cmn.Bck, svc *s3.Client, mname string) (*aiss3.InvManifest, int, error) {
 	input := s3.GetObjectInput{Bucket: aws.String(cloudBck.Name), Key: aws.String(mname)}
 	obj, err := svc.GetObject(context.Background(), &input)
 	if err != nil {
 		ecode, e := awsErrorToAISError(err, cloudBck, mname)
 		return nil, ecode, e
 	}
-
-	sgl := s3bp.mm.NewSGL(0)
-	_, err = io.Copy(sgl, obj.Body)
+	b, err := io.ReadAll(io.LimitReader(obj.Body, invMaxManifest))
 	cos.Close(obj.Body)
-
 	if err != nil {
-		sgl.Free()
 		return nil, 0, err
 	}
 
-	var (
-		fileSchema string
-		size       int64
-		lbuf       = make([]byte, invMaxLine)
-		cname      = cloudBck.Cname(mname)
-	)
-	for fileSchema == "" || size == 0 {
-		lbuf, err = sgl.NextLine(lbuf, true)
-		if err != nil {
-			if err == io.EOF {
-				err = nil
-			}
-			break
-		}
-		if len(lbuf) < len(invSchema)+10 {
-			continue
-		}
-		line := strings.Split(string(lbuf), ":")
-		if len(line) < 2 {
-			continue
-		}
-		if strings.Contains(line[0], invSchema) {
-			debug.Assert(fileSchema == "", fileSchema)
-			s := strings.TrimSpace(line[1])
-			fileSchema = cmn.UnquoteCEV(strings.TrimSuffix(s, ","))
-		} else if strings.Contains(line[0], invKey) {
-			s := strings.TrimSpace(line[1])
-			oname := cmn.UnquoteCEV(strings.TrimSuffix(s, ","))
-			if oname != csvname {
-				nlog.Warningln("manifested object", oname, "vs latest csv.gz", csvname)
-			}
-		}
+	cname := cloudBck.Cname(mname)
+	mf, err := aiss3.ParseInvManifest(b)
+	if err != nil {
+		return nil, 0, _parseErr(cname, b, err)
 	}
-
-	// parse, validate
-	if err != nil || fileSchema == "" {
-		err = _parseErr(cname, sgl, lbuf, err)
-	} else {
-		if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-			nlog.Infoln("parsed manifest", cname, fileSchema, "compressed size", size)
-		}
-		// e.g. "Bucket, Key, Size, ETag"
-		schema = strings.Split(fileSchema, ", ")
-		if len(schema) < 2 {
-			err = _parseErr(cname, sgl, lbuf, errors.New("invalid schema '"+fileSchema+"'"))
-		} else if schema[invBucketPos] != invSchemaBucket || schema[invKeyPos] != invSchemaKey {
-			err = _parseErr(cname, sgl, lbuf,
-				errors.New("unexpected schema '"+fileSchema+"': expecting Bucket followed by Key"))
-		}
+	if mf.SourceBucket != "" && mf.SourceBucket != cloudBck.Name {
+		err := fmt.Errorf("source bucket %q vs %q", mf.SourceBucket, cloudBck.Name)
+		return nil, 0, _parseErr(cname, b, err)
 	}
-
-	sgl.Free()
-	return schema, 0, err
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("parsed manifest", cname, mf.FileFormat, mf.FileSchema, "files:", len(mf.Files))
+	}
+	return mf, 0, nil
 }
 
 //
 // internal
 //
 
-func _parseErr(cname string, sgl *memsys.SGL, lbuf []byte, err error) error {
-	out := fmt.Sprintf("failed to parse %s for %q", cname, invSchema)
-	if s := _bhead(sgl, lbuf); s != "" {
+func _parseErr(cname string, b []byte, err error) error {
+	out := fmt.Sprintf("failed to parse %s", cname)
+	if s := cos.BHead(b, invMaxLine); s != "" {
 		out += ": [" + s + "]"
 	}
-	if err != nil {
-		out += ", err: " + err.Error()
-	}
-	return errors.New(out)
-}
-
-func _bhead(sgl *memsys.SGL, lbuf []byte) (s string) {
-	sgl.Rewind()
-	n, _ := sgl.Read(lbuf)
-	if n > 0 {
-		s = cos.BHead(lbuf, invMaxLine)
-	}
-	return s
+	return errors.New(out + ", err: " + err.Error())
 }
 
 func _errInv(tag string, err error) error {
@@ -6,19 +6,17 @@
  */
 package backend
 
-// TODO:
-// - check a variety of az clients instantiated below, and alternatives
-//
-// - support alternative authentication methods (currently, NewSharedKeyCredential only)
-//   ref: ./storage/azblob@v1.3.0/container/examples_test.go
-//
-// - [200224] stop using etag as obj. version - see IsImmutableStorageWithVersioningEnabled, blob.VersionID, and:
+// NOTE:
+// - credentials, in the order of precedence: connection string, shared key (account name and key), SAS token
+// - object version: blob.VersionID when blob versioning (or immutable storage with versioning) is enabled;
+//   otherwise, ETag
 //   ref: https://learn.microsoft.com/en-us/azure/storage/blobs/versioning-overview#how-blob-versioning-works
 
 import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
@@ -26,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
@@ -43,9 +42,8 @@ import (
 
 type (
 	azbp struct {
-		t     core.TargetPut
-		creds *azblob.SharedKeyCredential
-		u     string
+		t      core.TargetPut
+		client *service.Client // all container and blob clients derive from it
 		base
 	}
 )
@@ -57,6 +55,10 @@ const (
 	azAccNameEnvVar = "AZURE_STORAGE_ACCOUNT"
 	azAccKeyEnvVar  = "AZURE_STORAGE_KEY" // a.k.a. AZURE_STORAGE_PRIMARY_ACCOUNT_KEY or AZURE_STORAGE_SECONDARY_ACCOUNT_KEY
 
+	// alternative credentials
+	azConnStrEnvVar  = "AZURE_STORAGE_CONNECTION_STRING" // e.g. "UseDevelopmentStorage=true" (Azurite)
+	azSASTokenEnvVar = "AZURE_STORAGE_SAS_TOKEN"         // account or container SAS (query string, with or w/o leading '?')
+
 	// ais
 	azURLEnvVar   = "AIS_AZURE_URL"
 	azProtoEnvVar = "AIS_AZURE_PROTO"
@@ -102,25 +104,56 @@ func asEndpoint() string {
 }
 
 func NewAzure(t core.TargetPut) (core.Backend, error) {
-	blurl := asEndpoint()
-
-	// NOTE: NewSharedKeyCredential requires account name and its primary or secondary key
-	creds, err := azblob.NewSharedKeyCredential(azAccName(), azAccKey())
+	client, err := azNewClient()
 	if err != nil {
 		return nil, cmn.NewErrFailedTo(nil, azErrPrefix+": init]", "credentials", err)
 	}
-
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infoln("azure endpoint:", client.URL())
+	}
 	return &azbp{
-		t:     t,
-		creds: creds,
-		u:     blurl,
-		base:  base{apc.Azure},
+		t:      t,
+		client: client,
+		base:   base{apc.Azure},
 	}, nil
 }
 
+func azNewClient() (*service.Client, error) {
+	if connStr := os.Getenv(azConnStrEnvVar); connStr != "" {
+		return service.NewClientFromConnectionString(connStr, nil)
+	}
+	blurl := asEndpoint()
+	if key := azAccKey(); key != "" {
+		// NOTE: NewSharedKeyCredential requires account name and its primary or secondary key
+		creds, err := azblob.NewSharedKeyCredential(azAccName(), key)
+		if err != nil {
+			return nil, err
+		}
+		return service.NewClientWithSharedKeyCredential(blurl, creds, nil)
+	}
+	sas := os.Getenv(azSASTokenEnvVar)
+	if sas == "" {
+		return nil, fmt.Errorf("missing credentials: expecting %s, or %s and %s, or %s", azConnStrEnvVar,
+			azAccNameEnvVar, azAccKeyEnvVar, azSASTokenEnvVar)
+	}
+	return service.NewClientWithNoCredential(blurl+"?"+strings.TrimPrefix(sas, "?"), nil)
+}
+
+func (azbp *azbp) blobClient(cloudBck *cmn.Bck, objName string) *blockblob.Client {
+	return azbp.client.NewContainerClient(cloudBck.Name).NewBlockBlobClient(objName)
+}
+
 // (compare w/ cmn/backend)
 func azEncodeEtag(etag azcore.ETag) string { return cmn.UnquoteCEV(string(etag)) }
 
+// VersionID when versioning is enabled, ETag otherwise
+func azVersion(versionID *string, etag string) (string, bool) {
+	if versionID != nil && *versionID != "" {
+		return *versionID, true
+	}
+	return etag, false
+}
+
 func azEncodeChecksum(v []byte) string {
 	if len(v) == 0 {
 		return ""
@@ -214,17 +247,8 @@ func azureErrorToAISError(azureError error, bck *cmn.Bck, objName string) (int,
 //
 
 func (azbp *azbp) HeadBucket(ctx context.Context, bck *meta.Bck) (cos.StrKVs, int, error) {
-	var (
-		cloudBck = bck.RemoteBck()
-		cntURL   = azbp.u + "/" + cloudBck.Name
-	)
-	client, err := container.NewClientWithSharedKeyCredential(cntURL, azbp.creds, nil)
-	if err != nil {
-		status, err := azureErrorToAISError(err, cloudBck, "")
-		return nil, status, err
-	}
-	resp, err := client.GetProperties(ctx, nil)
-	if err != nil {
+	cloudBck := bck.RemoteBck()
+	if _, err := azbp.client.NewContainerClient(cloudBck.Name).GetProperties(ctx, nil); err != nil {
 		status, err := azureErrorToAISError(err, cloudBck, "")
 		return nil, status, err
 	}
@@ -232,12 +256,10 @@ func (azbp *azbp) HeadBucket(ctx context.Context, bck *meta.Bck) (cos.StrKVs, in
 	bckProps := make(cos.StrKVs, 2)
 	bckProps[apc.HdrBackendProvider] = apc.Azure
 
-	// TODO #200224
-	if true || resp.IsImmutableStorageWithVersioningEnabled != nil && *resp.IsImmutableStorageWithVersioningEnabled {
-		bckProps[apc.HdrBucketVerEnabled] = "true"
-	} else {
-		bckProps[apc.HdrBucketVerEnabled] = "false"
-	}
+	// blob versioning is an account-level (blob service) property that is not visible
+	// via container properties; either way, every blob has a version: VersionID if enabled,
+	// ETag otherwise (see azVersion)
+	bckProps[apc.HdrBucketVerEnabled] = "true"
 	return bckProps, http.StatusOK, nil
 }
 
@@ -245,7 +267,7 @@ func (azbp *azbp) HeadBucket(ctx context.Context, bck *meta.Bck) (cos.StrKVs, in
 // LIST OBJECTS
 //
 
-// TODO: support non-recursive (apc.LsNoRecursion) operation, as in:
+// non-recursive (apc.LsNoRecursion) listing uses the hierarchical API, as in:
 // $ az storage blob list -c abc --prefix sub/ --delimiter /
 // TODO: research "hierarchical namespaces"
 // See also: aws.go, gcp.go
@@ -253,25 +275,41 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
 	var (
 		cloudBck = bck.RemoteBck()
-		cntURL   = azbp.u + "/" + cloudBck.Name
+		client   = azbp.client.NewContainerClient(cloudBck.Name)
 		num      = int32(msg.PageSize)
-		opts     = container.ListBlobsFlatOptions{Prefix: apc.Ptr(msg.Prefix), MaxResults: &num}
+		marker   *string
+		blobs    []*container.BlobItem
+		next     *string
 	)
-	client, err := container.NewClientWithSharedKeyCredential(cntURL, azbp.creds, nil)
-	if err != nil {
-		return azureErrorToAISError(err, cloudBck, "")
-	}
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
 		nlog.Infof("list_objects %s", cloudBck.Name)
 	}
 	if msg.ContinuationToken != "" {
-		opts.Marker = apc.Ptr(msg.ContinuationToken)
+		marker = apc.Ptr(msg.ContinuationToken)
 	}
 
-	pager := client.NewListBlobsFlatPager(&opts)
-	resp, err := pager.NextPage(context.Background())
-	if err != nil {
-		return azureErrorToAISError(err, cloudBck, "")
+	lst.Entries = lst.Entries[:0]
+	if msg.IsFlagSet(apc.LsNoRecursion) {
+		opts := container.ListBlobsHierarchyOptions{Prefix: apc.Ptr(msg.Prefix), Marker: marker, MaxResults: &num}
+		pager := client.NewListBlobsHierarchyPager("/", &opts)
+		resp, err := pager.NextPage(context.Background())
+		if err != nil {
+			return azureErrorToAISError(err, cloudBck, "")
+		}
+		if !msg.IsFlagSet(apc.LsNoDirs) {
+			for _, prefix := range resp.Segment.BlobPrefixes {
+				lst.Entries = append(lst.Entries, &cmn.LsoEnt{Name: *prefix.Name, Flags: apc.EntryIsDir})
+			}
+		}
+		blobs, next = resp.Segment.BlobItems, resp.NextMarker
+	} else {
+		opts := container.ListBlobsFlatOptions{Prefix: apc.Ptr(msg.Prefix), Marker: marker, MaxResults: &num}
+		pager := client.NewListBlobsFlatPager(&opts)
+		resp, err := pager.NextPage(context.Background())
+		if err != nil {
+			return azureErrorToAISError(err, cloudBck, "")
+		}
+		blobs, next = resp.Segment.BlobItems, resp.NextMarker
 	}
 
 	var (
@@ -281,8 +319,7 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 	if wantCustom {
 		custom = make(cos.StrKVs, 4) // reuse
 	}
-	lst.Entries = lst.Entries[:0]
-	for _, blob := range resp.Segment.BlobItems {
+	for _, blob := range blobs {
 		en := cmn.LsoEnt{Name: *blob.Name, Size: *blob.Properties.ContentLength}
 
 		// not expecting directories
@@ -295,7 +332,7 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 
 		en.Checksum = azEncodeChecksum(blob.Properties.ContentMD5)
 		etag := azEncodeEtag(*blob.Properties.ETag)
-		en.Version = etag // (TODO a the top)
+		en.Version, _ = azVersion(blob.VersionID, etag)
 		if wantCustom {
 			clear(custom)
 			custom[cmn.ETag] = etag
@@ -313,8 +350,8 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 		lst.Entries = append(lst.Entries, &en)
 	}
 
-	if resp.NextMarker != nil {
-		lst.ContinuationToken = *resp.NextMarker
+	if next != nil {
+		lst.ContinuationToken = *next
 	}
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
 		nlog.Infof("[list_objects] count %d(marker: %s)", len(lst.Entries), lst.ContinuationToken)
@@ -327,12 +364,7 @@ func (azbp *azbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (
 //
 
 func (azbp *azbp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, _ int, _ error) {
-	serviceClient, err := service.NewClientWithSharedKeyCredential(azbp.u, azbp.creds, nil)
-	if err != nil {
-		status, err := azureErrorToAISError(err, &cmn.Bck{Provider: apc.Azure}, "")
-		return nil, status, err
-	}
-	pager := serviceClient.NewListContainersPager(&service.ListContainersOptions{})
+	pager := azbp.client.NewListContainersPager(&service.ListContainersOptions{})
 	for pager.More() {
 		resp, err := pager.NextPage(context.TODO())
 		if err != nil {
@@ -357,16 +389,8 @@ func (azbp *azbp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, _ int, _ error) {
 //
 
 func (azbp *azbp) HeadObj(ctx context.Context, lom *core.LOM, _ *http.Request) (*cmn.ObjAttrs, int, error) {
-	var (
-		cloudBck = lom.Bucket().RemoteBck()
-		blURL    = azbp.u + "/" + cloudBck.Name + "/" + lom.ObjName
-	)
-	client, err := blockblob.NewClientWithSharedKeyCredential(blURL, azbp.creds, nil)
-	if err != nil {
-		status, err := azureErrorToAISError(err, cloudBck, lom.ObjName)
-		return nil, status, err
-	}
-	resp, err := client.GetProperties(ctx, nil)
+	cloudBck := lom.Bucket().RemoteBck()
+	resp, err := azbp.blobClient(cloudBck, lom.ObjName).GetProperties(ctx, nil)
 	if err != nil {
 		status, err := azureErrorToAISError(err, cloudBck, lom.ObjName)
 		return nil, status, err
@@ -382,7 +406,11 @@ func (azbp *azbp) HeadObj(ctx context.Context, lom *core.LOM, _ *http.Request) (
 	etag := azEncodeEtag(*resp.ETag)
 	oa.SetCustomKey(cmn.ETag, etag)
 
-	oa.SetVersion(etag) // TODO #200224
+	ver, isVid := azVersion(resp.VersionID, etag)
+	oa.SetVersion(ver)
+	if isVid {
+		oa.SetCustomKey(cmn.VersionObjMD, ver)
+	}
 
 	if md5 := azEncodeChecksum(resp.ContentMD5); md5 != "" {
 		oa.SetCustomKey(cmn.MD5ObjMD, md5)
@@ -396,7 +424,7 @@ func (azbp *azbp) HeadObj(ctx context.Context, lom *core.LOM, _ *http.Request) (
 		oa.SetCustomKey(cos.HdrContentType, *v)
 	}
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infof("[head_object] %s", lom)
+		nlog.InfofWith(logFields(ctx), "[head_object] %s", lom)
 	}
 	return oa, 0, nil
 }
@@ -414,7 +442,7 @@ func (azbp *azbp) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, _ *htt
 	err := azbp.t.PutObject(lom, params)
 	core.FreePutParams(params)
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infoln("[get_object]", lom.String(), err)
+		nlog.InfolnWith(logFields(ctx), "[get_object]", lom.String(), err)
 	}
 	return 0, err
 }
@@ -422,20 +450,26 @@ func (azbp *azbp) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, _ *htt
 func (azbp *azbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int64) (res core.GetReaderResult) {
 	var (
 		cloudBck = lom.Bucket().RemoteBck()
-		blURL    = azbp.u + "/" + cloudBck.Name + "/" + lom.ObjName
+		client   = azbp.blobClient(cloudBck, lom.ObjName)
 	)
-	client, err := blockblob.NewClientWithSharedKeyCredential(blURL, azbp.creds, nil)
-	if err != nil {
-		res.ErrCode, res.Err = azureErrorToAISError(err, cloudBck, lom.ObjName)
-		return
+	// custom request headers, if any (see ctxHeader)
+	if hdr := ctxHeader(ctx); len(hdr) > 0 {
+		ctx = policy.WithHTTPHeader(ctx, hdr)
 	}
 
-	// Get checksum
+	// Get checksum and version
 	respProps, err := client.GetProperties(ctx, nil)
 	if err != nil {
 		res.ErrCode, res.Err = azureErrorToAISError(err, cloudBck, lom.ObjName)
 		return
 	}
+	// read exactly the version we are about to report (in re: concurrent overwrites)
+	if vid := respProps.VersionID; vid != nil && *vid != "" {
+		if client, err = client.WithVersionID(*vid); err != nil {
+			res.ErrCode, res.Err = azureErrorToAISError(err, cloudBck, lom.ObjName)
+			return
+		}
+	}
 
 	// (0, 0) range indicates "whole object"
 	var opts blob.DownloadStreamOptions
@@ -450,7 +484,6 @@ func (azbp *azbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, lengt
 		return res
 	}
 
-	debug.Assert(resp.IsCurrentVersion == nil || *resp.IsCurrentVersion, "expecting current/latest/the-only ver")
 	res.Size = *resp.ContentLength
 
 	if length == 0 {
@@ -459,8 +492,11 @@ func (azbp *azbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, lengt
 		etag := azEncodeEtag(*respProps.ETag)
 		lom.SetCustomKey(cmn.ETag, etag)
 
-		lom.SetVersion(etag) // TODO #200224
-
+		ver, isVid := azVersion(respProps.VersionID, etag)
+		lom.SetVersion(ver)
+		if isVid {
+			lom.SetCustomKey(cmn.VersionObjMD, ver)
+		}
 		if md5 := azEncodeChecksum(respProps.ContentMD5); md5 != "" {
 			lom.SetCustomKey(cmn.MD5ObjMD, md5)
 			res.ExpCksum = cos.NewCksum(cos.ChecksumMD5, md5)
@@ -475,21 +511,17 @@ func (azbp *azbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, lengt
 // PUT OBJECT
 //
 
-func (azbp *azbp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int, error) {
+func (azbp *azbp) PutObj(r io.ReadCloser, lom *core.LOM, oreq *http.Request) (int, error) {
 	defer cos.Close(r)
 
-	client, err := azblob.NewClientWithSharedKeyCredential(azbp.u, azbp.creds, nil)
-	if err != nil {
-		return azureErrorToAISError(err, &cmn.Bck{Provider: apc.Azure}, "")
-	}
 	cloudBck := lom.Bck().RemoteBck()
 
-	opts := azblob.UploadStreamOptions{}
+	opts := blockblob.UploadStreamOptions{}
 	if size := lom.Lsize(true); size > cos.MiB {
 		opts.Concurrency = int(min((size+cos.MiB-1)/cos.MiB, 8))
 	}
 
-	resp, err := client.UploadStream(context.Background(), cloudBck.Name, lom.ObjName, r, &opts)
+	resp, err := azbp.blobClient(cloudBck, lom.ObjName).UploadStream(context.Background(), r, &opts)
 	if err != nil {
 		return azureErrorToAISError(err, cloudBck, lom.ObjName)
 	}
@@ -497,13 +529,16 @@ func (azbp *azbp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int,
 	etag := azEncodeEtag(*resp.ETag)
 	lom.SetCustomKey(cmn.ETag, etag)
 
-	lom.SetVersion(etag) // TODO #200224
-
+	ver, isVid := azVersion(resp.VersionID, etag)
+	lom.SetVersion(ver)
+	if isVid {
+		lom.SetCustomKey(cmn.VersionObjMD, ver)
+	}
 	if v := resp.LastModified; v != nil {
 		lom.SetCustomKey(cmn.LastModified, fmtTime(*v))
 	}
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infof("[put_object] %s", lom)
+		nlog.InfofWith(logFields(reqCtx(oreq)), "[put_object] %s", lom)
 	}
 	return http.StatusOK, nil
 }
@@ -513,14 +548,8 @@ func (azbp *azbp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (int,
 //
 
 func (azbp *azbp) DeleteObj(lom *core.LOM) (int, error) {
-	client, err := azblob.NewClientWithSharedKeyCredential(azbp.u, azbp.creds, nil)
-	if err != nil {
-		return azureErrorToAISError(err, &cmn.Bck{Provider: apc.Azure}, "")
-	}
 	cloudBck := lom.Bck().RemoteBck()
-
-	_, err = client.DeleteBlob(context.Background(), cloudBck.Name, lom.ObjName, nil)
-	if err != nil {
+	if _, err := azbp.blobClient(cloudBck, lom.ObjName).Delete(context.Background(), nil); err != nil {
 		return azureErrorToAISError(err, cloudBck, lom.ObjName)
 	}
 	return http.StatusOK, nil
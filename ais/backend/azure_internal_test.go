@@ -0,0 +1,79 @@
+//go:build azure
+
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestAzVersion(t *testing.T) {
+	const etag = "0x8DC7A1B2C3D4E5F"
+	vid := "2024-05-14T15:04:05.1234567Z"
+
+	v, isVid := azVersion(&vid, etag)
+	tassert.Errorf(t, v == vid && isVid, "expected VersionID %q, got %q (%t)", vid, v, isVid)
+
+	empty := ""
+	for _, versionID := range []*string{nil, &empty} {
+		v, isVid = azVersion(versionID, etag)
+		tassert.Errorf(t, v == etag && !isVid, "expected ETag %q, got %q (%t)", etag, v, isVid)
+	}
+}
+
+func setAzEnv(t *testing.T, kvs ...string) {
+	for _, name := range []string{azConnStrEnvVar, azAccNameEnvVar, azAccKeyEnvVar, azSASTokenEnvVar, azURLEnvVar, azProtoEnvVar} {
+		t.Setenv(name, "")
+	}
+	for i := 0; i < len(kvs); i += 2 {
+		t.Setenv(kvs[i], kvs[i+1])
+	}
+}
+
+// credentials, in the order of precedence: connection string, shared key, SAS token
+func TestAzNewClient(t *testing.T) {
+	const (
+		account = "devstoreaccount1"
+		key     = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+		connStr = "DefaultEndpointsProtocol=http;AccountName=" + account + ";AccountKey=" + key +
+			";BlobEndpoint=http://127.0.0.1:10000/" + account + ";"
+		sas = "sv=2022-11-02&ss=b&srt=sco&sp=rl&sig=abc"
+	)
+
+	// none
+	setAzEnv(t)
+	_, err := azNewClient()
+	tassert.Errorf(t, err != nil && strings.Contains(err.Error(), "missing credentials"), "expected missing credentials, got %v", err)
+
+	// connection string wins over everything else
+	setAzEnv(t, azConnStrEnvVar, connStr, azAccNameEnvVar, "other", azAccKeyEnvVar, key, azSASTokenEnvVar, sas)
+	client, err := azNewClient()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, strings.HasPrefix(client.URL(), "http://127.0.0.1:10000/"+account), "unexpected URL %q", client.URL())
+
+	// shared key wins over SAS
+	setAzEnv(t, azAccNameEnvVar, account, azAccKeyEnvVar, key, azSASTokenEnvVar, sas)
+	client, err = azNewClient()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, strings.TrimSuffix(client.URL(), "/") == "https://"+account+azHost, "unexpected URL %q", client.URL())
+	tassert.Errorf(t, !strings.Contains(client.URL(), "sig="), "unexpected SAS in %q", client.URL())
+
+	// invalid shared key (not base64)
+	setAzEnv(t, azAccNameEnvVar, account, azAccKeyEnvVar, "not-a-key!")
+	_, err = azNewClient()
+	tassert.Errorf(t, err != nil, "expected invalid shared key to fail")
+
+	// SAS token, with or without leading '?', and custom endpoint
+	for _, token := range []string{sas, "?" + sas} {
+		setAzEnv(t, azAccNameEnvVar, account, azSASTokenEnvVar, token, azURLEnvVar, "http://127.0.0.1:10000/"+account)
+		client, err = azNewClient()
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, strings.Contains(client.URL(), "/"+account+"?"+sas), "unexpected URL %q", client.URL())
+	}
+}
@@ -5,15 +5,19 @@
 package backend
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 )
 
 type base struct {
@@ -26,6 +30,21 @@ func (b *base) CreateBucket(_ *meta.Bck) (int, error) {
 	return http.StatusNotImplemented, cmn.NewErrUnsupp("create", b.provider+" bucket")
 }
 
+// structured-logging fields for a given (object) backend call (see nlog.Fields);
+// ctx, if not nil, is the call's context (see traced)
+func logFields(ctx context.Context) *nlog.Fields {
+	return &nlog.Fields{Module: cos.SmoduleName(cos.SmoduleBackend), ReqID: tracing.ReqID(ctx, nil)}
+}
+
+// additional request headers (e.g., authorization) provided by the caller, if any (see ext/dload)
+func ctxHeader(ctx context.Context) http.Header {
+	if ctx == nil {
+		return nil
+	}
+	hdr, _ := ctx.Value(cos.CtxHTTPHeaders).(http.Header)
+	return hdr
+}
+
 func newErrInventory(provider string) error {
 	return cmn.NewErrUnsupp("list "+provider+" backend objects via", "bucket inventory")
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/googleapis/gax-go/v2/callctx"
 	jsoniter "github.com/json-iterator/go"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
@@ -307,7 +308,7 @@ func (*gsbp) HeadObj(ctx context.Context, lom *core.LOM, _ *http.Request) (oa *c
 	// - only shown via list-objects and HEAD when not present
 	oa.SetCustomKey(cos.HdrContentType, attrs.ContentType)
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infof("[head_object] %s", cloudBck.Cname(lom.ObjName))
+		nlog.InfofWith(logFields(ctx), "[head_object] %s", cloudBck.Cname(lom.ObjName))
 	}
 	return
 }
@@ -325,7 +326,7 @@ func (gsbp *gsbp) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, _ *htt
 	err := gsbp.t.PutObject(lom, params)
 	core.FreePutParams(params)
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infoln("[get_object]", lom.String(), err)
+		nlog.InfolnWith(logFields(ctx), "[get_object]", lom.String(), err)
 	}
 	return 0, err
 }
@@ -337,6 +338,14 @@ func (*gsbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int
 		cloudBck = lom.Bck().RemoteBck()
 		o        = gcpClient.Bucket(cloudBck.Name).Object(lom.ObjName)
 	)
+	// custom request headers, if any (see ctxHeader)
+	if hdr := ctxHeader(ctx); len(hdr) > 0 {
+		kvs := make([]string, 0, 2*len(hdr))
+		for name := range hdr {
+			kvs = append(kvs, name, hdr.Get(name))
+		}
+		ctx = callctx.SetHeaders(ctx, kvs...)
+	}
 	attrs, res.Err = o.Attrs(ctx)
 	if res.Err != nil {
 		res.ErrCode, res.Err = gcpErrorToAISError(res.Err, cloudBck)
@@ -397,7 +406,7 @@ func setCustomGs(lom *core.LOM, attrs *storage.ObjectAttrs) (expCksum *cos.Cksum
 // PUT OBJECT
 //
 
-func (gsbp *gsbp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (ecode int, err error) {
+func (gsbp *gsbp) PutObj(r io.ReadCloser, lom *core.LOM, oreq *http.Request) (ecode int, err error) {
 	var (
 		attrs    *storage.ObjectAttrs
 		written  int64
@@ -427,7 +436,7 @@ func (gsbp *gsbp) PutObj(r io.ReadCloser, lom *core.LOM, _ *http.Request) (ecode
 	}
 	_ = setCustomGs(lom, attrs)
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infof("[put_object] %s, size %d", lom, written)
+		nlog.InfofWith(logFields(reqCtx(oreq)), "[put_object] %s, size %d", lom, written)
 	}
 	return
 }
@@ -446,7 +455,7 @@ func (*gsbp) DeleteObj(lom *core.LOM) (ecode int, err error) {
 		return
 	}
 	if cmn.Rom.FastV(5, cos.SmoduleBackend) {
-		nlog.Infof("[delete_object] %s", lom)
+		nlog.InfofWith(logFields(nil), "[delete_object] %s", lom)
 	}
 	return
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tracing"
 )
 
 type (
@@ -24,6 +25,7 @@ type (
 		t      core.TargetPut
 		cliH   *http.Client
 		cliTLS *http.Client
+		lso    htLso // see httplso.go
 		base
 	}
 )
@@ -34,6 +36,7 @@ var _ core.Backend = (*htbp)(nil)
 func NewHTTP(t core.TargetPut, config *cmn.Config) core.Backend {
 	htbp := &htbp{
 		t:    t,
+		lso:  htLso{m: make(map[string]*htLsoCache, 4)},
 		base: base{apc.HTTP},
 	}
 	htbp.cliH, htbp.cliTLS = cmn.NewDefaultClients(config.Client.TimeoutLong.D())
@@ -60,7 +63,12 @@ func (htbp *htbp) HeadBucket(ctx context.Context, bck *meta.Bck) (bckProps cos.S
 	}
 
 	// Contact the original URL - as long as we can make connection we assume it's good.
-	resp, err := htbp.client(origURL).Head(origURL)
+	req, err := http.NewRequest(http.MethodHead, origURL, http.NoBody)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	setReqHeaders(ctx, req)
+	resp, err := htbp.client(origURL).Do(req)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
 	}
@@ -81,16 +89,6 @@ func (htbp *htbp) HeadBucket(ctx context.Context, bck *meta.Bck) (bckProps cos.S
 	return
 }
 
-func (*htbp) ListObjects(*meta.Bck, *apc.LsoMsg, *cmn.LsoRes) (ecode int, err error) {
-	debug.Assert(false)
-	return
-}
-
-func (*htbp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, ecode int, err error) {
-	debug.Assert(false)
-	return
-}
-
 func getOriginalURL(ctx context.Context, bck *meta.Bck, objName string) (string, error) {
 	origURL, ok := ctx.Value(cos.CtxOriginalURL).(string)
 	if !ok || origURL == "" {
@@ -115,9 +113,14 @@ func (htbp *htbp) HeadObj(ctx context.Context, lom *core.LOM, _ *http.Request) (
 	debug.AssertNoErr(err)
 
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-		nlog.Infof("[head_object] original_url: %q", origURL)
+		nlog.InfofWith(logFields(ctx), "[head_object] original_url: %q", origURL)
 	}
-	resp, err := htbp.client(origURL).Head(origURL)
+	req, err := http.NewRequest(http.MethodHead, origURL, http.NoBody)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	setReqHeaders(ctx, req)
+	resp, err := htbp.client(origURL).Do(req)
 	if err != nil {
 		return nil, http.StatusBadRequest, err
 	}
@@ -134,7 +137,7 @@ func (htbp *htbp) HeadObj(ctx context.Context, lom *core.LOM, _ *http.Request) (
 		oa.SetCustomKey(cmn.ETag, v)
 	}
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-		nlog.Infof("[head_object] %s", lom)
+		nlog.InfofWith(logFields(ctx), "[head_object] %s", lom)
 	}
 	return
 }
@@ -151,7 +154,7 @@ func (htbp *htbp) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, _ *htt
 		return 0, res.Err
 	}
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-		nlog.Infof("[get_object] %s", lom)
+		nlog.InfofWith(logFields(ctx), "[get_object] %s", lom)
 	}
 	return 0, nil
 }
@@ -168,17 +171,18 @@ func (htbp *htbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, lengt
 	debug.AssertNoErr(err)
 
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-		nlog.Infof("[HTTP CLOUD][GET] original_url: %q", origURL)
+		nlog.InfofWith(logFields(ctx), "[HTTP CLOUD][GET] original_url: %q", origURL)
 	}
 
 	req, res.Err = http.NewRequest(http.MethodGet, origURL, http.NoBody)
-	if err != nil {
+	if res.Err != nil {
 		res.ErrCode = http.StatusInternalServerError
 		return res
 	}
+	setReqHeaders(ctx, req)
 	if length > 0 {
 		rng := cmn.MakeRangeHdr(offset, length)
-		req.Header = http.Header{cos.HdrRange: []string{rng}}
+		req.Header.Set(cos.HdrRange, rng)
 	}
 	resp, res.Err = htbp.client(origURL).Do(req) //nolint:bodyclose // is closed by the caller
 	if res.Err != nil {
@@ -191,7 +195,7 @@ func (htbp *htbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, lengt
 	}
 
 	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
-		nlog.Infof("[HTTP CLOUD][GET] success, size: %d", resp.ContentLength)
+		nlog.InfofWith(logFields(ctx), "[HTTP CLOUD][GET] success, size: %d", resp.ContentLength)
 	}
 
 	lom.SetCustomKey(cmn.SourceObjMD, apc.HTTP)
@@ -204,6 +208,18 @@ func (htbp *htbp) GetObjReader(ctx context.Context, lom *core.LOM, offset, lengt
 	return res
 }
 
+// additional request headers (e.g., authorization) provided by the caller (see ext/dload),
+// and trace-context, if any
+func setReqHeaders(ctx context.Context, req *http.Request) {
+	if ctx == nil {
+		return
+	}
+	for name, vals := range ctxHeader(ctx) {
+		req.Header[name] = vals
+	}
+	tracing.Inject(ctx, req.Header)
+}
+
 func (*htbp) PutObj(io.ReadCloser, *core.LOM, *http.Request) (int, error) {
 	return http.StatusBadRequest, cmn.NewErrUnsupp("PUT", " objects => HTTP backend")
 }
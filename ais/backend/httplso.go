@@ -0,0 +1,449 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core/meta"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Listing HTTP(S) datasets (`ht://` buckets), whereby:
+// - bucket's original URL (`extra.http.original_url`) points to a directory that
+//   the web server renders as an index page (Apache and nginx autoindex, including
+//   nginx `autoindex_format json`); subdirectories are crawled recursively
+//   unless the caller specifies apc.LsNoRecursion;
+// - alternatively, `extra.http.manifest_url` points to a manifest: either a
+//   newline-separated list of object names (or URLs) or a JSON array of names
+//   or {"name", "size"} objects.
+// Web servers don't paginate, and so the entire listing is cached for the duration
+// of htLsoTTL and then paged through (continuation token being the last returned name).
+
+const (
+	htLsoTTL      = time.Minute
+	htLsoMaxDepth = 32
+	htLsoMaxSize  = 64 * cos.MiB // index page or manifest
+)
+
+type (
+	htEnt struct {
+		name string
+		size int64
+		dir  bool
+	}
+	htLsoCache struct {
+		ents    []htEnt // sorted by name
+		started int64   // mono-time
+	}
+	htLso struct {
+		m  map[string]*htLsoCache
+		mu sync.Mutex
+	}
+	// nginx `autoindex_format json`
+	htJSONEnt struct {
+		Name string `json:"name"`
+		Type string `json:"type"` // "file" | "directory"
+		Size int64  `json:"size"`
+	}
+)
+
+var htHrefRegex = regexp.MustCompile(`(?i)<a\s[^>]*href\s*=\s*["']([^"']+)["'][^>]*>`)
+
+func (htbp *htbp) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (int, error) {
+	if bck.Props == nil || bck.Props.Extra.HTTP.OrigURLBck == "" {
+		return http.StatusBadRequest, fmt.Errorf("cannot list %s: original URL is unknown", bck.Cname(""))
+	}
+	msg.PageSize = calcPageSize(msg.PageSize, bck.MaxPageSize())
+
+	ents, ecode, err := htbp.lso.get(htbp, bck, msg)
+	if err != nil {
+		return ecode, err
+	}
+
+	var (
+		i     int
+		noDir = msg.IsFlagSet(apc.LsNoDirs)
+	)
+	if token := msg.ContinuationToken; token != "" {
+		i = sort.Search(len(ents), func(j int) bool { return ents[j].name > token })
+	}
+	lst.Entries = lst.Entries[:0]
+	lst.ContinuationToken = ""
+	for ; i < len(ents) && int64(len(lst.Entries)) < msg.PageSize; i++ {
+		en := &ents[i]
+		if en.dir && noDir {
+			continue
+		}
+		if !cmn.ObjHasPrefix(en.name, msg.Prefix) {
+			continue
+		}
+		e := &cmn.LsoEnt{Name: en.name, Size: en.size}
+		if en.dir {
+			e.Flags = apc.EntryIsDir
+		}
+		lst.Entries = append(lst.Entries, e)
+	}
+	if i < len(ents) && len(lst.Entries) > 0 {
+		lst.ContinuationToken = lst.Entries[len(lst.Entries)-1].Name
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+		nlog.Infof("[list_objects] %s: count %d (marker: %q)", bck.Cname(""), len(lst.Entries), lst.ContinuationToken)
+	}
+	return 0, nil
+}
+
+func (*htbp) ListBuckets(cmn.QueryBcks) (bcks cmn.Bcks, ecode int, err error) {
+	return nil, http.StatusNotImplemented, cmn.NewErrNotImpl("list", "buckets via HTTP backend (see BMD)")
+}
+
+///////////
+// htLso //
+///////////
+
+func (c *htLso) get(htbp *htbp, bck *meta.Bck, msg *apc.LsoMsg) ([]htEnt, int, error) {
+	var (
+		baseURL   = bck.Props.Extra.HTTP.OrigURLBck
+		manifest  = bck.Props.Extra.HTTP.ManifestURL
+		recursive = !msg.IsFlagSet(apc.LsNoRecursion)
+		dir       string
+	)
+	// start crawling from the deepest directory implied by the prefix
+	if i := strings.LastIndexByte(msg.Prefix, '/'); i >= 0 {
+		dir = msg.Prefix[:i+1]
+	}
+	key := baseURL + "\x00" + manifest + "\x00" + dir + "\x00" + strconv.FormatBool(recursive)
+
+	c.mu.Lock()
+	now := mono.NanoTime()
+	for k, v := range c.m {
+		if time.Duration(now-v.started) > htLsoTTL {
+			delete(c.m, k)
+		}
+	}
+	if v, ok := c.m[key]; ok && msg.ContinuationToken != "" {
+		c.mu.Unlock()
+		return v.ents, 0, nil
+	}
+	c.mu.Unlock()
+
+	var (
+		ents  []htEnt
+		ecode int
+		err   error
+	)
+	if manifest != "" {
+		ents, ecode, err = htbp.lsManifest(baseURL, manifest, dir, recursive)
+	} else {
+		ents, ecode, err = htbp.lsIndex(baseURL, dir, recursive)
+	}
+	if err != nil {
+		return nil, ecode, err
+	}
+	sort.Slice(ents, func(i, j int) bool { return ents[i].name < ents[j].name })
+
+	c.mu.Lock()
+	c.m[key] = &htLsoCache{ents: ents, started: now}
+	c.mu.Unlock()
+	return ents, 0, nil
+}
+
+//
+// index pages (autoindex)
+//
+
+func (htbp *htbp) lsIndex(baseURL, dir string, recursive bool) (ents []htEnt, _ int, _ error) {
+	type todo struct {
+		dir   string
+		depth int
+	}
+	var (
+		queue = []todo{{dir, 0}}
+		seen  = make(map[string]struct{}, 8)
+	)
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[cur.dir]; ok {
+			continue
+		}
+		seen[cur.dir] = struct{}{}
+
+		pageURL := baseURL
+		if !cos.IsLastB(pageURL, '/') {
+			pageURL += "/"
+		}
+		pageURL += (&url.URL{Path: cur.dir}).EscapedPath()
+		body, ctype, ecode, err := htbp.fetch(pageURL)
+		if err != nil {
+			if cur.dir != dir && ecode == http.StatusNotFound {
+				nlog.Warningln("skipping", pageURL, "[", err, "]")
+				continue
+			}
+			return nil, ecode, err
+		}
+		page, err := htParseIndex(body, ctype, pageURL)
+		if err != nil {
+			return nil, http.StatusBadGateway, fmt.Errorf("failed to parse index page %s: %w", pageURL, err)
+		}
+		for _, en := range page {
+			en.name = cur.dir + en.name
+			if !en.dir {
+				ents = append(ents, en)
+				continue
+			}
+			if !recursive {
+				ents = append(ents, en)
+				continue
+			}
+			if cur.depth+1 >= htLsoMaxDepth {
+				nlog.Warningln("max depth", htLsoMaxDepth, "exceeded: not crawling", cos.JoinPath(baseURL, en.name))
+				continue
+			}
+			queue = append(queue, todo{en.name, cur.depth + 1})
+		}
+	}
+	return ents, 0, nil
+}
+
+// parse Apache and nginx autoindex pages, as well as nginx `autoindex_format json`
+func htParseIndex(body []byte, ctype, pageURL string) ([]htEnt, error) {
+	trimmed := bytes.TrimSpace(body)
+	if strings.Contains(ctype, "json") || (len(trimmed) > 0 && trimmed[0] == '[') {
+		var jents []htJSONEnt
+		if err := jsoniter.Unmarshal(trimmed, &jents); err != nil {
+			return nil, err
+		}
+		ents := make([]htEnt, 0, len(jents))
+		for _, je := range jents {
+			if je.Name == "" || je.Name == "." || je.Name == ".." {
+				continue
+			}
+			en := htEnt{name: je.Name, size: je.Size}
+			if je.Type == "directory" {
+				en = htEnt{name: strings.TrimSuffix(je.Name, "/") + "/", dir: true}
+			}
+			ents = append(ents, en)
+		}
+		return ents, nil
+	}
+
+	page, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		ents []htEnt
+		seen = make(map[string]struct{}, 16)
+	)
+	for _, line := range strings.Split(string(body), "\n") {
+		locs := htHrefRegex.FindAllStringSubmatchIndex(line, -1)
+		for k, loc := range locs {
+			name, ok := htRelName(page, line[loc[2]:loc[3]])
+			if !ok {
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			en := htEnt{name: name, dir: cos.IsLastB(name, '/')}
+			if !en.dir {
+				// the text between this link and the next one (or end of line), e.g.:
+				// nginx:  `</a>    07-Jun-2024 10:11    1048576`
+				// apache: `</a></td><td align="right">2024-06-07 10:11  </td><td align="right">1.0M</td>`
+				end := len(line)
+				if k+1 < len(locs) {
+					end = locs[k+1][0]
+				}
+				en.size = htParseSize(line[loc[1]:end])
+			}
+			ents = append(ents, en)
+		}
+	}
+	return ents, nil
+}
+
+// resolve href relative to the index page; return the name relative to the page
+// (only the page's immediate children qualify)
+func htRelName(page *url.URL, href string) (string, bool) {
+	if href == "" || href[0] == '?' || href[0] == '#' {
+		return "", false // sorting links and anchors
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	u := page.ResolveReference(ref)
+	if u.Host != page.Host || u.RawQuery != "" || !strings.HasPrefix(u.Path, page.Path) {
+		return "", false // external links, parent directory
+	}
+	name := u.Path[len(page.Path):]
+	if name == "" || name == "/" {
+		return "", false
+	}
+	if i := strings.IndexByte(strings.TrimSuffix(name, "/"), '/'); i >= 0 {
+		return "", false // not an immediate child
+	}
+	return name, true
+}
+
+// exact sizes only (nginx); human-readable (Apache) sizes are approximate and get ignored
+func htParseSize(s string) int64 {
+	s = htTagRegex.ReplaceAllString(s, " ")
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0
+	}
+	size, err := strconv.ParseInt(fields[len(fields)-1], 10, 64)
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
+
+var htTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+//
+// manifests
+//
+
+func (htbp *htbp) lsManifest(baseURL, manifest, dir string, recursive bool) ([]htEnt, int, error) {
+	body, ctype, ecode, err := htbp.fetch(manifest)
+	if err != nil {
+		return nil, ecode, err
+	}
+	all, err := htParseManifest(body, ctype, baseURL)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to parse manifest %s: %w", manifest, err)
+	}
+	var (
+		ents = make([]htEnt, 0, len(all))
+		dirs = make(map[string]struct{})
+	)
+	for _, en := range all {
+		if !strings.HasPrefix(en.name, dir) {
+			continue
+		}
+		if !recursive {
+			// virtual directories
+			if i := strings.IndexByte(en.name[len(dir):], '/'); i >= 0 {
+				d := en.name[:len(dir)+i+1]
+				if _, ok := dirs[d]; !ok {
+					dirs[d] = struct{}{}
+					ents = append(ents, htEnt{name: d, dir: true})
+				}
+				continue
+			}
+		}
+		ents = append(ents, en)
+	}
+	return ents, 0, nil
+}
+
+// manifest formats:
+// - newline-separated names or URLs (blank lines and '#' comments are skipped);
+// - JSON array of names (or URLs), or of {"name": ..., "size": ...} objects
+func htParseManifest(body []byte, ctype, baseURL string) ([]htEnt, error) {
+	var (
+		ents    []htEnt
+		trimmed = bytes.TrimSpace(body)
+	)
+	if strings.Contains(ctype, "json") || (len(trimmed) > 0 && trimmed[0] == '[') {
+		var raw []jsoniter.RawMessage
+		if err := jsoniter.Unmarshal(trimmed, &raw); err != nil {
+			return nil, err
+		}
+		for _, r := range raw {
+			var (
+				en htEnt
+				s  string
+			)
+			if err := jsoniter.Unmarshal(r, &s); err == nil {
+				en.name = s
+			} else {
+				var je htJSONEnt
+				if err := jsoniter.Unmarshal(r, &je); err != nil {
+					return nil, err
+				}
+				en.name, en.size = je.Name, je.Size
+			}
+			if en.name, _ = htManifestName(en.name, baseURL); en.name != "" {
+				ents = append(ents, en)
+			}
+		}
+		return ents, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 4096), 64*cos.KiB)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if name, ok := htManifestName(line, baseURL); ok {
+			ents = append(ents, htEnt{name: name})
+		}
+	}
+	return ents, scanner.Err()
+}
+
+// names are relative to the bucket's original URL; absolute URLs must be under it
+func htManifestName(s, baseURL string) (string, bool) {
+	if !strings.Contains(s, "://") {
+		name := strings.TrimLeft(s, "/")
+		return name, name != "" && !cos.IsLastB(name, '/')
+	}
+	dir := baseURL
+	if !cos.IsLastB(dir, '/') {
+		dir += "/" // (e.g., "http://h/data" must not match "http://h/database/x")
+	}
+	if !strings.HasPrefix(s, dir) {
+		if cmn.Rom.FastV(4, cos.SmoduleBackend) {
+			nlog.Warningln("skipping", s, "- not under", baseURL)
+		}
+		return "", false
+	}
+	name := strings.TrimLeft(s[len(dir):], "/")
+	return name, name != "" && !cos.IsLastB(name, '/')
+}
+
+func (htbp *htbp) fetch(u string) (body []byte, ctype string, _ int, _ error) {
+	resp, err := htbp.client(u).Get(u)
+	if err != nil {
+		return nil, "", http.StatusBadGateway, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", resp.StatusCode, fmt.Errorf("GET(%s) failed, status %d", u, resp.StatusCode)
+	}
+	body, err = io.ReadAll(io.LimitReader(resp.Body, htLsoMaxSize+1))
+	if err != nil {
+		return nil, "", http.StatusBadGateway, err
+	}
+	if len(body) > htLsoMaxSize {
+		return nil, "", http.StatusRequestEntityTooLarge, errors.New("GET(" + u + "): response exceeds " +
+			cos.ToSizeIEC(htLsoMaxSize, 0))
+	}
+	return body, resp.Header.Get(cos.HdrContentType), 0, nil
+}
@@ -0,0 +1,130 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+const (
+	htNginxIndex = `<html>
+<head><title>Index of /data/</title></head>
+<body>
+<h1>Index of /data/</h1><hr><pre><a href="../">../</a>
+<a href="sub/">sub/</a>                                               07-Jun-2024 10:11                   -
+<a href="a.tar">a.tar</a>                                             07-Jun-2024 10:11                1024
+<a href="with%20space.tar">with space.tar</a>                         07-Jun-2024 10:11                  10
+</pre><hr></body>
+</html>`
+	htApacheIndex = `<html><body><h1>Index of /data/sub</h1>
+<table>
+<tr><th><a href="?C=N;O=D">Name</a></th><th><a href="?C=S;O=A">Size</a></th></tr>
+<tr><td><a href="/data/">Parent Directory</a></td><td>&nbsp;</td><td align="right">  - </td></tr>
+<tr><td><a href="b.tar">b.tar</a></td><td align="right">2024-06-07 10:11  </td><td align="right">1.0M</td></tr>
+<tr><td><a href="http://elsewhere.com/c.tar">c.tar</a></td></tr>
+</table></body></html>`
+)
+
+func TestHTTPListObjects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/data/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/":
+			w.Write([]byte(htNginxIndex))
+		case "/data/sub/":
+			w.Write([]byte(htApacheIndex))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/json/", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+		w.Write([]byte(`[{"name":"x","type":"file","size":7},{"name":"d","type":"directory"}]`))
+	})
+	mux.HandleFunc("/manifest.txt", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("# comment\nm/1.tar\n\nm/2.tar\nhttp://elsewhere.com/3.tar\n"))
+	})
+	mux.HandleFunc("/manifest.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`["m/1.tar", {"name": "n/2.tar", "size": 5}]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var (
+		htbp = &htbp{cliH: srv.Client(), lso: htLso{m: make(map[string]*htLsoCache)}, base: base{apc.HTTP}}
+		bck  = &meta.Bck{Name: "b", Provider: apc.HTTP, Props: &cmn.Bprops{}}
+	)
+	list := func(msg *apc.LsoMsg) (names []string) {
+		var lst cmn.LsoRes
+		for {
+			if _, err := htbp.ListObjects(bck, msg, &lst); err != nil {
+				t.Fatal(err)
+			}
+			for _, en := range lst.Entries {
+				names = append(names, en.Name)
+			}
+			if lst.ContinuationToken == "" {
+				return names
+			}
+			msg.ContinuationToken = lst.ContinuationToken
+		}
+	}
+	check := func(tag string, names []string, expected string) {
+		if s := strings.Join(names, ","); s != expected {
+			t.Errorf("%s: expected %q, got %q", tag, expected, s)
+		}
+	}
+
+	// index pages
+	bck.Props.Extra.HTTP.OrigURLBck = srv.URL + "/data/"
+	check("recursive", list(&apc.LsoMsg{PageSize: 1}), "a.tar,sub/b.tar,with space.tar")
+	check("non-recursive", list(&apc.LsoMsg{Flags: apc.LsNoRecursion}), "a.tar,sub/,with space.tar")
+	check("prefix", list(&apc.LsoMsg{Prefix: "sub/b"}), "sub/b.tar")
+
+	bck.Props.Extra.HTTP.OrigURLBck = srv.URL + "/json/"
+	check("json index", list(&apc.LsoMsg{Flags: apc.LsNoRecursion}), "d/,x")
+
+	// manifests
+	bck.Props.Extra.HTTP.OrigURLBck = "http://elsewhere.com/"
+	bck.Props.Extra.HTTP.ManifestURL = srv.URL + "/manifest.txt"
+	check("txt manifest", list(&apc.LsoMsg{}), "3.tar,m/1.tar,m/2.tar")
+	bck.Props.Extra.HTTP.ManifestURL = srv.URL + "/manifest.json"
+	check("json manifest", list(&apc.LsoMsg{Flags: apc.LsNoRecursion}), "m/,n/")
+	check("json manifest", list(&apc.LsoMsg{Prefix: "n/"}), "n/2.tar")
+
+	// absolute URLs in manifests must be under the bucket's URL (with or without trailing slash)
+	for _, base := range []string{"http://h/data", "http://h/data/"} {
+		for s, expected := range map[string]string{
+			"http://h/data/x/y.tar":   "x/y.tar",
+			"http://h/data//z.tar":    "z.tar",
+			"http://h/database/x":     "",
+			"http://h/data":           "",
+			"http://other/data/a.tar": "",
+		} {
+			if name, ok := htManifestName(s, base); name != expected || ok != (expected != "") {
+				t.Errorf("%s (base %s): expected %q, got (%q, %t)", s, base, expected, name, ok)
+			}
+		}
+	}
+
+	// sizes: exact (nginx) vs approximate (apache)
+	ents, err := htParseIndex([]byte(htNginxIndex+htApacheIndex), "text/html", srv.URL+"/data/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, en := range ents {
+		if en.name == "a.tar" && en.size != 1024 {
+			t.Errorf("a.tar: expected size 1024, got %d", en.size)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tracing"
+)
+
+// traced backend: a client span per (object) backend call; the parent (if any)
+// is the span carried by the call's context or by the original (e.g., PUT) request
+
+type traced struct {
+	core.Backend
+}
+
+// interface guard
+var _ core.Backend = (*traced)(nil)
+
+func NewTraced(bp core.Backend) core.Backend { return &traced{bp} }
+
+func (tb *traced) start(ctx context.Context, op string, lom *core.LOM) (context.Context, *tracing.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := tracing.Start(ctx, "backend."+op, tracing.KindClient)
+	if span != nil {
+		span.SetAttr("ais.provider", tb.Provider())
+		if lom != nil {
+			span.SetAttr("ais.object", lom.Cname())
+		}
+	}
+	return ctx, span
+}
+
+func reqCtx(r *http.Request) context.Context {
+	if r == nil {
+		return nil
+	}
+	return r.Context()
+}
+
+func (tb *traced) ListObjects(bck *meta.Bck, msg *apc.LsoMsg, lst *cmn.LsoRes) (ecode int, err error) {
+	_, span := tb.start(nil, "list-objects", nil)
+	span.SetAttr("ais.bucket", bck.Cname(""))
+	ecode, err = tb.Backend.ListObjects(bck, msg, lst)
+	span.End(err)
+	return
+}
+
+func (tb *traced) PutObj(r io.ReadCloser, lom *core.LOM, origReq *http.Request) (ecode int, err error) {
+	_, span := tb.start(reqCtx(origReq), "put", lom)
+	ecode, err = tb.Backend.PutObj(r, lom, origReq)
+	span.End(err)
+	return
+}
+
+func (tb *traced) DeleteObj(lom *core.LOM) (ecode int, err error) {
+	_, span := tb.start(nil, "delete", lom)
+	ecode, err = tb.Backend.DeleteObj(lom)
+	span.End(err)
+	return
+}
+
+func (tb *traced) HeadObj(ctx context.Context, lom *core.LOM, origReq *http.Request) (oa *cmn.ObjAttrs, ecode int, err error) {
+	ctx, span := tb.start(ctx, "head", lom)
+	oa, ecode, err = tb.Backend.HeadObj(ctx, lom, origReq)
+	span.End(err)
+	return
+}
+
+func (tb *traced) GetObj(ctx context.Context, lom *core.LOM, owt cmn.OWT, origReq *http.Request) (ecode int, err error) {
+	ctx, span := tb.start(ctx, "get", lom)
+	ecode, err = tb.Backend.GetObj(ctx, lom, owt, origReq)
+	if span != nil && err == nil {
+		span.SetAttr("ais.size", lom.Lsize(true))
+	}
+	span.End(err)
+	return
+}
+
+// NOTE: the span ends when the reader is returned (time to first byte)
+func (tb *traced) GetObjReader(ctx context.Context, lom *core.LOM, offset, length int64) (res core.GetReaderResult) {
+	ctx, span := tb.start(ctx, "get-reader", lom)
+	res = tb.Backend.GetObjReader(ctx, lom, offset, length)
+	if span != nil {
+		span.SetAttr("ais.size", res.Size)
+		if length > 0 {
+			span.SetAttr("ais.offset", offset)
+		}
+	}
+	span.End(res.Err)
+	return
+}
@@ -527,6 +527,7 @@ func mergeRemoteBckProps(props *cmn.Bprops, header http.Header) *cmn.Bprops {
 		props.Extra.AWS.CloudRegion = header.Get(apc.HdrS3Region)
 		props.Extra.AWS.Endpoint = header.Get(apc.HdrS3Endpoint)
 		props.Extra.AWS.Profile = header.Get(apc.HdrS3Profile)
+		props.Extra.AWS.EndpointName = header.Get(apc.HdrS3EpName)
 	case apc.HTTP:
 		props.Extra.HTTP.OrigURLBck = header.Get(apc.HdrOrigURLBck)
 	}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/audit"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -24,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/space"
 	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	"github.com/NVIDIA/aistore/xact/xs"
 )
@@ -246,7 +248,10 @@ func initDaemon(version, buildTime string) cos.Runner {
 
 		// aux plumbing
 		nlog.SetTitle(title)
-		cmn.InitErrs(p.si.Name(), nil)
+		nlog.SetNodeID(p.si.Name())
+		cmn.InitErrs(p.si.Name(), nil, reqID)
+		tracing.Init(apc.Proxy, p.SID())
+		audit.Init(config.LogDir, p.SID())
 		return p
 	}
 
@@ -261,7 +266,10 @@ func initDaemon(version, buildTime string) cos.Runner {
 
 	// aux plumbing
 	nlog.SetTitle(title)
-	cmn.InitErrs(t.si.Name(), fs.CleanPathErr)
+	nlog.SetNodeID(t.si.Name())
+	cmn.InitErrs(t.si.Name(), fs.CleanPathErr, reqID)
+	tracing.Init(apc.Target, t.SID())
+	audit.Init(config.LogDir, t.SID())
 
 	return t
 }
@@ -284,6 +292,8 @@ func newTarget(co *configOwner) *target {
 func Run(version, buildTime string) int {
 	rmain := initDaemon(version, buildTime)
 	err := daemon.rg.runAll(rmain)
+	tracing.Stop()
+	audit.Stop()
 
 	if err == nil {
 		nlog.Infoln("Terminated OK")
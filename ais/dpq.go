@@ -36,6 +36,9 @@ type dpq struct {
 	fltPresence string // QparamFltPresence
 	etlName     string // QparamETLName
 	binfo       string // bucket info, with or without requirement to summarize remote obj-s
+	traceparent string // QparamTraceparent
+	user        string // QparamUser (verified - see below)
+	usig        string // QparamUserSig
 
 	skipVC        bool // QparamSkipVC (skip loading existing object's metadata)
 	isGFN         bool // QparamIsGFNRequest
@@ -125,6 +128,14 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			dpq.silent = cos.IsParseBool(value)
 		case apc.QparamLatestVer:
 			dpq.latestVer = cos.IsParseBool(value)
+		case apc.QparamTraceparent:
+			dpq.traceparent = value
+		case apc.QparamUser:
+			if dpq.user, err = url.QueryUnescape(value); err != nil {
+				return
+			}
+		case apc.QparamUserSig:
+			dpq.usig = value
 
 		default:
 			debug.Func(func() {
@@ -146,6 +157,10 @@ func (dpq *dpq) parse(rawQuery string) (err error) {
 			})
 		}
 	}
+	// the user must be signed by the redirecting proxy (and not forged by the client)
+	if dpq.user != "" && !verifyUser(dpq.user, dpq.ptime, dpq.usig) {
+		dpq.user = ""
+	}
 	return
 }
 
@@ -22,6 +22,8 @@ import (
 
 const maxVerConfirmations = 3 // NOTE: minimum number of max-ver confirmations required to make the decision
 
+const intraKeyLen = 32 // cluster-internal key (see cmn.ClusterConfig.IntraKey)
+
 const (
 	metaction1 = "early-start-have-registrations"
 	metaction2 = "primary-started-up"
@@ -369,11 +371,13 @@ func (p *proxy) _cluConfig(smap *smapX) (config *globalConfig, err error) {
 	}
 	if config != nil && config.version() > 0 {
 		orig, disc = smap.configURLsIC(config.Proxy.OriginalURL, config.Proxy.DiscoveryURL)
-		if orig == config.Proxy.OriginalURL && disc == config.Proxy.DiscoveryURL {
+		switch {
+		case config.IntraKey == "":
+			// upgrading from a version that didn't have it
+		case orig == config.Proxy.OriginalURL && disc == config.Proxy.DiscoveryURL:
 			// no changes, good to go
 			return config, nil
-		}
-		if orig == "" && disc == "" {
+		case orig == "" && disc == "":
 			// likely no IC members yet, nothing can do
 			return config, nil
 		}
@@ -391,6 +395,9 @@ func (p *proxy) _cluConfig(smap *smapX) (config *globalConfig, err error) {
 				clone.Proxy.DiscoveryURL = disc
 			}
 			clone.UUID = smap.UUID
+			if clone.IntraKey == "" {
+				clone.IntraKey = cos.CryptoRandS(intraKeyLen)
+			}
 			return true, nil
 		},
 	})
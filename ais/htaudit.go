@@ -0,0 +1,346 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+)
+
+// Audit trail (see cmn.AuditConf):
+// - proxies record all user-originated (i.e., not intra-cluster) mutating requests to
+//   buckets, objects, cluster, nodes, and jobs (download, ETL, dsort), native and S3 API alike -
+//   except object PUT (datapath) and those requests forwarded to the primary that records them instead;
+// - targets, if configured, record (redirected) object PUT and DELETE; the user ID
+//   comes from the redirect URL and must be signed by the redirecting proxy (see userSig) -
+//   otherwise, the record is marked as unverified.
+// The request's action message (if any) gets captured via readActionMsg; the outcome
+// (HTTP status and error) - via auditWriter.
+
+const (
+	maxAuditErrBody = 4 * cos.KiB // (captured)
+	maxAuditErrLen  = 256         // (recorded)
+)
+
+type (
+	// request-scoped (via request context)
+	auditReq struct {
+		msg       *apc.ActMsg
+		forwarded bool // (non-primary => primary)
+	}
+	auditCtxKey struct{}
+
+	auditWriter struct {
+		http.ResponseWriter
+		errb   []byte
+		status int
+	}
+)
+
+// interface guard
+var _ http.ResponseWriter = (*auditWriter)(nil)
+
+func auditReqFrom(r *http.Request) *auditReq {
+	areq, _ := r.Context().Value(auditCtxKey{}).(*auditReq)
+	return areq
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// object PUT, including S3 multipart upload parts
+func isObjPut(r *http.Request) bool {
+	if r.Method != http.MethodPut {
+		return false
+	}
+	resource, items := auditPath(r.URL.Path)
+	return (resource == apc.Objects || resource == apc.S3) && len(items) > 1
+}
+
+// wrap (proxy) handler
+func (p *proxy) audited(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isMutating(r.Method) || !cmn.GCO.Get().Audit.Enabled || isObjPut(r) {
+			handler(w, r)
+			return
+		}
+		if p.isIntraAudit(r.Header) {
+			handler(w, r)
+			return
+		}
+		p.audit(w, r, handler, func() (string, bool) { return p.reqUser(r.Header), true })
+	}
+}
+
+// wrap (target) handler
+func (t *target) audited(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+			handler(w, r)
+			return
+		}
+		if conf := &cmn.GCO.Get().Audit; !conf.Enabled || !conf.Targets {
+			handler(w, r)
+			return
+		}
+		if t.isIntraAudit(r.Header) {
+			handler(w, r)
+			return
+		}
+		query := r.URL.Query()
+		if isRedirect(query) == "" {
+			handler(w, r)
+			return
+		}
+		t.audit(w, r, handler, func() (string, bool) { return redirectUser(query) })
+	}
+}
+
+// caller ID (a client-settable header) alone is not to be trusted; moreover, unlike isIntraCall
+// (that also trusts newly joined nodes), the caller must be present in the current Smap
+func (h *htrun) isIntraAudit(hdr http.Header) bool {
+	if h.isIntraCall(hdr, false /*from primary*/) != nil {
+		return false
+	}
+	return h.owner.smap.get().GetNode(hdr.Get(apc.HdrCallerID)) != nil
+}
+
+func (h *htrun) audit(w http.ResponseWriter, r *http.Request, handler http.HandlerFunc, user func() (string, bool)) {
+	var (
+		areq            = &auditReq{}
+		aw              = &auditWriter{ResponseWriter: w}
+		resource, items = auditPath(r.URL.Path)
+		entity          = h.auditEntity(r, resource, items) // (before the bucket in question may cease to exist)
+	)
+	handler(aw, r.WithContext(context.WithValue(r.Context(), auditCtxKey{}, areq)))
+	if areq.forwarded {
+		return
+	}
+
+	rec := &audit.Record{
+		Method: r.Method,
+		Entity: entity,
+		Status: aw.status,
+	}
+	var verified bool
+	if rec.User, verified = user(); rec.User != "" && !verified {
+		rec.Unverified = true
+	}
+	if rec.Status == 0 {
+		rec.Status = http.StatusOK
+	}
+	switch {
+	case areq.msg != nil:
+		rec.Action, rec.Name = areq.msg.Action, areq.msg.Name
+	case resource == apc.Objects:
+		rec.Action = strings.ToLower(r.Method)
+	case resource == apc.S3:
+		rec.Action = auditActionS3(r, items)
+	case len(items) > 0 && resource != apc.Buckets:
+		rec.Action = items[0] // e.g. "set-config"
+	}
+	if rec.Status >= http.StatusBadRequest {
+		rec.Err = auditErr(aw.errb)
+	}
+	audit.Add(rec)
+}
+
+// e.g. "/v1/buckets/abc" => ("buckets", ["abc"])
+func auditPath(path string) (resource string, items []string) {
+	path = strings.TrimPrefix(path, "/"+apc.Version+"/")
+	items = strings.Split(strings.Trim(path, "/"), "/")
+	resource, items = items[0], items[1:]
+	return
+}
+
+func (h *htrun) auditEntity(r *http.Request, resource string, items []string) string {
+	switch resource {
+	case apc.Buckets, apc.Objects:
+		if len(items) == 0 || items[0] == "" {
+			return resource
+		}
+		query := r.URL.Query()
+		bck := cmn.Bck{Name: items[0], Provider: query.Get(apc.QparamProvider)}
+		if bck.Provider == "" {
+			bck.Provider = apc.AIS
+		}
+		if ns := query.Get(apc.QparamNamespace); ns != "" {
+			bck.Ns = cmn.ParseNsUname(ns)
+		}
+		if resource == apc.Objects && len(items) > 1 {
+			objName, _ := url.PathUnescape(strings.Join(items[1:], "/"))
+			return bck.Cname(objName)
+		}
+		return bck.Cname("")
+	case apc.S3:
+		if len(items) == 0 || items[0] == "" {
+			return resource
+		}
+		bck := cmn.Bck{Name: items[0], Provider: apc.AIS}
+		if h.owner.bmd != nil {
+			if b, err, _ := meta.InitByNameOnly(items[0], h.owner.bmd); err == nil {
+				bck = *b.Bucket()
+			}
+		}
+		if len(items) > 1 {
+			objName, _ := url.PathUnescape(strings.Join(items[1:], "/"))
+			return bck.Cname(objName)
+		}
+		return bck.Cname("")
+	case apc.Cluster:
+		return "cluster"
+	case apc.Daemon:
+		if nodeID := r.Header.Get(apc.HdrNodeID); nodeID != "" {
+			return nodeID
+		}
+		return h.SID()
+	default:
+		return resource // e.g. "download"
+	}
+}
+
+// e.g. "put-policy", "delete-listrange" (multi-object delete), "create-bck", "delete" (object)
+func auditActionS3(r *http.Request, items []string) string {
+	var (
+		query  = r.URL.Query()
+		method = strings.ToLower(r.Method)
+	)
+	if len(items) > 1 {
+		if query.Has(s3.QparamMptUploads) || query.Has(s3.QparamMptUploadID) {
+			return method + "-mpt"
+		}
+		return method
+	}
+	if query.Has(s3.QparamMultiDelete) {
+		return apc.ActDeleteObjects
+	}
+	for _, sub := range []string{s3.QparamPolicy, s3.QparamACL, s3.QparamLifecycle, s3.QparamVersioning} {
+		if query.Has(sub) {
+			return method + "-" + sub
+		}
+	}
+	switch r.Method {
+	case http.MethodPut:
+		return apc.ActCreateBck
+	case http.MethodDelete:
+		return apc.ActDestroyBck
+	default:
+		return method
+	}
+}
+
+func auditErr(b []byte) (s string) {
+	var herr cmn.ErrHTTP
+	if err := cos.JSON.Unmarshal(b, &herr); err == nil && herr.Message != "" {
+		s = herr.Message
+	} else {
+		s = strings.TrimSpace(string(b))
+	}
+	if len(s) > maxAuditErrLen {
+		s = s[:maxAuditErrLen] + "..."
+	}
+	return s
+}
+
+/////////////////
+// auditWriter //
+/////////////////
+
+func (aw *auditWriter) WriteHeader(status int) {
+	if aw.status == 0 {
+		aw.status = status
+	}
+	aw.ResponseWriter.WriteHeader(status)
+}
+
+func (aw *auditWriter) Write(b []byte) (int, error) {
+	if aw.status == 0 {
+		aw.status = http.StatusOK
+	}
+	if aw.status >= http.StatusBadRequest && len(aw.errb) < maxAuditErrBody {
+		n := min(len(b), maxAuditErrBody-len(aw.errb))
+		aw.errb = append(aw.errb, b[:n]...)
+	}
+	return aw.ResponseWriter.Write(b)
+}
+
+// (http.ResponseController)
+func (aw *auditWriter) Unwrap() http.ResponseWriter { return aw.ResponseWriter }
+
+//
+// redirected user
+//
+
+// The AuthN user ID of a redirected request is signed by the redirecting proxy:
+// HMAC-SHA256(user, QparamUnixTime) keyed with the cluster-internal key that the
+// primary generates and distributes via metasync (see cmn.ClusterConfig.IntraKey) -
+// that is, independently of how AuthN tokens are verified (shared secret or JWKS).
+func userSig(user, ptime string) string {
+	key := cmn.GCO.Get().IntraKey
+	if key == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, cos.UnsafeB(key))
+	mac.Write(cos.UnsafeB(user))
+	mac.Write([]byte{'\n'})
+	mac.Write(cos.UnsafeB(ptime))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func verifyUser(user, ptime, sig string) bool {
+	if sig == "" {
+		return false
+	}
+	expected := userSig(user, ptime)
+	return expected != "" && hmac.Equal(cos.UnsafeB(expected), cos.UnsafeB(sig))
+}
+
+// the redirecting proxy appends its query parameters - hence, the last values
+func redirectUser(query url.Values) (user string, verified bool) {
+	last := func(key string) string {
+		if vals := query[key]; len(vals) > 0 {
+			return vals[len(vals)-1]
+		}
+		return ""
+	}
+	if user = last(apc.QparamUser); user != "" {
+		verified = verifyUser(user, last(apc.QparamUnixTime), last(apc.QparamUserSig))
+	}
+	return user, verified
+}
+
+//
+// query
+//
+
+func auditQuery(query url.Values) *audit.Query {
+	q := &audit.Query{User: query.Get(apc.QparamUser), Action: query.Get(apc.QparamAuditAction)}
+	if s := query.Get(apc.QparamAuditSince); s != "" {
+		q.Since, _ = strconv.ParseInt(s, 10, 64)
+	}
+	if s := query.Get(apc.QparamAuditLimit); s != "" {
+		q.Limit, _ = strconv.Atoi(s)
+	}
+	return q
+}
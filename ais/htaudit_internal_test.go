@@ -0,0 +1,182 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestAuditRecord(t *testing.T) {
+	config := cmn.GCO.BeginUpdate()
+	config.Audit = cmn.AuditConf{Enabled: true}
+	cmn.GCO.CommitUpdate(config)
+	audit.Init(t.TempDir(), "p1")
+	defer audit.Stop()
+
+	h := &htrun{si: &meta.Snode{DaeID: "p1", DaeType: apc.Proxy}}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		msg, err := h.readActionMsg(w, r)
+		if err != nil {
+			return
+		}
+		if msg.Action == apc.ActDestroyBck {
+			cmn.WriteErr(w, r, errors.New("bucket is busy"), http.StatusConflict)
+			return
+		}
+		w.Write([]byte("ok"))
+	}
+	for _, action := range []string{apc.ActCreateBck, apc.ActDestroyBck} {
+		body := cos.MustMarshal(&apc.ActMsg{Action: action, Name: "abc"})
+		r := httptest.NewRequest(http.MethodPost, "/v1/buckets/abc?provider=aws", bytes.NewReader(body))
+		h.audit(httptest.NewRecorder(), r, handler, func() (string, bool) { return "alice", true })
+	}
+	// cluster-wide config update (no action message)
+	r := httptest.NewRequest(http.MethodPut, "/v1/cluster/set-config?log.level=4", http.NoBody)
+	h.audit(httptest.NewRecorder(), r, func(http.ResponseWriter, *http.Request) {}, func() (string, bool) { return "", false })
+
+	recs, err := audit.Get(&audit.Query{})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == 3, "expected 3 records, got %d", len(recs))
+
+	rec := recs[0]
+	tassert.Errorf(t, rec.Action == apc.ActSetConfig && rec.Entity == "cluster" && rec.Status == http.StatusOK,
+		"unexpected %+v", rec)
+	rec = recs[1]
+	tassert.Errorf(t, rec.Action == apc.ActDestroyBck && rec.Entity == "s3://abc" && rec.User == "alice",
+		"unexpected %+v", rec)
+	tassert.Errorf(t, rec.Status == http.StatusConflict && rec.Err == "bucket is busy", "unexpected outcome %+v", rec)
+	rec = recs[2]
+	tassert.Errorf(t, rec.Action == apc.ActCreateBck && rec.Status == http.StatusOK && rec.Err == "",
+		"unexpected %+v", rec)
+	tassert.Errorf(t, rec.Node == "p1" && rec.Method == http.MethodPost && rec.Name == "abc", "unexpected %+v", rec)
+}
+
+// caller ID alone (a client-settable header) does not make a request intra-cluster;
+// S3 API and object DELETE get recorded as well, object PUT does not
+func TestAuditCallers(t *testing.T) {
+	p := newPrimary()
+	config := cmn.GCO.BeginUpdate()
+	config.Audit = cmn.AuditConf{Enabled: true}
+	cmn.GCO.CommitUpdate(config)
+	audit.Init(t.TempDir(), p.SID())
+	defer audit.Stop()
+
+	handler := p.audited(func(w http.ResponseWriter, _ *http.Request) { w.Write([]byte("ok")) })
+	tests := []struct {
+		method, path string
+		callerID     string
+	}{
+		{http.MethodPost, "/v1/buckets/abc", "forged"},
+		{http.MethodDelete, "/v1/buckets/abc", p.SID()}, // intra-cluster (not recorded)
+		{http.MethodDelete, "/v1/objects/abc/obj", ""},
+		{http.MethodPut, "/v1/objects/abc/obj", ""}, // (not recorded)
+		{http.MethodPut, "/s3/abc?policy", ""},
+		{http.MethodPost, "/s3/abc?delete", ""},
+		{http.MethodDelete, "/s3/abc/obj", ""},
+		{http.MethodPut, "/s3/abc/obj", ""}, // (not recorded)
+		{http.MethodDelete, "/s3/abc", "forged"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, test.path, http.NoBody)
+		if test.callerID != "" {
+			r.Header.Set(apc.HdrCallerID, test.callerID)
+			r.Header.Set(apc.HdrCallerName, test.callerID)
+			r.Header.Set(apc.HdrCallerSmapVer, "1000")
+		}
+		handler(httptest.NewRecorder(), r)
+	}
+
+	recs, err := audit.Get(&audit.Query{})
+	tassert.CheckFatal(t, err)
+	expected := []struct{ action, entity string }{
+		{apc.ActDestroyBck, "ais://abc"},
+		{"delete", "ais://abc/obj"},
+		{apc.ActDeleteObjects, "ais://abc"},
+		{"put-policy", "ais://abc"},
+		{"delete", "ais://abc/obj"},
+		{"", "ais://abc"}, // (no action message)
+	}
+	tassert.Fatalf(t, len(recs) == len(expected), "expected %d records, got %d", len(expected), len(recs))
+	for i, exp := range expected {
+		rec := recs[i]
+		tassert.Errorf(t, rec.Action == exp.action && rec.Entity == exp.entity, "[%d] expected (%q, %q), got %+v",
+			i, exp.action, exp.entity, rec)
+	}
+}
+
+func TestRedirectUser(t *testing.T) {
+	// AuthN tokens validated via JWKS - no shared secret
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Enabled = true
+	config.Auth.Secret = ""
+	config.Auth.JWKSURL = "https://authn.example.com/.well-known/jwks.json"
+	config.IntraKey = cos.CryptoRandS(intraKeyLen)
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Auth.Enabled = false
+		config.Auth.JWKSURL = ""
+		config.IntraKey = ""
+		cmn.GCO.CommitUpdate(config)
+	}()
+
+	ptime := cos.UnixNano2S(time.Now().UnixNano())
+	query := url.Values{
+		apc.QparamUnixTime: []string{ptime},
+		apc.QparamUser:     []string{"alice"},
+		apc.QparamUserSig:  []string{userSig("alice", ptime)},
+	}
+	user, verified := redirectUser(query)
+	tassert.Errorf(t, user == "alice" && verified, "expected verified alice, got (%q, %t)", user, verified)
+
+	// forged by the client
+	forged := url.Values{apc.QparamUnixTime: []string{ptime}, apc.QparamUser: []string{"bob"}}
+	forged.Set(apc.QparamUserSig, query.Get(apc.QparamUserSig))
+	user, verified = redirectUser(forged)
+	tassert.Errorf(t, user == "bob" && !verified, "expected unverified bob, got (%q, %t)", user, verified)
+
+	// client-provided values precede those appended by the proxy
+	query[apc.QparamUser] = append([]string{"bob"}, query[apc.QparamUser]...)
+	user, verified = redirectUser(query)
+	tassert.Errorf(t, user == "alice" && verified, "expected verified alice, got (%q, %t)", user, verified)
+
+	// datapath (per-user metrics)
+	for _, tc := range []struct {
+		query url.Values
+		user  string
+	}{{query, "alice"}, {forged, ""}} {
+		dpq := dpqAlloc()
+		tassert.CheckFatal(t, dpq.parse(tc.query.Encode()))
+		tassert.Errorf(t, dpq.user == tc.user, "expected user %q, got %q", tc.user, dpq.user)
+		dpqFree(dpq)
+	}
+
+	// signed by another cluster
+	config = cmn.GCO.BeginUpdate()
+	config.IntraKey = cos.CryptoRandS(intraKeyLen)
+	cmn.GCO.CommitUpdate(config)
+	_, verified = redirectUser(query)
+	tassert.Errorf(t, !verified, "expected unverified with a different cluster key")
+
+	// no cluster key - nothing to verify with
+	config = cmn.GCO.BeginUpdate()
+	config.IntraKey = ""
+	cmn.GCO.CommitUpdate(config)
+	_, verified = redirectUser(query)
+	tassert.Errorf(t, !verified, "expected unverified without cluster key")
+}
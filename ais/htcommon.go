@@ -24,6 +24,7 @@ import (
 
 	"github.com/NVIDIA/aistore/3rdparty/golang/mux"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -396,6 +397,7 @@ type (
 
 	cresLso   struct{} // -> cmn.LsoRes
 	cresBsumm struct{} // -> cmn.AllBsummResults
+	cresAudit struct{} // -> []*audit.Record
 )
 
 var (
@@ -410,6 +412,7 @@ var (
 	_ cresv = cresIC{}
 	_ cresv = cresBM{}
 	_ cresv = cresBsumm{}
+	_ cresv = cresAudit{}
 )
 
 func (res *callResult) read(body io.Reader)  { res.bytes, res.err = io.ReadAll(body) }
@@ -456,6 +459,9 @@ func (c cresBM) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jr
 func (cresBsumm) newV() any                              { return &cmn.AllBsummResults{} }
 func (c cresBsumm) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
 
+func (cresAudit) newV() any                              { return &[]*audit.Record{} }
+func (c cresAudit) read(res *callResult, body io.Reader) { res.v = c.newV(); res.jread(body) }
+
 ////////////////
 // nlogWriter //
 ////////////////
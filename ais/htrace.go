@@ -0,0 +1,49 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/tracing"
+)
+
+// Distributed tracing: server-side span for a given (object) request.
+// The parent trace-context (if any) comes from the redirecting proxy
+// (apc.QparamTraceparent) or the client (W3C `traceparent` header).
+// Returns the request that carries the span (for the span's children, e.g. backend calls)
+// or the original request, if not sampled.
+func (h *htrun) traceReq(r *http.Request, traceparent, name, cname string) (*http.Request, *tracing.Span) {
+	ctx, span := tracing.Start(tracing.Extract(r.Context(), r.Header, traceparent), name, tracing.KindServer)
+	if span == nil {
+		return r, nil
+	}
+	span.SetAttr("ais.node", h.SID())
+	span.SetAttr("ais.object", cname)
+	span.SetAttr("http.method", r.Method)
+	return r.WithContext(ctx), span
+}
+
+// request ID (structured logging): trace-id of the request's span or its parent
+// trace-context (QparamTraceparent or `traceparent`), if any
+func reqID(r *http.Request) string {
+	return tracing.ReqID(r.Context(), r.Header, r.URL.Query().Get(apc.QparamTraceparent))
+}
+
+// structured-logging fields for a given (object) request
+func reqFields(r *http.Request, traceparent string) *nlog.Fields {
+	f := &nlog.Fields{Module: cos.SmoduleName(cos.SmoduleAIS)}
+	switch {
+	case r == nil:
+	case traceparent != "":
+		f.ReqID = tracing.ReqID(r.Context(), r.Header, traceparent)
+	default:
+		f.ReqID = reqID(r)
+	}
+	return f
+}
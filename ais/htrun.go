@@ -24,6 +24,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/env"
+	"github.com/NVIDIA/aistore/audit"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/archive"
 	"github.com/NVIDIA/aistore/cmn/atomic"
@@ -1048,7 +1049,7 @@ func (h *htrun) httpdaeget(w http.ResponseWriter, r *http.Request, query url.Val
 		)
 		// hide secret
 		c = *config
-		c.Auth.Secret = "**********"
+		c.Redact()
 		body = &c
 	case apc.WhatSmap:
 		body = h.owner.smap.get()
@@ -1083,6 +1084,13 @@ func (h *htrun) httpdaeget(w http.ResponseWriter, r *http.Request, query url.Val
 		body = statsNode
 	case apc.WhatMetricNames:
 		body = h.statsT.GetMetricNames()
+	case apc.WhatAudit:
+		recs, err := audit.Get(auditQuery(query))
+		if err != nil {
+			h.writeErr(w, r, err)
+			return
+		}
+		body = recs
 	case apc.WhatNodeStatsAndStatusV322:
 		ds := h.statsAndStatusV322()
 		daeStats := h.statsT.GetStatsV322()
@@ -2221,6 +2229,9 @@ func (h *htrun) newAmsg(actionMsg *apc.ActMsg, bmd *bucketMD, uuid ...string) *a
 func (*htrun) readActionMsg(w http.ResponseWriter, r *http.Request) (msg *apc.ActMsg, err error) {
 	msg = &apc.ActMsg{}
 	err = cmn.ReadJSON(w, r, msg)
+	if areq := auditReqFrom(r); areq != nil && err == nil {
+		areq.msg = msg
+	}
 	return
 }
 
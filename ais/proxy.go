@@ -38,6 +38,7 @@ import (
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xact"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	jsoniter "github.com/json-iterator/go"
@@ -62,6 +63,7 @@ type (
 		rproxy     reverseProxy
 		notifs     notifs
 		lstca      lstca
+		quota      pquota
 		reg        struct {
 			pool nodeRegPool
 			mu   sync.RWMutex
@@ -203,6 +205,7 @@ func (p *proxy) Run() error {
 	p.notifs.init(p)
 	p.ic.init(p)
 	p.qm.init()
+	p.quota.init(p)
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -211,15 +214,15 @@ func (p *proxy) Run() error {
 		{r: apc.Reverse, h: p.reverseHandler, net: accessNetPublic},
 
 		// pubnet handlers: cluster must be started
-		{r: apc.Buckets, h: p.bucketHandler, net: accessNetPublic},
-		{r: apc.Objects, h: p.objectHandler, net: accessNetPublic},
-		{r: apc.Download, h: p.downloadHandler, net: accessNetPublic},
-		{r: apc.ETL, h: p.etlHandler, net: accessNetPublic},
-		{r: apc.Sort, h: p.dsortHandler, net: accessNetPublic},
+		{r: apc.Buckets, h: p.audited(p.bucketHandler), net: accessNetPublic},
+		{r: apc.Objects, h: p.audited(p.objectHandler), net: accessNetPublic},
+		{r: apc.Download, h: p.audited(p.downloadHandler), net: accessNetPublic},
+		{r: apc.ETL, h: p.audited(p.etlHandler), net: accessNetPublic},
+		{r: apc.Sort, h: p.audited(p.dsortHandler), net: accessNetPublic},
 
 		{r: apc.IC, h: p.ic.handler, net: accessNetIntraControl},
-		{r: apc.Daemon, h: p.daemonHandler, net: accessNetPublicControl},
-		{r: apc.Cluster, h: p.clusterHandler, net: accessNetPublicControl},
+		{r: apc.Daemon, h: p.audited(p.daemonHandler), net: accessNetPublicControl},
+		{r: apc.Cluster, h: p.audited(p.clusterHandler), net: accessNetPublicControl},
 		{r: apc.Tokens, h: p.tokenHandler, net: accessNetPublic},
 
 		{r: apc.Metasync, h: p.metasyncHandler, net: accessNetIntraControl},
@@ -229,7 +232,7 @@ func (p *proxy) Run() error {
 		{r: apc.Notifs, h: p.notifs.handler, net: accessNetIntraControl},
 
 		// S3 compatibility
-		{r: "/" + apc.S3, h: p.s3Handler, net: accessNetPublic},
+		{r: "/" + apc.S3, h: p.audited(p.s3Handler), net: accessNetPublic},
 
 		// "easy URL"
 		{r: "/" + apc.GSScheme, h: p.easyURLHandler, net: accessNetPublic},
@@ -551,7 +554,7 @@ func (p *proxy) easyURLHandler(w http.ResponseWriter, r *http.Request) {
 		} else if !strings.Contains(r.URL.RawQuery, apc.QparamProvider) {
 			r.URL.RawQuery += "&" + apc.QparamProvider + "=" + provider
 		}
-		p.bucketHandler(w, r)
+		p.audited(p.bucketHandler)(w, r)
 		return
 	}
 	// num items: 2
@@ -584,9 +587,9 @@ func (p *proxy) easyURLHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	// and finally
 	if objName != "" {
-		p.objectHandler(w, r)
+		p.audited(p.objectHandler)(w, r)
 	} else {
-		p.bucketHandler(w, r)
+		p.audited(p.bucketHandler)(w, r)
 	}
 }
 
@@ -745,8 +748,11 @@ func (p *proxy) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ..
 	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
 		nlog.Infoln("GET " + bck.Cname(objName) + " => " + tsi.String())
 	}
+	r, span := p.traceReq(r, "", "proxy.redirect", bck.Cname(objName))
+	span.SetAttr("ais.target", tsi.ID())
 	redirectURL := p.redirectURL(r, tsi, time.Now() /*started*/, cmn.NetIntraData, netPub)
 	http.Redirect(w, r, redirectURL, http.StatusMovedPermanently)
+	span.End(nil)
 
 	// 4. stats
 	p.statsT.Inc(stats.GetCount)
@@ -792,6 +798,9 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 	if err != nil {
 		return
 	}
+	if p.checkQuota(w, r, bck, max(r.ContentLength, 0)) != nil {
+		return
+	}
 
 	// 3. redirect
 	var (
@@ -827,8 +836,11 @@ func (p *proxy) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiRe
 		nlog.Infof("%s %s => %s%s", verb, bck.Cname(objName), tsi.StringEx(), s)
 	}
 
+	r, span := p.traceReq(r, "", "proxy.redirect", bck.Cname(objName))
+	span.SetAttr("ais.target", tsi.ID())
 	redirectURL := p.redirectURL(r, tsi, started, cmn.NetIntraData, netPub)
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+	span.End(nil)
 
 	// 4. stats
 	if !appendTyProvided {
@@ -1343,6 +1355,10 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 			nlog.Infof(warnDstNotExist, p, bckTo, bckFrom)
 		}
 
+		if p.checkQuota(w, r, bckTo, 0) != nil {
+			return
+		}
+
 		// start x-tcb or x-tco
 		if v := query.Get(apc.QparamFltPresence); v != "" {
 			fltPresence, _ = strconv.Atoi(v)
@@ -1406,6 +1422,9 @@ func (p *proxy) _bckpost(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg
 				nlog.Infof(warnDstNotExist, p, bckTo, bck)
 			}
 		}
+		if p.checkQuota(w, r, bckTo, 0) != nil {
+			return
+		}
 
 		xid, err = p.tcobjs(bck, bckTo, cmn.GCO.Get(), msg, tcomsg)
 		if err != nil {
@@ -1607,6 +1626,12 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 		}
 	}
 
+	// soft-deleted objects are local by definition (see bucket property "soft_delete")
+	if lsmsg.IsFlagSet(apc.LsDeleted) && bck.IsRemote() {
+		p.writeErrMsg(w, r, "cannot list soft-deleted objects in remote bucket "+bck.Cname(""))
+		return
+	}
+
 	// default props & flags => user-provided message
 	switch {
 	case lsmsg.Props == "":
@@ -1621,7 +1646,7 @@ func (p *proxy) listObjects(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	case lsmsg.Props == apc.GetPropsNameSize:
 		lsmsg.SetFlag(apc.LsNameSize)
 	}
-	if bck.IsHTTP() || lsmsg.IsFlagSet(apc.LsArchDir) {
+	if lsmsg.IsFlagSet(apc.LsArchDir) {
 		lsmsg.SetFlag(apc.LsObjCached)
 	}
 
@@ -1686,7 +1711,8 @@ func (p *proxy) lsPage(bck *meta.Bck, amsg *apc.ActMsg, lsmsg *apc.LsoMsg, hdr h
 	}
 
 	if listRemote {
-		if lsmsg.StartAfter != "" {
+		// (listing via bucket inventory supports start-after)
+		if lsmsg.StartAfter != "" && !cos.IsParseBool(hdr.Get(apc.HdrInventory)) {
 			// TODO: remote AIS first, then Cloud
 			return nil, fmt.Errorf("%s option --start_after (%s) not yet supported for remote buckets (%s)",
 				lsotag, lsmsg.StartAfter, bck)
@@ -1771,7 +1797,7 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 	if err != nil {
 		return
 	}
-	if msg.Action == apc.ActRenameObject {
+	if msg.Action == apc.ActRenameObject || msg.Action == apc.ActUndelete {
 		apireq.after = 2
 	}
 	if err := p.parseReq(w, r, apireq); err != nil {
@@ -1808,6 +1834,15 @@ func (p *proxy) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *apiR
 			return
 		}
 		p.redirectObjAction(w, r, bck, apireq.items[1], msg)
+	case apc.ActUndelete:
+		if err := p.checkAccess(w, r, bck, apc.AcePUT); err != nil {
+			return
+		}
+		if !bck.Props.SoftDel.Enabled {
+			p.writeErrActf(w, r, msg.Action, "soft-delete is not enabled for %s", bck)
+			return
+		}
+		p.redirectObjAction(w, r, bck, apireq.items[1], msg)
 	case apc.ActPromote:
 		if err := p.checkAccess(w, r, bck, apc.AcePromote); err != nil {
 			return
@@ -2201,6 +2236,17 @@ func (p *proxy) redirectURL(r *http.Request, si *meta.Snode, ts time.Time, netIn
 		apc.QparamProxyID:  []string{p.SID()},
 		apc.QparamUnixTime: []string{cos.UnixNano2S(ts.UnixNano())},
 	}
+	if tp := tracing.Traceparent(r.Context()); tp != "" { // propagate (see traceReq)
+		query.Set(apc.QparamTraceparent, tp)
+	}
+	if config := cmn.GCO.Get(); config.Metrics.PerUser || (config.Audit.Enabled && config.Audit.Targets) {
+		if user := p.reqUser(r.Header); user != "" {
+			query.Set(apc.QparamUser, user)
+			if sig := userSig(user, query.Get(apc.QparamUnixTime)); sig != "" {
+				query.Set(apc.QparamUserSig, sig)
+			}
+		}
+	}
 	redirect += query.Encode()
 	return
 }
@@ -2595,11 +2641,17 @@ func (p *proxy) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		ds := p.statsAndStatus()
 		daeStats := p.statsT.GetStats()
 		ds.Tracker = daeStats.Tracker
+		ds.Latency = daeStats.Latency
 		p.fillNsti(&ds.Cluster)
 		p.writeJSON(w, r, ds, what)
 
 	case apc.WhatSysInfo:
 		p.writeJSON(w, r, apc.GetMemCPU(), what)
+	case apc.WhatAudit:
+		if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+			return
+		}
+		p.htrun.httpdaeget(w, r, query, nil /*htext*/)
 	case apc.WhatSmap:
 		const retries = 16
 		var (
@@ -3099,7 +3151,7 @@ func (p *proxy) rootHandler(w http.ResponseWriter, r *http.Request) {
 	default:
 		r.URL.Path = fs3 + "/" + r.URL.Path
 	}
-	p.s3Handler(w, r)
+	p.audited(p.s3Handler)(w, r)
 }
 
 // GET | HEAD vanilla http(s) location via `ht://` bucket with the corresponding `OrigURLBck`
@@ -5,20 +5,40 @@
 package ais
 
 import (
+	"context"
+	"crypto"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
+	ratomic "sync/atomic"
 	"time"
 
+	"github.com/NVIDIA/aistore/ais/s3"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
-	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// With RS256 and ES256 signing, AuthN publishes its public keys as a JWK set (see `auth.jwks_url`).
+// Gateways cache the keys, refresh them periodically, and refetch on demand
+// upon encountering a token signed with an unknown key (e.g., right after AuthN rotates its key).
+// The on-demand refetch is synchronous but bounded: one fetch at a time, with all concurrent
+// requests (that carry unknown keys) waiting for it - outside the auth manager lock - for
+// at most jwksWaitTimeout, and then retrying validation once.
+
+const (
+	jwksRefreshIval = 10 * time.Minute
+	jwksRefetchIval = 10 * time.Second // min interval between (on-demand) refetches
+	jwksWaitTimeout = 3 * time.Second  // max time to wait for on-demand refetch
 )
 
 type (
@@ -32,6 +52,20 @@ type (
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
 		version       int64
+		jwks          jwksCache
+	}
+	jwksCache struct {
+		public    ratomic.Pointer[map[string]crypto.PublicKey]
+		clientH   *http.Client
+		clientTLS *http.Client
+		inflight  *jwksFetch // on-demand refetch in progress, if any
+		url       string     // last fetched
+		fetched   time.Time  // ditto
+		mu        sync.Mutex // (fetch)
+		fmu       sync.Mutex // (inflight)
+	}
+	jwksFetch struct {
+		done chan struct{}
 	}
 )
 
@@ -40,7 +74,30 @@ type (
 /////////////////
 
 func newAuthManager() *authManager {
-	return &authManager{tkList: make(tkList), revokedTokens: make(map[string]bool), version: 1}
+	a := &authManager{tkList: make(tkList), revokedTokens: make(map[string]bool), version: 1}
+	a.jwks.clientH, a.jwks.clientTLS = cmn.NewDefaultClients(cmn.GCO.Get().Client.Timeout.D())
+	hk.Reg("jwks"+hk.NameSuffix, a.jwks.housekeep, jwksRefreshIval)
+	return a
+}
+
+// verification keys: shared secret and/or (cached) AuthN public keys
+func (a *authManager) keys(config *cmn.Config) *tok.Keys {
+	keys := &tok.Keys{Secret: config.Auth.Secret}
+	if config.Auth.JWKSURL != "" {
+		if !config.Auth.AllowHMAC {
+			keys.Secret = ""
+		}
+		keys.Public = map[string]crypto.PublicKey{} // (non-nil: reject HMAC-signed tokens unless secret is set)
+		if public := a.jwks.public.Load(); public != nil {
+			keys.Public = *public
+		}
+	}
+	return keys
+}
+
+// (is called under lock)
+func (a *authManager) decrypt(token string) (*tok.Token, error) {
+	return tok.DecryptToken(token, a.keys(cmn.GCO.Get()))
 }
 
 // Add tokens to list of invalid ones. After that it cleans up the list
@@ -66,13 +123,19 @@ func (a *authManager) updateRevokedList(newRevoked *tokenList) (allRevoked *toke
 		Tokens:  make([]string, 0, len(a.revokedTokens)),
 		Version: a.version,
 	}
-	var (
-		now    = time.Now()
-		secret = cmn.GCO.Get().Auth.Secret
-	)
+	now := time.Now()
 	for token := range a.revokedTokens {
-		tk, err := tok.DecryptToken(token, secret)
-		debug.AssertNoErr(err)
+		tk, err := a.decrypt(token)
+		if err != nil {
+			// keep revoked tokens signed with (not yet) known keys
+			if errors.Is(err, tok.ErrUnknownKey) {
+				allRevoked.Tokens = append(allRevoked.Tokens, token)
+			} else {
+				nlog.Warningln("removing invalid revoked token:", err)
+				delete(a.revokedTokens, token)
+			}
+			continue
+		}
 		if tk.Expires.Before(now) {
 			delete(a.revokedTokens, token)
 		} else {
@@ -108,6 +171,22 @@ func (a *authManager) revokedTokenList() (allRevoked *tokenList) {
 //
 // Returns decrypted token information if it is valid
 func (a *authManager) validateToken(token string) (tk *tok.Token, err error) {
+	tk, err = a._validate(token)
+	if err == nil || !errors.Is(err, tok.ErrUnknownKey) {
+		return tk, err
+	}
+	// signed with a key we don't know (yet) - e.g., AuthN has just rotated its key
+	if u := cmn.GCO.Get().Auth.JWKSURL; u != "" && a.jwks.refetch(u, jwksWaitTimeout) {
+		tk, err = a._validate(token)
+	}
+	if err != nil {
+		nlog.Errorln(err)
+		err = tok.ErrInvalidToken
+	}
+	return tk, err
+}
+
+func (a *authManager) _validate(token string) (tk *tok.Token, err error) {
 	a.Lock()
 	if _, ok := a.revokedTokens[token]; ok {
 		tk, err = nil, fmt.Errorf("%v: %s", tok.ErrTokenRevoked, tk)
@@ -123,11 +202,11 @@ func (a *authManager) validateToken(token string) (tk *tok.Token, err error) {
 func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, error) {
 	tk, ok := a.tkList[token]
 	if !ok || tk == nil {
-		var (
-			err    error
-			secret = cmn.GCO.Get().Auth.Secret
-		)
-		if tk, err = tok.DecryptToken(token, secret); err != nil {
+		var err error
+		if tk, err = a.decrypt(token); err != nil {
+			if errors.Is(err, tok.ErrUnknownKey) {
+				return nil, err // (the caller may refetch and retry)
+			}
 			nlog.Errorln(err)
 			return nil, tok.ErrInvalidToken
 		}
@@ -140,6 +219,100 @@ func (a *authManager) validateAddRm(token string, now time.Time) (*tok.Token, er
 	return tk, nil
 }
 
+///////////////
+// jwksCache //
+///////////////
+
+func (c *jwksCache) housekeep() time.Duration {
+	config := cmn.GCO.Get()
+	if !config.Auth.Enabled || config.Auth.JWKSURL == "" {
+		c.public.Store(nil)
+		return jwksRefreshIval
+	}
+	c.refresh(config.Auth.JWKSURL, true /*periodic*/)
+	return jwksRefreshIval
+}
+
+// on demand (unknown key): start fetching unless already in progress, and wait for
+// the (single) fetch to complete - but not longer than the specified timeout;
+// returns false upon timeout (in which case the fetch keeps going in the background)
+func (c *jwksCache) refetch(u string, timeout time.Duration) bool {
+	c.fmu.Lock()
+	f := c.inflight
+	if f == nil {
+		f = &jwksFetch{done: make(chan struct{})}
+		c.inflight = f
+		go func() {
+			c.refresh(u, false /*periodic*/)
+			c.fmu.Lock()
+			c.inflight = nil
+			c.fmu.Unlock()
+			close(f.done)
+		}()
+	}
+	c.fmu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-f.done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (c *jwksCache) fetching() bool {
+	c.fmu.Lock()
+	f := c.inflight
+	c.fmu.Unlock()
+	return f != nil
+}
+
+// returns true if the keys were (re)fetched
+func (c *jwksCache) refresh(u string, periodic bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !periodic && u == c.url && time.Since(c.fetched) < jwksRefetchIval {
+		return false
+	}
+	c.url, c.fetched = u, time.Now()
+	public, err := c.fetch(u)
+	if err != nil {
+		nlog.Errorln("failed to fetch JWKS:", err)
+		return false
+	}
+	c.public.Store(&public)
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln("fetched", len(public), "public key(s) from", u)
+	}
+	return true
+}
+
+func (c *jwksCache) fetch(u string) (map[string]crypto.PublicKey, error) {
+	client := c.clientH
+	if strings.HasPrefix(u, "https://") {
+		client = c.clientTLS
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer cos.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	var jwks tok.JWKS
+	if err := jsoniter.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, fmt.Errorf("GET %s: %w", u, err)
+	}
+	return jwks.PublicKeys()
+}
+
 ///////////////
 // tokenList //
 ///////////////
@@ -209,7 +382,10 @@ func (p *proxy) httpTokenDelete(w http.ResponseWriter, r *http.Request) {
 func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	token, err := tok.ExtractToken(hdr)
 	if err != nil {
-		return nil, err
+		// S3 clients: AuthN token in place of the AWS session token (e.g., AWS_SESSION_TOKEN)
+		if token = hdr.Get(s3.HeaderSecurityToken); token == "" {
+			return nil, err
+		}
 	}
 	tk, err := p.authn.validateToken(token)
 	if err != nil {
@@ -219,6 +395,25 @@ func (p *proxy) validateToken(hdr http.Header) (*tok.Token, error) {
 	return tk, nil
 }
 
+// AuthN user ID of a given request (per-user metrics and audit - see cmn.MetricsConf, cmn.AuditConf);
+// by the time we redirect (or record the request) the token is normally validated and cached
+func (p *proxy) reqUser(hdr http.Header) string {
+	if !cmn.Rom.AuthEnabled() {
+		return ""
+	}
+	token, err := tok.ExtractToken(hdr)
+	if err != nil {
+		if token = hdr.Get(s3.HeaderSecurityToken); token == "" {
+			return ""
+		}
+	}
+	tk, err := p.authn.validateToken(token)
+	if err != nil {
+		return ""
+	}
+	return tk.UserID
+}
+
 // When AuthN is on, accessing a bucket requires two permissions:
 //   - access to the bucket is granted to a user
 //   - bucket ACL allows the required operation
@@ -264,12 +459,28 @@ func (p *proxy) access(hdr http.Header, bck *meta.Bck, ace apc.AccessAttrs) (err
 			}
 			return err
 		}
-		uid := p.owner.smap.Get().UUID
+		var (
+			allowed bool
+			uid     = p.owner.smap.Get().UUID
+		)
+		// cluster ID and expiration: unconditionally
+		if err := tk.CheckCluster(uid); err != nil {
+			return err
+		}
 		if bck != nil {
 			bucket = bck.Bucket()
+			if !tk.IsAdmin && bck.Props != nil {
+				var denied bool
+				if allowed, denied = bck.Props.Grants.Check(tk.UserID, ace); denied {
+					return fmt.Errorf("%w: [%s, bucket %s, denied by bucket grants]", tok.ErrNoPermissions, tk, bck.Cname(""))
+				}
+			}
 		}
-		if err := tk.CheckPermissions(uid, bucket, ace); err != nil {
-			return err
+		// bucket grants (if any) take precedence over the token's ACL
+		if !allowed {
+			if err := tk.CheckPermissions(uid, bucket, ace); err != nil {
+				return err
+			}
 		}
 	}
 	if bck == nil {
@@ -0,0 +1,133 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func newTestAuthManager() *authManager {
+	a := &authManager{tkList: make(tkList), revokedTokens: make(map[string]bool), version: 1}
+	a.jwks.clientH, a.jwks.clientTLS = http.DefaultClient, http.DefaultClient
+	return a
+}
+
+func setTestJWKS(u, secret string, allowHMAC bool) (restore func()) {
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Enabled, config.Auth.JWKSURL, config.Auth.Secret, config.Auth.AllowHMAC = true, u, secret, allowHMAC
+	cmn.GCO.CommitUpdate(config)
+	return func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Auth.Enabled, config.Auth.JWKSURL, config.Auth.Secret, config.Auth.AllowHMAC = false, "", "", false
+		cmn.GCO.CommitUpdate(config)
+	}
+}
+
+// the first token signed with a just-rotated key gets validated - concurrent requests
+// wait for a single (on-demand) JWKS refetch
+func TestJWKSRefetch(t *testing.T) {
+	sk, err := tok.GenerateKey(tok.SigningRS256)
+	tassert.CheckFatal(t, err)
+	jwk, err := tok.NewJWK(sk.KID, sk.Public())
+	tassert.CheckFatal(t, err)
+	token, err := tok.IssueAdminJWT(time.Now().Add(time.Hour), "admin", sk)
+	tassert.CheckFatal(t, err)
+
+	var (
+		unblock = make(chan struct{})
+		nfetch  atomic.Int32
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		nfetch.Add(1)
+		<-unblock // (AuthN takes a moment to respond)
+		w.Write(cos.MustMarshal(&tok.JWKS{Keys: []tok.JWK{jwk}}))
+	}))
+	defer srv.Close()
+	defer setTestJWKS(srv.URL, "", false)()
+
+	a := newTestAuthManager()
+
+	const num = 8
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, num)
+	)
+	for range num {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tk, err := a.validateToken(token)
+			if err == nil && (!tk.IsAdmin || tk.UserID != "admin") {
+				err = fmt.Errorf("unexpected %s", tk)
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	tassert.Errorf(t, a.jwks.fetching(), "expected on-demand refetch in progress")
+	close(unblock)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		tassert.CheckError(t, err)
+	}
+	tassert.Errorf(t, nfetch.Load() == 1, "expected a single (on-demand) fetch, got %d", nfetch.Load())
+}
+
+// waiting for JWKS is bounded
+func TestJWKSRefetchTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-unblock
+		w.Write(cos.MustMarshal(&tok.JWKS{}))
+	}))
+	defer srv.Close()
+	defer setTestJWKS(srv.URL, "", false)()
+
+	a := newTestAuthManager()
+	started := time.Now()
+	ok := a.jwks.refetch(srv.URL, 100*time.Millisecond)
+	tassert.Errorf(t, !ok, "expected timeout")
+	tassert.Errorf(t, time.Since(started) < time.Second, "waited for too long: %v", time.Since(started))
+
+	close(unblock)
+	for a.jwks.fetching() {
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// with jwks_url, HMAC-signed tokens are accepted only when explicitly allowed
+func TestJWKSRejectHMAC(t *testing.T) {
+	const secret = "aBitLongSecretKey"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(cos.MustMarshal(&tok.JWKS{}))
+	}))
+	defer srv.Close()
+
+	token, err := tok.IssueAdminJWT(time.Now().Add(time.Hour), "admin", tok.NewHMACKey(secret))
+	tassert.CheckFatal(t, err)
+
+	restore := setTestJWKS(srv.URL, secret, false)
+	_, err = newTestAuthManager().validateToken(token)
+	tassert.Fatalf(t, errors.Is(err, tok.ErrInvalidToken), "expected invalid token, got %v", err)
+	restore()
+
+	defer setTestJWKS(srv.URL, secret, true /*allow HMAC*/)()
+	tk, err := newTestAuthManager().validateToken(token)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.IsAdmin && tk.UserID == "admin", "unexpected %s", tk)
+}
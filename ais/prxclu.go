@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -76,6 +77,10 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.xgetRunning(w, r, what, query)
 	case apc.WhatNodeStats, apc.WhatNodeStatsV322:
 		p.qcluStats(w, r, what, query)
+	case apc.WhatBckStats:
+		p.qcluBckStats(w, r, what, query)
+	case apc.WhatAudit:
+		p.qcluAudit(w, r, what, query)
 	case apc.WhatSysInfo:
 		p.qcluSysinfo(w, r, what, query)
 	case apc.WhatMountpaths:
@@ -87,6 +92,12 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		p.writeJSON(w, r, all, what)
+	case apc.WhatQuota:
+		all := p.quota.load(true /*wait*/)
+		if all == nil {
+			all = &cmn.AllQuotaUsages{}
+		}
+		p.writeJSON(w, r, all, what)
 	case apc.WhatTargetIPs:
 		// Return comma-separated IPs of the targets.
 		// It can be used to easily fill the `--noproxy` parameter in cURL.
@@ -111,7 +122,7 @@ func (p *proxy) httpcluget(w http.ResponseWriter, r *http.Request) {
 		config := cmn.GCO.Get()
 		// hide secret
 		c := config.ClusterConfig
-		c.Auth.Secret = "**********"
+		c.Redact()
 		p.writeJSON(w, r, &c, what)
 	case apc.WhatBMD, apc.WhatSmapVote, apc.WhatSnode, apc.WhatSmap:
 		p.htrun.httpdaeget(w, r, query, nil /*htext*/)
@@ -289,6 +300,60 @@ func (p *proxy) qcluStats(w http.ResponseWriter, r *http.Request, what string, q
 	p.writeJSON(w, r, out, what)
 }
 
+// cluster-wide (i.e., summed up across all targets) per-bucket and per-user metrics
+func (p *proxy) qcluBckStats(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	targetStats, erred := p._queryTs(w, r, query)
+	if targetStats == nil || erred {
+		return
+	}
+	out := &stats.BckStats{}
+	for tid, raw := range targetStats {
+		var ts stats.BckStats
+		if err := jsoniter.Unmarshal(raw, &ts); err != nil {
+			p.writeErrf(w, r, "%s: failed to unmarshal %s's %q: %v", p, meta.Tname(tid), what, err)
+			return
+		}
+		out.Merge(&ts)
+	}
+	p.writeJSON(w, r, out, what)
+}
+
+// cluster-wide audit log: the most recent records from all nodes (newest first)
+func (p *proxy) qcluAudit(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
+	if err := p.checkAccess(w, r, nil, apc.AceAdmin); err != nil {
+		return
+	}
+	var (
+		q   = auditQuery(query)
+		all = make([][]*audit.Record, 0, 8)
+	)
+	recs, err := audit.Get(q)
+	if err != nil {
+		p.writeErr(w, r, err)
+		return
+	}
+	all = append(all, recs)
+
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodGet, Path: apc.URLPathDae.S, Query: query}
+	args.timeout = cmn.Rom.MaxKeepalive()
+	args.to = core.AllNodes
+	args.cresv = cresAudit{}
+	results := p.bcastGroup(args)
+	freeBcArgs(args)
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			freeBcastRes(results)
+			p.writeErr(w, r, err)
+			return
+		}
+		all = append(all, *res.v.(*[]*audit.Record))
+	}
+	freeBcastRes(results)
+	p.writeJSON(w, r, audit.Merge(all, q), what)
+}
+
 func (p *proxy) qcluMountpaths(w http.ResponseWriter, r *http.Request, what string, query url.Values) {
 	targetMountpaths, erred := p._queryTs(w, r, query)
 	if targetMountpaths == nil || erred {
@@ -1736,22 +1801,13 @@ func (p *proxy) _remaisConf(ctx *configModifier, config *globalConfig) (bool, er
 		}
 	} else {
 		debug.Assert(action == apc.ActAttachRemAis)
-		u := ctx.hdr.Get(apc.HdrRemAisURL)
-		detail := fmt.Sprintf("remote cluster [alias %s => %v]", alias, u)
-
+		var (
+			u      = ctx.hdr.Get(apc.HdrRemAisURL) // one or more comma-separated URLs
+			urls   = aisConf[alias]
+			detail = fmt.Sprintf("remote cluster [alias %s => %v]", alias, u)
+		)
 		// validation rules:
-		// rule #1: no two remote ais clusters can share the same alias (TODO: allow configuring multiple URLs per)
-		for a, urls := range aisConf {
-			if a != alias {
-				continue
-			}
-			errmsg := fmt.Sprintf("%s: %s is already attached", p.si, detail)
-			if !cos.StringInSlice(u, urls) {
-				return false, errors.New(errmsg)
-			}
-			nlog.Warningln(errmsg + " - proceeding anyway")
-		}
-		// rule #2: aliases and UUIDs are two distinct non-overlapping sets
+		// rule #1: aliases and UUIDs are two distinct non-overlapping sets
 		p.remais.mu.RLock()
 		for _, remais := range p.remais.A {
 			debug.Assert(remais.Alias != alias)
@@ -1763,15 +1819,29 @@ func (p *proxy) _remaisConf(ctx *configModifier, config *globalConfig) (bool, er
 		}
 		p.remais.mu.RUnlock()
 
-		parsed, err := url.ParseRequestURI(u)
-		if err != nil {
-			return false, cmn.NewErrFailedTo(p, action, detail, err)
+		// rule #2: valid URLs
+		// (attaching an already attached alias adds URL(s) - all must point to the same cluster, see backend/ais)
+		var added int
+		for _, s := range strings.Split(u, ",") {
+			s = strings.TrimSpace(s)
+			parsed, err := url.ParseRequestURI(s)
+			if err != nil {
+				return false, cmn.NewErrFailedTo(p, action, detail, err)
+			}
+			if parsed.Scheme != "http" && parsed.Scheme != "https" {
+				return false, cmn.NewErrFailedTo(p, action, detail, errors.New("invalid URL scheme"))
+			}
+			if cos.StringInSlice(s, urls) {
+				continue
+			}
+			urls = append(urls, s)
+			added++
 		}
-		if parsed.Scheme != "http" && parsed.Scheme != "https" {
-			return false, cmn.NewErrFailedTo(p, action, detail, errors.New("invalid URL scheme"))
+		if added == 0 {
+			nlog.Warningln(p.String()+":", detail, "is already attached - proceeding anyway")
 		}
 		nlog.Infof("%s: %s %s", p, action, detail)
-		aisConf[alias] = []string{u}
+		aisConf[alias] = urls
 	}
 	config.Backend.Set(apc.AIS, aisConf)
 
@@ -116,6 +116,16 @@ func (p *proxy) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		p.writeErr(w, r, err)
 		return
 	}
+	if _, ok := initMsg.(*etl.InitProcMsg); ok {
+		if err := etl.ProcAllowed(); err != nil {
+			p.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
+	}
+	if etl.IsK8sRuntime(initMsg) && !k8s.IsK8s() {
+		p.writeErr(w, r, k8s.ErrK8sRequired)
+		return
+	}
 
 	// must be new
 	etlMD := p.owner.etl.get()
@@ -0,0 +1,157 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	ratomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Storage quotas (see cmn.QuotaConf):
+// - on demand, collect local usages from all targets (see tgtquota.go) - in the bucket-summary format;
+// - aggregate them per bucket (the same way as bucket summaries - see prxbsumm.go) and per namespace;
+// - enforce hard quotas upon PUT, APPEND, and copy (the latter - by destination).
+// Note that usages get refreshed only when accessing buckets that have (or namespaces that have) active quotas,
+// and at most once every `quotaRefreshIval` - no periodic polling.
+
+const quotaRefreshIval = 10 * time.Second
+
+type pquota struct {
+	p          *proxy
+	all        ratomic.Pointer[cmn.AllQuotaUsages]
+	warned     sync.Map      // soft quota exceeded: bucket or namespace => (last) warning time
+	last       ratomic.Int64 // mono-time of the last refresh
+	refreshing ratomic.Bool
+}
+
+func (q *pquota) init(p *proxy) { q.p = p }
+
+// returns current usages, and refreshes them when stale:
+// - synchronously, when never collected or when `wait` is true;
+// - otherwise, asynchronously (returning the stale ones in the meantime)
+func (q *pquota) load(wait bool) *cmn.AllQuotaUsages {
+	all := q.all.Load()
+	if last := q.last.Load(); last != 0 && mono.Since(last) < quotaRefreshIval {
+		return all
+	}
+	if !q.refreshing.CompareAndSwap(false, true) {
+		return all
+	}
+	if all != nil && !wait {
+		go q.refresh()
+		return all
+	}
+	q.refresh()
+	return q.all.Load()
+}
+
+func (q *pquota) refresh() {
+	all, err := q.collect()
+	if err != nil {
+		nlog.Warningln(q.p.String(), "quota: failed to collect usage:", err)
+	} else {
+		q.all.Store(all)
+	}
+	q.last.Store(mono.NanoTime()) // (not retrying failures until the next interval)
+	q.refreshing.Store(false)
+}
+
+func (q *pquota) collect() (*cmn.AllQuotaUsages, error) {
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{
+		Method: http.MethodGet,
+		Path:   apc.URLPathDae.S,
+		Query:  url.Values{apc.QparamWhat: []string{apc.WhatQuota}},
+	}
+	args.to = core.Targets
+	args.timeout = cmn.Rom.MaxKeepalive()
+	args.cresv = cresBsumm{} // -> cmn.AllBsummResults
+	results := q.p.bcastGroup(args)
+	freeBcArgs(args)
+
+	summaries := make(cmn.AllBsummResults, 0, 4)
+	for _, res := range results {
+		if res.err != nil {
+			err := res.toErr()
+			freeBcastRes(results)
+			return nil, err
+		}
+		for _, summ := range *res.v.(*cmn.AllBsummResults) {
+			summaries = summaries.Aggregate(summ)
+		}
+	}
+	freeBcastRes(results)
+
+	all := &cmn.AllQuotaUsages{Buckets: make(cmn.QuotaUsages, len(summaries)), Namespaces: make(cmn.QuotaUsages, 4)}
+	for _, summ := range summaries {
+		size, objs := int64(summ.TotalSize.PresentObjs), int64(summ.ObjCount.Present)
+		all.Buckets.Add(string(summ.Bck.MakeUname("")), size, objs)
+		all.Namespaces.Add(summ.Bck.Ns.Uname(), size, objs)
+	}
+	return all, nil
+}
+
+// returns cmn.ErrQuotaExceeded when either bucket or its namespace exceeds its respective hard quota
+// (size: the size about to be added, if known)
+func (q *pquota) check(bck *meta.Bck, size int64) error {
+	config := cmn.GCO.Get()
+	if !hasQuota(bck, config) {
+		return nil
+	}
+	all := q.load(false /*wait*/)
+	if all == nil {
+		return nil
+	}
+	if bck.Props.Quota.IsActive() {
+		if u, ok := all.Buckets[string(bck.MakeUname(""))]; ok {
+			if err := q._check(&bck.Props.Quota, bck.Cname(""), u, size); err != nil {
+				return err
+			}
+		}
+	}
+	if nsq, ok := config.Space.NsQuotas.Get(bck.Ns); ok && nsq.IsActive() {
+		uname := bck.Ns.Uname()
+		if u, ok := all.Namespaces[uname]; ok {
+			return q._check(&nsq, "namespace "+uname, u, size)
+		}
+	}
+	return nil
+}
+
+func (q *pquota) _check(quota *cmn.QuotaConf, what string, u *cmn.QuotaUsage, size int64) error {
+	soft, err := quota.Check(what, u, size)
+	if err != nil {
+		q.p.statsT.Inc(stats.ErrQuotaCount)
+		return err
+	}
+	if soft {
+		now := time.Now()
+		if v, ok := q.warned.Load(what); !ok || now.Sub(v.(time.Time)) > quotaRefreshIval {
+			q.warned.Store(what, now)
+			nlog.Warningln(q.p.String(), what, "exceeded soft quota:", quota.String())
+		}
+	}
+	return nil
+}
+
+// proxy handlers' helper
+func (p *proxy) checkQuota(w http.ResponseWriter, r *http.Request, bck *meta.Bck, size int64) error {
+	err := p.quota.check(bck, size)
+	if err != nil {
+		p.writeErr(w, r, err, cmn.StatusQuotaExceeded)
+	}
+	return err
+}
@@ -61,6 +61,9 @@ func (p *proxy) forwardCP(w http.ResponseWriter, r *http.Request, msg *apc.ActMs
 	if smap.isPrimary(p.si) {
 		return
 	}
+	if areq := auditReqFrom(r); areq != nil {
+		areq.forwarded = true // the primary will record it
+	}
 	// We must **not** send any request body when doing HEAD request.
 	// Otherwise, the request can be rejected and terminated.
 	if r.Method != http.MethodHead {
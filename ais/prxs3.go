@@ -8,6 +8,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,13 +26,19 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
-// TODO: `checkAccess` permissions (see ais/proxy.go)
-
 var (
 	errS3Req = errors.New("invalid s3 request")
 	errS3Obj = errors.New("missing or empty object name")
 )
 
+// same as `p.checkAccess` (see prxauth.go), with S3-formatted error response
+func (p *proxy) checkAccessS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, ace apc.AccessAttrs) (err error) {
+	if err = p.access(r.Header, bck, ace); err != nil {
+		s3.WriteErr(w, r, err, aceErrToCode(err))
+	}
+	return
+}
+
 // [METHOD] /s3
 func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if cmn.Rom.FastV(5, cos.SmoduleS3) {
@@ -60,7 +67,7 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 		if len(apiItems) == 0 {
 			// list all buckets; NOTE: compare with `p.easyURLHandler` and see
 			// "list buckets for a given provider" comment there
-			p.bckNamesFromBMD(w)
+			p.bckNamesFromBMD(w, r)
 			return
 		}
 		var (
@@ -70,7 +77,17 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 			_, cors      = q[s3.QparamCORS]
 			_, acl       = q[s3.QparamACL]
 		)
-		if lifecycle || policy || cors || acl {
+		switch {
+		case lifecycle:
+			p.getBckLifecycleS3(w, r, apiItems[0])
+			return
+		case policy:
+			p.getBckPolicyS3(w, r, apiItems[0])
+			return
+		case acl:
+			p.getBckACLS3(w, r, apiItems[0])
+			return
+		case cors:
 			p.unsupported(w, r, apiItems[0])
 			return
 		}
@@ -98,6 +115,18 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apiItems[0])
 				return
 			}
+			if _, lifecycle := q[s3.QparamLifecycle]; lifecycle {
+				p.putBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if _, policy := q[s3.QparamPolicy]; policy {
+				p.putBckPolicyS3(w, r, apiItems[0])
+				return
+			}
+			if _, acl := q[s3.QparamACL]; acl {
+				p.putBckACLS3(w, r, apiItems[0])
+				return
+			}
 			p.putBckS3(w, r, apiItems[0])
 			return
 		}
@@ -129,6 +158,14 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.delMultipleObjs(w, r, apiItems[0])
 				return
 			}
+			if _, lifecycle := q[s3.QparamLifecycle]; lifecycle {
+				p.delBckLifecycleS3(w, r, apiItems[0])
+				return
+			}
+			if _, policy := q[s3.QparamPolicy]; policy {
+				p.delBckPolicyS3(w, r, apiItems[0])
+				return
+			}
 			p.delBckS3(w, r, apiItems[0])
 			return
 		}
@@ -141,7 +178,10 @@ func (p *proxy) s3Handler(w http.ResponseWriter, r *http.Request) {
 
 // GET /s3
 // NOTE: unlike native API, this one is limited to list only those that are currently present in the BMD.
-func (p *proxy) bckNamesFromBMD(w http.ResponseWriter) {
+func (p *proxy) bckNamesFromBMD(w http.ResponseWriter, r *http.Request) {
+	if err := p.checkAccessS3(w, r, nil, apc.AceListBuckets); err != nil {
+		return
+	}
 	var (
 		bmd  = p.owner.bmd.get()
 		resp = s3.NewListBucketResult() // https://docs.aws.amazon.com/AmazonS3/latest/API/API_ListBuckets.html
@@ -163,6 +203,9 @@ func (p *proxy) putBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
 		return
 	}
+	if err := p.checkAccessS3(w, r, nil, apc.AceCreateBucket); err != nil {
+		return
+	}
 	bck := meta.NewBck(bucket, apc.AIS, cmn.NsGlobal)
 	if err := bck.Validate(); err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -180,8 +223,7 @@ func (p *proxy) delBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	if err := bck.Allow(apc.AceDestroyBucket); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceDestroyBucket); err != nil {
 		return
 	}
 	msg := apc.ActMsg{Action: apc.ActDestroyBck}
@@ -205,8 +247,7 @@ func (p *proxy) handleMptUpload(w http.ResponseWriter, r *http.Request, parts []
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	if err := bck.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AcePUT); err != nil {
 		return
 	}
 	smap := p.owner.smap.get()
@@ -233,8 +274,7 @@ func (p *proxy) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket s
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	if err := bck.Allow(apc.AceObjDELETE); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjDELETE); err != nil {
 		return
 	}
 	decoder := xml.NewDecoder(r.Body)
@@ -296,8 +336,7 @@ func (p *proxy) headBckS3(w http.ResponseWriter, r *http.Request, bucket string)
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	if err := bck.Allow(apc.AceBckHEAD); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
 		return
 	}
 	// From https://docs.aws.amazon.com/AmazonS3/latest/API/API_HeadBucket.html:
@@ -321,6 +360,9 @@ func (p *proxy) listObjectsS3(w http.ResponseWriter, r *http.Request, bucket str
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjLIST); err != nil {
+		return
+	}
 	amsg := &apc.ActMsg{Action: apc.ActList}
 
 	// currently, always forwarding
@@ -416,9 +458,33 @@ func (p *proxy) putObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		p.directPutObjS3(w, r, items)
 		return
 	}
+	if q := r.URL.Query(); q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID) {
+		p.putMptPartCopyS3(w, r, items)
+		return
+	}
 	p.copyObjS3(w, r, items)
 }
 
+// PUT /s3/<bucket-name>/<object-name>?partNumber=<n>&uploadId=<id> - with HeaderObjSrc in the request header
+// (UploadPartCopy: unlike p.copyObjS3, redirect to the target that handles the upload, i.e., by destination)
+func (p *proxy) putMptPartCopyS3(w http.ResponseWriter, r *http.Request, items []string) {
+	src := strings.Trim(r.Header.Get(cos.S3HdrObjSrc), "/")
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) < 2 {
+		s3.WriteErr(w, r, errS3Obj, 0)
+		return
+	}
+	bckSrc, err, ecode := meta.InitByNameOnly(parts[0], p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bckSrc, apc.AceGET); err != nil {
+		return
+	}
+	p.directPutObjS3(w, r, items)
+}
+
 // PUT /s3/<bucket-name>/<object-name> - with HeaderObjSrc in the request header
 // (compare with p.directPutObjS3)
 func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
@@ -435,8 +501,7 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	if err := bckSrc.Allow(apc.AceGET); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bckSrc, apc.AceGET); err != nil {
 		return
 	}
 	// dst
@@ -449,8 +514,11 @@ func (p *proxy) copyObjS3(w http.ResponseWriter, r *http.Request, items []string
 		si   *meta.Snode
 		smap = p.owner.smap.get()
 	)
-	if err = bckDst.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bckDst, apc.AcePUT); err != nil {
+		return
+	}
+	if err := p.quota.check(bckDst, 0); err != nil {
+		s3.WriteErr(w, r, err, cmn.StatusQuotaExceeded)
 		return
 	}
 	objName := strings.Trim(parts[1], "/")
@@ -481,8 +549,11 @@ func (p *proxy) directPutObjS3(w http.ResponseWriter, r *http.Request, items []s
 		si     *meta.Snode
 		smap   = p.owner.smap.get()
 	)
-	if err = bck.Allow(apc.AcePUT); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bck, apc.AcePUT); err != nil {
+		return
+	}
+	if err := p.quota.check(bck, max(r.ContentLength, 0)); err != nil {
+		s3.WriteErr(w, r, err, cmn.StatusQuotaExceeded)
 		return
 	}
 	if len(items) < 2 {
@@ -521,8 +592,7 @@ func (p *proxy) getObjS3(w http.ResponseWriter, r *http.Request, items []string,
 		netPub string
 		smap   = p.owner.smap.get()
 	)
-	if err = bck.Allow(apc.AceGET); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bck, apc.AceGET); err != nil {
 		return
 	}
 	if listMultipart {
@@ -614,8 +684,7 @@ func (p *proxy) headObjS3(w http.ResponseWriter, r *http.Request, items []string
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	if err := bck.Allow(apc.AceObjHEAD); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err := p.checkAccessS3(w, r, bck, apc.AceObjHEAD); err != nil {
 		return
 	}
 	smap := p.owner.smap.get()
@@ -643,8 +712,7 @@ func (p *proxy) delObjS3(w http.ResponseWriter, r *http.Request, items []string)
 		si   *meta.Snode
 		smap = p.owner.smap.get()
 	)
-	if err = bck.Allow(apc.AceObjDELETE); err != nil {
-		s3.WriteErr(w, r, err, http.StatusForbidden)
+	if err = p.checkAccessS3(w, r, bck, apc.AceObjDELETE); err != nil {
 		return
 	}
 	if len(items) < 2 {
@@ -676,6 +744,9 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
 	resp := s3.NewVersioningConfiguration(bck.Props.Versioning.Enabled)
 	sgl := p.gmm.NewSGL(0)
 	resp.MustMarshal(sgl)
@@ -684,7 +755,7 @@ func (p *proxy) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 	sgl.Free()
 }
 
-// GET /s3/<bucket-name>?lifecycle|cors|policy|acl
+// GET /s3/<bucket-name>?cors
 func (p *proxy) unsupported(w http.ResponseWriter, r *http.Request, bucket string) {
 	if _, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd); err != nil {
 		s3.WriteErr(w, r, err, ecode)
@@ -704,6 +775,9 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
+	if err := p.checkAccessS3(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	vconf := &s3.VersioningConfiguration{}
 	if err := decoder.Decode(vconf); err != nil {
@@ -724,3 +798,196 @@ func (p *proxy) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucke
 		s3.WriteErr(w, r, err, 0)
 	}
 }
+
+// GET /s3/<bucket-name>?lifecycle
+func (p *proxy) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	if len(bck.Props.Lifecycle.Rules) == 0 {
+		s3.WriteErr(w, r, s3.ErrNoLifecycle(bucket), http.StatusNotFound)
+		return
+	}
+	resp := s3.NewLifecycleConfiguration(bck.Props.Lifecycle.Rules)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?lifecycle
+func (p *proxy) putBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	lc := &s3.LifecycleConfiguration{}
+	if err := xml.NewDecoder(r.Body).Decode(lc); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	rules, err := lc.ToRules()
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	enabled := true
+	p._setBckLifecycleS3(w, r, msg, bucket, &cmn.LifecycleConfToSet{Rules: &rules, Enabled: &enabled})
+}
+
+// DELETE /s3/<bucket-name>?lifecycle
+func (p *proxy) delBckLifecycleS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	var (
+		rules   cmn.LifecycleRules
+		enabled bool
+	)
+	p._setBckLifecycleS3(w, r, msg, bucket, &cmn.LifecycleConfToSet{Rules: &rules, Enabled: &enabled})
+}
+
+func (p *proxy) _setBckLifecycleS3(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bucket string,
+	toSet *cmn.LifecycleConfToSet) {
+	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AcePATCH); err != nil {
+		return
+	}
+	propsToUpdate := cmn.BpropsToSet{Lifecycle: toSet}
+	nprops, err := p.makeNewBckProps(bck, &propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
+
+// GET /s3/<bucket-name>?policy
+func (p *proxy) getBckPolicyS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	policy := s3.NewPolicy(bucket, bck.Props.Access, bck.Props.Grants)
+	if policy == nil {
+		s3.WriteErr(w, r, s3.ErrNoBucketPolicy(bucket), http.StatusNotFound)
+		return
+	}
+	p.writeJSON(w, r, policy, "get-bucket-policy")
+}
+
+// PUT /s3/<bucket-name>?policy
+func (p *proxy) putBckPolicyS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	policy, err := s3.ParsePolicy(b)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	access, grants, err := policy.ToAccess(bucket)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	p._setBckAccessS3(w, r, msg, bucket, &cmn.BpropsToSet{Access: &access, Grants: &grants})
+}
+
+// DELETE /s3/<bucket-name>?policy
+// (resets bucket's access permissions to the cluster default and removes all grants)
+func (p *proxy) delBckPolicyS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	var (
+		dflt   = (&cmn.Bck{Name: bucket}).DefaultProps(&cmn.GCO.Get().ClusterConfig)
+		access = dflt.Access
+		grants = cmn.AccessGrants{}
+	)
+	p._setBckAccessS3(w, r, msg, bucket, &cmn.BpropsToSet{Access: &access, Grants: &grants})
+}
+
+// GET /s3/<bucket-name>?acl
+func (p *proxy) getBckACLS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckHEAD); err != nil {
+		return
+	}
+	resp := s3.NewACL(bck.Props.Access, bck.Props.Grants)
+	sgl := p.gmm.NewSGL(0)
+	resp.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+// PUT /s3/<bucket-name>?acl
+// NOTE: canned ACL (via `x-amz-acl` header) only
+func (p *proxy) putBckACLS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &apc.ActMsg{Action: apc.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	canned := r.Header.Get(s3.HeaderACL)
+	if canned == "" {
+		err := fmt.Errorf("%s[NotImplemented: access control list in the request body is not supported (use %q header)]",
+			s3.ErrPrefix, strings.ToLower(s3.HeaderACL))
+		s3.WriteErr(w, r, err, http.StatusNotImplemented)
+		return
+	}
+	access, err := s3.CannedACL(canned)
+	if err != nil {
+		s3.WriteErr(w, r, err, http.StatusNotImplemented)
+		return
+	}
+	p._setBckAccessS3(w, r, msg, bucket, &cmn.BpropsToSet{Access: &access})
+}
+
+func (p *proxy) _setBckAccessS3(w http.ResponseWriter, r *http.Request, msg *apc.ActMsg, bucket string,
+	propsToUpdate *cmn.BpropsToSet) {
+	bck, err, ecode := meta.InitByNameOnly(bucket, p.owner.bmd)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	if err := p.checkAccessS3(w, r, bck, apc.AceBckSetACL); err != nil {
+		return
+	}
+	nprops, err := p.makeNewBckProps(bck, propsToUpdate)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
+	if _, err := p.setBprops(msg, bck, nprops); err != nil {
+		s3.WriteErr(w, r, err, 0)
+	}
+}
@@ -1125,6 +1125,20 @@ func (p *proxy) makeNewBckProps(bck *meta.Bck, propsToUpdate *cmn.BpropsToSet, c
 			return
 		}
 	}
+	if epname := nprops.Extra.AWS.EndpointName; epname != "" && epname != bprops.Extra.AWS.EndpointName {
+		var awsConf *cmn.BackendConfAWS
+		if nprops.Provider != apc.AWS {
+			err = fmt.Errorf("%s: s3 endpoint %q cannot be used with %s bucket %s", p.si, epname, nprops.Provider, bck)
+			return
+		}
+		if awsConf = cfg.Backend.S3Conf(); awsConf == nil {
+			err = &cmn.ErrMissingBackend{Provider: apc.AWS}
+			return
+		}
+		if _, _, err = awsConf.Resolve(bck.Name, epname); err != nil {
+			return
+		}
+	}
 	// cannot have re-mirroring and erasure coding on the same bucket at the same time
 	remirror := _reMirror(bprops, nprops)
 	targetCnt, reec := _reEC(bprops, nprops, bck, p.owner.smap.get())
@@ -19,6 +19,7 @@ const (
 	QparamContinuationToken = "continuation-token"
 	QparamStartAfter        = "start-after"
 	QparamDelimiter         = "delimiter"
+	QparamVersionID         = "versionId"
 
 	// multipart
 	QparamMptUploads        = "uploads"
@@ -38,6 +39,10 @@ const (
 	HeaderExpires       = "X-Amz-Expires"
 	HeaderSignedHeaders = "X-Amz-SignedHeaders"
 	HeaderSignature     = "X-Amz-Signature"
+	HeaderSecurityToken = "X-Amz-Security-Token" //nolint:gosec // ditto
+
+	// canned ACL (PUT ?acl)
+	HeaderACL = "X-Amz-Acl"
 
 	versioningEnabled  = "Enabled"
 	versioningDisabled = "Suspended"
@@ -65,6 +65,8 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, ecode int) {
 		out.Code = "BucketAlreadyExists"
 	case cmn.IsErrBckNotFound(err):
 		out.Code = "NoSuchBucket"
+	case cmn.IsErrQuotaExceeded(err):
+		out.Code = "QuotaExceeded"
 	case in.TypeCode != "":
 		out.Code = in.TypeCode
 	default:
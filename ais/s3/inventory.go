@@ -5,17 +5,71 @@
 package s3
 
 import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v3"
 )
 
-// NOTE currently implemented main assumption/requirement:
-// one bucket, one inventory (for this same bucket), and one statically defined .csv
+// S3 bucket inventory: manifest (JSON) that lists inventory data files - in one of the
+// three supported formats: CSV (gzipped), Apache ORC, or Apache Parquet.
+// Regardless of the format, the locally stored inventory is always CSV (see InvDstExt),
+// with the columns ordered as per manifest's `fileSchema`.
 
 const (
 	InvName   = ".inventory"
-	InvSrcExt = ".csv.gz"
 	InvDstExt = ".csv"
+
+	InvManifestName = "manifest.json"
+
+	InvFormatCSV     = "CSV"
+	InvFormatORC     = "ORC"
+	InvFormatParquet = "Parquet"
+)
+
+// canonical (CSV) schema: mandatory fields
+const (
+	InvSchemaBucket = "Bucket"
+	InvSchemaKey    = "Key"
+)
+
+// timestamps (e.g. LastModifiedDate) as in CSV inventories
+const invTimeLayout = "2006-01-02T15:04:05.000Z"
+
+type (
+	// https://docs.aws.amazon.com/AmazonS3/latest/userguide/storage-inventory-location.html
+	InvManifest struct {
+		SourceBucket      string            `json:"sourceBucket"`
+		DestinationBucket string            `json:"destinationBucket"`
+		Version           string            `json:"version"`
+		CreationTimestamp string            `json:"creationTimestamp"`
+		FileFormat        string            `json:"fileFormat"`
+		FileSchema        string            `json:"fileSchema"`
+		Files             []InvManifestFile `json:"files"`
+
+		// parsed `fileSchema`
+		Schema  []string `json:"-"` // canonical field names, e.g. "Bucket", "Key", "Size", "LastModifiedDate"
+		Columns []string `json:"-"` // as named in the inventory files: e.g. "bucket", "key", "size", "last_modified_date"
+	}
+	InvManifestFile struct {
+		Key         string `json:"key"`
+		Size        int64  `json:"size"`
+		MD5checksum string `json:"MD5checksum"`
+	}
 )
 
 func InvPrefObjname(bck *cmn.Bck, name, id string) (prefix, objName string) {
@@ -33,3 +87,273 @@ func InvPrefObjname(bck *cmn.Bck, name, id string) (prefix, objName string) {
 	}
 	return prefix, objName
 }
+
+/////////////////
+// InvManifest //
+/////////////////
+
+func ParseInvManifest(b []byte) (*InvManifest, error) {
+	m := &InvManifest{}
+	if err := jsoniter.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+	if len(m.Files) == 0 {
+		return nil, errors.New("inventory manifest lists no files")
+	}
+	if err := m.parseSchema(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// e.g.:
+// CSV:     "Bucket, Key, Size, ETag"
+// ORC:     "struct<bucket:string,key:string,size:bigint,e_tag:string>"
+// Parquet: "message s3.inventory { required binary bucket (STRING); required binary key (STRING); optional int64 size; ... }"
+func (m *InvManifest) parseSchema() error {
+	var (
+		s    = strings.TrimSpace(m.FileSchema)
+		cols []string
+	)
+	switch {
+	case strings.EqualFold(m.FileFormat, InvFormatCSV):
+		m.FileFormat = InvFormatCSV
+		for _, col := range strings.Split(s, ",") {
+			cols = append(cols, strings.TrimSpace(col))
+		}
+	case strings.EqualFold(m.FileFormat, InvFormatORC):
+		m.FileFormat = InvFormatORC
+		if !strings.HasPrefix(s, "struct<") || !strings.HasSuffix(s, ">") {
+			return fmt.Errorf("invalid ORC schema %q", m.FileSchema)
+		}
+		for _, field := range strings.Split(s[len("struct<"):len(s)-1], ",") {
+			name, _, _ := strings.Cut(field, ":")
+			cols = append(cols, strings.TrimSpace(name))
+		}
+	case strings.EqualFold(m.FileFormat, InvFormatParquet):
+		m.FileFormat = InvFormatParquet
+		i, j := strings.IndexByte(s, '{'), strings.LastIndexByte(s, '}')
+		if i < 0 || j < i {
+			return fmt.Errorf("invalid Parquet schema %q", m.FileSchema)
+		}
+		for _, field := range strings.Split(s[i+1:j], ";") {
+			// <repetition> <type> <name> [(<annotation>)]
+			if f := strings.Fields(field); len(f) >= 3 {
+				cols = append(cols, f[2])
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported inventory format %q (expecting one of: %s, %s, %s)", m.FileFormat,
+			InvFormatCSV, InvFormatORC, InvFormatParquet)
+	}
+
+	m.Columns = cols
+	m.Schema = make([]string, len(cols))
+	for i, col := range cols {
+		m.Schema[i] = invFieldName(col)
+	}
+	if InvFieldPos(m.Schema, InvSchemaBucket) < 0 || InvFieldPos(m.Schema, InvSchemaKey) < 0 {
+		return fmt.Errorf("invalid inventory schema %q: expecting (at least) %s and %s", m.FileSchema,
+			InvSchemaBucket, InvSchemaKey)
+	}
+	return nil
+}
+
+// columnar formats name fields in snake case, e.g.: "last_modified_date" => "LastModifiedDate"
+func invFieldName(col string) string {
+	if !strings.Contains(col, "_") && col != strings.ToLower(col) {
+		return col // (CSV)
+	}
+	var sb strings.Builder
+	for _, part := range strings.Split(col, "_") {
+		if part != "" {
+			sb.WriteString(strings.ToUpper(part[:1]))
+			sb.WriteString(part[1:])
+		}
+	}
+	return sb.String()
+}
+
+func InvFieldPos(schema []string, name string) int {
+	for i, s := range schema {
+		if s == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// object name from a CSV inventory line (the names are URL-encoded)
+func InvObjName(field string) string {
+	s := cmn.UnquoteCEV(field)
+	if name, err := url.QueryUnescape(s); err == nil {
+		return name
+	}
+	return s
+}
+
+//
+// columnar (ORC, Parquet) => CSV
+//
+
+type (
+	invColReader interface {
+		// calls back with decoded (and formatted) columns, one row group (stripe) at a time
+		read(cols []string, cb func(vals [][]string, nrows int) error) error
+	}
+	invCSVWriter struct {
+		bw     *bufio.Writer
+		buf    []byte
+		keyPos int
+		nrows  int64
+	}
+)
+
+// convert columnar inventory file into CSV lines (same format as the one used by CSV inventories);
+// return the number of converted rows
+func InvToCSV(format string, ra io.ReaderAt, size int64, cols []string, w io.Writer) (int64, error) {
+	var (
+		cr  invColReader
+		err error
+	)
+	switch format {
+	case InvFormatParquet:
+		cr, err = newParquetReader(ra, size)
+	case InvFormatORC:
+		cr, err = newORCReader(ra, size)
+	default:
+		err = fmt.Errorf("cannot convert inventory format %q", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+	cw := &invCSVWriter{bw: bufio.NewWriterSize(w, 64*cos.KiB), keyPos: -1}
+	for i, col := range cols {
+		if invFieldName(col) == InvSchemaKey {
+			cw.keyPos = i
+		}
+	}
+	if err := cr.read(cols, cw.write); err != nil {
+		return cw.nrows, err
+	}
+	return cw.nrows, cw.bw.Flush()
+}
+
+func (cw *invCSVWriter) write(vals [][]string, nrows int) error {
+	for i := range nrows {
+		cw.buf = cw.buf[:0]
+		for j, col := range vals {
+			if j > 0 {
+				cw.buf = append(cw.buf, ',')
+			}
+			cw.buf = append(cw.buf, '"')
+			if j == cw.keyPos {
+				cw.buf = append(cw.buf, url.QueryEscape(col[i])...)
+			} else {
+				cw.buf = append(cw.buf, col[i]...)
+			}
+			cw.buf = append(cw.buf, '"')
+		}
+		cw.buf = append(cw.buf, '\n')
+		if _, err := cw.bw.Write(cw.buf); err != nil {
+			return err
+		}
+	}
+	cw.nrows += int64(nrows)
+	return nil
+}
+
+func invFormatTime(t time.Time) string { return t.UTC().Format(invTimeLayout) }
+
+//
+// decompression (shared by ORC and Parquet)
+//
+
+const (
+	invCodecNone = iota
+	invCodecSnappy
+	invCodecGzip
+	invCodecDeflate // raw
+	invCodecLZ4     // block
+	invCodecZstd
+)
+
+const invMaxDecompressed = 256 * cos.MiB // sanity
+
+var (
+	zstdDec     *zstd.Decoder
+	zstdDecOnce sync.Once
+	zstdDecErr  error
+)
+
+func invDecompress(codec int, src []byte, size int) ([]byte, error) {
+	if size < 0 || size > invMaxDecompressed {
+		return nil, fmt.Errorf("invalid decompressed size %d", size)
+	}
+	switch codec {
+	case invCodecNone:
+		return src, nil
+	case invCodecSnappy:
+		n, err := s2.DecodedLen(src)
+		if err != nil {
+			return nil, err
+		}
+		if n > invMaxDecompressed {
+			return nil, fmt.Errorf("invalid decompressed size %d", n)
+		}
+		return s2.Decode(make([]byte, n), src)
+	case invCodecGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return readAllSize(zr, size)
+	case invCodecDeflate:
+		zr := flate.NewReader(bytes.NewReader(src))
+		defer zr.Close()
+		return readAllSize(zr, size)
+	case invCodecLZ4:
+		dst := make([]byte, size)
+		n, err := lz4.UncompressBlock(src, dst)
+		if err != nil {
+			return nil, err
+		}
+		return dst[:n], nil
+	case invCodecZstd:
+		zstdDecOnce.Do(func() {
+			zstdDec, zstdDecErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+		})
+		if zstdDecErr != nil {
+			return nil, zstdDecErr
+		}
+		return zstdDec.DecodeAll(src, make([]byte, 0, size))
+	default:
+		return nil, fmt.Errorf("unsupported compression codec %d", codec)
+	}
+}
+
+func readAllSize(r io.Reader, size int) ([]byte, error) {
+	b := bytes.NewBuffer(make([]byte, 0, size))
+	_, err := io.Copy(b, io.LimitReader(r, invMaxDecompressed+1))
+	if err == nil && b.Len() > invMaxDecompressed {
+		err = fmt.Errorf("decompressed size exceeds %d", invMaxDecompressed)
+	}
+	return b.Bytes(), err
+}
+
+var errInvTruncated = errors.New("truncated or corrupted inventory file")
+
+func invReadAt(ra io.ReaderAt, size, off, n int64) ([]byte, error) {
+	if off < 0 || n < 0 || off+n > size || n > invMaxDecompressed {
+		return nil, fmt.Errorf("%w: invalid range [%d, %d) (file size %d)", errInvTruncated, off, off+n, size)
+	}
+	b := make([]byte, n)
+	if m, err := ra.ReadAt(b, off); m < len(b) {
+		if err == nil || err == io.EOF {
+			err = errInvTruncated
+		}
+		return nil, err
+	}
+	return b, nil
+}
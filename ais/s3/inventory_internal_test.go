@@ -0,0 +1,566 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+)
+
+//
+// test data: 3 objects, one with no size
+//
+
+var (
+	invKeys  = []string{"a/b c.txt", "x,y", "z"}
+	invSizes = []int64{10, -1, 30}
+	invTimes = []time.Time{
+		time.Date(2024, 5, 20, 10, 11, 12, 345000000, time.UTC),
+		time.Date(2014, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2024, 5, 21, 0, 0, 0, 1000, time.UTC),
+	}
+	invCols     = []string{"bucket", "key", "size", "last_modified_date"}
+	invExpected = `"bkt","a%2Fb+c.txt","10","2024-05-20T10:11:12.345Z"
+"bkt","x%2Cy","","2014-12-31T23:59:59.000Z"
+"bkt","z","30","2024-05-21T00:00:00.000Z"
+`
+)
+
+func TestInvManifest(t *testing.T) {
+	tests := []struct {
+		format, schema string
+		columns        []string
+	}{
+		{"CSV", "Bucket, Key, Size, LastModifiedDate", []string{"Bucket", "Key", "Size", "LastModifiedDate"}},
+		{"ORC", "struct<bucket:string,key:string,size:bigint,last_modified_date:timestamp>", invCols},
+		{"Parquet", "message s3.inventory { required binary bucket (STRING); required binary key (STRING); " +
+			"optional int64 size; optional int64 last_modified_date (TIMESTAMP(MILLIS,true)); }", invCols},
+	}
+	for _, test := range tests {
+		b := []byte(`{"sourceBucket": "bkt", "fileFormat": "` + test.format + `", "fileSchema": "` + test.schema +
+			`", "files": [{"key": "inv/bkt/id/data/1", "size": 100, "MD5checksum": "x"}]}`)
+		m, err := ParseInvManifest(b)
+		if err != nil {
+			t.Fatal(test.format, err)
+		}
+		if !reflect.DeepEqual(m.Columns, test.columns) {
+			t.Fatalf("%s: expected columns %v, got %v", test.format, test.columns, m.Columns)
+		}
+		if expected := []string{"Bucket", "Key", "Size", "LastModifiedDate"}; !reflect.DeepEqual(m.Schema, expected) {
+			t.Fatalf("%s: expected schema %v, got %v", test.format, expected, m.Schema)
+		}
+		if len(m.Files) != 1 || m.Files[0].Size != 100 {
+			t.Fatalf("%s: unexpected files %+v", test.format, m.Files)
+		}
+	}
+
+	// invalid
+	for _, s := range []string{
+		`{"fileFormat": "CSV", "fileSchema": "Bucket, Size", "files": [{"key": "a"}]}`,
+		`{"fileFormat": "JSON", "fileSchema": "Bucket, Key", "files": [{"key": "a"}]}`,
+		`{"fileFormat": "CSV", "fileSchema": "Bucket, Key", "files": []}`,
+	} {
+		if _, err := ParseInvManifest([]byte(s)); err == nil {
+			t.Fatalf("expected error parsing %s", s)
+		}
+	}
+
+	if name := InvObjName(`"a%2Fb+c.txt"`); name != "a/b c.txt" {
+		t.Fatalf("unexpected object name %q", name)
+	}
+}
+
+// see https://orc.apache.org/specification/ORCv1 ("Run Length Encoding")
+func TestInvRLE(t *testing.T) {
+	tests := []struct {
+		b        []byte
+		expected []int64
+		v2       bool
+	}{
+		{[]byte{0x0a, 0x27, 0x10}, []int64{10000, 10000, 10000, 10000, 10000}, true},
+		{[]byte{0x5e, 0x03, 0x5c, 0xa1, 0xab, 0x1e, 0xde, 0xad, 0xbe, 0xef}, []int64{23713, 43806, 57005, 48879}, true},
+		{
+			[]byte{0x8e, 0x13, 0x2b, 0x21, 0x07, 0xd0, 0x1e, 0x00, 0x14, 0x70, 0x28, 0x32, 0x3c, 0x46, 0x50, 0x5a, 0x64, 0x6e,
+				0x78, 0x82, 0x8c, 0x96, 0xa0, 0xaa, 0xb4, 0xbe, 0xfc, 0xe8},
+			[]int64{2030, 2000, 2020, 1000000, 2040, 2050, 2060, 2070, 2080, 2090, 2100, 2110, 2120, 2130, 2140, 2150,
+				2160, 2170, 2180, 2190},
+			true,
+		},
+		{[]byte{0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46}, []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}, true},
+		{[]byte{0x61, 0x00, 0x07}, slicesRepeat(7, 100), false},
+		{[]byte{0xfb, 0x02, 0x03, 0x06, 0x07, 0xb}, []int64{2, 3, 6, 7, 11}, false},
+	}
+	for i, test := range tests {
+		vals, err := orcInts(test.b, len(test.expected), false, test.v2)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(vals, test.expected) {
+			t.Fatalf("test %d: expected %v, got %v", i, test.expected, vals)
+		}
+	}
+
+	// byte RLE
+	bs, err := orcByteRLE([]byte{0x61, 0x00, 0xfe, 0x44, 0x45}, 102)
+	if err != nil || len(bs) != 102 || bs[99] != 0 || bs[100] != 0x44 || bs[101] != 0x45 {
+		t.Fatalf("byte RLE: %v %v", bs, err)
+	}
+
+	// parquet RLE/bit-packing hybrid (bit-packed 0..7, width 3; followed by RLE run of 5s)
+	out := make([]uint64, 12)
+	if err := rleHybrid([]byte{0x03, 0x88, 0xc6, 0xfa, 0x08, 0x05}, 3, out); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []uint64{0, 1, 2, 3, 4, 5, 6, 7, 5, 5, 5, 5}; !reflect.DeepEqual(out, expected) {
+		t.Fatalf("RLE hybrid: expected %v, got %v", expected, out)
+	}
+}
+
+func slicesRepeat(v int64, n int) []int64 {
+	out := make([]int64, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func TestInvParquet(t *testing.T) {
+	b := genParquet()
+	var sb strings.Builder
+	n, err := InvToCSV(InvFormatParquet, bytes.NewReader(b), int64(len(b)), invCols, &sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || sb.String() != invExpected {
+		t.Fatalf("expected:\n%s\ngot (%d rows):\n%s", invExpected, n, sb.String())
+	}
+	if _, err := InvToCSV(InvFormatParquet, bytes.NewReader(b), int64(len(b)), []string{"bucket", "owner"}, &sb); err == nil {
+		t.Fatal("expected error (missing column)")
+	}
+	if _, err := InvToCSV(InvFormatParquet, bytes.NewReader(b[:len(b)-10]), int64(len(b)-10), invCols, &sb); err == nil {
+		t.Fatal("expected error (truncated)")
+	}
+}
+
+func TestInvORC(t *testing.T) {
+	b := genORC()
+	var sb strings.Builder
+	n, err := InvToCSV(InvFormatORC, bytes.NewReader(b), int64(len(b)), invCols, &sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || sb.String() != invExpected {
+		t.Fatalf("expected:\n%s\ngot (%d rows):\n%s", invExpected, n, sb.String())
+	}
+	if _, err := InvToCSV(InvFormatORC, bytes.NewReader(b[:len(b)-10]), int64(len(b)-10), invCols, &sb); err == nil {
+		t.Fatal("expected error (truncated)")
+	}
+}
+
+// fixtures: see testdata/inventory/README.md
+func TestInvFixtures(t *testing.T) {
+	dir := filepath.Join("testdata", "inventory")
+	expected, err := os.ReadFile(filepath.Join(dir, "expected.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{InvFormatParquet, InvFormatORC} {
+		sub := filepath.Join(dir, strings.ToLower(format))
+		b, err := os.ReadFile(filepath.Join(sub, InvManifestName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := ParseInvManifest(b)
+		if err != nil {
+			t.Fatal(format, err)
+		}
+		if m.FileFormat != format || len(m.Files) != 1 {
+			t.Fatalf("%s: unexpected manifest %+v", format, m)
+		}
+		schema := []string{"Bucket", "Key", "VersionId", "IsLatest", "IsDeleteMarker", "Size", "LastModifiedDate",
+			"ETag", "StorageClass"}
+		if !reflect.DeepEqual(m.Schema, schema) {
+			t.Fatalf("%s: expected schema %v, got %v", format, schema, m.Schema)
+		}
+
+		file := m.Files[0]
+		data, err := os.ReadFile(filepath.Join(sub, path.Base(file.Key)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sum := md5.Sum(data); int64(len(data)) != file.Size || hex.EncodeToString(sum[:]) != file.MD5checksum {
+			t.Fatalf("%s: %s does not match its manifest entry %+v", format, file.Key, file)
+		}
+		var sb strings.Builder
+		n, err := InvToCSV(m.FileFormat, bytes.NewReader(data), int64(len(data)), m.Columns, &sb)
+		if err != nil {
+			t.Fatal(format, err)
+		}
+		if n != 10 || sb.String() != string(expected) {
+			t.Fatalf("%s: expected:\n%s\ngot (%d rows):\n%s", format, expected, n, sb.String())
+		}
+	}
+}
+
+//
+// Parquet: test writer
+//
+
+type (
+	tfield struct {
+		v  any // int32, int64, bool, string, []tfield (struct), tlist
+		id int16
+	}
+	tlist struct {
+		items []any
+		etyp  byte
+	}
+)
+
+func tencStruct(b []byte, fields []tfield) []byte {
+	var last int16
+	for _, f := range fields {
+		var typ byte
+		switch f.v.(type) {
+		case int32:
+			typ = tcI32
+		case int64:
+			typ = tcI64
+		case bool:
+			typ = tcFalse
+			if f.v.(bool) {
+				typ = tcTrue
+			}
+		case string:
+			typ = tcBinary
+		case []tfield:
+			typ = tcStruct
+		case tlist:
+			typ = tcList
+		}
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			b = append(b, byte(delta)<<4|typ)
+		} else {
+			b = append(b, typ)
+			b = binary.AppendVarint(b, int64(f.id))
+		}
+		last = f.id
+		if typ != tcTrue && typ != tcFalse {
+			b = tencValue(b, f.v)
+		}
+	}
+	return append(b, tcStop)
+}
+
+func tencValue(b []byte, v any) []byte {
+	switch v := v.(type) {
+	case int32:
+		return binary.AppendVarint(b, int64(v))
+	case int64:
+		return binary.AppendVarint(b, v)
+	case string:
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		return append(b, v...)
+	case []tfield:
+		return tencStruct(b, v)
+	case tlist:
+		b = append(b, byte(len(v.items))<<4|v.etyp)
+		for _, item := range v.items {
+			b = tencValue(b, item)
+		}
+	}
+	return b
+}
+
+func genParquet() []byte {
+	const nrows = 3
+	var (
+		file   = []byte(pqMagic)
+		chunks []any
+		le32   = func(b []byte, v int) []byte { return binary.LittleEndian.AppendUint32(b, uint32(v)) }
+		page   = func(ptype int32, data []byte, usize int, hdr tfield) []byte {
+			ph := tencStruct(nil, []tfield{{id: 1, v: ptype}, {id: 2, v: int32(usize)}, {id: 3, v: int32(len(data))}, hdr})
+			return append(ph, data...)
+		}
+		addChunk = func(name string, typ int32, codec int32, dictOff int64, pages []byte) {
+			off := int64(len(file))
+			file = append(file, pages...)
+			md := []tfield{
+				{id: 1, v: typ},
+				{id: 2, v: tlist{etyp: tcI32, items: []any{int32(0), int32(8)}}},
+				{id: 3, v: tlist{etyp: tcBinary, items: []any{name}}},
+				{id: 4, v: codec},
+				{id: 5, v: int64(nrows)},
+				{id: 6, v: int64(len(pages))},
+				{id: 7, v: int64(len(pages))},
+				{id: 9, v: off},
+			}
+			if dictOff >= 0 {
+				md[7].v = off + dictOff
+				md = append(md, tfield{id: 11, v: off})
+			}
+			chunks = append(chunks, []tfield{{id: 2, v: off}, {id: 3, v: md}})
+		}
+	)
+
+	// bucket: dictionary (snappy)
+	{
+		dict := le32(nil, 3)
+		dict = append(dict, "bkt"...)
+		dpage := page(pqDictionaryPage, s2.EncodeSnappy(nil, dict), len(dict),
+			tfield{id: 7, v: []tfield{{id: 1, v: int32(1)}, {id: 2, v: int32(pqEncPlain)}}})
+		data := []byte{1, 0x06, 0x00} // bit width 1, RLE run of 3 zeros
+		dataPage := page(pqDataPage, s2.EncodeSnappy(nil, data), len(data),
+			tfield{id: 5, v: []tfield{{id: 1, v: int32(nrows)}, {id: 2, v: int32(pqEncRLEDictionary)}}})
+		addChunk("bucket", pqByteArray, 1, int64(len(dpage)), append(dpage, dataPage...))
+	}
+	// key: required, plain (uncompressed)
+	{
+		var data []byte
+		for _, key := range invKeys {
+			data = le32(data, len(key))
+			data = append(data, key...)
+		}
+		addChunk("key", pqByteArray, 0, -1, page(pqDataPage, data, len(data),
+			tfield{id: 5, v: []tfield{{id: 1, v: int32(nrows)}, {id: 2, v: int32(pqEncPlain)}}}))
+	}
+	// size: optional, data page v2 (not compressed)
+	{
+		var (
+			defs = []byte{0x03, 0b101} // bit-packed definition levels: 1, 0, 1
+			data []byte
+		)
+		for _, size := range invSizes {
+			if size >= 0 {
+				data = binary.LittleEndian.AppendUint64(data, uint64(size))
+			}
+		}
+		data = append(defs, data...)
+		addChunk("size", pqInt64, 1, -1, page(pqDataPageV2, data, len(data),
+			tfield{id: 8, v: []tfield{
+				{id: 1, v: int32(nrows)}, {id: 2, v: int32(1)}, {id: 3, v: int32(nrows)}, {id: 4, v: int32(pqEncPlain)},
+				{id: 5, v: int32(len(defs))}, {id: 6, v: int32(0)}, {id: 7, v: false},
+			}}))
+	}
+	// last_modified_date: optional timestamp (millis), delta-binary-packed, data page v1 (snappy)
+	{
+		data := le32(nil, 2)
+		data = append(data, 0x03, 0b111) // definition levels
+		data = append(data, deltaPacked(invTimes)...)
+		addChunk("last_modified_date", pqInt64, 1, -1, page(pqDataPage, s2.EncodeSnappy(nil, data), len(data),
+			tfield{id: 5, v: []tfield{{id: 1, v: int32(nrows)}, {id: 2, v: int32(pqEncDeltaBinaryPacked)}}}))
+	}
+
+	var (
+		required, optional = int32(0), int32(1)
+		schema             = tlist{etyp: tcStruct, items: []any{
+			[]tfield{{id: 4, v: "schema"}, {id: 5, v: int32(4)}},
+			[]tfield{{id: 1, v: int32(pqByteArray)}, {id: 3, v: required}, {id: 4, v: "bucket"}, {id: 6, v: int32(0)}},
+			[]tfield{{id: 1, v: int32(pqByteArray)}, {id: 3, v: required}, {id: 4, v: "key"}, {id: 6, v: int32(0)}},
+			[]tfield{{id: 1, v: int32(pqInt64)}, {id: 3, v: optional}, {id: 4, v: "size"}},
+			[]tfield{{id: 1, v: int32(pqInt64)}, {id: 3, v: optional}, {id: 4, v: "last_modified_date"},
+				{id: 10, v: []tfield{{id: 8, v: []tfield{{id: 1, v: true}, {id: 2, v: []tfield{{id: 1, v: []tfield{}}}}}}}}},
+		}}
+		rowGroups = tlist{etyp: tcStruct, items: []any{
+			[]tfield{{id: 1, v: tlist{etyp: tcStruct, items: chunks}}, {id: 2, v: int64(len(file))}, {id: 3, v: int64(nrows)}},
+		}}
+		footer = tencStruct(nil, []tfield{{id: 1, v: int32(1)}, {id: 2, v: schema}, {id: 3, v: int64(nrows)}, {id: 4, v: rowGroups}})
+	)
+	file = append(file, footer...)
+	file = le32(file, len(footer))
+	return append(file, pqMagic...)
+}
+
+// single block, single miniblock (of 32 values)
+func deltaPacked(times []time.Time) (b []byte) {
+	vals := make([]int64, len(times))
+	for i, t := range times {
+		vals[i] = t.UnixMilli()
+	}
+	minDelta := vals[1] - vals[0]
+	for i := 2; i < len(vals); i++ {
+		minDelta = min(minDelta, vals[i]-vals[i-1])
+	}
+	b = binary.AppendUvarint(b, 32) // block size
+	b = binary.AppendUvarint(b, 1)  // miniblocks
+	b = binary.AppendUvarint(b, uint64(len(vals)))
+	b = binary.AppendVarint(b, vals[0])
+	b = binary.AppendVarint(b, minDelta)
+	b = append(b, 64) // bit width
+	for i := 1; i < 33; i++ {
+		var d uint64
+		if i < len(vals) {
+			d = uint64(vals[i] - vals[i-1] - minDelta)
+		}
+		b = binary.LittleEndian.AppendUint64(b, d)
+	}
+	return b
+}
+
+//
+// ORC: test writer
+//
+
+func pbVarint(b []byte, num int, v uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(num)<<3)
+	return binary.AppendUvarint(b, v)
+}
+
+func pbBytes(b []byte, num int, data []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(num)<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(data)))
+	return append(b, data...)
+}
+
+// ZLIB (raw deflate) in ORC chunks; the last chunk is stored as "original"
+func orcCompress(data []byte) (out []byte) {
+	chunk := func(b []byte, original bool) {
+		h := len(b) << 1
+		if original {
+			h |= 1
+		}
+		out = append(out, byte(h), byte(h>>8), byte(h>>16))
+		out = append(out, b...)
+	}
+	if len(data) > 4 {
+		var buf bytes.Buffer
+		zw, _ := flate.NewWriter(&buf, flate.BestCompression)
+		zw.Write(data[:len(data)-4])
+		zw.Close()
+		chunk(buf.Bytes(), false)
+		data = data[len(data)-4:]
+	}
+	chunk(data, true)
+	return out
+}
+
+// RLE v2, DIRECT, 64-bit width
+func orcDirect64(vals []uint64) []byte {
+	b := []byte{0x40 | 31<<1 | byte((len(vals)-1)>>8), byte(len(vals) - 1)}
+	for _, v := range vals {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	return b
+}
+
+func zigzag(v int64) uint64 { return uint64(v<<1) ^ uint64(v>>63) }
+
+func orcNanos(n int) uint64 {
+	if n == 0 || n%100 != 0 {
+		return uint64(n) << 3
+	}
+	n /= 100
+	z := 1
+	for n%10 == 0 && z < 7 {
+		n /= 10
+		z++
+	}
+	return uint64(n)<<3 | uint64(z)
+}
+
+func genORC() []byte {
+	const nrows = 3
+	type stream struct {
+		data      []byte
+		kind, col int
+	}
+	var (
+		keys    []byte
+		keyLens []uint64
+		secs    []uint64
+		nanos   []uint64
+		sizes   []uint64
+	)
+	for i, key := range invKeys {
+		keys = append(keys, key...)
+		keyLens = append(keyLens, uint64(len(key)))
+		secs = append(secs, zigzag(invTimes[i].Unix()-orcEpochSec))
+		nanos = append(nanos, orcNanos(invTimes[i].Nanosecond()))
+		if invSizes[i] >= 0 {
+			sizes = append(sizes, zigzag(invSizes[i]))
+		}
+	}
+	streams := []stream{
+		{kind: orcData, col: 1, data: []byte{0x00, 0x00}},              // dictionary indices: short repeat (3 x 0)
+		{kind: orcLength, col: 1, data: []byte{0x4e, 0x00, 0x03}},      // dictionary lengths: direct, 8 bits
+		{kind: orcDictionaryData, col: 1, data: []byte("bkt")},         //
+		{kind: orcData, col: 2, data: keys},                            //
+		{kind: orcLength, col: 2, data: orcDirect64(keyLens)},          //
+		{kind: orcPresent, col: 3, data: []byte{0xff, 0b10100000}},     // byte RLE, one literal
+		{kind: orcData, col: 3, data: orcDirect64(sizes)},              //
+		{kind: orcData, col: 4, data: orcDirect64(secs)},               //
+		{kind: orcSecondary, col: 4, data: orcDirect64(nanos)},         //
+		{kind: 6 /*row index*/, col: 2, data: []byte("ignored index")}, //
+	}
+	var (
+		file    = []byte(orcMagic)
+		sfooter []byte
+		dataLen int
+	)
+	for _, s := range streams {
+		data := orcCompress(s.data)
+		file = append(file, data...)
+		dataLen += len(data)
+		var sb []byte
+		sb = pbVarint(sb, 1, uint64(s.kind))
+		sb = pbVarint(sb, 2, uint64(s.col))
+		sb = pbVarint(sb, 3, uint64(len(data)))
+		sfooter = pbBytes(sfooter, 1, sb)
+	}
+	for _, enc := range []struct{ kind, dictSize int }{{orcDirect, 0}, {orcDictionaryV2, 1}, {orcDirectV2, 0}, {orcDirectV2, 0}, {orcDirectV2, 0}} {
+		var eb []byte
+		eb = pbVarint(eb, 1, uint64(enc.kind))
+		if enc.dictSize > 0 {
+			eb = pbVarint(eb, 2, uint64(enc.dictSize))
+		}
+		sfooter = pbBytes(sfooter, 2, eb)
+	}
+	sfooter = pbBytes(sfooter, 3, []byte("UTC"))
+	sfooter = orcCompress(sfooter)
+	file = append(file, sfooter...)
+
+	// file footer
+	var footer, stripe, root []byte
+	stripe = pbVarint(stripe, 1, uint64(len(orcMagic)))
+	stripe = pbVarint(stripe, 2, 0)
+	stripe = pbVarint(stripe, 3, uint64(dataLen))
+	stripe = pbVarint(stripe, 4, uint64(len(sfooter)))
+	stripe = pbVarint(stripe, 5, nrows)
+	footer = pbBytes(footer, 3, stripe)
+
+	root = pbVarint(root, 1, orcStruct)
+	root = pbBytes(root, 2, []byte{1, 2, 3, 4}) // packed subtypes
+	for _, col := range invCols {
+		root = pbBytes(root, 3, []byte(col))
+	}
+	footer = pbBytes(footer, 4, root)
+	for _, kind := range []uint64{orcString, orcString, orcLong, orcTimestamp} {
+		footer = pbBytes(footer, 4, pbVarint(nil, 1, kind))
+	}
+	footer = pbVarint(footer, 6, nrows)
+	footer = orcCompress(footer)
+	file = append(file, footer...)
+
+	// postscript
+	var ps []byte
+	ps = pbVarint(ps, 1, uint64(len(footer)))
+	ps = pbVarint(ps, 2, 1 /*ZLIB*/)
+	ps = pbVarint(ps, 3, 256*1024)
+	ps = pbBytes(ps, 8000, []byte(orcMagic))
+	file = append(file, ps...)
+	return append(file, byte(len(ps)))
+}
@@ -0,0 +1,970 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Apache ORC reader - the subset that is sufficient to read S3 inventory files:
+// - top-level (struct) columns of primitive types: boolean, integer, floating point,
+//   string (varchar, char, binary), timestamp, and date;
+// - DIRECT and DICTIONARY encodings (both v1 and v2);
+// - compression: NONE, ZLIB, SNAPPY, LZ4, ZSTD.
+// See https://orc.apache.org/specification/ORCv1
+
+const orcMagic = "ORC"
+
+// type kinds
+const (
+	orcBoolean          = 0
+	orcByte             = 1
+	orcShort            = 2
+	orcInt              = 3
+	orcLong             = 4
+	orcFloat            = 5
+	orcDouble           = 6
+	orcString           = 7
+	orcBinary           = 8
+	orcTimestamp        = 9
+	orcStruct           = 12
+	orcDate             = 15
+	orcVarchar          = 16
+	orcChar             = 17
+	orcTimestampInstant = 18
+)
+
+// stream kinds
+const (
+	orcPresent        = 0
+	orcData           = 1
+	orcLength         = 2
+	orcDictionaryData = 3
+	orcSecondary      = 5
+)
+
+// column encodings
+const (
+	orcDirect       = 0
+	orcDictionary   = 1
+	orcDirectV2     = 2
+	orcDictionaryV2 = 3
+)
+
+const (
+	orcMaxTail  = 16 * 1024 * 1024
+	orcEpochSec = 1420070400 // 2015-01-01 00:00:00 UTC
+)
+
+type (
+	orcFile struct {
+		ra        io.ReaderAt
+		columns   map[string]int // top-level field name => column ID
+		types     []orcType
+		stripes   []orcStripe
+		size      int64
+		codec     int
+		blockSize int
+	}
+	orcType struct {
+		subtypes   []int
+		fieldNames []string
+		kind       int
+	}
+	orcStripe struct {
+		offset, indexLen, dataLen, footerLen, nrows int64
+	}
+	orcStripeCtx struct {
+		f        *orcFile
+		streams  map[[2]int][]byte // (column, kind) => raw (compressed) stream
+		encs     []orcEncoding
+		tzOffset int64
+		nrows    int
+	}
+	orcEncoding struct {
+		kind     int
+		dictSize int
+	}
+)
+
+func newORCReader(ra io.ReaderAt, size int64) (*orcFile, error) {
+	if size < int64(len(orcMagic))+1 {
+		return nil, errInvTruncated
+	}
+	tlen := min(size, 16*1024)
+	tail, err := invReadAt(ra, size, size-tlen, tlen)
+	if err != nil {
+		return nil, err
+	}
+	psLen := int64(tail[len(tail)-1])
+	if psLen+1 > tlen {
+		return nil, errInvTruncated
+	}
+	f := &orcFile{ra: ra, size: size, columns: make(map[string]int, 8)}
+
+	// postscript
+	var footerLen int64
+	err = pbRange(tail[tlen-1-psLen:tlen-1], func(num int, v uint64, b []byte) (err error) {
+		switch num {
+		case 1:
+			footerLen = int64(v)
+		case 2:
+			f.codec, err = orcCodec(v)
+		case 3:
+			f.blockSize = int(v)
+		case 8000:
+			if string(b) != orcMagic {
+				err = errors.New("not an ORC file (invalid magic)")
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("orc postscript: %w", err)
+	}
+	if footerLen <= 0 || footerLen+psLen+1 > min(size, orcMaxTail) {
+		return nil, fmt.Errorf("orc: invalid footer length %d", footerLen)
+	}
+
+	// footer
+	footer, err := invReadAt(ra, size, size-1-psLen-footerLen, footerLen)
+	if err != nil {
+		return nil, err
+	}
+	if footer, err = f.decompress(footer); err != nil {
+		return nil, fmt.Errorf("orc footer: %w", err)
+	}
+	if err := f.parseFooter(footer); err != nil {
+		return nil, fmt.Errorf("orc footer: %w", err)
+	}
+	return f, nil
+}
+
+func orcCodec(v uint64) (int, error) {
+	switch v {
+	case 0:
+		return invCodecNone, nil
+	case 1:
+		return invCodecDeflate, nil
+	case 2:
+		return invCodecSnappy, nil
+	case 4:
+		return invCodecLZ4, nil
+	case 5:
+		return invCodecZstd, nil
+	default:
+		return 0, fmt.Errorf("unsupported compression kind %d", v)
+	}
+}
+
+func (f *orcFile) parseFooter(footer []byte) error {
+	err := pbRange(footer, func(num int, _ uint64, b []byte) error {
+		switch num {
+		case 3:
+			var st orcStripe
+			err := pbRange(b, func(num int, v uint64, _ []byte) error {
+				switch num {
+				case 1:
+					st.offset = int64(v)
+				case 2:
+					st.indexLen = int64(v)
+				case 3:
+					st.dataLen = int64(v)
+				case 4:
+					st.footerLen = int64(v)
+				case 5:
+					st.nrows = int64(v)
+				}
+				return nil
+			})
+			f.stripes = append(f.stripes, st)
+			return err
+		case 4:
+			var typ orcType
+			err := pbRange(b, func(num int, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					typ.kind = int(v)
+				case 2:
+					if b == nil {
+						typ.subtypes = append(typ.subtypes, int(v))
+						return nil
+					}
+					for len(b) > 0 { // packed
+						v, n := binary.Uvarint(b)
+						if n <= 0 {
+							return errInvTruncated
+						}
+						typ.subtypes = append(typ.subtypes, int(v))
+						b = b[n:]
+					}
+				case 3:
+					typ.fieldNames = append(typ.fieldNames, string(b))
+				}
+				return nil
+			})
+			f.types = append(f.types, typ)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(f.types) == 0 || f.types[0].kind != orcStruct || len(f.types[0].subtypes) != len(f.types[0].fieldNames) {
+		return errors.New("invalid schema: expecting top-level struct")
+	}
+	for i, name := range f.types[0].fieldNames {
+		col := f.types[0].subtypes[i]
+		if col <= 0 || col >= len(f.types) {
+			return errors.New("invalid schema: column ID out of range")
+		}
+		f.columns[name] = col
+	}
+	return nil
+}
+
+func (f *orcFile) read(cols []string, cb func(vals [][]string, nrows int) error) error {
+	ids := make([]int, len(cols))
+	for i, col := range cols {
+		id, ok := f.columns[col]
+		if !ok {
+			return fmt.Errorf("orc: column %q not found", col)
+		}
+		ids[i] = id
+	}
+	vals := make([][]string, len(cols))
+	for _, st := range f.stripes {
+		if st.nrows == 0 {
+			continue
+		}
+		if st.nrows < 0 || st.nrows > invMaxDecompressed {
+			return fmt.Errorf("orc: invalid number of rows %d", st.nrows)
+		}
+		ctx, err := f.stripe(&st)
+		if err != nil {
+			return err
+		}
+		for i, id := range ids {
+			if vals[i], err = ctx.column(id); err != nil {
+				return fmt.Errorf("orc: column %q: %w", cols[i], err)
+			}
+		}
+		if err := cb(vals, ctx.nrows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *orcFile) stripe(st *orcStripe) (*orcStripeCtx, error) {
+	buf, err := invReadAt(f.ra, f.size, st.offset, st.indexLen+st.dataLen+st.footerLen)
+	if err != nil {
+		return nil, err
+	}
+	footer, err := f.decompress(buf[st.indexLen+st.dataLen:])
+	if err != nil {
+		return nil, fmt.Errorf("orc stripe footer: %w", err)
+	}
+	var (
+		ctx = &orcStripeCtx{f: f, streams: make(map[[2]int][]byte, 16), nrows: int(st.nrows)}
+		off int64
+	)
+	err = pbRange(footer, func(num int, _ uint64, b []byte) error {
+		switch num {
+		case 1: // stream (in the order of appearance)
+			var kind, col, length int64
+			err := pbRange(b, func(num int, v uint64, _ []byte) error {
+				switch num {
+				case 1:
+					kind = int64(v)
+				case 2:
+					col = int64(v)
+				case 3:
+					length = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if length < 0 || off+length > st.indexLen+st.dataLen {
+				return errInvTruncated
+			}
+			ctx.streams[[2]int{int(col), int(kind)}] = buf[off : off+length]
+			off += length
+		case 2: // column encoding
+			var enc orcEncoding
+			err := pbRange(b, func(num int, v uint64, _ []byte) error {
+				switch num {
+				case 1:
+					enc.kind = int(v)
+				case 2:
+					enc.dictSize = int(v)
+				}
+				return nil
+			})
+			ctx.encs = append(ctx.encs, enc)
+			return err
+		case 3: // writer timezone
+			if loc, err := time.LoadLocation(string(b)); err == nil {
+				_, offset := time.Date(2015, 1, 1, 0, 0, 0, 0, loc).Zone()
+				ctx.tzOffset = int64(offset)
+			}
+		}
+		return nil
+	})
+	return ctx, err
+}
+
+// ORC compression: a sequence of chunks, each with a 3-byte header
+func (f *orcFile) decompress(b []byte) ([]byte, error) {
+	if f.codec == invCodecNone {
+		return b, nil
+	}
+	var out []byte
+	for len(b) > 0 {
+		if len(b) < 3 {
+			return nil, errInvTruncated
+		}
+		var (
+			h        = int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+			l        = h >> 1
+			original = h&1 == 1
+		)
+		if 3+l > len(b) {
+			return nil, errInvTruncated
+		}
+		chunk := b[3 : 3+l]
+		b = b[3+l:]
+		if original {
+			out = append(out, chunk...)
+			continue
+		}
+		data, err := invDecompress(f.codec, chunk, max(f.blockSize, 256*1024))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data...)
+		if len(out) > invMaxDecompressed {
+			return nil, fmt.Errorf("decompressed size exceeds %d", invMaxDecompressed)
+		}
+	}
+	return out, nil
+}
+
+//////////////////
+// orcStripeCtx //
+//////////////////
+
+func (ctx *orcStripeCtx) stream(col, kind int) ([]byte, error) {
+	b, ok := ctx.streams[[2]int{col, kind}]
+	if !ok {
+		return nil, nil
+	}
+	return ctx.f.decompress(b)
+}
+
+func (ctx *orcStripeCtx) column(col int) ([]string, error) {
+	if col >= len(ctx.encs) {
+		return nil, errors.New("missing column encoding")
+	}
+	var (
+		typ     = &ctx.f.types[col]
+		enc     = ctx.encs[col]
+		v2      = enc.kind == orcDirectV2 || enc.kind == orcDictionaryV2
+		nonNull = ctx.nrows
+		present []bool
+		vals    []string
+	)
+	// nulls
+	pstream, err := ctx.stream(col, orcPresent)
+	if err != nil {
+		return nil, err
+	}
+	if pstream != nil {
+		if present, err = orcBools(pstream, ctx.nrows); err != nil {
+			return nil, err
+		}
+		nonNull = 0
+		for _, p := range present {
+			if p {
+				nonNull++
+			}
+		}
+	}
+	data, err := ctx.stream(col, orcData)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ.kind {
+	case orcBoolean:
+		var bools []bool
+		if bools, err = orcBools(data, nonNull); err == nil {
+			vals = make([]string, nonNull)
+			for i, b := range bools {
+				vals[i] = strconv.FormatBool(b)
+			}
+		}
+	case orcByte:
+		var bs []byte
+		if bs, err = orcByteRLE(data, nonNull); err == nil {
+			vals = make([]string, nonNull)
+			for i, b := range bs {
+				vals[i] = strconv.Itoa(int(int8(b)))
+			}
+		}
+	case orcShort, orcInt, orcLong, orcDate:
+		var ints []int64
+		if ints, err = orcInts(data, nonNull, true, v2); err == nil {
+			vals = make([]string, nonNull)
+			for i, v := range ints {
+				if typ.kind == orcDate {
+					vals[i] = time.Unix(v*86400, 0).UTC().Format(time.DateOnly)
+				} else {
+					vals[i] = strconv.FormatInt(v, 10)
+				}
+			}
+		}
+	case orcFloat, orcDouble:
+		size := 8
+		if typ.kind == orcFloat {
+			size = 4
+		}
+		if len(data) < size*nonNull {
+			return nil, errInvTruncated
+		}
+		vals = make([]string, nonNull)
+		for i := range vals {
+			if size == 4 {
+				v := math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+				vals[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+			} else {
+				vals[i] = strconv.FormatFloat(math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:])), 'g', -1, 64)
+			}
+		}
+	case orcString, orcBinary, orcVarchar, orcChar:
+		vals, err = ctx.strings(col, enc, data, nonNull, v2)
+	case orcTimestamp, orcTimestampInstant:
+		vals, err = ctx.timestamps(col, typ.kind, data, nonNull, v2)
+	default:
+		err = fmt.Errorf("unsupported type kind %d", typ.kind)
+	}
+	if err != nil || present == nil {
+		return vals, err
+	}
+
+	// spread non-null values
+	out := make([]string, ctx.nrows)
+	var j int
+	for i, p := range present {
+		if p {
+			out[i] = vals[j]
+			j++
+		}
+	}
+	return out, nil
+}
+
+func (ctx *orcStripeCtx) strings(col int, enc orcEncoding, data []byte, n int, v2 bool) ([]string, error) {
+	lengths, err := ctx.stream(col, orcLength)
+	if err != nil {
+		return nil, err
+	}
+	switch enc.kind {
+	case orcDirect, orcDirectV2:
+		return orcStrings(data, lengths, n, v2)
+	case orcDictionary, orcDictionaryV2:
+		dictData, err := ctx.stream(col, orcDictionaryData)
+		if err != nil {
+			return nil, err
+		}
+		dict, err := orcStrings(dictData, lengths, enc.dictSize, v2)
+		if err != nil {
+			return nil, fmt.Errorf("dictionary: %w", err)
+		}
+		idx, err := orcInts(data, n, false, v2)
+		if err != nil {
+			return nil, err
+		}
+		vals := make([]string, n)
+		for i, j := range idx {
+			if j < 0 || j >= int64(len(dict)) {
+				return nil, fmt.Errorf("dictionary index %d out of range [0, %d)", j, len(dict))
+			}
+			vals[i] = dict[j]
+		}
+		return vals, nil
+	default:
+		return nil, fmt.Errorf("unsupported column encoding %d", enc.kind)
+	}
+}
+
+func (ctx *orcStripeCtx) timestamps(col, kind int, data []byte, n int, v2 bool) ([]string, error) {
+	secondary, err := ctx.stream(col, orcSecondary)
+	if err != nil {
+		return nil, err
+	}
+	secs, err := orcInts(data, n, true, v2)
+	if err != nil {
+		return nil, err
+	}
+	nanos, err := orcInts(secondary, n, false, v2)
+	if err != nil {
+		return nil, err
+	}
+	base := int64(orcEpochSec)
+	if kind == orcTimestamp {
+		base -= ctx.tzOffset // (local time in the writer's timezone)
+	}
+	vals := make([]string, n)
+	for i, s := range secs {
+		ns, zeros := nanos[i]>>3, nanos[i]&7
+		if zeros != 0 {
+			for range zeros + 1 {
+				ns *= 10
+			}
+		}
+		vals[i] = invFormatTime(time.Unix(base+s, ns))
+	}
+	return vals, nil
+}
+
+//
+// ORC encodings
+//
+
+func orcStrings(data, lengths []byte, n int, v2 bool) ([]string, error) {
+	lens, err := orcInts(lengths, n, false, v2)
+	if err != nil {
+		return nil, fmt.Errorf("lengths: %w", err)
+	}
+	var (
+		vals = make([]string, n)
+		off  int64
+	)
+	for i, l := range lens {
+		if l < 0 || off+l > int64(len(data)) {
+			return nil, errInvTruncated
+		}
+		vals[i] = string(data[off : off+l])
+		off += l
+	}
+	return vals, nil
+}
+
+// boolean: byte RLE, MSB first
+func orcBools(b []byte, n int) ([]bool, error) {
+	bs, err := orcByteRLE(b, (n+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bool, n)
+	for i := range out {
+		out[i] = bs[i/8]&(0x80>>(i%8)) != 0
+	}
+	return out, nil
+}
+
+func orcByteRLE(b []byte, n int) ([]byte, error) {
+	out := make([]byte, 0, n)
+	for off := 0; len(out) < n; {
+		if off >= len(b) {
+			return nil, errInvTruncated
+		}
+		ctrl := int(int8(b[off]))
+		off++
+		if ctrl >= 0 { // run
+			if off >= len(b) {
+				return nil, errInvTruncated
+			}
+			for range ctrl + 3 {
+				out = append(out, b[off])
+			}
+			off++
+			continue
+		}
+		l := -ctrl // literals
+		if off+l > len(b) {
+			return nil, errInvTruncated
+		}
+		out = append(out, b[off:off+l]...)
+		off += l
+	}
+	return out[:n], nil
+}
+
+func orcInts(b []byte, n int, signed, v2 bool) ([]int64, error) {
+	if v2 {
+		return orcRLEv2(b, n, signed)
+	}
+	return orcRLEv1(b, n, signed)
+}
+
+func orcVarint(b []byte, off int, signed bool) (int64, int, error) {
+	if off >= len(b) {
+		return 0, 0, errInvTruncated
+	}
+	u, k := binary.Uvarint(b[off:])
+	if k <= 0 {
+		return 0, 0, errInvTruncated
+	}
+	if signed {
+		return unzigzag(u), off + k, nil
+	}
+	return int64(u), off + k, nil
+}
+
+func unzigzag(u uint64) int64 { return int64(u>>1) ^ -int64(u&1) }
+
+func orcRLEv1(b []byte, n int, signed bool) ([]int64, error) {
+	out := make([]int64, 0, n)
+	for off := 0; len(out) < n; {
+		if off >= len(b) {
+			return nil, errInvTruncated
+		}
+		ctrl := int(int8(b[off]))
+		off++
+		if ctrl >= 0 { // run: length, delta, base
+			if off >= len(b) {
+				return nil, errInvTruncated
+			}
+			delta := int64(int8(b[off]))
+			base, next, err := orcVarint(b, off+1, signed)
+			if err != nil {
+				return nil, err
+			}
+			off = next
+			for i := range int64(ctrl + 3) {
+				out = append(out, base+i*delta)
+			}
+			continue
+		}
+		for range -ctrl { // literals
+			v, next, err := orcVarint(b, off, signed)
+			if err != nil {
+				return nil, err
+			}
+			out, off = append(out, v), next
+		}
+	}
+	return out[:n], nil
+}
+
+// RLE v2 sub-encodings
+const (
+	orcShortRepeat = iota
+	orcDirectRLE
+	orcPatchedBase
+	orcDelta
+)
+
+func orcRLEv2(b []byte, n int, signed bool) ([]int64, error) {
+	out := make([]int64, 0, n)
+	for off := 0; len(out) < n; {
+		if off >= len(b) {
+			return nil, errInvTruncated
+		}
+		h := b[off]
+		switch h >> 6 {
+		case orcShortRepeat:
+			var (
+				w   = int(h>>3&7) + 1
+				cnt = int(h&7) + 3
+			)
+			if off+1+w > len(b) {
+				return nil, errInvTruncated
+			}
+			var u uint64
+			for _, c := range b[off+1 : off+1+w] {
+				u = u<<8 | uint64(c)
+			}
+			v := int64(u)
+			if signed {
+				v = unzigzag(u)
+			}
+			for range cnt {
+				out = append(out, v)
+			}
+			off += 1 + w
+		case orcDirectRLE:
+			if off+2 > len(b) {
+				return nil, errInvTruncated
+			}
+			var (
+				w = orcWidth(int(h >> 1 & 0x1f))
+				l = (int(h&1)<<8 | int(b[off+1])) + 1
+			)
+			vals, k, err := unpackBE(b[off+2:], w, l)
+			if err != nil {
+				return nil, err
+			}
+			for _, u := range vals {
+				if signed {
+					out = append(out, unzigzag(u))
+				} else {
+					out = append(out, int64(u))
+				}
+			}
+			off += 2 + k
+		case orcPatchedBase:
+			var err error
+			if out, off, err = orcPatched(b, off, out); err != nil {
+				return nil, err
+			}
+		case orcDelta:
+			var err error
+			if out, off, err = orcDeltaRLE(b, off, out, signed); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out, nil
+}
+
+func orcPatched(b []byte, off int, out []int64) ([]int64, int, error) {
+	if off+4 > len(b) {
+		return nil, 0, errInvTruncated
+	}
+	var (
+		h   = b[off]
+		w   = orcWidth(int(h >> 1 & 0x1f))
+		l   = (int(h&1)<<8 | int(b[off+1])) + 1
+		bw  = int(b[off+2]>>5&7) + 1         // base width (bytes)
+		pw  = orcWidth(int(b[off+2] & 0x1f)) // patch width (bits)
+		pgw = int(b[off+3]>>5&7) + 1         // patch gap width (bits)
+		pll = int(b[off+3] & 0x1f)           // patch list length
+	)
+	off += 4
+	if off+bw > len(b) {
+		return nil, 0, errInvTruncated
+	}
+	var u uint64
+	for _, c := range b[off : off+bw] {
+		u = u<<8 | uint64(c)
+	}
+	off += bw
+	base := int64(u)
+	if msb := uint64(1) << (8*bw - 1); u&msb != 0 { // sign-magnitude
+		base = -int64(u &^ msb)
+	}
+	vals, k, err := unpackBE(b[off:], w, l)
+	if err != nil {
+		return nil, 0, err
+	}
+	off += k
+	patches, k, err := unpackBE(b[off:], orcClosestFixedBits(pw+pgw), pll)
+	if err != nil {
+		return nil, 0, err
+	}
+	off += k
+
+	// apply patches
+	var (
+		mask = uint64(1)<<pw - 1
+		pos  int
+	)
+	for _, p := range patches {
+		gap, patch := int(p>>pw), p&mask
+		pos += gap
+		if gap == 255 && patch == 0 {
+			continue // (gap > 255)
+		}
+		if pos >= len(vals) {
+			return nil, 0, errors.New("patch position out of range")
+		}
+		vals[pos] |= patch << w
+	}
+	for _, v := range vals {
+		out = append(out, base+int64(v))
+	}
+	return out, off, nil
+}
+
+func orcDeltaRLE(b []byte, off int, out []int64, signed bool) ([]int64, int, error) {
+	if off+2 > len(b) {
+		return nil, 0, errInvTruncated
+	}
+	var (
+		h = b[off]
+		w = int(h >> 1 & 0x1f)
+		l = (int(h&1)<<8 | int(b[off+1])) + 1
+	)
+	if w != 0 {
+		w = orcWidth(w)
+	}
+	first, off, err := orcVarint(b, off+2, signed)
+	if err != nil {
+		return nil, 0, err
+	}
+	deltaBase, off, err := orcVarint(b, off, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	out = append(out, first)
+	if w == 0 { // fixed delta
+		for i := 1; i < l; i++ {
+			out = append(out, out[len(out)-1]+deltaBase)
+		}
+		return out, off, nil
+	}
+	prev := first + deltaBase
+	out = append(out, prev)
+	if l <= 2 {
+		return out, off, nil
+	}
+	deltas, k, err := unpackBE(b[off:], w, l-2)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, d := range deltas {
+		if deltaBase < 0 {
+			prev -= int64(d)
+		} else {
+			prev += int64(d)
+		}
+		out = append(out, prev)
+	}
+	return out, off + k, nil
+}
+
+// 5-bit encoded bit width
+func orcWidth(code int) int {
+	switch {
+	case code < 24:
+		return code + 1
+	case code == 24:
+		return 26
+	case code == 25:
+		return 28
+	case code == 26:
+		return 30
+	case code == 27:
+		return 32
+	case code == 28:
+		return 40
+	case code == 29:
+		return 48
+	case code == 30:
+		return 56
+	default:
+		return 64
+	}
+}
+
+func orcClosestFixedBits(n int) int {
+	switch {
+	case n == 0:
+		return 1
+	case n <= 24:
+		return n
+	case n <= 26:
+		return 26
+	case n <= 28:
+		return 28
+	case n <= 30:
+		return 30
+	case n <= 32:
+		return 32
+	case n <= 40:
+		return 40
+	case n <= 48:
+		return 48
+	case n <= 56:
+		return 56
+	default:
+		return 64
+	}
+}
+
+// bit-unpack big-endian (MSB first); returns the number of consumed bytes
+func unpackBE(b []byte, width, n int) ([]uint64, int, error) {
+	need := (n*width + 7) / 8
+	if need > len(b) {
+		return nil, 0, errInvTruncated
+	}
+	var (
+		out = make([]uint64, n)
+		pos int
+	)
+	for i := range out {
+		var v uint64
+		for j := 0; j < width; {
+			var (
+				avail = 8 - pos&7
+				take  = min(avail, width-j)
+				bits  = (uint64(b[pos>>3]) >> (avail - take)) & (1<<take - 1)
+			)
+			v = v<<take | bits
+			j += take
+			pos += take
+		}
+		out[i] = v
+	}
+	return out, need, nil
+}
+
+//
+// protobuf (decoding only)
+//
+
+// calls back for each field: varint and fixed-size values via `v`, length-delimited via `b`
+func pbRange(b []byte, cb func(num int, v uint64, b []byte) error) error {
+	for off := 0; off < len(b); {
+		key, k := binary.Uvarint(b[off:])
+		if k <= 0 {
+			return errInvTruncated
+		}
+		off += k
+		var (
+			num  = int(key >> 3)
+			v    uint64
+			data []byte
+		)
+		switch key & 7 {
+		case 0:
+			if v, k = binary.Uvarint(b[off:]); k <= 0 {
+				return errInvTruncated
+			}
+			off += k
+		case 1:
+			if off+8 > len(b) {
+				return errInvTruncated
+			}
+			v = binary.LittleEndian.Uint64(b[off:])
+			off += 8
+		case 2:
+			l, k := binary.Uvarint(b[off:])
+			if k <= 0 || l > uint64(len(b)-off-k) {
+				return errInvTruncated
+			}
+			off += k
+			data = b[off : off+int(l) : off+int(l)]
+			off += int(l)
+		case 5:
+			if off+4 > len(b) {
+				return errInvTruncated
+			}
+			v = uint64(binary.LittleEndian.Uint32(b[off:]))
+			off += 4
+		default:
+			return fmt.Errorf("protobuf: unsupported wire type %d", key&7)
+		}
+		if err := cb(num, v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,920 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Apache Parquet reader - the subset that is sufficient to read S3 inventory files:
+// - flat schema (no repeated fields);
+// - data pages v1 and v2, dictionary pages;
+// - encodings: PLAIN, PLAIN_DICTIONARY, RLE_DICTIONARY, DELTA_BINARY_PACKED,
+//   DELTA_LENGTH_BYTE_ARRAY, DELTA_BYTE_ARRAY;
+// - compression: UNCOMPRESSED, SNAPPY, GZIP, LZ4_RAW, ZSTD.
+// See https://github.com/apache/parquet-format
+
+const pqMagic = "PAR1"
+
+// physical types
+const (
+	pqBoolean = iota
+	pqInt32
+	pqInt64
+	pqInt96
+	pqFloat
+	pqDouble
+	pqByteArray
+	pqFixedLenByteArray
+)
+
+// page types
+const (
+	pqDataPage       = 0
+	pqDictionaryPage = 2
+	pqDataPageV2     = 3
+)
+
+// encodings
+const (
+	pqEncPlain             = 0
+	pqEncPlainDictionary   = 2
+	pqEncDeltaBinaryPacked = 5
+	pqEncDeltaLengthBytes  = 6
+	pqEncDeltaBytes        = 7
+	pqEncRLEDictionary     = 8
+)
+
+// converted types and time units
+const (
+	pqConvTimestampMillis = 9
+	pqConvTimestampMicros = 10
+
+	pqUnitMillis = 1
+	pqUnitMicros = 2
+	pqUnitNanos  = 3
+)
+
+const pqMaxFooter = 64 * 1024 * 1024
+
+type (
+	pqFile struct {
+		ra     io.ReaderAt
+		meta   tstruct
+		leaves map[string]*pqLeaf
+		size   int64
+	}
+	pqLeaf struct {
+		name    string
+		typ     int64
+		typeLen int
+		tsUnit  int // timestamp (pqUnitMillis, etc.) or zero
+		idx     int // column index in row groups
+		maxDef  int
+		maxRep  int
+	}
+	pqChunk struct {
+		leaf   *pqLeaf
+		dict   []string
+		out    []string
+		levels []uint64
+	}
+)
+
+func newParquetReader(ra io.ReaderAt, size int64) (*pqFile, error) {
+	if size < int64(2*len(pqMagic)+4) {
+		return nil, errInvTruncated
+	}
+	tail, err := invReadAt(ra, size, size-8, 8)
+	if err != nil {
+		return nil, err
+	}
+	if string(tail[4:]) != pqMagic {
+		return nil, errors.New("not a parquet file (invalid magic)")
+	}
+	flen := int64(binary.LittleEndian.Uint32(tail))
+	if flen > pqMaxFooter {
+		return nil, fmt.Errorf("parquet footer too large (%d)", flen)
+	}
+	footer, err := invReadAt(ra, size, size-8-flen, flen)
+	if err != nil {
+		return nil, err
+	}
+	d := &tdecoder{b: footer}
+	meta, err := d.readStruct(0)
+	if err != nil {
+		return nil, fmt.Errorf("parquet footer: %w", err)
+	}
+	f := &pqFile{ra: ra, size: size, meta: meta, leaves: make(map[string]*pqLeaf, 8)}
+	if err := f.initSchema(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// walk the schema tree (depth-first) to compute leaf columns and their max definition/repetition levels
+func (f *pqFile) initSchema() error {
+	var (
+		schema = f.meta.list(2)
+		idx    int
+	)
+	if len(schema) == 0 {
+		return errors.New("parquet: empty schema")
+	}
+	var walk func(i int, path []string, maxDef, maxRep int) (int, error)
+	walk = func(i int, path []string, maxDef, maxRep int) (int, error) {
+		if i >= len(schema) {
+			return i, errors.New("parquet: invalid schema")
+		}
+		el, ok := schema[i].(tstruct)
+		if !ok {
+			return i, errors.New("parquet: invalid schema element")
+		}
+		if i > 0 { // (root's repetition is irrelevant)
+			switch el.i64(3) {
+			case 1: // optional
+				maxDef++
+			case 2: // repeated
+				maxDef++
+				maxRep++
+			}
+			path = append(path, el.str(4))
+		}
+		nc := int(el.i64(5))
+		if nc == 0 && i > 0 {
+			leaf := &pqLeaf{
+				name:    strings.Join(path, "."),
+				typ:     el.i64(1),
+				typeLen: int(el.i64(2)),
+				idx:     idx,
+				maxDef:  maxDef,
+				maxRep:  maxRep,
+			}
+			leaf.tsUnit = pqTimeUnit(el)
+			f.leaves[leaf.name] = leaf
+			idx++
+			return i + 1, nil
+		}
+		var err error
+		i++
+		for range nc {
+			if i, err = walk(i, path, maxDef, maxRep); err != nil {
+				return i, err
+			}
+		}
+		return i, nil
+	}
+	_, err := walk(0, nil, 0, 0)
+	return err
+}
+
+func pqTimeUnit(el tstruct) int {
+	if el.i64(1) == pqInt96 {
+		return pqUnitNanos
+	}
+	if lt := el.sub(10); lt != nil {
+		if ts := lt.sub(8); ts != nil {
+			unit := ts.sub(2)
+			switch {
+			case unit.has(1):
+				return pqUnitMillis
+			case unit.has(2):
+				return pqUnitMicros
+			case unit.has(3):
+				return pqUnitNanos
+			}
+		}
+	}
+	if el.has(6) {
+		switch el.i64(6) {
+		case pqConvTimestampMillis:
+			return pqUnitMillis
+		case pqConvTimestampMicros:
+			return pqUnitMicros
+		}
+	}
+	return 0
+}
+
+func (f *pqFile) read(cols []string, cb func(vals [][]string, nrows int) error) error {
+	leaves := make([]*pqLeaf, len(cols))
+	for i, col := range cols {
+		leaf, ok := f.leaves[col]
+		if !ok {
+			return fmt.Errorf("parquet: column %q not found", col)
+		}
+		if leaf.maxRep > 0 {
+			return fmt.Errorf("parquet: repeated column %q is not supported", col)
+		}
+		leaves[i] = leaf
+	}
+	vals := make([][]string, len(cols))
+	for _, rg := range f.meta.list(4) {
+		rg, ok := rg.(tstruct)
+		if !ok {
+			return errors.New("parquet: invalid row group")
+		}
+		var (
+			nrows  = int(rg.i64(3))
+			chunks = rg.list(1)
+		)
+		if nrows == 0 {
+			continue
+		}
+		if nrows < 0 || nrows > invMaxDecompressed {
+			return fmt.Errorf("parquet: invalid number of rows %d", nrows)
+		}
+		for i, leaf := range leaves {
+			if leaf.idx >= len(chunks) {
+				return errors.New("parquet: missing column chunk")
+			}
+			cc, ok := chunks[leaf.idx].(tstruct)
+			if !ok {
+				return errors.New("parquet: invalid column chunk")
+			}
+			if cc.str(1) != "" {
+				return errors.New("parquet: column chunks in external files are not supported")
+			}
+			chunk := &pqChunk{leaf: leaf, out: make([]string, 0, nrows)}
+			if err := f.readChunk(cc.sub(3), chunk, nrows); err != nil {
+				return fmt.Errorf("parquet: column %q: %w", leaf.name, err)
+			}
+			vals[i] = chunk.out
+		}
+		if err := cb(vals, nrows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *pqFile) readChunk(md tstruct, chunk *pqChunk, nrows int) error {
+	if md == nil {
+		return errors.New("missing column metadata")
+	}
+	var (
+		codec  = md.i64(4)
+		offset = md.i64(9)
+		length = md.i64(7)
+	)
+	if md.has(11) {
+		if doff := md.i64(11); doff > 0 && doff < offset {
+			offset = doff
+		}
+	}
+	c, err := pqCodec(codec)
+	if err != nil {
+		return err
+	}
+	buf, err := invReadAt(f.ra, f.size, offset, length)
+	if err != nil {
+		return err
+	}
+	for off := 0; len(chunk.out) < nrows; {
+		if off >= len(buf) {
+			return errInvTruncated
+		}
+		d := &tdecoder{b: buf, off: off}
+		ph, err := d.readStruct(0)
+		if err != nil {
+			return fmt.Errorf("page header: %w", err)
+		}
+		var (
+			usize = int(ph.i64(2))
+			csize = int(ph.i64(3))
+		)
+		off = d.off
+		if csize < 0 || off+csize > len(buf) {
+			return errInvTruncated
+		}
+		page := buf[off : off+csize]
+		off += csize
+
+		switch ph.i64(1) {
+		case pqDictionaryPage:
+			data, err := invDecompress(c, page, usize)
+			if err != nil {
+				return err
+			}
+			n := int(ph.sub(7).i64(1))
+			if chunk.dict, _, err = chunk.leaf.plain(data, n); err != nil {
+				return err
+			}
+		case pqDataPage:
+			data, err := invDecompress(c, page, usize)
+			if err != nil {
+				return err
+			}
+			dph := ph.sub(5)
+			if err := chunk.dataPage(data, nil, int(dph.i64(1)), int(dph.i64(2))); err != nil {
+				return err
+			}
+		case pqDataPageV2:
+			var (
+				dph  = ph.sub(8)
+				dlen = int(dph.i64(5))
+				rlen = int(dph.i64(6))
+			)
+			if dlen < 0 || rlen < 0 || rlen+dlen > len(page) {
+				return errInvTruncated
+			}
+			data := page[rlen+dlen:]
+			if !dph.has(7) || dph.bool(7) {
+				if data, err = invDecompress(c, data, usize-rlen-dlen); err != nil {
+					return err
+				}
+			}
+			defs := page[rlen : rlen+dlen]
+			if err := chunk.dataPage(data, defs, int(dph.i64(1)), int(dph.i64(4))); err != nil {
+				return err
+			}
+		default:
+			// index page, etc. - skip
+		}
+	}
+	if len(chunk.out) != nrows {
+		return fmt.Errorf("expecting %d values, got %d", nrows, len(chunk.out))
+	}
+	return nil
+}
+
+func pqCodec(codec int64) (int, error) {
+	switch codec {
+	case 0:
+		return invCodecNone, nil
+	case 1:
+		return invCodecSnappy, nil
+	case 2:
+		return invCodecGzip, nil
+	case 6:
+		return invCodecZstd, nil
+	case 7:
+		return invCodecLZ4, nil
+	default:
+		return 0, fmt.Errorf("unsupported compression codec %d", codec)
+	}
+}
+
+// data page: v1 when defs == nil (levels are then length-prefixed and precede values)
+func (chunk *pqChunk) dataPage(data, defs []byte, n, enc int) error {
+	var (
+		leaf    = chunk.leaf
+		nonNull = n
+	)
+	if n < 0 || len(chunk.out)+n > cap(chunk.out) {
+		return fmt.Errorf("invalid number of values %d", n)
+	}
+	if leaf.maxDef > 0 {
+		if defs == nil {
+			if len(data) < 4 {
+				return errInvTruncated
+			}
+			l := int(binary.LittleEndian.Uint32(data))
+			if 4+l > len(data) {
+				return errInvTruncated
+			}
+			defs, data = data[4:4+l], data[4+l:]
+		}
+		if cap(chunk.levels) < n {
+			chunk.levels = make([]uint64, n)
+		}
+		chunk.levels = chunk.levels[:n]
+		if err := rleHybrid(defs, bitWidth(uint64(leaf.maxDef)), chunk.levels); err != nil {
+			return fmt.Errorf("definition levels: %w", err)
+		}
+		nonNull = 0
+		for _, l := range chunk.levels {
+			if int(l) == leaf.maxDef {
+				nonNull++
+			}
+		}
+	}
+
+	var (
+		vals []string
+		err  error
+	)
+	switch enc {
+	case pqEncPlain:
+		vals, _, err = leaf.plain(data, nonNull)
+	case pqEncPlainDictionary, pqEncRLEDictionary:
+		vals, err = chunk.dictValues(data, nonNull)
+	case pqEncDeltaBinaryPacked:
+		var ints []int64
+		if ints, _, err = deltaBinaryPacked(data, nonNull); err == nil {
+			vals = make([]string, len(ints))
+			for i, v := range ints {
+				vals[i] = leaf.formatInt(v)
+			}
+		}
+	case pqEncDeltaLengthBytes:
+		vals, err = deltaLengthBytes(data, nonNull)
+	case pqEncDeltaBytes:
+		vals, err = deltaBytes(data, nonNull)
+	default:
+		err = fmt.Errorf("unsupported encoding %d", enc)
+	}
+	if err != nil {
+		return err
+	}
+	if nonNull == n {
+		chunk.out = append(chunk.out, vals...)
+		return nil
+	}
+	var j int
+	for _, l := range chunk.levels {
+		if int(l) == leaf.maxDef {
+			chunk.out = append(chunk.out, vals[j])
+			j++
+		} else {
+			chunk.out = append(chunk.out, "") // null
+		}
+	}
+	return nil
+}
+
+func (chunk *pqChunk) dictValues(data []byte, n int) ([]string, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	if len(data) < 1 {
+		return nil, errInvTruncated
+	}
+	idx := make([]uint64, n)
+	if err := rleHybrid(data[1:], int(data[0]), idx); err != nil {
+		return nil, fmt.Errorf("dictionary indices: %w", err)
+	}
+	vals := make([]string, n)
+	for i, j := range idx {
+		if j >= uint64(len(chunk.dict)) {
+			return nil, fmt.Errorf("dictionary index %d out of range [0, %d)", j, len(chunk.dict))
+		}
+		vals[i] = chunk.dict[j]
+	}
+	return vals, nil
+}
+
+////////////
+// pqLeaf //
+////////////
+
+// PLAIN-encoded values
+func (leaf *pqLeaf) plain(data []byte, n int) (vals []string, off int, _ error) {
+	if n < 0 || n > len(data)*8 {
+		return nil, 0, fmt.Errorf("invalid number of values %d", n)
+	}
+	vals = make([]string, n)
+	switch leaf.typ {
+	case pqBoolean:
+		if (n+7)/8 > len(data) {
+			return nil, 0, errInvTruncated
+		}
+		for i := range n {
+			vals[i] = strconv.FormatBool(data[i/8]&(1<<(i%8)) != 0)
+		}
+		return vals, (n + 7) / 8, nil
+	case pqInt32, pqFloat:
+		if 4*n > len(data) {
+			return nil, 0, errInvTruncated
+		}
+		for i := range n {
+			v := binary.LittleEndian.Uint32(data[4*i:])
+			if leaf.typ == pqFloat {
+				vals[i] = strconv.FormatFloat(float64(math.Float32frombits(v)), 'g', -1, 32)
+			} else {
+				vals[i] = strconv.FormatInt(int64(int32(v)), 10)
+			}
+		}
+		return vals, 4 * n, nil
+	case pqInt64, pqDouble:
+		if 8*n > len(data) {
+			return nil, 0, errInvTruncated
+		}
+		for i := range n {
+			v := binary.LittleEndian.Uint64(data[8*i:])
+			if leaf.typ == pqDouble {
+				vals[i] = strconv.FormatFloat(math.Float64frombits(v), 'g', -1, 64)
+			} else {
+				vals[i] = leaf.formatInt(int64(v))
+			}
+		}
+		return vals, 8 * n, nil
+	case pqInt96:
+		if 12*n > len(data) {
+			return nil, 0, errInvTruncated
+		}
+		for i := range n {
+			var (
+				nanos = int64(binary.LittleEndian.Uint64(data[12*i:]))
+				jday  = int64(binary.LittleEndian.Uint32(data[12*i+8:]))
+			)
+			const julianUnixEpoch = 2440588
+			vals[i] = invFormatTime(time.Unix((jday-julianUnixEpoch)*86400, nanos))
+		}
+		return vals, 12 * n, nil
+	case pqByteArray:
+		for i := range n {
+			if off+4 > len(data) {
+				return nil, 0, errInvTruncated
+			}
+			l := int(binary.LittleEndian.Uint32(data[off:]))
+			off += 4
+			if l < 0 || off+l > len(data) {
+				return nil, 0, errInvTruncated
+			}
+			vals[i] = string(data[off : off+l])
+			off += l
+		}
+		return vals, off, nil
+	case pqFixedLenByteArray:
+		l := leaf.typeLen
+		if l < 0 || l*n > len(data) {
+			return nil, 0, errInvTruncated
+		}
+		for i := range n {
+			vals[i] = string(data[i*l : (i+1)*l])
+		}
+		return vals, l * n, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported type %d", leaf.typ)
+	}
+}
+
+func (leaf *pqLeaf) formatInt(v int64) string {
+	switch leaf.tsUnit {
+	case pqUnitMillis:
+		return invFormatTime(time.UnixMilli(v))
+	case pqUnitMicros:
+		return invFormatTime(time.UnixMicro(v))
+	case pqUnitNanos:
+		return invFormatTime(time.Unix(0, v))
+	default:
+		return strconv.FormatInt(v, 10)
+	}
+}
+
+//
+// encodings
+//
+
+func bitWidth(v uint64) (n int) {
+	for ; v != 0; v >>= 1 {
+		n++
+	}
+	return n
+}
+
+// RLE/bit-packing hybrid
+func rleHybrid(b []byte, width int, out []uint64) error {
+	if width < 0 || width > 64 {
+		return fmt.Errorf("invalid bit width %d", width)
+	}
+	var (
+		off    int
+		nbytes = (width + 7) / 8
+	)
+	for i := 0; i < len(out); {
+		h, n := binary.Uvarint(b[off:])
+		if n <= 0 {
+			return errInvTruncated
+		}
+		off += n
+		if h&1 == 0 { // RLE run
+			cnt := int(h >> 1)
+			if off+nbytes > len(b) {
+				return errInvTruncated
+			}
+			var v uint64
+			for j := range nbytes {
+				v |= uint64(b[off+j]) << (8 * j)
+			}
+			off += nbytes
+			for ; cnt > 0 && i < len(out); cnt-- {
+				out[i] = v
+				i++
+			}
+			continue
+		}
+		// bit-packed run (groups of 8 values)
+		cnt := int(h>>1) * 8
+		size := cnt * width / 8
+		if off+size > len(b) {
+			return errInvTruncated
+		}
+		vals := unpackLE(b[off:off+size], width, min(cnt, len(out)-i))
+		i += copy(out[i:], vals)
+		off += size
+	}
+	return nil
+}
+
+// bit-unpack little-endian (LSB first)
+func unpackLE(b []byte, width, n int) []uint64 {
+	var (
+		out = make([]uint64, n)
+		pos int
+	)
+	for i := range out {
+		var v uint64
+		for j := 0; j < width; {
+			var (
+				bit  = pos & 7
+				take = min(8-bit, width-j)
+				bits = (uint64(b[pos>>3]) >> bit) & (1<<take - 1)
+			)
+			v |= bits << j
+			j += take
+			pos += take
+		}
+		out[i] = v
+	}
+	return out
+}
+
+func deltaBinaryPacked(b []byte, n int) (vals []int64, off int, _ error) {
+	var hdr [3]uint64
+	for i := range hdr {
+		v, k := binary.Uvarint(b[off:])
+		if k <= 0 {
+			return nil, 0, errInvTruncated
+		}
+		hdr[i], off = v, off+k
+	}
+	first, k := binary.Varint(b[off:])
+	if k <= 0 {
+		return nil, 0, errInvTruncated
+	}
+	off += k
+	var (
+		blockSize  = int(hdr[0])
+		miniblocks = int(hdr[1])
+		total      = int(hdr[2])
+	)
+	if miniblocks <= 0 || blockSize <= 0 || blockSize%miniblocks != 0 || total < n {
+		return nil, 0, errors.New("invalid delta-binary-packed header")
+	}
+	vals = make([]int64, 0, total)
+	if total > 0 {
+		vals = append(vals, first)
+	}
+	var (
+		perMini = blockSize / miniblocks
+		prev    = first
+	)
+	for len(vals) < total {
+		minDelta, k := binary.Varint(b[off:])
+		if k <= 0 || off+k+miniblocks > len(b) {
+			return nil, 0, errInvTruncated
+		}
+		off += k
+		widths := b[off : off+miniblocks]
+		off += miniblocks
+		for _, w := range widths {
+			if len(vals) >= total {
+				break
+			}
+			size := perMini * int(w) / 8
+			if w > 64 || off+size > len(b) {
+				return nil, 0, errInvTruncated
+			}
+			for _, d := range unpackLE(b[off:off+size], int(w), perMini) {
+				if len(vals) >= total {
+					break
+				}
+				prev += minDelta + int64(d)
+				vals = append(vals, prev)
+			}
+			off += size
+		}
+	}
+	return vals[:n], off, nil
+}
+
+func deltaLengthBytes(b []byte, n int) ([]string, error) {
+	lens, off, err := deltaBinaryPacked(b, n)
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]string, n)
+	for i, l := range lens {
+		if l < 0 || off+int(l) > len(b) {
+			return nil, errInvTruncated
+		}
+		vals[i] = string(b[off : off+int(l)])
+		off += int(l)
+	}
+	return vals, nil
+}
+
+func deltaBytes(b []byte, n int) ([]string, error) {
+	prefixes, off, err := deltaBinaryPacked(b, n)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, err := deltaLengthBytes(b[off:], n)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		vals = make([]string, n)
+		prev string
+	)
+	for i, p := range prefixes {
+		if p < 0 || int(p) > len(prev) {
+			return nil, errors.New("invalid delta-byte-array prefix length")
+		}
+		vals[i] = prev[:p] + suffixes[i]
+		prev = vals[i]
+	}
+	return vals, nil
+}
+
+//
+// thrift compact protocol (decoding only)
+//
+
+const (
+	tcStop   = 0
+	tcTrue   = 1
+	tcFalse  = 2
+	tcByte   = 3
+	tcI16    = 4
+	tcI32    = 5
+	tcI64    = 6
+	tcDouble = 7
+	tcBinary = 8
+	tcList   = 9
+	tcSet    = 10
+	tcMap    = 11
+	tcStruct = 12
+
+	tcMaxDepth = 16
+)
+
+type (
+	// field ID => value (int64, bool, float64, []byte, []any, tstruct)
+	tstruct  map[int16]any
+	tdecoder struct {
+		b   []byte
+		off int
+	}
+)
+
+func (s tstruct) has(id int16) bool    { _, ok := s[id]; return ok }
+func (s tstruct) i64(id int16) int64   { v, _ := s[id].(int64); return v }
+func (s tstruct) bool(id int16) bool   { v, _ := s[id].(bool); return v }
+func (s tstruct) str(id int16) string  { v, _ := s[id].([]byte); return string(v) }
+func (s tstruct) list(id int16) []any  { v, _ := s[id].([]any); return v }
+func (s tstruct) sub(id int16) tstruct { v, _ := s[id].(tstruct); return v }
+
+func (d *tdecoder) byte() (byte, error) {
+	if d.off >= len(d.b) {
+		return 0, errInvTruncated
+	}
+	d.off++
+	return d.b[d.off-1], nil
+}
+
+func (d *tdecoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.b[d.off:])
+	if n <= 0 {
+		return 0, errInvTruncated
+	}
+	d.off += n
+	return v, nil
+}
+
+func (d *tdecoder) varint() (int64, error) {
+	v, n := binary.Varint(d.b[d.off:]) // (zigzag)
+	if n <= 0 {
+		return 0, errInvTruncated
+	}
+	d.off += n
+	return v, nil
+}
+
+func (d *tdecoder) readStruct(depth int) (tstruct, error) {
+	if depth > tcMaxDepth {
+		return nil, errors.New("thrift: max depth exceeded")
+	}
+	var (
+		s    = make(tstruct, 8)
+		last int16
+	)
+	for {
+		h, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if h == tcStop {
+			return s, nil
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			v, err := d.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+		v, err := d.readValue(h&0x0f, depth)
+		if err != nil {
+			return nil, err
+		}
+		s[id] = v
+	}
+}
+
+func (d *tdecoder) readValue(typ byte, depth int) (any, error) {
+	switch typ {
+	case tcTrue:
+		return true, nil
+	case tcFalse:
+		return false, nil
+	case tcByte:
+		b, err := d.byte()
+		return int64(int8(b)), err
+	case tcI16, tcI32, tcI64:
+		return d.varint()
+	case tcDouble:
+		if d.off+8 > len(d.b) {
+			return nil, errInvTruncated
+		}
+		d.off += 8
+		return math.Float64frombits(binary.LittleEndian.Uint64(d.b[d.off-8:])), nil
+	case tcBinary:
+		l, err := d.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if l > uint64(len(d.b)-d.off) {
+			return nil, errInvTruncated
+		}
+		d.off += int(l)
+		return d.b[d.off-int(l) : d.off], nil
+	case tcList, tcSet:
+		h, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		size, etyp := uint64(h>>4), h&0x0f
+		if size == 15 {
+			if size, err = d.uvarint(); err != nil {
+				return nil, err
+			}
+		}
+		if size > uint64(len(d.b)-d.off) {
+			return nil, errInvTruncated
+		}
+		l := make([]any, 0, size)
+		for range size {
+			var v any
+			if etyp == tcTrue || etyp == tcFalse {
+				b, err := d.byte()
+				if err != nil {
+					return nil, err
+				}
+				v = b == tcTrue
+			} else if v, err = d.readValue(etyp, depth+1); err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	case tcMap: // (not used by Parquet metadata - skipping)
+		size, err := d.uvarint()
+		if err != nil || size == 0 {
+			return nil, err
+		}
+		kv, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		if size > uint64(len(d.b)-d.off) {
+			return nil, errInvTruncated
+		}
+		for range size {
+			if _, err := d.readValue(kv>>4, depth+1); err != nil {
+				return nil, err
+			}
+			if _, err := d.readValue(kv&0x0f, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case tcStruct:
+		return d.readStruct(depth + 1)
+	default:
+		return nil, fmt.Errorf("thrift: invalid type %d", typ)
+	}
+}
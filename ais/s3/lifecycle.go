@@ -0,0 +1,154 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Bucket lifecycle configuration (GET/PUT/DELETE /s3/<bucket-name>?lifecycle).
+// Supported are expiration (in days) and abort-incomplete-multipart actions
+// filtered by prefix and/or object tags.
+// See also: cmn.LifecycleConf
+
+const (
+	lifecycleEnabled  = "Enabled"
+	lifecycleDisabled = "Disabled"
+)
+
+type (
+	LifecycleConfiguration struct {
+		Rules []LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		Expiration *LifecycleExpiration `xml:"Expiration,omitempty"`
+		AbortMpt   *LifecycleAbortMpt   `xml:"AbortIncompleteMultipartUpload,omitempty"`
+		Filter     *LifecycleFilter     `xml:"Filter,omitempty"`
+		ID         string               `xml:"ID,omitempty"`
+		Prefix     string               `xml:"Prefix,omitempty"` // legacy (ie., prior to Filter)
+		Status     string               `xml:"Status"`
+	}
+	LifecycleFilter struct {
+		Tag    *LifecycleTag `xml:"Tag,omitempty"`
+		And    *LifecycleAnd `xml:"And,omitempty"`
+		Prefix string        `xml:"Prefix,omitempty"`
+	}
+	LifecycleAnd struct {
+		Prefix string         `xml:"Prefix,omitempty"`
+		Tags   []LifecycleTag `xml:"Tag"`
+	}
+	LifecycleTag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+	LifecycleExpiration struct {
+		Date string `xml:"Date,omitempty"`
+		Days int    `xml:"Days,omitempty"`
+	}
+	LifecycleAbortMpt struct {
+		DaysAfterInitiation int `xml:"DaysAfterInitiation"`
+	}
+)
+
+func ErrNoLifecycle(bucket string) error {
+	return fmt.Errorf("%s[NoSuchLifecycleConfiguration: bucket %q has no lifecycle configuration]", ErrPrefix, bucket)
+}
+
+func NewLifecycleConfiguration(rules cmn.LifecycleRules) *LifecycleConfiguration {
+	lc := &LifecycleConfiguration{Rules: make([]LifecycleRule, 0, len(rules))}
+	for i := range rules {
+		var (
+			rule = &rules[i]
+			out  = LifecycleRule{ID: rule.ID, Status: lifecycleEnabled}
+		)
+		if rule.Disabled {
+			out.Status = lifecycleDisabled
+		}
+		switch {
+		case len(rule.Tags) == 0:
+			out.Filter = &LifecycleFilter{Prefix: rule.Prefix}
+		case len(rule.Tags) == 1 && rule.Prefix == "":
+			for k, v := range rule.Tags {
+				out.Filter = &LifecycleFilter{Tag: &LifecycleTag{Key: k, Value: v}}
+			}
+		default:
+			and := &LifecycleAnd{Prefix: rule.Prefix, Tags: make([]LifecycleTag, 0, len(rule.Tags))}
+			for k, v := range rule.Tags {
+				and.Tags = append(and.Tags, LifecycleTag{Key: k, Value: v})
+			}
+			out.Filter = &LifecycleFilter{And: and}
+		}
+		if rule.ExpirationDays > 0 {
+			out.Expiration = &LifecycleExpiration{Days: rule.ExpirationDays}
+		}
+		if rule.AbortMptDays > 0 {
+			out.AbortMpt = &LifecycleAbortMpt{DaysAfterInitiation: rule.AbortMptDays}
+		}
+		lc.Rules = append(lc.Rules, out)
+	}
+	return lc
+}
+
+func (lc *LifecycleConfiguration) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(lc)
+	debug.AssertNoErr(err)
+}
+
+// convert to native bucket props
+func (lc *LifecycleConfiguration) ToRules() (cmn.LifecycleRules, error) {
+	if len(lc.Rules) == 0 {
+		return nil, errors.New("lifecycle configuration must contain at least one rule")
+	}
+	rules := make(cmn.LifecycleRules, 0, len(lc.Rules))
+	for i := range lc.Rules {
+		var (
+			in   = &lc.Rules[i]
+			rule = cmn.LifecycleRule{ID: in.ID, Prefix: in.Prefix}
+		)
+		switch in.Status {
+		case lifecycleEnabled:
+		case lifecycleDisabled:
+			rule.Disabled = true
+		default:
+			return nil, fmt.Errorf("lifecycle rule %q: invalid status %q", in.ID, in.Status)
+		}
+		if f := in.Filter; f != nil {
+			if f.Prefix != "" {
+				rule.Prefix = f.Prefix
+			}
+			if f.Tag != nil {
+				rule.Tags = cos.StrKVs{f.Tag.Key: f.Tag.Value}
+			}
+			if f.And != nil {
+				if f.And.Prefix != "" {
+					rule.Prefix = f.And.Prefix
+				}
+				rule.Tags = make(cos.StrKVs, len(f.And.Tags))
+				for _, tag := range f.And.Tags {
+					rule.Tags[tag.Key] = tag.Value
+				}
+			}
+		}
+		if in.Expiration != nil {
+			if in.Expiration.Date != "" {
+				return nil, fmt.Errorf("lifecycle rule %q: expiration date is not supported (use days)", in.ID)
+			}
+			rule.ExpirationDays = in.Expiration.Days
+		}
+		if in.AbortMpt != nil {
+			rule.AbortMptDays = in.AbortMpt.DaysAfterInitiation
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,76 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+const lcyXML = `<LifecycleConfiguration>
+  <Rule>
+    <ID>logs</ID>
+    <Filter><Prefix>logs/</Prefix></Filter>
+    <Status>Enabled</Status>
+    <Expiration><Days>30</Days></Expiration>
+  </Rule>
+  <Rule>
+    <ID>tmp</ID>
+    <Filter><And><Prefix>tmp/</Prefix><Tag><Key>k1</Key><Value>v1</Value></Tag><Tag><Key>k2</Key><Value>v2</Value></Tag></And></Filter>
+    <Status>Disabled</Status>
+    <Expiration><Days>1</Days></Expiration>
+    <AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload>
+  </Rule>
+</LifecycleConfiguration>`
+
+func TestLifecycleRules(t *testing.T) {
+	lc := &LifecycleConfiguration{}
+	if err := xml.NewDecoder(strings.NewReader(lcyXML)).Decode(lc); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := lc.ToRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cmn.LifecycleRules{
+		{ID: "logs", Prefix: "logs/", ExpirationDays: 30},
+		{ID: "tmp", Prefix: "tmp/", Tags: cos.StrKVs{"k1": "v1", "k2": "v2"}, ExpirationDays: 1, AbortMptDays: 7, Disabled: true},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("expected %s, got %s", expected, rules)
+	}
+	conf := cmn.LifecycleConf{Rules: rules, Enabled: true}
+	if err := conf.ValidateAsProps(); err != nil {
+		t.Fatal(err)
+	}
+
+	// round trip
+	again, err := NewLifecycleConfiguration(rules).ToRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, expected) {
+		t.Fatalf("expected %s, got %s", expected, again)
+	}
+
+	// matching
+	if !rules[0].Match("logs/2024/01", nil) || rules[0].Match("log/2024/01", nil) {
+		t.Fatal("prefix mismatch")
+	}
+	if rules[1].Match("tmp/a", cos.StrKVs{"k1": "v1"}) || !rules[1].Match("tmp/a", cos.StrKVs{"k1": "v1", "k2": "v2", "k3": ""}) {
+		t.Fatal("tags mismatch")
+	}
+
+	// unsupported
+	lc.Rules[0].Expiration.Date = "2024-12-31T00:00:00Z"
+	if _, err := lc.ToRules(); err == nil {
+		t.Fatal("expected error (expiration date)")
+	}
+}
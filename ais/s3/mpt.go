@@ -7,12 +7,12 @@ package s3
 import (
 	"fmt"
 	"net/http"
-	"os"
 	"sort"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
@@ -21,16 +21,19 @@ import (
 // NOTE: xattr stores only the (*) marked attributes
 type (
 	MptPart struct {
-		MD5  string // MD5 of the part (*)
-		FQN  string // FQN of the corresponding workfile
-		Size int64  // part size in bytes (*)
-		Num  int32  // part number (*)
+		MD5  string `json:"md5"`         // MD5 of the part (*)
+		FQN  string `json:"fqn"`         // FQN of the corresponding workfile
+		Size int64  `json:"size,string"` // part size in bytes (*)
+		Num  int32  `json:"num"`         // part number (*)
 	}
 	mpt struct {
-		bckName string
-		objName string
+		mdfqn   string     // persistent state (see mptmd.go)
+		bck     cmn.Bck    // bucket
+		objName string     // object name
 		parts   []*MptPart // by part number
 		ctime   time.Time  // InitUpload time
+		mtime   time.Time  // last activity (new part)
+		mu      sync.Mutex // serializes persisting
 	}
 	uploads map[string]*mpt // by upload ID
 )
@@ -41,18 +44,23 @@ var (
 )
 
 // Start miltipart upload
-func InitUpload(id, bckName, objName string) {
+func InitUpload(id string, lom *core.LOM) {
+	now := time.Now()
+	mpt := &mpt{
+		bck:     *lom.Bucket(),
+		objName: lom.ObjName,
+		parts:   make([]*MptPart, 0, iniCapParts),
+		ctime:   now,
+		mtime:   now,
+	}
 	mu.Lock()
 	if ups == nil {
 		ups = make(uploads, 8)
 	}
-	ups[id] = &mpt{
-		bckName: bckName,
-		objName: objName,
-		parts:   make([]*MptPart, 0, iniCapParts),
-		ctime:   time.Now(),
-	}
+	ups[id] = mpt
 	mu.Unlock()
+
+	mpt.persist(id, lom.Mountpath())
 }
 
 // Add part to an active upload.
@@ -65,8 +73,12 @@ func AddPart(id string, npart *MptPart) (err error) {
 		err = fmt.Errorf("upload %q not found (%s, %d)", id, npart.FQN, npart.Num)
 	} else {
 		mpt.parts = append(mpt.parts, npart)
+		mpt.mtime = time.Now()
 	}
 	mu.Unlock()
+	if ok {
+		mpt.persist(id, nil)
+	}
 	return
 }
 
@@ -132,16 +144,13 @@ func CleanupUpload(id, fqn string, aborted bool) (exists bool) {
 	delete(ups, id)
 	mu.Unlock()
 
+	mpt.unpersist()
 	if !aborted {
 		if err := storeMptXattr(fqn, mpt); err != nil {
 			nlog.Warningf("fqn %s, id %s: %v", fqn, id, err)
 		}
 	}
-	for _, part := range mpt.parts {
-		if err := os.Remove(part.FQN); err != nil && !os.IsNotExist(err) {
-			nlog.Errorln(err)
-		}
-	}
+	_rmParts(mpt.parts)
 	return true
 }
 
@@ -149,6 +158,9 @@ func ListUploads(bckName, idMarker string, maxUploads int) (result *ListMptUploa
 	mu.RLock()
 	results := make([]UploadInfoResult, 0, len(ups))
 	for id, mpt := range ups {
+		if mpt.bck.Name != bckName {
+			continue
+		}
 		results = append(results, UploadInfoResult{Key: mpt.objName, UploadID: id, Initiated: mpt.ctime})
 	}
 	mu.RUnlock()
@@ -186,7 +198,7 @@ func ListParts(id string, lom *core.LOM) (parts []*PartInfo, ecode int, err erro
 			mu.RUnlock()
 			return nil, ecode, err
 		}
-		mpt.bckName, mpt.objName = lom.Bck().Name, lom.ObjName
+		mpt.bck, mpt.objName = *lom.Bucket(), lom.ObjName
 		mpt.ctime = lom.Atime()
 	}
 	parts = make([]*PartInfo, 0, len(mpt.parts))
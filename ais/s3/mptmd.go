@@ -0,0 +1,244 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Active multipart uploads survive target restarts:
+// - upload state (bucket, object, parts, and their respective workfiles) is stored
+//   under fname.MptDir at the root of the mountpath that holds the parts;
+// - the state gets updated upon each new part and removed upon completion or abort;
+// - at startup, target reloads all persisted uploads (see LoadUploads) and re-adopts
+//   their part workfiles that would otherwise be considered old (and removed) by space cleanup;
+// - uploads that show no activity for longer than config.Timeout.MptAbandoned
+//   are garbage collected by the housekeeper.
+
+const (
+	dfltMptAbandoned = 7 * hk.DayInterval
+	mptHkIval        = 10 * time.Minute
+)
+
+type (
+	// persistent state of an active multipart upload
+	mptMD struct {
+		ID      string     `json:"id"`
+		Bck     cmn.Bck    `json:"bck"`
+		ObjName string     `json:"obj_name"`
+		Parts   []*MptPart `json:"parts"`
+		Ctime   int64      `json:"ctime,string"`
+		Mtime   int64      `json:"mtime,string"`
+	}
+)
+
+// interface guard
+var _ jsp.Opts = (*mptMD)(nil)
+
+func (*mptMD) JspOpts() jsp.Options { return jsp.CksumSign(cmn.MetaverMpt) }
+
+func mptFname(id string) string {
+	digest := xxhash.Checksum64S(cos.UnsafeB(id), cos.MLCG32)
+	return strconv.FormatUint(digest, 36)
+}
+
+// store upload state; the mountpath (mi) is required only the first time
+func (mpt *mpt) persist(id string, mi *fs.Mountpath) {
+	mpt.mu.Lock()
+	if mpt.mdfqn == "" {
+		if mi == nil {
+			mpt.mu.Unlock()
+			return
+		}
+		dir := filepath.Join(mi.Path, fname.MptDir)
+		if err := cos.CreateDir(dir); err != nil {
+			mpt.mu.Unlock()
+			nlog.Errorln("upload", id, "failed to persist:", err)
+			return
+		}
+		mpt.mdfqn = filepath.Join(dir, mptFname(id))
+	}
+	mu.RLock()
+	md := &mptMD{
+		ID:      id,
+		Bck:     mpt.bck,
+		ObjName: mpt.objName,
+		Parts:   make([]*MptPart, len(mpt.parts)),
+		Ctime:   mpt.ctime.UnixNano(),
+		Mtime:   mpt.mtime.UnixNano(),
+	}
+	copy(md.Parts, mpt.parts)
+	mu.RUnlock()
+
+	if err := jsp.SaveMeta(mpt.mdfqn, md, nil); err != nil {
+		nlog.Errorln("upload", id, "failed to persist:", err)
+	}
+	mpt.mu.Unlock()
+}
+
+func (mpt *mpt) unpersist() {
+	mpt.mu.Lock()
+	if mpt.mdfqn != "" {
+		if err := cos.RemoveFile(mpt.mdfqn); err != nil {
+			nlog.Errorln(err)
+		}
+		mpt.mdfqn = ""
+	}
+	mpt.mu.Unlock()
+}
+
+//
+// startup
+//
+
+// Load all persisted uploads from all available mountpaths and start
+// periodic garbage collection of the abandoned ones.
+// Must be called once upon target startup, after BMD is loaded.
+func LoadUploads() {
+	var n int
+	for _, mi := range fs.GetAvail() {
+		dir := filepath.Join(mi.Path, fname.MptDir)
+		dentries, err := os.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Errorln(err)
+			}
+			continue
+		}
+		for _, dent := range dentries {
+			if dent.IsDir() {
+				continue
+			}
+			if _loadUpload(filepath.Join(dir, dent.Name())) {
+				n++
+			}
+		}
+	}
+	if n > 0 {
+		nlog.Infoln("loaded", n, "active multipart upload(s)")
+	}
+	hk.Reg("s3-mpt"+hk.NameSuffix, housekeep, mptHkIval)
+}
+
+func _loadUpload(mdfqn string) bool {
+	md := &mptMD{}
+	if _, err := jsp.LoadMeta(mdfqn, md); err != nil {
+		nlog.Errorln("failed to load multipart upload state", mdfqn, "err:", err)
+		cos.RemoveFile(mdfqn)
+		return false
+	}
+	lom := &core.LOM{ObjName: md.ObjName}
+	if err := lom.InitBck(&md.Bck); err != nil {
+		nlog.Warningln("upload", md.ID, "discarding:", err)
+		_rmParts(md.Parts)
+		cos.RemoveFile(mdfqn)
+		return false
+	}
+	mpt := &mpt{
+		mdfqn:   mdfqn,
+		bck:     md.Bck,
+		objName: md.ObjName,
+		parts:   make([]*MptPart, 0, max(len(md.Parts), iniCapParts)),
+		ctime:   time.Unix(0, md.Ctime),
+		mtime:   time.Unix(0, md.Mtime),
+	}
+	for _, part := range md.Parts {
+		// re-adopt the workfile (a workfile created by a different process is considered old)
+		prefix := md.ID + "." + strconv.FormatInt(int64(part.Num), 10)
+		wfqn := filepath.Join(filepath.Dir(part.FQN), filepath.Base(fs.CSM.Gen(lom, fs.WorkfileType, prefix)))
+		if err := os.Rename(part.FQN, wfqn); err != nil {
+			nlog.Errorln("upload", md.ID, "part", part.Num, "lost:", err)
+			continue
+		}
+		part.FQN = wfqn
+		mpt.parts = append(mpt.parts, part)
+	}
+
+	mu.Lock()
+	if ups == nil {
+		ups = make(uploads, 8)
+	}
+	ups[md.ID] = mpt
+	mu.Unlock()
+
+	// persist re-adopted part names
+	mpt.persist(md.ID, nil)
+	return true
+}
+
+func _rmParts(parts []*MptPart) {
+	for _, part := range parts {
+		if err := os.Remove(part.FQN); err != nil && !os.IsNotExist(err) {
+			nlog.Errorln(err)
+		}
+	}
+}
+
+//
+// housekeeping: garbage collect abandoned uploads
+//
+
+func housekeep() time.Duration {
+	var (
+		abandoned []string
+		config    = cmn.GCO.Get()
+		maxAge    = config.Timeout.MptAbandoned.D()
+		now       = time.Now()
+	)
+	if maxAge == 0 {
+		maxAge = dfltMptAbandoned
+	}
+	mu.RLock()
+	for id, mpt := range ups {
+		if now.Sub(mpt.mtime) > maxAge {
+			abandoned = append(abandoned, id)
+		}
+	}
+	mu.RUnlock()
+
+	for _, id := range abandoned {
+		if CleanupUpload(id, "", true /*aborted*/) {
+			nlog.Infoln("upload", id, "abandoned for more than", maxAge, "- removed")
+		}
+	}
+	return mptHkIval
+}
+
+// Abort uploads into a given bucket (and under a given prefix, if specified)
+// that were initiated more than `age` ago - see bucket lifecycle (cmn.LifecycleRule).
+func AbortStale(bck *cmn.Bck, prefix string, age time.Duration) (n int) {
+	var (
+		stale []string
+		now   = time.Now()
+	)
+	mu.RLock()
+	for id, mpt := range ups {
+		if mpt.bck.Equal(bck) && strings.HasPrefix(mpt.objName, prefix) && now.Sub(mpt.ctime) > age {
+			stale = append(stale, id)
+		}
+	}
+	mu.RUnlock()
+
+	for _, id := range stale {
+		if CleanupUpload(id, "", true /*aborted*/) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,259 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/fname"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/tools/trand"
+)
+
+func TestPersistUpload(t *testing.T) {
+	const (
+		id   = "upload-id"
+		nump = 10
+	)
+	now := time.Now()
+	in := &mpt{
+		mdfqn:   filepath.Join(t.TempDir(), mptFname(id)),
+		bck:     cmn.Bck{Name: "bucket", Provider: apc.AIS},
+		objName: "a/b/c",
+		ctime:   now,
+		mtime:   now,
+	}
+	for i := range nump {
+		in.parts = append(in.parts, &MptPart{Num: int32(i + 1), MD5: trand.String(8), FQN: trand.String(16), Size: 1024 + int64(i)})
+	}
+	in.persist(id, nil)
+
+	out := &mptMD{}
+	if _, err := jsp.LoadMeta(in.mdfqn, out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != id || !out.Bck.Equal(&in.bck) || out.ObjName != in.objName {
+		t.Fatalf("in %s/%s (%s) != out %s/%s (%s)", in.bck.String(), in.objName, id, out.Bck.String(), out.ObjName, out.ID)
+	}
+	if out.Ctime != now.UnixNano() || out.Mtime != now.UnixNano() {
+		t.Fatalf("timestamps: expected %d, got (%d, %d)", now.UnixNano(), out.Ctime, out.Mtime)
+	}
+	if len(out.Parts) != nump {
+		t.Fatalf("expected %d parts, got %d", nump, len(out.Parts))
+	}
+	for i := range nump {
+		if *in.parts[i] != *out.Parts[i] {
+			t.Fatalf("in %v != out %v", *in.parts[i], *out.Parts[i])
+		}
+	}
+
+	mdfqn := in.mdfqn
+	in.unpersist()
+	if _, err := jsp.LoadMeta(mdfqn, &mptMD{}); err == nil {
+		t.Fatal("expected upload state to be removed")
+	}
+}
+
+// target with a single mountpath and a single bucket
+func newMptTest(t *testing.T) (bck *meta.Bck, mi *fs.Mountpath) {
+	var (
+		bmd   = mock.NewBaseBownerMock()
+		tMock = mock.NewTarget(bmd)
+		mpath = t.TempDir()
+	)
+	bck = meta.NewBck("mpt", apc.AIS, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumNone}})
+	bmd.Add(bck)
+	fs.TestNew(mock.NewIOS())
+	mi, err := fs.Add(mpath, tMock.SID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { fs.Remove(mpath) })
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	if err := cos.CreateDir(mi.MakePathBck(bck.Bucket())); err != nil {
+		t.Fatal(err)
+	}
+	hk.TestInit()
+
+	mu.Lock()
+	ups = nil
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		ups = nil
+		mu.Unlock()
+	})
+	return bck, mi
+}
+
+// initiate upload and add parts; when `oldpid` is true the part workfiles are named
+// as if created by a different (e.g., previous) process
+func newMptUpload(t *testing.T, bck *meta.Bck, objName string, nump int, oldpid bool) (id string, fqns []string) {
+	lom := &core.LOM{ObjName: objName}
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		t.Fatal(err)
+	}
+	id = cos.GenUUID()
+	InitUpload(id, lom)
+	for i := 1; i <= nump; i++ {
+		wfqn := fs.CSM.Gen(lom, fs.WorkfileType, id+"."+strconv.Itoa(i))
+		if oldpid {
+			j := strings.LastIndexByte(wfqn, '.')
+			wfqn = wfqn[:j+1] + strconv.FormatInt(int64(os.Getpid()+1), 16)
+		}
+		if err := cos.CreateDir(filepath.Dir(wfqn)); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(wfqn, []byte(trand.String(16)), cos.PermRWR); err != nil {
+			t.Fatal(err)
+		}
+		if err := AddPart(id, &MptPart{Num: int32(i), MD5: trand.String(8), FQN: wfqn, Size: 16}); err != nil {
+			t.Fatal(err)
+		}
+		fqns = append(fqns, wfqn)
+	}
+	return id, fqns
+}
+
+func isOldWorkfile(t *testing.T, fqn string) bool {
+	_, old, ok := (&fs.WorkfileContentResolver{}).ParseUniqueFQN(filepath.Base(fqn))
+	if !ok {
+		t.Fatalf("%s is not a workfile", fqn)
+	}
+	return old
+}
+
+// target restart: reload persisted uploads and re-adopt their (old) part workfiles
+func TestLoadUploads(t *testing.T) {
+	const nump = 3
+	bck, mi := newMptTest(t)
+	id, fqns := newMptUpload(t, bck, "a/b/c", nump, true /*oldpid*/)
+	for _, fqn := range fqns {
+		if !isOldWorkfile(t, fqn) {
+			t.Fatalf("expected %s to be considered old", fqn)
+		}
+	}
+	// invalid upload state
+	bogus := filepath.Join(mi.Path, fname.MptDir, "bogus")
+	if err := os.WriteFile(bogus, []byte("bogus"), cos.PermRWR); err != nil {
+		t.Fatal(err)
+	}
+
+	// restart
+	mu.Lock()
+	ups = nil
+	mu.Unlock()
+	LoadUploads()
+
+	mu.RLock()
+	mpt, ok := ups[id]
+	mu.RUnlock()
+	if !ok || len(ups) != 1 {
+		t.Fatalf("expected upload %q to be loaded, got %v", id, ups)
+	}
+	if !mpt.bck.Equal(bck.Bucket()) || mpt.objName != "a/b/c" || len(mpt.parts) != nump {
+		t.Fatalf("unexpected upload %s/%s with %d parts", mpt.bck.String(), mpt.objName, len(mpt.parts))
+	}
+	md := &mptMD{}
+	if _, err := jsp.LoadMeta(mpt.mdfqn, md); err != nil {
+		t.Fatal(err)
+	}
+	for i, part := range mpt.parts {
+		if part.Num != int32(i+1) || part.FQN == fqns[i] || isOldWorkfile(t, part.FQN) {
+			t.Fatalf("part %d (%s) was not re-adopted", part.Num, part.FQN)
+		}
+		if _, err := os.Stat(part.FQN); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(fqns[i]); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be renamed, got %v", fqns[i], err)
+		}
+		if md.Parts[i].FQN != part.FQN {
+			t.Fatalf("expected persisted part %d to be %s, got %s", part.Num, part.FQN, md.Parts[i].FQN)
+		}
+	}
+	if _, err := os.Stat(bogus); !os.IsNotExist(err) {
+		t.Fatalf("expected invalid upload state %s to be removed, got %v", bogus, err)
+	}
+	size, err := ObjSize(id)
+	if err != nil || size != nump*16 {
+		t.Fatalf("expected size %d, got %d (%v)", nump*16, size, err)
+	}
+}
+
+// uploads with no activity for longer than config.Timeout.MptAbandoned get removed
+func TestMptAbandoned(t *testing.T) {
+	bck, _ := newMptTest(t)
+	config := cmn.GCO.BeginUpdate()
+	config.Timeout.MptAbandoned = cos.Duration(time.Hour)
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Timeout.MptAbandoned = 0
+		cmn.GCO.CommitUpdate(config)
+	}()
+
+	abandoned, afqns := newMptUpload(t, bck, "abandoned", 2, false)
+	active, fqns := newMptUpload(t, bck, "active", 2, false)
+	mu.Lock()
+	amdfqn := ups[abandoned].mdfqn
+	ups[abandoned].mtime = time.Now().Add(-2 * time.Hour)
+	ups[active].ctime = time.Now().Add(-2 * time.Hour) // (initiated long ago but still active)
+	mu.Unlock()
+
+	if ival := housekeep(); ival != mptHkIval {
+		t.Fatalf("expected next run in %v, got %v", mptHkIval, ival)
+	}
+	mu.RLock()
+	_, aok := ups[abandoned]
+	_, ok := ups[active]
+	mu.RUnlock()
+	if aok || !ok {
+		t.Fatalf("expected only %q to be removed (%t, %t)", abandoned, aok, ok)
+	}
+	for _, fqn := range append(afqns, amdfqn) {
+		if _, err := os.Stat(fqn); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be removed, got %v", fqn, err)
+		}
+	}
+	for _, fqn := range fqns {
+		if _, err := os.Stat(fqn); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// default (when not configured): one week
+	config = cmn.GCO.BeginUpdate()
+	config.Timeout.MptAbandoned = 0
+	cmn.GCO.CommitUpdate(config)
+	mu.Lock()
+	ups[active].mtime = time.Now().Add(-dfltMptAbandoned + time.Hour)
+	mu.Unlock()
+	housekeep()
+	if _, err := ObjSize(active); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	ups[active].mtime = time.Now().Add(-dfltMptAbandoned - time.Hour)
+	mu.Unlock()
+	housekeep()
+	if _, err := ObjSize(active); err == nil {
+		t.Fatalf("expected %q to be removed", active)
+	}
+}
@@ -0,0 +1,402 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket policy (GET/PUT/DELETE /s3/<bucket-name>?policy) and ACL (GET/PUT ?acl).
+//
+// Supported is a subset of IAM policy syntax that translates into bucket props:
+// - Principal "*" with Effect "Deny" - removes permissions from the bucket's `Access`
+//   (applies to everyone);
+// - Principal "*" with Effect "Allow" - grants permissions to any authenticated (AuthN) user;
+// - Principal {"AWS": [...]} - grants (or denies) permissions to the named AuthN users,
+//   where "arn:aws:iam::<account>:user/<name>" is the same as "<name>".
+// Resources must refer to the bucket itself ("arn:aws:s3:::<bucket>" and/or "arn:aws:s3:::<bucket>/*").
+// Condition, NotAction, NotPrincipal, and NotResource are not supported.
+// See also: cmn.AccessGrants
+
+const (
+	policyVersion = "2012-10-17"
+	arnBucket     = "arn:aws:s3:::"
+	arnUser       = ":user/"
+
+	effectAllow = "Allow"
+	effectDeny  = "Deny"
+
+	actionAll = "s3:*"
+)
+
+type (
+	Policy struct {
+		Version   string            `json:"Version,omitempty"`
+		ID        string            `json:"Id,omitempty"`
+		Statement []PolicyStatement `json:"Statement"`
+	}
+	PolicyStatement struct {
+		Sid       string    `json:"Sid,omitempty"`
+		Effect    string    `json:"Effect"`
+		Principal Principal `json:"Principal"`
+		Action    strList   `json:"Action"`
+		Resource  strList   `json:"Resource"`
+	}
+	// "*" or {"AWS": "name" | ["name", ...]}
+	Principal []string
+
+	// string or list of strings
+	strList []string
+
+	// ACL
+	AccessControlPolicy struct {
+		XMLName xml.Name   `xml:"AccessControlPolicy"`
+		Ns      string     `xml:"xmlns,attr"`
+		Owner   ACLOwner   `xml:"Owner"`
+		Grants  []ACLGrant `xml:"AccessControlList>Grant"`
+	}
+	ACLOwner struct {
+		ID          string `xml:"ID"`
+		DisplayName string `xml:"DisplayName"`
+	}
+	ACLGrant struct {
+		Grantee    ACLGrantee `xml:"Grantee"`
+		Permission string     `xml:"Permission"`
+	}
+	ACLGrantee struct {
+		Xsi  string `xml:"xmlns:xsi,attr"`
+		Type string `xml:"xsi:type,attr"`
+		ID   string `xml:"ID,omitempty"`
+		URI  string `xml:"URI,omitempty"`
+	}
+)
+
+// S3 action => AIS permissions; reverse translation (GET ?policy) uses only "canonical" actions
+var policyActions = []struct {
+	action    string
+	ace       apc.AccessAttrs
+	canonical bool
+}{
+	{"s3:GetObject", apc.AceGET | apc.AceObjHEAD, true},
+	{"s3:PutObject", apc.AcePUT | apc.AceAPPEND, true},
+	{"s3:DeleteObject", apc.AceObjDELETE, true},
+	{"s3:ListBucket", apc.AceObjLIST | apc.AceBckHEAD, true},
+	{"s3:GetBucketPolicy", apc.AceBckHEAD, true},
+	{"s3:GetBucketAcl", apc.AceBckHEAD, false},
+	{"s3:PutBucketPolicy", apc.AceBckSetACL, true},
+	{"s3:DeleteBucketPolicy", apc.AceBckSetACL, false},
+	{"s3:PutBucketAcl", apc.AceBckSetACL, false},
+	{"s3:PutBucketVersioning", apc.AcePATCH, true},
+	{"s3:PutLifecycleConfiguration", apc.AcePATCH, false},
+	{"s3:DeleteBucket", apc.AceDestroyBucket, true},
+}
+
+// all of the above ("s3:*")
+var policyScope apc.AccessAttrs
+
+func init() {
+	for _, a := range policyActions {
+		policyScope |= a.ace
+	}
+}
+
+func ErrNoBucketPolicy(bucket string) error {
+	return fmt.Errorf("%s[NoSuchBucketPolicy: bucket %q has no bucket policy]", ErrPrefix, bucket)
+}
+
+func errMalformedPolicy(format string, a ...any) error {
+	return fmt.Errorf("%s[MalformedPolicy: %s]", ErrPrefix, fmt.Sprintf(format, a...))
+}
+
+var policyJSON = jsoniter.Config{DisallowUnknownFields: true}.Froze()
+
+func ParsePolicy(b []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := policyJSON.Unmarshal(b, policy); err != nil {
+		return nil, errMalformedPolicy("%v (note: only a subset of policy elements is supported)", err)
+	}
+	return policy, nil
+}
+
+// translate policy into bucket's access permissions and per-principal grants
+func (policy *Policy) ToAccess(bucket string) (access apc.AccessAttrs, grants cmn.AccessGrants, err error) {
+	switch policy.Version {
+	case "", policyVersion, "2008-10-17":
+	default:
+		return 0, nil, errMalformedPolicy("invalid version %q", policy.Version)
+	}
+	if len(policy.Statement) == 0 {
+		return 0, nil, errMalformedPolicy("no statements")
+	}
+	access, grants = apc.AccessAll, make(cmn.AccessGrants, 2)
+	for i := range policy.Statement {
+		stmt := &policy.Statement[i]
+		if err := stmt.validateResource(bucket); err != nil {
+			return 0, nil, err
+		}
+		ace, err := actionsToAccess(stmt.Action)
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(stmt.Principal) == 0 {
+			return 0, nil, errMalformedPolicy("statement %d: missing principal", i)
+		}
+		for _, principal := range stmt.Principal {
+			grant := grants[principal]
+			switch {
+			case stmt.Effect == effectDeny && principal == cmn.GrantAnyUser:
+				access &^= ace
+			case stmt.Effect == effectDeny:
+				grant.Deny |= ace
+			case stmt.Effect == effectAllow:
+				grant.Allow |= ace
+			default:
+				return 0, nil, errMalformedPolicy("statement %d: invalid effect %q", i, stmt.Effect)
+			}
+			if grant.Allow != 0 || grant.Deny != 0 {
+				grants[principal] = grant
+			}
+		}
+	}
+	return access, grants, nil
+}
+
+func (stmt *PolicyStatement) validateResource(bucket string) error {
+	if len(stmt.Resource) == 0 {
+		return errMalformedPolicy("missing resource")
+	}
+	for _, res := range stmt.Resource {
+		if res != arnBucket+bucket && res != arnBucket+bucket+"/*" {
+			return errMalformedPolicy("unsupported resource %q (expecting %q or %q)", res,
+				arnBucket+bucket, arnBucket+bucket+"/*")
+		}
+	}
+	return nil
+}
+
+func actionsToAccess(actions []string) (ace apc.AccessAttrs, _ error) {
+	if len(actions) == 0 {
+		return 0, errMalformedPolicy("missing action")
+	}
+outer:
+	for _, action := range actions {
+		if action == actionAll {
+			ace |= policyScope
+			continue
+		}
+		for _, a := range policyActions {
+			if strings.EqualFold(a.action, action) {
+				ace |= a.ace
+				continue outer
+			}
+		}
+		return 0, errMalformedPolicy("unsupported action %q", action)
+	}
+	return ace, nil
+}
+
+func accessToActions(ace apc.AccessAttrs) (actions strList) {
+	ace &= policyScope
+	if ace == policyScope {
+		return strList{actionAll}
+	}
+	var covered apc.AccessAttrs
+	for _, a := range policyActions {
+		if a.canonical && ace&a.ace == a.ace && covered&a.ace != a.ace {
+			actions = append(actions, a.action)
+			covered |= a.ace
+		}
+	}
+	return actions
+}
+
+// reverse translation (returns nil if there's nothing to show)
+func NewPolicy(bucket string, access apc.AccessAttrs, grants cmn.AccessGrants) *Policy {
+	var (
+		policy   = &Policy{Version: policyVersion}
+		resource = strList{arnBucket + bucket, arnBucket + bucket + "/*"}
+		add      = func(effect string, principal Principal, ace apc.AccessAttrs) {
+			if actions := accessToActions(ace); len(actions) > 0 {
+				policy.Statement = append(policy.Statement, PolicyStatement{
+					Effect: effect, Principal: principal, Action: actions, Resource: resource,
+				})
+			}
+		}
+		principals = make([]string, 0, len(grants))
+	)
+	add(effectDeny, Principal{cmn.GrantAnyUser}, apc.AccessAll&^access)
+	for principal := range grants {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+	for _, principal := range principals {
+		grant := grants[principal]
+		add(effectAllow, Principal{principal}, grant.Allow)
+		add(effectDeny, Principal{principal}, grant.Deny)
+	}
+	if len(policy.Statement) == 0 {
+		return nil
+	}
+	return policy
+}
+
+///////////////
+// Principal //
+///////////////
+
+func (p *Principal) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := jsoniter.Unmarshal(b, &s); err == nil {
+		if s != cmn.GrantAnyUser {
+			return errMalformedPolicy("invalid principal %q", s)
+		}
+		*p = Principal{cmn.GrantAnyUser}
+		return nil
+	}
+	var m map[string]strList
+	if err := jsoniter.Unmarshal(b, &m); err != nil {
+		return errMalformedPolicy("invalid principal %s", string(b))
+	}
+	for k, names := range m {
+		if k != "AWS" {
+			return errMalformedPolicy("unsupported principal type %q", k)
+		}
+		for _, name := range names {
+			if i := strings.Index(name, arnUser); i > 0 && strings.HasPrefix(name, "arn:") {
+				name = name[i+len(arnUser):]
+			}
+			if name == "" {
+				return errMalformedPolicy("invalid principal %s", string(b))
+			}
+			*p = append(*p, name)
+		}
+	}
+	return nil
+}
+
+func (p Principal) MarshalJSON() ([]byte, error) {
+	if len(p) == 1 && p[0] == cmn.GrantAnyUser {
+		return jsoniter.Marshal(cmn.GrantAnyUser)
+	}
+	return jsoniter.Marshal(map[string]strList{"AWS": strList(p)})
+}
+
+/////////////
+// strList //
+/////////////
+
+func (l *strList) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := jsoniter.Unmarshal(b, &s); err == nil {
+		*l = strList{s}
+		return nil
+	}
+	var ls []string
+	if err := jsoniter.Unmarshal(b, &ls); err != nil {
+		return errors.New("expecting string or list of strings")
+	}
+	*l = ls
+	return nil
+}
+
+func (l strList) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return jsoniter.Marshal(l[0])
+	}
+	return jsoniter.Marshal([]string(l))
+}
+
+/////////
+// ACL //
+/////////
+
+const (
+	aclPermRead        = "READ"
+	aclPermWrite       = "WRITE"
+	aclPermReadACP     = "READ_ACP"
+	aclPermWriteACP    = "WRITE_ACP"
+	aclPermFullControl = "FULL_CONTROL"
+
+	aclAllUsers = "http://acs.amazonaws.com/groups/global/AllUsers"
+	aclXsi      = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+var aclPerms = []struct {
+	perm string
+	ace  apc.AccessAttrs
+}{
+	{aclPermRead, apc.AceObjLIST},
+	{aclPermWrite, apc.AcePUT | apc.AceObjDELETE},
+	{aclPermReadACP, apc.AceBckHEAD},
+	{aclPermWriteACP, apc.AceBckSetACL},
+}
+
+// canned ACL (x-amz-acl) => bucket's access permissions
+// ("private" - no restrictions at the bucket level: access is controlled by AuthN)
+func CannedACL(acl string) (apc.AccessAttrs, error) {
+	switch acl {
+	case "private":
+		return apc.AccessAll, nil
+	case "public-read":
+		return apc.AccessRO, nil
+	case "public-read-write":
+		return apc.AccessRW, nil
+	default:
+		return 0, fmt.Errorf("%s[NotImplemented: canned ACL %q is not supported]", ErrPrefix, acl)
+	}
+}
+
+func NewACL(access apc.AccessAttrs, grants cmn.AccessGrants) *AccessControlPolicy {
+	acl := &AccessControlPolicy{Ns: s3Namespace, Owner: ACLOwner{ID: AISServer, DisplayName: AISServer}}
+	acl.add(ACLGrantee{Xsi: aclXsi, Type: "Group", URI: aclAllUsers}, access)
+
+	principals := make([]string, 0, len(grants))
+	for principal := range grants {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+	for _, principal := range principals {
+		grantee := ACLGrantee{Xsi: aclXsi, Type: "CanonicalUser", ID: principal}
+		if principal == cmn.GrantAnyUser {
+			grantee = ACLGrantee{Xsi: aclXsi, Type: "Group", URI: "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"}
+		}
+		acl.add(grantee, grants[principal].Allow&^grants[principal].Deny)
+	}
+	return acl
+}
+
+func (acl *AccessControlPolicy) add(grantee ACLGrantee, ace apc.AccessAttrs) {
+	var full = true
+	for _, p := range aclPerms {
+		if ace&p.ace != p.ace {
+			full = false
+		}
+	}
+	if full {
+		acl.Grants = append(acl.Grants, ACLGrant{Grantee: grantee, Permission: aclPermFullControl})
+		return
+	}
+	for _, p := range aclPerms {
+		if ace&p.ace == p.ace {
+			acl.Grants = append(acl.Grants, ACLGrant{Grantee: grantee, Permission: p.perm})
+		}
+	}
+}
+
+func (acl *AccessControlPolicy) MustMarshal(sgl *memsys.SGL) {
+	sgl.Write([]byte(xml.Header))
+	err := xml.NewEncoder(sgl).Encode(acl)
+	debug.AssertNoErr(err)
+}
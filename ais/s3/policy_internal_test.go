@@ -0,0 +1,145 @@
+// Package s3 provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const policyJSONStr = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "readonly",
+      "Effect": "Deny",
+      "Principal": "*",
+      "Action": ["s3:PutObject", "s3:DeleteObject"],
+      "Resource": "arn:aws:s3:::abc/*"
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["arn:aws:iam::123456789012:user/alice", "bob"]},
+      "Action": ["s3:GetObject", "s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::abc", "arn:aws:s3:::abc/*"]
+    },
+    {
+      "Effect": "Deny",
+      "Principal": {"AWS": "bob"},
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::abc/*"
+    }
+  ]
+}`
+
+func TestBucketPolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(policyJSONStr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	access, grants, err := policy.ToAccess("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := apc.AccessAll &^ (apc.AcePUT | apc.AceAPPEND | apc.AceObjDELETE); access != expected {
+		t.Fatalf("expected access %s, got %s", expected.Describe(true), access.Describe(true))
+	}
+	read := apc.AceGET | apc.AceObjHEAD | apc.AceObjLIST | apc.AceBckHEAD
+	expected := cmn.AccessGrants{
+		"alice": {Allow: read},
+		"bob":   {Allow: read, Deny: apc.AceGET | apc.AceObjHEAD},
+	}
+	if !reflect.DeepEqual(grants, expected) {
+		t.Fatalf("expected %v, got %v", expected, grants)
+	}
+	if _, denied := grants.Check("bob", apc.AceGET); !denied {
+		t.Fatal("expected bob to be denied GET")
+	}
+	if allowed, denied := grants.Check("alice", apc.AceObjLIST); !allowed || denied {
+		t.Fatal("expected alice to be allowed to list objects")
+	}
+
+	// round trip
+	b, err := jsoniter.Marshal(NewPolicy("abc", access, grants))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParsePolicy(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	access2, grants2, err := again.ToAccess("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access2 != access || !reflect.DeepEqual(grants2, grants) {
+		t.Fatalf("round trip: expected (%s, %v), got (%s, %v): %s", access.Describe(true), grants,
+			access2.Describe(true), grants2, string(b))
+	}
+
+	// no policy
+	if NewPolicy("abc", apc.AccessAll, nil) != nil {
+		t.Fatal("expected no policy")
+	}
+
+	// wrong bucket
+	if _, _, err := policy.ToAccess("xyz"); err == nil {
+		t.Fatal("expected error (resource)")
+	}
+}
+
+func TestBucketPolicyUnsupported(t *testing.T) {
+	tests := []string{
+		// condition
+		`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::abc/*",
+		  "Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}]}`,
+		// action
+		`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObjectTagging","Resource":"arn:aws:s3:::abc/*"}]}`,
+		// principal type
+		`{"Statement":[{"Effect":"Allow","Principal":{"Service":"s3.amazonaws.com"},"Action":"s3:*","Resource":"arn:aws:s3:::abc"}]}`,
+		// resource
+		`{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::abc/logs/*"}]}`,
+		// effect
+		`{"Statement":[{"Effect":"Maybe","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::abc/*"}]}`,
+		// no statements
+		`{"Version":"2012-10-17","Statement":[]}`,
+	}
+	for i, s := range tests {
+		policy, err := ParsePolicy([]byte(s))
+		if err == nil {
+			_, _, err = policy.ToAccess("abc")
+		}
+		if err == nil {
+			t.Fatalf("test %d: expected error", i)
+		}
+		if !strings.Contains(err.Error(), "MalformedPolicy") {
+			t.Fatalf("test %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestBucketACL(t *testing.T) {
+	access, err := CannedACL("public-read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	acl := NewACL(access, cmn.AccessGrants{"alice": {Allow: apc.AccessAll}})
+	perms := make([]string, 0, len(acl.Grants))
+	for _, g := range acl.Grants {
+		perms = append(perms, g.Grantee.Type+":"+g.Grantee.ID+":"+g.Permission)
+	}
+	expected := []string{"Group::READ", "Group::READ_ACP", "CanonicalUser:alice:FULL_CONTROL"}
+	if !reflect.DeepEqual(perms, expected) {
+		t.Fatalf("expected %v, got %v", expected, perms)
+	}
+	if _, err := CannedACL("log-delivery-write"); err == nil {
+		t.Fatal("expected error (unsupported canned ACL)")
+	}
+}
@@ -0,0 +1,764 @@
+#!/usr/bin/env python3
+#
+# Generates S3 inventory fixtures (Parquet and ORC) along with their manifests
+# and the expected CSV - see README.md in this directory.
+#
+# Standalone (Python standard library only), written from the format specifications:
+# - https://github.com/apache/parquet-format
+# - https://orc.apache.org/specification/ORCv1
+#
+# Usage (from this directory):
+#   python3 gen.py
+#
+
+import datetime
+import hashlib
+import json
+import os
+import urllib.parse
+import zlib
+
+BUCKET = "src-bucket"
+PREFIX = BUCKET + "/daily/data/"
+INV_EPOCH = 1420070400  # ORC: 2015-01-01 00:00:00 UTC
+
+# AWS S3 inventory of a versioned bucket: the two required fields followed by optional ones
+COLUMNS = [
+    "bucket",
+    "key",
+    "version_id",
+    "is_latest",
+    "is_delete_marker",
+    "size",
+    "last_modified_date",
+    "e_tag",
+    "storage_class",
+]
+
+PARQUET_SCHEMA = (
+    "message s3.inventory { required binary bucket (UTF8); required binary key (UTF8); "
+    "optional binary version_id (UTF8); optional boolean is_latest; optional boolean is_delete_marker; "
+    "optional int64 size; optional int64 last_modified_date (TIMESTAMP_MILLIS); "
+    "optional binary e_tag (UTF8); optional binary storage_class (UTF8);}"
+)
+ORC_SCHEMA = (
+    "struct<bucket:string,key:string,version_id:string,is_latest:boolean,is_delete_marker:boolean,"
+    "size:bigint,last_modified_date:timestamp,e_tag:string,storage_class:string>"
+)
+
+
+def ms(s):
+    t = datetime.datetime.strptime(s, "%Y-%m-%dT%H:%M:%S.%fZ").replace(tzinfo=datetime.timezone.utc)
+    return int(t.timestamp()) * 1000 + t.microsecond // 1000
+
+
+# key, version_id, is_latest, is_delete_marker, size, last_modified_date (millis), e_tag, storage_class
+ROWS = [
+    ("data/train/shard-000001.tar", "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY", True, False, 1073741824,
+     ms("2024-05-20T10:11:12.345Z"), "8f4e8d2b1d6e4c3a9b0a7c5d3e1f2a4b-128", "STANDARD"),
+    ("data/train/shard-000002.tar", "Xd3dIbrHY3HL4kqtJlcpXroDTDmJ+rmSp", True, False, 1073741312,
+     ms("2024-05-20T10:11:13.000Z"), "1a2b3c4d5e6f708192a3b4c5d6e7f809-128", "STANDARD"),
+    ("a b/c+d=e&f.txt", None, None, False, 10,
+     ms("2014-12-31T23:59:59.000Z"), "9e107d9d372bb6826bd81d3542a419d6", "INTELLIGENT_TIERING"),
+    ("x,y\"z'", "kqtJlcpXroDTDmJ+rmSpXd3dIbrHY3HL4", False, False, 0,
+     ms("2024-05-21T00:00:00.001Z"), "d41d8cd98f00b204e9800998ecf8427e", "GLACIER"),
+    ("x,y\"z'", "rmSpXd3dIbrHY3HL4kqtJlcpXroDTDmJ+", True, True, None,
+     ms("2024-05-22T08:00:00.000Z"), None, None),
+    ("日本語/ファイル名.bin", "pXroDTDmJ+rmSpXd3dIbrHY3HL4kqtJlc", True, False, 5497558138880,
+     ms("2023-01-02T03:04:05.678Z"), "e4d909c290d0fb1ca068ffaddf22cbd0-1024", "STANDARD_IA"),
+    ("logs/2024/05/20/app.log.gz", None, True, False, 123456,
+     ms("2024-05-20T23:59:59.999Z"), "0cc175b9c0f1b6a831c399e269772661", "STANDARD"),
+    ("logs/2024/05/21/app.log.gz", None, True, False, 654321,
+     ms("2024-05-22T00:00:00.000Z"), "92eb5ffee6ae2fec3ad71c777531578f", "STANDARD"),
+    ("empty/", None, True, False, 0,
+     ms("2024-06-01T00:00:00.000Z"), "d41d8cd98f00b204e9800998ecf8427e", "STANDARD"),
+    ("deleted", "HY3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbr", True, True, None,
+     ms("2024-06-02T12:00:00.000Z"), None, None),
+]
+
+GROUPS = [(0, 5), (5, 10)]  # row groups (Parquet), stripes (ORC)
+
+
+def column(name, lo=0, hi=len(ROWS)):
+    if name == "bucket":
+        return [BUCKET] * (hi - lo)
+    i = COLUMNS.index(name) - 1
+    return [row[i] for row in ROWS[lo:hi]]
+
+
+#
+# common
+#
+
+
+def uvarint(v):
+    out = bytearray()
+    while v >= 0x80:
+        out.append(v & 0x7F | 0x80)
+        v >>= 7
+    out.append(v)
+    return bytes(out)
+
+
+def zigzag(v):
+    return (v << 1) ^ (v >> 63) if v < 0 else v << 1
+
+
+def nbits(v):
+    return max(v.bit_length(), 1)
+
+
+#
+# Parquet
+#
+
+# thrift compact protocol types
+T_TRUE, T_FALSE, T_I16, T_I32, T_I64, T_BIN, T_LIST, T_STRUCT = 1, 2, 4, 5, 6, 8, 9, 12
+
+
+def tvalue(typ, v):
+    if typ in (T_I16, T_I32, T_I64):
+        return uvarint(zigzag(v))
+    if typ == T_BIN:
+        if isinstance(v, str):
+            v = v.encode()
+        return uvarint(len(v)) + v
+    if typ == T_STRUCT:
+        return tstruct(v)
+    if typ == T_LIST:
+        etyp, items = v
+        hdr = bytes([len(items) << 4 | etyp]) if len(items) < 15 else bytes([0xF0 | etyp]) + uvarint(len(items))
+        return hdr + b"".join(tvalue(etyp, item) for item in items)
+    raise ValueError(typ)
+
+
+# fields: [(id, type, value)], with None values omitted
+def tstruct(fields):
+    out, last = bytearray(), 0
+    for fid, typ, v in fields:
+        if v is None:
+            continue
+        if typ == T_TRUE:
+            typ = T_TRUE if v else T_FALSE
+        delta = fid - last
+        if 0 < delta <= 15:
+            out.append(delta << 4 | typ)
+        else:
+            out.append(typ)
+            out += uvarint(zigzag(fid))
+        last = fid
+        if typ not in (T_TRUE, T_FALSE):
+            out += tvalue(typ, v)
+    out.append(0)
+    return bytes(out)
+
+
+# snappy (block format), greedy 4-byte matching
+def snappy(data):
+    out = bytearray(uvarint(len(data)))
+
+    def literal(s, e):
+        while s < e:
+            n = min(e - s, 65536)
+            if n <= 60:
+                out.append((n - 1) << 2)
+            elif n <= 256:
+                out.extend((60 << 2, n - 1))
+            else:
+                out.extend((61 << 2, (n - 1) & 0xFF, (n - 1) >> 8))
+            out.extend(data[s:s + n])
+            s += n
+
+    def copy(off, n):
+        if 4 <= n <= 11 and off < 2048:
+            out.extend(((off >> 8) << 5 | (n - 4) << 2 | 1, off & 0xFF))
+        else:
+            out.extend(((n - 1) << 2 | 2, off & 0xFF, off >> 8))
+
+    table, i, lit = {}, 0, 0
+    while i + 4 <= len(data):
+        k = bytes(data[i:i + 4])
+        j = table.get(k)
+        table[k] = i
+        if j is None or i - j > 65535:
+            i += 1
+            continue
+        n = 4
+        while i + n < len(data) and n < 64 and data[j + n] == data[i + n]:
+            n += 1
+        literal(lit, i)
+        copy(i - j, n)
+        i += n
+        lit = i
+    literal(lit, len(data))
+    return bytes(out)
+
+
+# RLE/bit-packing hybrid: RLE runs for 8+ repeated values, bit-packed groups of 8 otherwise
+def rle_hybrid(vals, width):
+    out, i, packed = bytearray(), 0, []
+
+    def flush():
+        if not packed:
+            return
+        groups = (len(packed) + 7) // 8
+        acc, nacc, body = 0, 0, bytearray()
+        for v in packed + [0] * (groups * 8 - len(packed)):
+            acc |= v << nacc
+            nacc += width
+            while nacc >= 8:
+                body.append(acc & 0xFF)
+                acc >>= 8
+                nacc -= 8
+        out.extend(uvarint(groups << 1 | 1))
+        out.extend(body)
+        packed.clear()
+
+    while i < len(vals):
+        run = 1
+        while i + run < len(vals) and vals[i + run] == vals[i]:
+            run += 1
+        if run >= 8 and len(packed) % 8 == 0:
+            flush()
+            out.extend(uvarint(run << 1))
+            out.extend(vals[i].to_bytes((width + 7) // 8, "little"))
+            i += run
+        else:
+            packed.append(vals[i])
+            i += 1
+    flush()
+    return bytes(out)
+
+
+PQ_BOOLEAN, PQ_INT64, PQ_BYTE_ARRAY = 0, 2, 6
+PQ_PLAIN, PQ_PLAIN_DICTIONARY, PQ_RLE, PQ_BIT_PACKED = 0, 2, 3, 4
+PQ_DATA_PAGE, PQ_DICTIONARY_PAGE = 0, 2
+PQ_SNAPPY = 1
+PQ_UTF8, PQ_TIMESTAMP_MILLIS = 0, 9
+
+# physical type, required, converted type
+PQ_TYPES = {
+    "bucket": (PQ_BYTE_ARRAY, True, PQ_UTF8),
+    "key": (PQ_BYTE_ARRAY, True, PQ_UTF8),
+    "version_id": (PQ_BYTE_ARRAY, False, PQ_UTF8),
+    "is_latest": (PQ_BOOLEAN, False, None),
+    "is_delete_marker": (PQ_BOOLEAN, False, None),
+    "size": (PQ_INT64, False, None),
+    "last_modified_date": (PQ_INT64, False, PQ_TIMESTAMP_MILLIS),
+    "e_tag": (PQ_BYTE_ARRAY, False, PQ_UTF8),
+    "storage_class": (PQ_BYTE_ARRAY, False, PQ_UTF8),
+}
+
+
+def pq_plain(typ, vals):
+    if typ == PQ_BOOLEAN:
+        out = bytearray((len(vals) + 7) // 8)
+        for i, v in enumerate(vals):
+            if v:
+                out[i // 8] |= 1 << (i % 8)
+        return bytes(out)
+    if typ == PQ_INT64:
+        return b"".join(v.to_bytes(8, "little", signed=True) for v in vals)
+    return b"".join(len(v.encode()).to_bytes(4, "little") + v.encode() for v in vals)
+
+
+def pq_stat(typ, v):
+    if typ == PQ_INT64:
+        return v.to_bytes(8, "little", signed=True)
+    if typ == PQ_BOOLEAN:
+        return bytes([v])
+    return v.encode()
+
+
+# column chunk: pages is a list of (row count, encoding), in the order of appearance;
+# a dictionary page is prepended when any of the pages is dictionary-encoded
+def pq_chunk(name, vals, pages, offset):
+    typ, required, _ = PQ_TYPES[name]
+    dict_vals = []
+    for (n, enc), start in zip(pages, page_starts(pages)):
+        if enc == PQ_PLAIN_DICTIONARY:
+            for v in vals[start:start + n]:
+                if v is not None and v not in dict_vals:
+                    dict_vals.append(v)
+    out, usize, encodings, enc_stats = bytearray(), 0, {PQ_RLE, PQ_BIT_PACKED}, []
+    dict_off = None
+
+    def page(ptype, data, hdr):
+        nonlocal usize
+        compressed = snappy(data)
+        ph = tstruct([(1, T_I32, ptype), (2, T_I32, len(data)), (3, T_I32, len(compressed))] + hdr)
+        usize += len(ph) + len(data)
+        out.extend(ph + compressed)
+
+    if dict_vals:
+        dict_off = offset
+        page(PQ_DICTIONARY_PAGE, pq_plain(typ, dict_vals),
+             [(7, T_STRUCT, [(1, T_I32, len(dict_vals)), (2, T_I32, PQ_PLAIN_DICTIONARY), (3, T_TRUE, False)])])
+        encodings.add(PQ_PLAIN_DICTIONARY)
+        enc_stats.append((PQ_DICTIONARY_PAGE, PQ_PLAIN_DICTIONARY))
+    data_off = offset + len(out)
+    for (n, enc), start in zip(pages, page_starts(pages)):
+        pvals = vals[start:start + n]
+        nonnull = [v for v in pvals if v is not None]
+        data = b""
+        if not required:
+            levels = rle_hybrid([0 if v is None else 1 for v in pvals], 1)
+            data = len(levels).to_bytes(4, "little") + levels
+        if enc == PQ_PLAIN_DICTIONARY:
+            width = nbits(len(dict_vals) - 1)
+            data += bytes([width]) + rle_hybrid([dict_vals.index(v) for v in nonnull], width)
+        else:
+            data += pq_plain(typ, nonnull)
+        encodings.add(enc)
+        enc_stats.append((PQ_DATA_PAGE, enc))
+        page(PQ_DATA_PAGE, data, [(5, T_STRUCT, [
+            (1, T_I32, n), (2, T_I32, enc), (3, T_I32, PQ_RLE), (4, T_I32, PQ_BIT_PACKED),
+            (5, T_STRUCT, [(3, T_I64, n - len(nonnull))]),
+        ])])
+
+    nonnull = [v for v in vals if v is not None]
+    stats = [(3, T_I64, len(vals) - len(nonnull))]
+    if nonnull:
+        stats += [(5, T_BIN, pq_stat(typ, max(nonnull))), (6, T_BIN, pq_stat(typ, min(nonnull)))]
+    md = [
+        (1, T_I32, typ),
+        (2, T_LIST, (T_I32, sorted(encodings))),
+        (3, T_LIST, (T_BIN, [name])),
+        (4, T_I32, PQ_SNAPPY),
+        (5, T_I64, len(vals)),
+        (6, T_I64, usize),
+        (7, T_I64, len(out)),
+        (9, T_I64, data_off),
+        (11, T_I64, dict_off),
+        (12, T_STRUCT, stats),
+        (13, T_LIST, (T_STRUCT, [[(1, T_I32, pt), (2, T_I32, e), (3, T_I32, 1)] for pt, e in enc_stats])),
+    ]
+    return bytes(out), [(2, T_I64, offset), (3, T_STRUCT, md)], usize
+
+
+def page_starts(pages):
+    starts, n = [], 0
+    for cnt, _ in pages:
+        starts.append(n)
+        n += cnt
+    return starts
+
+
+# per row group: column => data pages; e.g., the "key" column in the first row group
+# "falls back" from dictionary to plain encoding mid-chunk
+PQ_LAYOUT = [
+    {
+        "bucket": [(5, PQ_PLAIN_DICTIONARY)],
+        "key": [(3, PQ_PLAIN_DICTIONARY), (2, PQ_PLAIN)],
+        "version_id": [(5, PQ_PLAIN_DICTIONARY)],
+        "is_latest": [(5, PQ_PLAIN)],
+        "is_delete_marker": [(5, PQ_PLAIN)],
+        "size": [(5, PQ_PLAIN_DICTIONARY)],
+        "last_modified_date": [(5, PQ_PLAIN)],
+        "e_tag": [(5, PQ_PLAIN)],
+        "storage_class": [(5, PQ_PLAIN_DICTIONARY)],
+    },
+    {
+        "bucket": [(5, PQ_PLAIN_DICTIONARY)],
+        "key": [(5, PQ_PLAIN)],
+        "version_id": [(2, PQ_PLAIN), (3, PQ_PLAIN)],
+        "is_latest": [(5, PQ_PLAIN)],
+        "is_delete_marker": [(5, PQ_PLAIN)],
+        "size": [(5, PQ_PLAIN)],
+        "last_modified_date": [(5, PQ_PLAIN_DICTIONARY)],
+        "e_tag": [(5, PQ_PLAIN)],
+        "storage_class": [(5, PQ_PLAIN_DICTIONARY)],
+    },
+]
+
+
+def gen_parquet():
+    file = bytearray(b"PAR1")
+    row_groups = []
+    for ordinal, ((lo, hi), layout) in enumerate(zip(GROUPS, PQ_LAYOUT)):
+        start, chunks, usize = len(file), [], 0
+        for name in COLUMNS:
+            b, chunk, u = pq_chunk(name, column(name, lo, hi), layout[name], len(file))
+            file += b
+            chunks.append(chunk)
+            usize += u
+        row_groups.append([
+            (1, T_LIST, (T_STRUCT, chunks)),
+            (2, T_I64, usize),
+            (3, T_I64, hi - lo),
+            (5, T_I64, start),
+            (6, T_I64, len(file) - start),
+            (7, T_I16, ordinal),
+        ])
+    schema = [[(4, T_BIN, "s3.inventory"), (5, T_I32, len(COLUMNS))]]
+    for name in COLUMNS:
+        typ, required, conv = PQ_TYPES[name]
+        schema.append([(1, T_I32, typ), (3, T_I32, 0 if required else 1), (4, T_BIN, name), (6, T_I32, conv)])
+    footer = tstruct([
+        (1, T_I32, 1),
+        (2, T_LIST, (T_STRUCT, schema)),
+        (3, T_I64, len(ROWS)),
+        (4, T_LIST, (T_STRUCT, row_groups)),
+        (5, T_LIST, (T_STRUCT, [[(1, T_BIN, "generator"), (2, T_BIN, "ais/s3/testdata/inventory/gen.py")]])),
+        (6, T_BIN, "gen.py (see ais/s3/testdata/inventory/README.md)"),
+        (7, T_LIST, (T_STRUCT, [[(1, T_STRUCT, [])] for _ in COLUMNS])),
+    ])
+    file += footer + len(footer).to_bytes(4, "little") + b"PAR1"
+    return bytes(file)
+
+
+#
+# ORC
+#
+
+ORC_BOOLEAN, ORC_LONG, ORC_STRING, ORC_TIMESTAMP, ORC_STRUCT = 0, 4, 7, 9, 12
+ORC_PRESENT, ORC_DATA, ORC_LENGTH, ORC_DICTIONARY_DATA, ORC_SECONDARY, ORC_ROW_INDEX = 0, 1, 2, 3, 5, 6
+ORC_DIRECT, ORC_DIRECT_V2, ORC_DICTIONARY_V2 = 0, 2, 3
+ORC_ZLIB, ORC_BLOCK = 1, 256 * 1024
+
+ORC_TYPES = {
+    "bucket": ORC_STRING,
+    "key": ORC_STRING,
+    "version_id": ORC_STRING,
+    "is_latest": ORC_BOOLEAN,
+    "is_delete_marker": ORC_BOOLEAN,
+    "size": ORC_LONG,
+    "last_modified_date": ORC_TIMESTAMP,
+    "e_tag": ORC_STRING,
+    "storage_class": ORC_STRING,
+}
+
+
+def pb_varint(num, v):
+    return uvarint(num << 3) + uvarint(v)
+
+
+def pb_bytes(num, b):
+    return uvarint(num << 3 | 2) + uvarint(len(b)) + b
+
+
+def pb_packed(num, vals):
+    return pb_bytes(num, b"".join(uvarint(v) for v in vals))
+
+
+# ORC compression: ZLIB (raw deflate) chunks; a chunk is stored "original" when deflate does not help
+def orc_compress(data):
+    out = bytearray()
+    for i in range(0, len(data), ORC_BLOCK):
+        chunk = data[i:i + ORC_BLOCK]
+        z = zlib.compressobj(9, zlib.DEFLATED, -15)
+        c = z.compress(chunk) + z.flush()
+        h = len(c) << 1
+        if len(c) >= len(chunk):
+            c, h = chunk, len(chunk) << 1 | 1
+        out += bytes([h & 0xFF, h >> 8 & 0xFF, h >> 16]) + c
+    return bytes(out)
+
+
+def byte_rle(bs):
+    out, i = bytearray(), 0
+    while i < len(bs):
+        run = 1
+        while i + run < len(bs) and run < 130 and bs[i + run] == bs[i]:
+            run += 1
+        if run >= 3:
+            out += bytes([run - 3, bs[i]])
+            i += run
+            continue
+        j = i
+        while j < len(bs) and j - i < 128 and not (j + 2 < len(bs) and bs[j] == bs[j + 1] == bs[j + 2]):
+            j += 1
+        out += bytes([256 - (j - i)]) + bytes(bs[i:j])
+        i = j
+    return bytes(out)
+
+
+def bools(vals):
+    out = bytearray((len(vals) + 7) // 8)
+    for i, v in enumerate(vals):
+        if v:
+            out[i // 8] |= 0x80 >> (i % 8)
+    return byte_rle(out)
+
+
+FIXED_BITS = list(range(1, 25)) + [26, 28, 30, 32, 40, 48, 56, 64]
+
+
+def closest_fixed(n):
+    return next(b for b in FIXED_BITS if b >= max(n, 1))
+
+
+def enc_width(n):
+    return FIXED_BITS.index(closest_fixed(n))
+
+
+def pack_be(vals, width):
+    acc, nacc, out = 0, 0, bytearray()
+    for v in vals:
+        acc = acc << width | v
+        nacc += width
+        while nacc >= 8:
+            nacc -= 8
+            out.append(acc >> nacc & 0xFF)
+        acc &= (1 << nacc) - 1
+    if nacc:
+        out.append(acc << (8 - nacc) & 0xFF)
+    return bytes(out)
+
+
+# RLE v2; runs is a list of (sub-encoding, values)
+def rle2(runs, signed):
+    out = bytearray()
+    for enc, vals in runs:
+        n = len(vals)
+        if enc == "repeat":
+            assert 3 <= n <= 10 and len(set(vals)) == 1
+            u = zigzag(vals[0]) if signed else vals[0]
+            w = max((u.bit_length() + 7) // 8, 1)
+            out += bytes([(w - 1) << 3 | (n - 3)]) + u.to_bytes(w, "big")
+        elif enc == "direct":
+            us = [zigzag(v) if signed else v for v in vals]
+            w = closest_fixed(max(nbits(u) for u in us))
+            out += bytes([1 << 6 | enc_width(w) << 1 | (n - 1) >> 8, (n - 1) & 0xFF]) + pack_be(us, w)
+        elif enc == "delta":
+            deltas = [b - a for a, b in zip(vals, vals[1:])]
+            assert all(d >= 0 for d in deltas) or all(d <= 0 for d in deltas)
+            first = uvarint(zigzag(vals[0]) if signed else vals[0]) + uvarint(zigzag(deltas[0]))
+            if len(set(deltas)) == 1:  # fixed delta
+                out += bytes([3 << 6 | (n - 1) >> 8, (n - 1) & 0xFF]) + first
+            else:
+                rest = [abs(d) for d in deltas[1:]]
+                w = closest_fixed(max(nbits(d) for d in rest))
+                w = 2 if w == 1 else w  # (zero width is reserved for fixed delta)
+                out += bytes([3 << 6 | enc_width(w) << 1 | (n - 1) >> 8, (n - 1) & 0xFF]) + first
+                out += pack_be(rest, w)
+        elif enc == "patched":
+            out += patched_base(vals)
+        else:
+            raise ValueError(enc)
+    return bytes(out)
+
+
+# PATCHED_BASE: values (minus base) are packed with the width that fits (at least) half of them,
+# the high bits of the remaining outliers go into the patch list
+def patched_base(vals):
+    n, base = len(vals), min(vals)
+    adj = [v - base for v in vals]
+    w = closest_fixed(nbits(sorted(adj)[(n - 1) // 2]))
+    patches, prev = [], 0
+    for i, v in enumerate(adj):
+        if v >> w:
+            patches.append((i - prev, v >> w))
+            prev = i
+    pw = closest_fixed(max(nbits(p) for _, p in patches))
+    pgw = max(nbits(g) for g, _ in patches)
+    assert pgw <= 8 and len(patches) < 32
+    bw = max((abs(base).bit_length() + 1 + 7) // 8, 1)
+    ubase = abs(base) | (1 << (8 * bw - 1) if base < 0 else 0)
+    out = bytearray([
+        2 << 6 | enc_width(w) << 1 | (n - 1) >> 8, (n - 1) & 0xFF,
+        (bw - 1) << 5 | enc_width(pw),
+        (pgw - 1) << 5 | len(patches),
+    ])
+    out += ubase.to_bytes(bw, "big")
+    out += pack_be([v & ((1 << w) - 1) for v in adj], w)
+    out += pack_be([g << pw | p for g, p in patches], closest_fixed(pw + pgw))
+    return bytes(out)
+
+
+def orc_nanos(ns):
+    if ns == 0 or ns % 100:
+        return ns << 3
+    ns, z = ns // 100, 2
+    while ns % 10 == 0 and z < 8:
+        ns //= 10
+        z += 1
+    return ns << 3 | (z - 1)
+
+
+# per stripe: column => encoding and RLE v2 sub-encodings (a list of run lengths, by stream)
+ORC_LAYOUT = [
+    {
+        "bucket": (ORC_DICTIONARY_V2, {ORC_DATA: [("repeat", 5)], ORC_LENGTH: [("direct", 1)]}),
+        "key": (ORC_DIRECT_V2, {ORC_LENGTH: [("direct", 2), ("direct", 3)]}),
+        "version_id": (ORC_DIRECT_V2, {ORC_LENGTH: [("repeat", 4)]}),
+        "size": (ORC_DIRECT_V2, {ORC_DATA: [("patched", 4)]}),
+        "last_modified_date": (ORC_DIRECT_V2, {ORC_DATA: [("direct", 5)], ORC_SECONDARY: [("direct", 5)]}),
+        "e_tag": (ORC_DIRECT_V2, {ORC_LENGTH: [("direct", 4)]}),
+        "storage_class": (ORC_DICTIONARY_V2, {ORC_DATA: [("direct", 4)], ORC_LENGTH: [("direct", 3)]}),
+    },
+    {
+        "bucket": (ORC_DICTIONARY_V2, {ORC_DATA: [("repeat", 5)], ORC_LENGTH: [("direct", 1)]}),
+        "key": (ORC_DIRECT_V2, {ORC_LENGTH: [("direct", 5)]}),
+        "version_id": (ORC_DICTIONARY_V2, {ORC_DATA: [("delta", 2)], ORC_LENGTH: [("direct", 2)]}),
+        "size": (ORC_DIRECT_V2, {ORC_DATA: [("direct", 4)]}),
+        "last_modified_date": (ORC_DIRECT_V2, {ORC_DATA: [("delta", 5)],
+                                               ORC_SECONDARY: [("direct", 2), ("repeat", 3)]}),
+        "e_tag": (ORC_DIRECT_V2, {ORC_LENGTH: [("direct", 4)]}),
+        "storage_class": (ORC_DICTIONARY_V2, {ORC_DATA: [("direct", 1), ("repeat", 3)], ORC_LENGTH: [("direct", 2)]}),
+    },
+]
+
+
+def split_runs(spec, vals):
+    runs, i = [], 0
+    for enc, n in spec:
+        runs.append((enc, vals[i:i + n]))
+        i += n
+    assert i == len(vals), (spec, vals)
+    return runs
+
+
+def orc_column(name, vals, layout):
+    """returns encoding, dictionary size, and streams: [(kind, bytes)]"""
+    kind = ORC_TYPES[name]
+    enc, rle = layout.get(name, (ORC_DIRECT, {}))
+    streams = []
+    nonnull = [v for v in vals if v is not None]
+    if len(nonnull) < len(vals):
+        streams.append((ORC_PRESENT, bools([v is not None for v in vals])))
+    dict_size = 0
+    if kind == ORC_BOOLEAN:
+        streams.append((ORC_DATA, bools(nonnull)))
+    elif kind == ORC_LONG:
+        streams.append((ORC_DATA, rle2(split_runs(rle[ORC_DATA], nonnull), True)))
+    elif kind == ORC_TIMESTAMP:
+        secs = [v // 1000 - INV_EPOCH for v in nonnull]
+        nanos = [orc_nanos(v % 1000 * 1000000) for v in nonnull]
+        streams.append((ORC_DATA, rle2(split_runs(rle[ORC_DATA], secs), True)))
+        streams.append((ORC_SECONDARY, rle2(split_runs(rle[ORC_SECONDARY], nanos), False)))
+    elif enc == ORC_DICTIONARY_V2:
+        dictionary = sorted(set(nonnull), key=lambda s: s.encode())
+        dict_size = len(dictionary)
+        idx = [dictionary.index(v) for v in nonnull]
+        lens = [len(s.encode()) for s in dictionary]
+        streams.append((ORC_DATA, rle2(split_runs(rle[ORC_DATA], idx), False)))
+        streams.append((ORC_DICTIONARY_DATA, "".join(dictionary).encode()))
+        streams.append((ORC_LENGTH, rle2(split_runs(rle[ORC_LENGTH], lens), False)))
+    else:
+        lens = [len(s.encode()) for s in nonnull]
+        streams.append((ORC_DATA, "".join(nonnull).encode()))
+        streams.append((ORC_LENGTH, rle2(split_runs(rle[ORC_LENGTH], lens), False)))
+    return enc, dict_size, streams
+
+
+def orc_stats(kind, vals):
+    nonnull = [v for v in vals if v is not None]
+    b = pb_varint(1, len(nonnull))
+    if kind == ORC_LONG and nonnull:
+        b += pb_bytes(2, pb_varint(1, zigzag(min(nonnull))) + pb_varint(2, zigzag(max(nonnull))))
+    elif kind == ORC_STRING and nonnull:
+        b += pb_bytes(4, pb_bytes(1, min(nonnull).encode()) + pb_bytes(2, max(nonnull).encode()))
+    elif kind == ORC_TIMESTAMP and nonnull:
+        b += pb_bytes(9, pb_varint(3, zigzag(min(nonnull))) + pb_varint(4, zigzag(max(nonnull))))
+    return b + pb_varint(10, int(len(nonnull) < len(vals)))
+
+
+def gen_orc():
+    file = bytearray(b"ORC")
+    stripes, stripe_stats = [], []
+    for (lo, hi), layout in zip(GROUPS, ORC_LAYOUT):
+        index, data, sfooter = bytearray(), bytearray(), bytearray()
+        encs = [(ORC_DIRECT, 0)]  # (root struct)
+        cols = [(ORC_STRUCT, [True] * (hi - lo))] + [(ORC_TYPES[n], column(n, lo, hi)) for n in COLUMNS]
+
+        # row index (one entry per column; positions are not used by the reader)
+        for col, (kind, vals) in enumerate(cols):
+            entry = pb_packed(1, [0, 0, 0]) + pb_bytes(2, orc_stats(kind, vals))
+            b = orc_compress(pb_bytes(1, entry))
+            index += b
+            sfooter += pb_bytes(1, pb_varint(1, ORC_ROW_INDEX) + pb_varint(2, col) + pb_varint(3, len(b)))
+        for col, name in enumerate(COLUMNS, start=1):
+            enc, dict_size, streams = orc_column(name, column(name, lo, hi), layout)
+            encs.append((enc, dict_size))
+            for kind, s in streams:
+                b = orc_compress(s)
+                data += b
+                sfooter += pb_bytes(1, pb_varint(1, kind) + pb_varint(2, col) + pb_varint(3, len(b)))
+        for enc, dict_size in encs:
+            sfooter += pb_bytes(2, pb_varint(1, enc) + (pb_varint(2, dict_size) if dict_size else b""))
+        sfooter += pb_bytes(3, b"UTC")
+        sfooter = orc_compress(bytes(sfooter))
+
+        st = pb_varint(1, len(file)) + pb_varint(2, len(index)) + pb_varint(3, len(data))
+        st += pb_varint(4, len(sfooter)) + pb_varint(5, hi - lo)
+        stripes.append(st)
+        stripe_stats.append(b"".join(pb_bytes(1, orc_stats(kind, vals)) for kind, vals in cols))
+        file += index + data + sfooter
+
+    # metadata (stripe statistics)
+    metadata = orc_compress(b"".join(pb_bytes(1, s) for s in stripe_stats))
+    file += metadata
+
+    # footer
+    footer = pb_varint(1, 3) + pb_varint(2, len(file) - 3)
+    for st in stripes:
+        footer += pb_bytes(3, st)
+    footer += pb_bytes(4, pb_varint(1, ORC_STRUCT) + pb_packed(2, range(1, len(COLUMNS) + 1)) +
+                       b"".join(pb_bytes(3, n.encode()) for n in COLUMNS))
+    for name in COLUMNS:
+        footer += pb_bytes(4, pb_varint(1, ORC_TYPES[name]))
+    footer += pb_varint(6, len(ROWS))
+    footer += pb_bytes(7, orc_stats(ORC_STRUCT, [True] * len(ROWS)))
+    for name in COLUMNS:
+        footer += pb_bytes(7, orc_stats(ORC_TYPES[name], column(name)))
+    footer += pb_varint(8, 10000)
+    footer = orc_compress(bytes(footer))
+    file += footer
+
+    # postscript (never compressed)
+    ps = pb_varint(1, len(footer)) + pb_varint(2, ORC_ZLIB) + pb_varint(3, ORC_BLOCK)
+    ps += pb_packed(4, [0, 12]) + pb_varint(5, len(metadata)) + pb_varint(6, 9) + pb_bytes(8000, b"ORC")
+    file += ps + bytes([len(ps)])
+    return bytes(file)
+
+
+#
+# manifests and the expected CSV
+#
+
+
+def manifest(fmt, schema, fname, b):
+    return {
+        "sourceBucket": BUCKET,
+        "destinationBucket": "arn:aws:s3:::inventory-bucket",
+        "version": "2016-11-30",
+        "creationTimestamp": "1717286400000",
+        "fileFormat": fmt,
+        "fileSchema": schema,
+        "files": [{"key": PREFIX + fname, "size": len(b), "MD5checksum": hashlib.md5(b).hexdigest()}],
+    }
+
+
+def csv_field(name, v):
+    if v is None:
+        return '""'
+    if name == "key":
+        v = urllib.parse.quote_plus(v, safe="~")
+    elif isinstance(v, bool):
+        v = "true" if v else "false"
+    elif name == "last_modified_date":
+        t = datetime.datetime.fromtimestamp(v // 1000, tz=datetime.timezone.utc)
+        v = t.strftime("%Y-%m-%dT%H:%M:%S.") + "%03dZ" % (v % 1000)
+    return '"%s"' % v
+
+
+def main():
+    for fmt, schema, fname, b in (
+        ("Parquet", PARQUET_SCHEMA, "inventory.parquet", gen_parquet()),
+        ("ORC", ORC_SCHEMA, "inventory.orc", gen_orc()),
+    ):
+        d = fmt.lower()
+        os.makedirs(d, exist_ok=True)
+        with open(os.path.join(d, fname), "wb") as f:
+            f.write(b)
+        with open(os.path.join(d, "manifest.json"), "w") as f:
+            json.dump(manifest(fmt, schema, fname, b), f, indent=2)
+            f.write("\n")
+    with open("expected.csv", "w") as f:
+        for i in range(len(ROWS)):
+            f.write(",".join(csv_field(name, column(name)[i]) for name in COLUMNS) + "\n")
+
+
+if __name__ == "__main__":
+    main()
@@ -39,11 +39,13 @@ import (
 	"github.com/NVIDIA/aistore/ext/etl"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/fs/health"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/res"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/volume"
 	"github.com/NVIDIA/aistore/xact/xreg"
@@ -71,6 +73,7 @@ type (
 		res          *res.Res
 		transactions transactions
 		regstate     regstate
+		quota        tquota
 	}
 )
 
@@ -322,9 +325,10 @@ func (t *target) Run() error {
 		nlog.Errorln("")
 	}
 
-	// register object type and workfile type
+	// register object, workfile, and chunk types
 	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{})
 
 	// Init meta-owners and load local instances
 	if prev := t.owner.bmd.init(); prev {
@@ -379,6 +383,11 @@ func (t *target) Run() error {
 
 	xreg.RegWithHK()
 
+	s3.LoadUploads()
+	xs.AbortStaleMpt = s3.AbortStale
+	hk.Reg(apc.ActLifecycle+hk.NameSuffix, t.lcyHK, lcyInitDelay)
+	t.quota.init()
+
 	marked := xreg.GetResilverMarked()
 	if marked.Interrupted || daemon.resilver.required {
 		go t.goresilver(marked.Interrupted)
@@ -386,6 +395,7 @@ func (t *target) Run() error {
 
 	dsort.Tinit(t.statsT, db, config)
 	dload.Init(t.statsT, db, &config.Client)
+	go t.resumeDownloads()
 
 	err = t.htrun.run(config)
 
@@ -468,7 +478,7 @@ func (t *target) endStartupStandby() (err error) {
 func (t *target) initRecvHandlers() {
 	networkHandlers := []networkHandler{
 		{r: apc.Buckets, h: t.bucketHandler, net: accessNetAll},
-		{r: apc.Objects, h: t.objectHandler, net: accessNetAll},
+		{r: apc.Objects, h: t.audited(t.objectHandler), net: accessNetAll},
 		{r: apc.Daemon, h: t.daemonHandler, net: accessNetPublicControl},
 		{r: apc.Metasync, h: t.metasyncHandler, net: accessNetIntraControl},
 		{r: apc.Health, h: t.healthHandler, net: accessNetPublicControl},
@@ -482,7 +492,7 @@ func (t *target) initRecvHandlers() {
 		{r: apc.Sort, h: dsort.TargetHandler, net: accessControlData},
 		{r: apc.ETL, h: t.etlHandler, net: accessNetAll},
 
-		{r: "/" + apc.S3, h: t.s3Handler, net: accessNetPublicData},
+		{r: "/" + apc.S3, h: t.audited(t.s3Handler), net: accessNetPublicData},
 		{r: "/", h: t.errURL, net: accessNetAll},
 	}
 	t.regNetHandlers(networkHandlers)
@@ -669,12 +679,14 @@ func (t *target) httpobjget(w http.ResponseWriter, r *http.Request, apireq *apiR
 		}
 	}
 
+	r, span := t.traceReq(r, apireq.dpq.traceparent, "target.get", apireq.bck.Cname(apireq.items[1]))
 	lom := core.AllocLOM(apireq.items[1])
 	lom, err = t.getObject(w, r, apireq.dpq, apireq.bck, lom)
 	if err != nil {
 		t._erris(w, r, apireq.dpq.silent, err, 0)
 	}
 	core.FreeLOM(lom)
+	span.End(err)
 }
 
 func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck *meta.Bck, lom *core.LOM) (*core.LOM, error) {
@@ -729,7 +741,7 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 		goi.dpq = dpq
 		goi.req = r
 		goi.w = w
-		goi.ctx = context.Background()
+		goi.ctx = tracing.Detach(r.Context()) // (cold GET is not to be canceled with the request)
 		goi.ranges = byteRanges{Range: r.Header.Get(cos.HdrRange), Size: 0}
 		goi.latestVer = _validateWarmGet(goi.lom, dpq.latestVer) // apc.QparamLatestVer || versioning.*_warm_get
 	}
@@ -756,6 +768,8 @@ func (t *target) getObject(w http.ResponseWriter, r *http.Request, dpq *dpq, bck
 	// do
 	if ecode, err := goi.getObject(); err != nil {
 		t.statsT.IncErr(stats.GetCount)
+		t.statsT.AddReq(stats.ReqGet, goi.lom.Bucket(), dpq.user, 0, 0, true)
+		tracing.SpanFromContext(goi.ctx).SetErr(err)
 
 		// handle right here, return nil
 		if err != errSendingResp {
@@ -841,8 +855,12 @@ func (t *target) httpobjput(w http.ResponseWriter, r *http.Request, apireq *apiR
 	var (
 		handle string
 		err    error
+		span   *tracing.Span
 		ecode  int
 	)
+	r, span = t.traceReq(r, apireq.dpq.traceparent, "target.put", lom.Cname())
+	defer func() { span.End(err) }()
+
 	switch {
 	case apireq.dpq.arch.path != "": // apc.QparamArchpath
 		apireq.dpq.arch.mime, err = archive.MimeFQN(t.smm, apireq.dpq.arch.mime, lom.FQN)
@@ -967,6 +985,25 @@ func (t *target) httpobjpost(w http.ResponseWriter, r *http.Request, apireq *api
 		} else {
 			t.statsT.IncErr(stats.RenameCount)
 		}
+	case apc.ActUndelete:
+		lom = core.AllocLOM(apireq.items[1])
+		if err = lom.InitBck(apireq.bck.Bucket()); err != nil {
+			break
+		}
+		if tid := apireq.query.Get(apc.QparamUndelTo); tid != "" {
+			err = t.undelPeer(w, lom, tid, cos.IsParseBool(apireq.query.Get(apc.QparamUndelProbe)))
+			if cos.IsNotExist(err, 0) {
+				t.writeErr(w, r, err, http.StatusNotFound, Silent)
+				core.FreeLOM(lom)
+				return
+			}
+		} else {
+			err = t.undelete(lom, apireq.query)
+		}
+		if err == nil {
+			core.FreeLOM(lom)
+			lom = nil
+		}
 	case apc.ActBlobDl:
 		var (
 			xid     string
@@ -1015,15 +1052,17 @@ func (t *target) httpobjhead(w http.ResponseWriter, r *http.Request, apireq *api
 			return
 		}
 	}
+	r, span := t.traceReq(r, query.Get(apc.QparamTraceparent), "target.head", bck.Cname(objName))
 	lom := core.AllocLOM(objName)
-	ecode, err := t.objHead(w.Header(), query, bck, lom)
+	ecode, err := t.objHead(tracing.Detach(r.Context()), w.Header(), query, bck, lom)
 	core.FreeLOM(lom)
 	if err != nil {
 		t._erris(w, r, cos.IsParseBool(query.Get(apc.QparamSilent)), err, ecode)
 	}
+	span.End(err)
 }
 
-func (t *target) objHead(hdr http.Header, query url.Values, bck *meta.Bck, lom *core.LOM) (ecode int, err error) {
+func (t *target) objHead(ctx context.Context, hdr http.Header, query url.Values, bck *meta.Bck, lom *core.LOM) (ecode int, err error) {
 	var (
 		fltPresence int
 		exists      = true
@@ -1100,7 +1139,7 @@ func (t *target) objHead(hdr http.Header, query url.Values, bck *meta.Bck, lom *
 	} else {
 		// cold HEAD
 		var oa *cmn.ObjAttrs
-		oa, ecode, err = t.Backend(lom.Bck()).HeadObj(context.Background(), lom, nil /*origReq*/)
+		oa, ecode, err = t.Backend(lom.Bck()).HeadObj(ctx, lom, nil /*origReq*/)
 		if err != nil {
 			if ecode != http.StatusNotFound {
 				err = cmn.NewErrFailedTo(t, "HEAD", lom.Cname(), err)
@@ -1374,7 +1413,11 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 	}
 	if delFromAIS {
 		size := lom.Lsize()
-		aisErr = lom.RemoveObj()
+		if !evict && lom.Bprops().SoftDel.Enabled {
+			aisErr = lom.SoftDelete()
+		} else {
+			aisErr = lom.RemoveObj()
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1384,12 +1427,17 @@ func (t *target) delobj(lom *core.LOM, evict bool) (int, error, bool) {
 				}
 				return 0, aisErr, false
 			}
-		} else if evict {
-			debug.Assert(lom.Bck().IsRemote())
-			t.statsT.AddMany(
-				cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
-				cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
-			)
+		} else {
+			if u := t.quota.get(lom.Bck()); u != nil {
+				u.add(-size, -1)
+			}
+			if evict {
+				debug.Assert(lom.Bck().IsRemote())
+				t.statsT.AddMany(
+					cos.NamedVal64{Name: stats.LruEvictCount, Value: 1},
+					cos.NamedVal64{Name: stats.LruEvictSize, Value: size},
+				)
+			}
 		}
 	}
 	if backendErr != nil {
@@ -1502,7 +1550,7 @@ func _blobdl(params *core.BlobParams, oa *cmn.ObjAttrs) (string, *xs.XactBlobDl,
 			cos.Close(params.Lmfh)
 		}
 		if params.Wfqn != "" {
-			if errRemove := cos.RemoveFile(params.Wfqn); errRemove != nil {
+			if errRemove := params.Lom.RemoveWork(params.Wfqn); errRemove != nil {
 				nlog.Errorln("nested err", errRemove)
 			}
 		}
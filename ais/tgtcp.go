@@ -300,6 +300,13 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	case apc.WhatNodeConfig, apc.WhatSmap, apc.WhatBMD, apc.WhatSmapVote,
 		apc.WhatSnode, apc.WhatLog, apc.WhatMetricNames:
 		t.htrun.httpdaeget(w, r, query, t /*htext*/)
+	case apc.WhatAudit:
+		// (cluster-wide audit log is served by proxies, subject to AceAdmin)
+		if err := t.isIntraCall(r.Header, false /*from primary*/); err != nil {
+			t.writeErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		t.htrun.httpdaeget(w, r, query, t /*htext*/)
 	case apc.WhatSysInfo:
 		tsysinfo := apc.TSysInfo{MemCPUInfo: apc.GetMemCPU(), CapacityInfo: fs.CapStatusGetWhat()}
 		t.writeJSON(w, r, tsysinfo, httpdaeWhat)
@@ -310,6 +317,7 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		ds := t.statsAndStatus()
 		daeStats := t.statsT.GetStats()
 		ds.Tracker = daeStats.Tracker
+		ds.Latency = daeStats.Latency
 		ds.TargetCDF = daeStats.TargetCDF
 		t.writeJSON(w, r, ds, httpdaeWhat)
 	case apc.WhatNodeStatsV322: // [backward compatibility] v3.22 and prior
@@ -322,6 +330,7 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		ds.RebSnap = _rebSnap()
 		daeStats := t.statsT.GetStats()
 		ds.Tracker = daeStats.Tracker
+		ds.Latency = daeStats.Latency
 		ds.TargetCDF = daeStats.TargetCDF
 		t.fillNsti(&ds.Cluster)
 		t.writeJSON(w, r, ds, httpdaeWhat)
@@ -333,6 +342,10 @@ func (t *target) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		ds.TargetCDF = daeStats.TargetCDF
 		t.writeJSON(w, r, ds, httpdaeWhat)
 
+	case apc.WhatQuota:
+		t.writeJSON(w, r, t.quota.usages(), httpdaeWhat)
+	case apc.WhatBckStats:
+		t.writeJSON(w, r, t.statsT.GetBckStats(), httpdaeWhat)
 	case apc.WhatDiskStats:
 		diskStats := make(ios.AllDiskStats)
 		fs.FillDiskStats(diskStats)
@@ -991,7 +1004,7 @@ func (t *target) receiveConfig(newConfig *globalConfig, msg *aisMsg, payload msP
 		return
 	}
 
-	if oldConfig.Space != newConfig.Space {
+	if !oldConfig.Space.EqualWMs(&newConfig.Space) {
 		fs.ExpireCapCache()
 	}
 
@@ -48,64 +48,16 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		var (
-			query            = r.URL.Query()
-			xid              = query.Get(apc.QparamUUID)
-			jobID            = query.Get(apc.QparamJobID)
-			dlb              = dload.Body{}
-			progressInterval = dload.DownloadProgressInterval
+			query = r.URL.Query()
+			xid   = query.Get(apc.QparamUUID)
+			jobID = query.Get(apc.QparamJobID)
+			dlb   = dload.Body{}
 		)
 		debug.Assertf(cos.IsValidUUID(xid) && cos.IsValidUUID(jobID), "%q, %q", xid, jobID)
 		if err := cmn.ReadJSON(w, r, &dlb); err != nil {
 			return
 		}
-
-		dlBodyBase := dload.Base{}
-		if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBodyBase); err != nil {
-			err = fmt.Errorf(cmn.FmtErrUnmarshal, t, "download message", cos.BHead(dlb.RawMessage), err)
-			t.writeErr(w, r, err)
-			return
-		}
-
-		if dlBodyBase.ProgressInterval != "" {
-			dur, err := time.ParseDuration(dlBodyBase.ProgressInterval)
-			if err != nil {
-				t.writeErrf(w, r, "%s: invalid progress interval %q: %v", t, dlBodyBase.ProgressInterval, err)
-				return
-			}
-			progressInterval = dur
-		}
-
-		bck := meta.CloneBck(&dlBodyBase.Bck)
-		if err := bck.Init(t.Bowner()); err != nil {
-			t.writeErr(w, r, err)
-			return
-		}
-
-		xdl, err := renewdl(xid, bck)
-		if err != nil {
-			t.writeErr(w, r, err, http.StatusInternalServerError)
-			return
-		}
-		dljob, err := dload.ParseStartRequest(bck, jobID, dlb, xdl)
-		if err != nil {
-			xdl.Abort(err)
-			t.writeErr(w, r, err)
-			return
-		}
-		if cmn.Rom.FastV(4, cos.SmoduleAIS) {
-			nlog.Infoln("Downloading:", dljob.ID())
-		}
-
-		dljob.AddNotif(&dload.NotifDownload{
-			Base: nl.Base{
-				When:     core.UponProgress,
-				Interval: progressInterval,
-				Dsts:     []string{equalIC},
-				F:        t.notifyTerm,
-				P:        t.notifyProgress,
-			},
-		}, dljob)
-		response, statusCode, respErr = xdl.Download(dljob)
+		response, statusCode, respErr = t.startDownload(xid, jobID, dlb)
 
 	case http.MethodGet:
 		if _, err := t.parseURL(w, r, apc.URLPathDownload.L, 0, false); err != nil {
@@ -193,6 +145,81 @@ func (t *target) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (t *target) startDownload(xid, jobID string, dlb dload.Body) (any, int, error) {
+	var (
+		progressInterval = dload.DownloadProgressInterval
+		dlBodyBase       = dload.Base{}
+	)
+	if err := jsoniter.Unmarshal(dlb.RawMessage, &dlBodyBase); err != nil {
+		err = fmt.Errorf(cmn.FmtErrUnmarshal, t, "download message", cos.BHead(dlb.RawMessage), err)
+		return nil, http.StatusBadRequest, err
+	}
+
+	if dlBodyBase.ProgressInterval != "" {
+		dur, err := time.ParseDuration(dlBodyBase.ProgressInterval)
+		if err != nil {
+			err = fmt.Errorf("%s: invalid progress interval %q: %v", t, dlBodyBase.ProgressInterval, err)
+			return nil, http.StatusBadRequest, err
+		}
+		progressInterval = dur
+	}
+
+	bck := meta.CloneBck(&dlBodyBase.Bck)
+	if err := bck.Init(t.Bowner()); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	xdl, err := renewdl(xid, bck)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	dljob, err := dload.ParseStartRequest(bck, jobID, dlb, xdl)
+	if err != nil {
+		xdl.Abort(err)
+		return nil, http.StatusBadRequest, err
+	}
+	if cmn.Rom.FastV(4, cos.SmoduleAIS) {
+		nlog.Infoln("Downloading:", dljob.ID())
+	}
+
+	dljob.AddNotif(&dload.NotifDownload{
+		Base: nl.Base{
+			When:     core.UponProgress,
+			Interval: progressInterval,
+			Dsts:     []string{equalIC},
+			F:        t.notifyTerm,
+			P:        t.notifyProgress,
+		},
+	}, dljob)
+	return xdl.Download(dljob)
+}
+
+// resume download jobs interrupted by the previous shutdown (or crash)
+func (t *target) resumeDownloads() {
+	jobs := dload.InterruptedJobs()
+	if len(jobs) == 0 {
+		return
+	}
+	for !t.ClusterStarted() {
+		if nlog.Stopping() {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+	for _, job := range jobs {
+		_, status, err := t.startDownload(job.XactID, job.ID, job.Body)
+		if err == nil && status >= http.StatusBadRequest {
+			err = fmt.Errorf("status %d", status)
+		}
+		if err != nil {
+			nlog.Errorln(t.String(), "failed to resume download job", job.ID+":", err)
+			dload.AbandonJob(job.ID, err)
+			continue
+		}
+		nlog.Infoln(t.String(), "resumed download job", job.ID)
+	}
+}
+
 func renewdl(xid string, bck *meta.Bck) (*dload.Xact, error) {
 	rns := xreg.RenewDownloader(xid, bck)
 	if rns.Err != nil {
@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,10 +26,6 @@ import (
 
 // [METHOD] /v1/etl
 func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
-	if !k8s.IsK8s() {
-		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
-		return
-	}
 	switch {
 	case r.Method == http.MethodPut:
 		t.handleETLPut(w, r)
@@ -44,8 +41,14 @@ func (t *target) etlHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 // PUT /v1/etl
-// start ETL spec/code
+// start ETL spec/code/process/built-in
 func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
+	// only the primary broadcasts ETL init (see proxy.startETL); in particular,
+	// never run user-provided commands (or pods) upon a direct external request
+	if err := t.isIntraCall(r.Header, true /*from primary*/); err != nil {
+		t.writeErr(w, r, err, http.StatusForbidden)
+		return
+	}
 	// disallow to run when above high wm (let alone OOS)
 	cs := fs.Cap()
 	if err := cs.Err(); err != nil {
@@ -68,6 +71,10 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		t.writeErr(w, r, err)
 		return
 	}
+	if etl.IsK8sRuntime(initMsg) && !k8s.IsK8s() {
+		t.writeErr(w, r, k8s.ErrK8sRequired, 0, Silent)
+		return
+	}
 	xid := r.URL.Query().Get(apc.QparamUUID)
 
 	switch msg := initMsg.(type) {
@@ -75,6 +82,10 @@ func (t *target) handleETLPut(w http.ResponseWriter, r *http.Request) {
 		err = etl.InitSpec(msg, xid, etl.StartOpts{})
 	case *etl.InitCodeMsg:
 		err = etl.InitCode(msg, xid)
+	case *etl.InitProcMsg:
+		err = etl.InitProc(msg, xid)
+	case *etl.InitBuiltinMsg:
+		err = etl.InitBuiltin(msg, xid)
 	default:
 		debug.Assert(false, initMsg.String())
 	}
@@ -118,7 +129,9 @@ func (t *target) handleETLGet(w http.ResponseWriter, r *http.Request) {
 	case apc.ETLHealth:
 		t.healthETL(w, r, apiItems[0])
 	case apc.ETLMetrics:
-		k8s.InitMetricsClient()
+		if k8s.IsK8s() {
+			k8s.InitMetricsClient()
+		}
 		t.metricsETL(w, r, apiItems[0])
 	default:
 		t.writeErrURL(w, r)
@@ -290,7 +303,7 @@ func (t *target) headObjectETL(w http.ResponseWriter, r *http.Request) {
 	}
 
 	lom := core.AllocLOM(objName)
-	ecode, err := t.objHead(w.Header(), r.URL.Query(), bck, lom)
+	ecode, err := t.objHead(context.Background(), w.Header(), r.URL.Query(), bck, lom)
 	core.FreeLOM(lom)
 	if err != nil {
 		// always silent (compare w/ httpobjhead)
@@ -0,0 +1,32 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func testTarget() *target { return t } // (see TestMain)
+
+// targets must not start ETLs (in particular, local processes) upon external requests
+func TestETLPutExternal(t *testing.T) {
+	tgt := testTarget()
+	body := `{"id":"hello","command":["touch","/tmp/ais-etl-pwned"],"runtime":"process"}`
+	for _, callerID := range []string{"", "forged"} {
+		r := httptest.NewRequest(http.MethodPut, apc.URLPathETL.S, strings.NewReader(body))
+		if callerID != "" {
+			r.Header.Set(apc.HdrCallerID, callerID)
+		}
+		w := httptest.NewRecorder()
+		tgt.etlHandler(w, r)
+		tassert.Errorf(t, w.Code == http.StatusForbidden, "expected %d, got %d", http.StatusForbidden, w.Code)
+	}
+}
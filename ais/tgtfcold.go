@@ -32,6 +32,7 @@ func (goi *getOI) coldReopen(res *core.GetReaderResult) error {
 		lom    = goi.lom
 		revert string
 	)
+	u, osize := t.quota.prep(lom) // (before renaming or truncating the previous version, if any)
 	if goi.verchanged {
 		revert = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileColdget)
 		if errV := lom.RenameMainTo(revert); errV != nil {
@@ -89,6 +90,9 @@ func (goi *getOI) coldReopen(res *core.GetReaderResult) error {
 		goi._cleanup(revert, wfh, buf, slab, err, "(persist)")
 		return err
 	}
+	if u != nil {
+		u.put(written, osize)
+	}
 
 	// reopen & transmit ---
 	lmfh, err = lom.Open()
@@ -199,6 +203,7 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 		t, lom = goi.t, goi.lom
 		revert string
 	)
+	u, osize := t.quota.prep(lom) // (before renaming or truncating the previous version, if any)
 	if goi.verchanged {
 		revert = fs.CSM.Gen(lom, fs.WorkfileType, fs.WorkfileColdget)
 		if err := lom.RenameMainTo(revert); err != nil {
@@ -268,6 +273,9 @@ func (goi *getOI) coldStream(res *core.GetReaderResult) error {
 		goi._cleanup(revert, lmfh, buf, slab, err, "(persist)")
 		return errSendingResp
 	}
+	if u != nil {
+		u.put(written, osize)
+	}
 
 	slab.Free(buf)
 
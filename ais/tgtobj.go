@@ -56,6 +56,7 @@ type (
 		config     *cmn.Config   // (during this request)
 		resphdr    http.Header   // as implied
 		workFQN    string        // temp fqn to be renamed
+		user       string        // AuthN user ID (per-user metrics)
 		atime      int64         // access time.Now()
 		ltime      int64         // mono.NanoTime, to measure latency
 		size       int64         // aka Content-Length
@@ -125,6 +126,8 @@ type (
 		started  int64         // time of receiving
 		size     int64         // aka Content-Length
 		put      bool          // overwrite
+		u        *tqusage      // quota: local usage (nil if not tracked)
+		osize    int64         // quota: size of the shard prior to appending (-1 if new)
 	}
 )
 
@@ -145,11 +148,12 @@ func (poi *putOI) do(resphdr http.Header, r *http.Request, dpq *dpq) (int, error
 	if dpq.owt != "" {
 		poi.owt.FromS(dpq.owt)
 	}
+	poi.user = dpq.user
 	if dpq.uuid != "" {
 		// resolve cluster-wide xact "behind" this PUT (promote via a single target won't show up)
 		xctn, err := xreg.GetXact(dpq.uuid)
 		if err != nil {
-			nlog.Errorln(err)
+			nlog.ErrorlnWith(poi.logFields(), err)
 			return 0, err
 		}
 		if xctn != nil {
@@ -170,7 +174,7 @@ func (poi *putOI) putObject() (ecode int, err error) {
 	if !poi.skipVC && !poi.coldGET && !poi.cksumToUse.IsEmpty() {
 		if poi.lom.EqCksum(poi.cksumToUse) {
 			if cmn.Rom.FastV(4, cos.SmoduleAIS) {
-				nlog.Infof("destination %s has identical %s: PUT is a no-op", poi.lom, poi.cksumToUse)
+				nlog.InfofWith(poi.logFields(), "destination %s has identical %s: PUT is a no-op", poi.lom, poi.cksumToUse)
 			}
 			cos.DrainReader(poi.r)
 			return 0, nil
@@ -194,13 +198,17 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		// same-checksum-skip-writing, on the other
 		if poi.owt == cmn.OwtPut && poi.restful {
 			debug.Assert(cos.IsValidAtime(poi.atime), poi.atime)
-			size := poi.lom.Lsize()
+			var (
+				size = poi.lom.Lsize()
+				lat  = mono.SinceNano(poi.ltime)
+			)
 			poi.t.statsT.AddMany(
 				cos.NamedVal64{Name: stats.PutCount, Value: 1},
 				cos.NamedVal64{Name: stats.PutSize, Value: size},
 				cos.NamedVal64{Name: stats.PutThroughput, Value: size},
-				cos.NamedVal64{Name: stats.PutLatency, Value: mono.SinceNano(poi.ltime)},
+				cos.NamedVal64{Name: stats.PutLatency, Value: lat},
 			)
+			poi.t.statsT.AddReq(stats.ReqPut, poi.lom.Bucket(), poi.user, size, lat, false)
 			// RESTful PUT response header
 			if poi.resphdr != nil {
 				cmn.ToHeader(poi.lom.ObjAttrs(), poi.resphdr, 0 /*skip setting content-length*/)
@@ -211,16 +219,26 @@ func (poi *putOI) putObject() (ecode int, err error) {
 		poi.xctn.InObjsAdd(1, poi.lom.Lsize())
 	}
 	if cmn.Rom.FastV(5, cos.SmoduleAIS) {
-		nlog.Infoln(poi.loghdr())
+		nlog.InfolnWith(poi.logFields(), poi.loghdr())
 	}
 	return
 rerr:
 	if poi.owt == cmn.OwtPut && poi.restful && !poi.t2t {
 		poi.t.statsT.IncErr(stats.PutCount)
+		poi.t.statsT.AddReq(stats.ReqPut, poi.lom.Bucket(), poi.user, 0, 0, true)
 	}
 	return
 }
 
+// structured-logging fields (see nlog.Fields)
+func (poi *putOI) logFields() *nlog.Fields {
+	f := reqFields(poi.oreq, "")
+	if poi.xctn != nil {
+		f.Xid = poi.xctn.ID()
+	}
+	return f
+}
+
 // verbose only
 func (poi *putOI) loghdr() string {
 	sb := strings.Builder{}
@@ -250,8 +268,8 @@ func (poi *putOI) finalize() (ecode int, err error) {
 				err1 = err
 			}
 			poi.t.fsErr(err1, poi.workFQN)
-			if err2 := cos.RemoveFile(poi.workFQN); err2 != nil && !os.IsNotExist(err2) {
-				nlog.Errorf(fmtNested, poi.t, err1, "remove", poi.workFQN, err2)
+			if err2 := poi.lom.RemoveWork(poi.workFQN); err2 != nil && !os.IsNotExist(err2) {
+				nlog.ErrorfWith(poi.logFields(), fmtNested, poi.t, err1, "remove", poi.workFQN, err2)
 			}
 		}
 		poi.lom.Uncache()
@@ -284,7 +302,7 @@ func (poi *putOI) fini() (ecode int, err error) {
 		ecode, err = poi.putRemote()
 		if err != nil {
 			loghdr := poi.loghdr()
-			nlog.Errorf("PUT (%s): %v(%d)", loghdr, err, ecode)
+			nlog.ErrorfWith(poi.logFields(), "PUT (%s): %v(%d)", loghdr, err, ecode)
 			if ecode != http.StatusServiceUnavailable {
 				return
 			}
@@ -294,7 +312,7 @@ func (poi *putOI) fini() (ecode int, err error) {
 			if err != nil {
 				return
 			}
-			nlog.Infof("PUT (%s): retried OK", loghdr)
+			nlog.InfofWith(poi.logFields(), "PUT (%s): retried OK", loghdr)
 		}
 	}
 
@@ -306,7 +324,7 @@ func (poi *putOI) fini() (ecode int, err error) {
 	case cmn.OwtGetPrefetchLock:
 		if !lom.TryLock(true) {
 			if cmn.Rom.FastV(4, cos.SmoduleAIS) {
-				nlog.Warningln(poi.loghdr(), "is busy")
+				nlog.WarninglnWith(poi.logFields(), poi.loghdr(), "is busy")
 			}
 			return 0, cmn.ErrSkip // e.g. prefetch can skip it and keep on going
 		}
@@ -327,25 +345,36 @@ func (poi *putOI) fini() (ecode int, err error) {
 				debug.AssertNoErr(err)
 			} else if remSrc, ok := lom.GetCustomKey(cmn.SourceObjMD); !ok || remSrc == "" {
 				if err = lom.IncVersion(); err != nil {
-					nlog.Errorln(err)
+					nlog.ErrorlnWith(poi.logFields(), err)
 				}
 			}
 		}
 	}
 
+	// quota: local usage (not counting in-cluster migration - see tgtquota.go)
+	var (
+		osize int64 = -1
+		u     *tqusage
+	)
+	if poi.owt != cmn.OwtRebalance {
+		u, osize = poi.t.quota.prep(lom)
+	}
+
 	// done
 	if err = lom.RenameFinalize(poi.workFQN); err != nil {
 		return
 	}
 	if lom.HasCopies() {
 		if errdc := lom.DelAllCopies(); errdc != nil {
-			nlog.Errorf("PUT (%s): failed to delete old copies [%v], proceeding anyway...", poi.loghdr(), errdc)
+			nlog.ErrorfWith(poi.logFields(), "PUT (%s): failed to delete old copies [%v], proceeding anyway...", poi.loghdr(), errdc)
 		}
 	}
 	if lom.AtimeUnix() == 0 { // (is set when migrating within cluster; prefetch special case)
 		lom.SetAtimeUnix(poi.atime)
 	}
-	err = lom.PersistMain()
+	if err = lom.PersistMain(); err == nil && u != nil {
+		u.put(lom.Lsize(), osize)
+	}
 	return
 }
 
@@ -385,7 +414,12 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 		}{}
 		ckconf = poi.lom.CksumConf()
 	)
-	if lmfh, err = poi.lom.CreateWork(poi.workFQN); err != nil {
+	if csize := poi.chunked(); csize > 0 {
+		lmfh, err = poi.lom.CreateChunked(poi.workFQN, csize)
+	} else {
+		lmfh, err = poi.lom.CreateWork(poi.workFQN)
+	}
+	if err != nil {
 		return
 	}
 	if poi.size <= 0 {
@@ -460,6 +494,22 @@ func (poi *putOI) write() (buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	return
 }
 
+// returns chunk size iff the object (of a known size) is to be stored as chunks
+// (see core/lchunk.go); not chunking objects that are about to be PUT remotely
+func (poi *putOI) chunked() int64 {
+	if poi.size <= 0 {
+		return 0
+	}
+	if poi.lom.Bck().IsRemote() && poi.owt < cmn.OwtRebalance {
+		return 0
+	}
+	config := poi.config
+	if config == nil {
+		config = cmn.GCO.Get()
+	}
+	return poi.lom.Csize(&config.Chunks, poi.size)
+}
+
 // post-write close & cleanup
 func (poi *putOI) _cleanup(buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, err error) {
 	if buf != nil {
@@ -473,10 +523,10 @@ func (poi *putOI) _cleanup(buf []byte, slab *memsys.Slab, lmfh cos.LomWriter, er
 	// not ok
 	poi.r.Close()
 	if nerr := lmfh.Close(); nerr != nil {
-		nlog.Errorf(fmtNested, poi.t, err, "close", poi.workFQN, nerr)
+		nlog.ErrorfWith(poi.logFields(), fmtNested, poi.t, err, "close", poi.workFQN, nerr)
 	}
-	if nerr := cos.RemoveFile(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
-		nlog.Errorf(fmtNested, poi.t, err, "remove", poi.workFQN, nerr)
+	if nerr := poi.lom.RemoveWork(poi.workFQN); nerr != nil && !os.IsNotExist(nerr) {
+		nlog.ErrorfWith(poi.logFields(), fmtNested, poi.t, err, "remove", poi.workFQN, nerr)
 	}
 }
 
@@ -509,6 +559,15 @@ func (goi *getOI) getObject() (ecode int, err error) {
 	return ecode, err
 }
 
+// structured-logging fields (see nlog.Fields)
+func (goi *getOI) logFields() *nlog.Fields {
+	var traceparent string
+	if goi.dpq != nil {
+		traceparent = goi.dpq.traceparent
+	}
+	return reqFields(goi.req, traceparent)
+}
+
 // is under rlock
 func (goi *getOI) get() (ecode int, err error) {
 	var (
@@ -583,10 +642,10 @@ do:
 		cold, ecode, err = goi.validateRecover()
 		if err != nil {
 			if !cold {
-				nlog.Errorln(err)
+				nlog.ErrorlnWith(goi.logFields(), err)
 				return ecode, err
 			}
-			nlog.Errorf("%v - proceeding to cold-GET from %s", err, goi.lom.Bck())
+			nlog.ErrorfWith(goi.logFields(), "%v - proceeding to cold-GET from %s", err, goi.lom.Bck())
 		}
 	}
 
@@ -621,7 +680,7 @@ do:
 			goi.lom.Unlock(true)
 			goi.unlocked = true
 			if !cos.IsNotExist(res.Err, res.ErrCode) {
-				nlog.Infoln(ftcg+"(read)", goi.lom.Cname(), res.Err, res.ErrCode)
+				nlog.InfolnWith(goi.logFields(), ftcg+"(read)", goi.lom.Cname(), res.Err, res.ErrCode)
 			}
 			return res.ErrCode, res.Err
 		}
@@ -663,11 +722,11 @@ fin:
 	if goi.retry {
 		goi.retry = false
 		if !retried {
-			nlog.Warningf("GET %s: retrying...", goi.lom)
+			nlog.WarningfWith(goi.logFields(), "GET %s: retrying...", goi.lom)
 			retried = true // only once
 			goto do
 		}
-		nlog.Warningf("GET %s: failed retrying %v(%d)", goi.lom, err, ecode)
+		nlog.WarningfWith(goi.logFields(), "GET %s: failed retrying %v(%d)", goi.lom, err, ecode)
 	}
 	return ecode, err
 }
@@ -691,7 +750,7 @@ outer:
 			now = mono.NanoTime()
 			fallthrough
 		case mono.Since(now) < max(cmn.Rom.CplaneOperation(), 2*time.Second):
-			nlog.Errorln(t.String()+": failed to load", lom.String(), err, "- retrying...")
+			nlog.ErrorlnWith(goi.logFields(), t.String()+": failed to load", lom.String(), err, "- retrying...")
 		default:
 			err = cmn.NewErrBusy("object", lom.Cname())
 			break outer
@@ -722,7 +781,7 @@ func (goi *getOI) _coldPut(res *core.GetReaderResult) (int, error) {
 
 	if err != nil {
 		lom.Unlock(true)
-		nlog.Infoln(ftcg+"(put)", lom.Cname(), err)
+		nlog.InfolnWith(goi.logFields(), ftcg+"(put)", lom.Cname(), err)
 		return code, err
 	}
 
@@ -730,7 +789,7 @@ func (goi *getOI) _coldPut(res *core.GetReaderResult) (int, error) {
 	if err = lom.Load(true /*cache it*/, true /*locked*/); err != nil {
 		lom.Unlock(true)
 		err = fmt.Errorf("unexpected failure to load %s: %w", lom, err) // (unlikely)
-		nlog.Errorln(err)
+		nlog.ErrorlnWith(goi.logFields(), err)
 		return http.StatusInternalServerError, err
 	}
 
@@ -763,7 +822,7 @@ validate:
 		return
 	}
 
-	nlog.Warningln(err)
+	nlog.WarninglnWith(goi.logFields(), err)
 	redundant := lom.HasCopies() || lom.ECEnabled()
 	//
 	// return err if there's no redundancy OR already recovered once (and failed)
@@ -773,7 +832,7 @@ validate:
 		// TODO: mark `deleted` and postpone actual deletion
 		//
 		if erl := lom.RemoveObj(true /*force through rlock*/); erl != nil {
-			nlog.Warningf("%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
+			nlog.WarningfWith(goi.logFields(), "%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
 		}
 		return
 	}
@@ -789,7 +848,7 @@ validate:
 		restored := lom.RestoreToLocation()
 		goi.lom.Lock(false)
 		if restored {
-			nlog.Warningf("%s: recovered corrupted %s from local replica", goi.t, lom)
+			nlog.WarningfWith(goi.logFields(), "%s: recovered corrupted %s from local replica", goi.t, lom)
 			code = 0
 			goto validate
 		}
@@ -801,7 +860,7 @@ validate:
 		_, code, err = goi.restoreFromAny(true /*skipLomRestore*/)
 		goi.lom.Lock(false)
 		if err == nil {
-			nlog.Warningf("%s: recovered corrupted %s from EC slices", goi.t, lom)
+			nlog.WarningfWith(goi.logFields(), "%s: recovered corrupted %s from EC slices", goi.t, lom)
 			code = 0
 			goto validate
 		}
@@ -809,7 +868,7 @@ validate:
 
 	// TODO: ditto
 	if erl := lom.RemoveObj(true /*force through rlock*/); erl != nil {
-		nlog.Warningf("%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
+		nlog.WarningfWith(goi.logFields(), "%s: failed to remove corrupted %s, err: %v", goi.t, lom, erl)
 	}
 	return
 }
@@ -839,7 +898,7 @@ func (goi *getOI) restoreFromAny(skipLomRestore bool) (doubleCheck bool, ecode i
 		)
 		if resMarked.Interrupted || running || gfnActive {
 			if goi.lom.RestoreToLocation() { // from copies
-				nlog.Infof("%s restored to location", goi.lom)
+				nlog.InfofWith(goi.logFields(), "%s restored to location", goi.lom)
 				return
 			}
 			doubleCheck = running
@@ -883,7 +942,7 @@ gfn:
 		ecErr = goi.lom.Load(true /*cache it*/, false /*locked*/) // TODO: optimize locking
 		debug.AssertNoErr(ecErr)
 		if ecErr == nil {
-			nlog.Infoln(goi.t.String(), "EC-recovered", goi.lom.String())
+			nlog.InfolnWith(goi.logFields(), goi.t.String(), "EC-recovered", goi.lom.String())
 			return
 		}
 		err = cmn.NewErrFailedTo(goi.t, "load EC-recovered", goi.lom.Cname(), ecErr)
@@ -929,7 +988,7 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 	resp, err := g.client.data.Do(req) //nolint:bodyclose // closed by `poi.putObject`
 	cmn.FreeHra(reqArgs)
 	if err != nil {
-		nlog.Errorf("%s: gfn failure, %s %q, err: %v", goi.t, tsi, lom, err)
+		nlog.ErrorfWith(goi.logFields(), "%s: gfn failure, %s %q, err: %v", goi.t, tsi, lom, err)
 		return false
 	}
 
@@ -950,26 +1009,31 @@ func (goi *getOI) getFromNeighbor(lom *core.LOM, tsi *meta.Snode) bool {
 	freePOI(poi)
 	if erp == nil {
 		if cmn.Rom.FastV(5, cos.SmoduleAIS) {
-			nlog.Infof("%s: gfn %s <= %s", goi.t, goi.lom, tsi)
+			nlog.InfofWith(goi.logFields(), "%s: gfn %s <= %s", goi.t, goi.lom, tsi)
 		}
 		return true
 	}
-	nlog.Errorf("%s: gfn-GET failed to PUT locally: %v(%d)", goi.t, erp, ecode)
+	nlog.ErrorfWith(goi.logFields(), "%s: gfn-GET failed to PUT locally: %v(%d)", goi.t, erp, ecode)
 	return false
 }
 
 func (goi *getOI) txfini() (ecode int, err error) {
 	var (
-		lmfh *os.File
+		lmfh cos.LomReader
 		hrng *htrange
 		fqn  = goi.lom.FQN
 		dpq  = goi.dpq
 	)
-	if !goi.cold && !dpq.isGFN {
+	// open
+	switch {
+	case goi.lom.IsChunked():
+		lmfh, err = goi.lom.Open()
+	case !goi.cold && !dpq.isGFN:
 		fqn = goi.lom.LBGet() // best-effort GET load balancing (see also mirror.findLeastUtilized())
+		fallthrough
+	default:
+		lmfh, err = os.Open(fqn)
 	}
-	// open
-	lmfh, err = os.Open(fqn)
 	if err != nil {
 		if os.IsNotExist(err) {
 			ecode = http.StatusNotFound
@@ -1006,7 +1070,7 @@ func (goi *getOI) txfini() (ecode int, err error) {
 	return ecode, err
 }
 
-func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htrange) (err error) {
+func (goi *getOI) _txrng(fqn string, lmfh cos.LomReader, whdr http.Header, hrng *htrange) (err error) {
 	var (
 		r     io.Reader
 		lom   = goi.lom
@@ -1045,7 +1109,7 @@ func (goi *getOI) _txrng(fqn string, lmfh *os.File, whdr http.Header, hrng *htra
 }
 
 // in particular, setup reader and writer and set headers
-func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error) {
+func (goi *getOI) _txreg(fqn string, lmfh cos.LomReader, whdr http.Header) (err error) {
 	var (
 		dpq   = goi.dpq
 		lom   = goi.lom
@@ -1067,7 +1131,7 @@ func (goi *getOI) _txreg(fqn string, lmfh *os.File, whdr http.Header) (err error
 }
 
 // TODO: checksum
-func (goi *getOI) _txarch(fqn string, lmfh *os.File, whdr http.Header) error {
+func (goi *getOI) _txarch(fqn string, lmfh cos.LomReader, whdr http.Header) error {
 	var (
 		ar  archive.Reader
 		dpq = goi.dpq
@@ -1124,7 +1188,7 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 		if !cos.IsRetriableConnErr(err) {
 			goi.t.fsErr(err, fqn)
 		}
-		nlog.Errorln(cmn.NewErrFailedTo(goi.t, "GET", fqn, err))
+		nlog.ErrorlnWith(goi.logFields(), cmn.NewErrFailedTo(goi.t, "GET", fqn, err))
 		// at this point, error is already written into the response -
 		// return special code to indicate just that
 		return errSendingResp
@@ -1138,7 +1202,7 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 		goi.t.reb.FilterAdd(*bname)
 	} else if !goi.cold { // GFN & cold-GET: must be already loaded w/ atime set
 		if err := goi.lom.Load(false /*cache it*/, true /*locked*/); err != nil {
-			nlog.Errorf("%s: GET post-transmission failure: %v", goi.t, err)
+			nlog.ErrorfWith(goi.logFields(), "%s: GET post-transmission failure: %v", goi.t, err)
 			return errSendingResp
 		}
 		goi.lom.SetAtimeUnix(goi.atime)
@@ -1152,12 +1216,14 @@ func (goi *getOI) transmit(r io.Reader, buf []byte, fqn string) error {
 }
 
 func (goi *getOI) stats(written int64) {
+	lat := mono.SinceNano(goi.ltime)
 	goi.t.statsT.AddMany(
 		cos.NamedVal64{Name: stats.GetCount, Value: 1},
 		cos.NamedVal64{Name: stats.GetSize, Value: written},
-		cos.NamedVal64{Name: stats.GetThroughput, Value: written},                // vis-à-vis user (as written m.b. range)
-		cos.NamedVal64{Name: stats.GetLatency, Value: mono.SinceNano(goi.ltime)}, // see also: stats.GetColdRwLatency
+		cos.NamedVal64{Name: stats.GetThroughput, Value: written}, // vis-à-vis user (as written m.b. range)
+		cos.NamedVal64{Name: stats.GetLatency, Value: lat},        // see also: stats.GetColdRwLatency
 	)
+	goi.t.statsT.AddReq(stats.ReqGet, goi.lom.Bucket(), goi.dpq.user, written, lat, false)
 	if goi.verchanged {
 		goi.t.statsT.AddMany(
 			cos.NamedVal64{Name: stats.VerChangeCount, Value: 1},
@@ -1645,6 +1711,8 @@ func (a *putA2I) do() (int, error) {
 	if a.filename == "" {
 		return 0, errors.New("archive path is not defined")
 	}
+	a.u, a.osize = a.t.quota.prep(a.lom) // (before renaming the shard - see below)
+
 	// standard library does not support appending to tgz, zip, and such;
 	// for TAR there is an optimizing workaround not requiring a full copy
 	if a.mime == archive.ExtTar && !a.put /*append*/ && !a.lom.IsChunked() {
@@ -1782,6 +1850,9 @@ func (a *putA2I) finalize(size int64, cksum *cos.Cksum, fqn string) error {
 	if err := a.lom.Persist(); err != nil {
 		return err
 	}
+	if a.u != nil {
+		a.u.put(size, a.osize)
+	}
 	if a.lom.ECEnabled() {
 		if err := ec.ECM.EncodeObject(a.lom, nil); err != nil && err != ec.ErrorECDisabled {
 			return err
@@ -1800,6 +1871,9 @@ func (t *target) putMirror(lom *core.LOM) {
 	if !mconfig.Enabled {
 		return
 	}
+	if lom.IsChunked() {
+		return // not mirroring chunks (that are already spread across mountpaths)
+	}
 	if mpathCnt := fs.NumAvail(); mpathCnt < int(mconfig.Copies) {
 		t.statsT.IncErr(stats.ErrPutMirrorCount)
 		nanotim := mono.NanoTime()
@@ -0,0 +1,202 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Local (this target's) usage of the buckets that have storage quotas (see cmn.QuotaConf):
+// - gets fully counted when a bucket is tracked for the first time;
+// - is then updated incrementally upon PUT (including cold GET, copy, APPEND, promote, etc.),
+//   append-to-archive, undelete, and DELETE;
+// - is periodically recounted to account for in-cluster migrations (rebalance, resilver),
+//   LRU eviction, and other removals that bypass the regular datapath.
+// Proxies query all targets for their respective usages (on demand) and enforce the quotas (see prxquota.go).
+
+const quotaRecountIval = time.Hour
+
+type (
+	tqusage struct {
+		size atomic.Int64
+		objs atomic.Int64
+	}
+	tquota struct {
+		m          map[string]*tqusage // bucket uname => local usage
+		mu         sync.RWMutex
+		recounting atomic.Bool
+	}
+)
+
+func (q *tquota) init() {
+	q.m = make(map[string]*tqusage, 4)
+	hk.Reg("quota"+hk.NameSuffix, q.housekeep, quotaRecountIval)
+}
+
+func hasQuota(bck *meta.Bck, config *cmn.Config) bool {
+	if bck.Props == nil {
+		return false
+	}
+	if bck.Props.Quota.IsActive() {
+		return true
+	}
+	q, ok := config.Space.NsQuotas.Get(bck.Ns)
+	return ok && q.IsActive()
+}
+
+// returns nil when the bucket is not tracked (or not yet counted)
+func (q *tquota) get(bck *meta.Bck) (u *tqusage) {
+	q.mu.RLock()
+	if len(q.m) > 0 {
+		u = q.m[string(bck.MakeUname(""))]
+	}
+	q.mu.RUnlock()
+	return u
+}
+
+// to be called prior to writing (or overwriting) the object: returns its
+// current size (-1 when it doesn't exist), or nil usage when not tracked
+func (q *tquota) prep(lom *core.LOM) (u *tqusage, osize int64) {
+	osize = -1
+	if u = q.get(lom.Bck()); u != nil {
+		if finfo, err := os.Stat(lom.FQN); err == nil {
+			osize = finfo.Size()
+		}
+	}
+	return u, osize
+}
+
+func (u *tqusage) add(size, objs int64) {
+	u.size.Add(size)
+	u.objs.Add(objs)
+}
+
+// new (osize < 0) or overwritten object
+func (u *tqusage) put(size, osize int64) {
+	if osize < 0 {
+		u.add(size, 1)
+	} else {
+		u.add(size-osize, 0)
+	}
+}
+
+// (apc.WhatQuota) current usages formatted as bucket summaries, to be aggregated
+// by proxies the same way they aggregate the latter (see prxbsumm.go);
+// start counting newly tracked buckets, if any
+func (q *tquota) usages() cmn.AllBsummResults {
+	var (
+		bmd    = core.T.Bowner().Get()
+		config = cmn.GCO.Get()
+		out    = make(cmn.AllBsummResults, 0, 4)
+		bcks   []cmn.Bck
+	)
+	q.mu.RLock()
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if !hasQuota(bck, config) {
+			return false
+		}
+		if u, ok := q.m[string(bck.MakeUname(""))]; ok {
+			out = append(out, u.summ(bck))
+		} else {
+			bcks = append(bcks, *bck.Bucket())
+		}
+		return false
+	})
+	q.mu.RUnlock()
+	if len(bcks) > 0 {
+		go q.recount(bcks, false /*all*/)
+	}
+	return out
+}
+
+func (u *tqusage) summ(bck *meta.Bck) *cmn.BsummResult {
+	// (may go transiently negative when racing with recount)
+	size, objs := max(u.size.Load(), 0), max(u.objs.Load(), 0)
+	res := &cmn.BsummResult{Bck: *bck.Bucket()}
+	res.ObjCount.Present = uint64(objs)
+	res.TotalSize.PresentObjs = uint64(size)
+	res.TotalSize.OnDisk = uint64(size)
+	res.IsBckPresent = true
+	return res
+}
+
+func (q *tquota) housekeep() time.Duration {
+	var (
+		bmd    = core.T.Bowner().Get()
+		config = cmn.GCO.Get()
+		bcks   []cmn.Bck
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if hasQuota(bck, config) {
+			bcks = append(bcks, *bck.Bucket())
+		}
+		return false
+	})
+	if len(bcks) == 0 {
+		q.mu.Lock()
+		clear(q.m)
+		q.mu.Unlock()
+	} else {
+		go q.recount(bcks, true /*all*/)
+	}
+	return quotaRecountIval
+}
+
+// walk the buckets and (re)count their local usage; when `all` is true,
+// stop tracking all other buckets (e.g., destroyed ones or the ones that no longer have quotas)
+func (q *tquota) recount(bcks []cmn.Bck, all bool) {
+	if !q.recounting.CAS(false, true) {
+		return
+	}
+	defer q.recounting.Store(false)
+
+	var (
+		started = time.Now()
+		counted = make(map[string]*tqusage, len(bcks))
+	)
+	for i := range bcks {
+		counted[string(bcks[i].MakeUname(""))] = &tqusage{}
+	}
+	opts := &mpather.JgroupOpts{
+		CTs:     []string{fs.ObjectType},
+		DoLoad:  mpather.Load,
+		Buckets: bcks,
+		VisitObj: func(lom *core.LOM, _ []byte) error {
+			if u, ok := counted[string(lom.Bck().MakeUname(""))]; ok {
+				u.add(lom.Lsize(), 1)
+			}
+			return nil
+		},
+		Throttle: true,
+	}
+	jg := mpather.NewJoggerGroup(opts, cmn.GCO.Get(), "")
+	jg.Run()
+	<-jg.ListenFinished()
+	if err := jg.Stop(); err != nil {
+		nlog.Errorln("quota: failed to count local usage:", err)
+		return
+	}
+
+	q.mu.Lock()
+	if all {
+		clear(q.m)
+	}
+	for uname, u := range counted {
+		q.m[uname] = u
+	}
+	q.mu.Unlock()
+	nlog.Infoln("quota: counted", len(bcks), "bucket(s) in", time.Since(started))
+}
@@ -0,0 +1,156 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/archive"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// local usage must be updated by all paths that commit (or remove) objects:
+// PUT, APPEND (handle-based, via flush => promote), promote, append-to-archive, and DELETE
+func TestQuotaUsage(t *testing.T) {
+	var (
+		tgt    = testTarget()
+		bck    = meta.NewBck("quota", apc.AIS, cmn.NsGlobal)
+		u      = &tqusage{}
+		config = cmn.GCO.Get()
+		buf    = make([]byte, 16*cos.KiB)
+	)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}})
+	tgt.owner.bmd.putPersist(bmd, nil)
+	errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	tassert.Fatalf(t, len(errs) == 0, "failed to create %s: %v", bck, errs)
+
+	tgt.quota.mu.Lock()
+	prevm := tgt.quota.m
+	tgt.quota.m = map[string]*tqusage{string(bck.MakeUname("")): u}
+	tgt.quota.mu.Unlock()
+
+	// (promote selects the destination target via HRW)
+	smap := newSmap()
+	smap.addTarget(tgt.si)
+	tgt.owner.smap.put(smap)
+
+	defer func() {
+		tgt.quota.mu.Lock()
+		tgt.quota.m = prevm
+		tgt.quota.mu.Unlock()
+		tgt.owner.smap.put(newSmap())
+		bmd := tgt.owner.bmd.get().clone()
+		bmd.del(bck)
+		tgt.owner.bmd.putPersist(bmd, nil)
+		fs.DestroyBucket("test", bck.Bucket(), bck.Props.BID)
+	}()
+
+	expect := func(tag string, size, objs int64) {
+		t.Helper()
+		tassert.Errorf(t, u.size.Load() == size && u.objs.Load() == objs,
+			"%s: expected (size %d, objs %d), got (%d, %d)", tag, size, objs, u.size.Load(), u.objs.Load())
+	}
+	newLOM := func(objName string) *core.LOM {
+		lom := core.AllocLOM(objName)
+		tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+		return lom
+	}
+
+	// PUT
+	lom := newLOM("quota-put")
+	defer core.FreeLOM(lom)
+	r, _ := readers.NewRand(cos.KiB, cos.ChecksumNone)
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       tgt,
+		lom:     lom,
+		r:       r,
+		workFQN: filepath.Join(testMountpath, "quota-put.work"),
+		config:  config,
+		owt:     cmn.OwtPut,
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(t, err)
+	expect("PUT", cos.KiB, 1)
+
+	// overwrite
+	r, _ = readers.NewRand(2*cos.KiB, cos.ChecksumNone)
+	poi.r = r
+	_, err = poi.putObject()
+	tassert.CheckFatal(t, err)
+	expect("overwrite", 2*cos.KiB, 1)
+
+	// APPEND (to the existing object), and flush
+	aoi := &apndOI{started: time.Now().UnixNano(), t: tgt, config: config, lom: lom, op: apc.AppendOp}
+	aoi.r, _ = readers.NewRand(cos.KiB, cos.ChecksumNone)
+	hdl, _, err := aoi.apnd(buf)
+	tassert.CheckFatal(t, err)
+	expect("APPEND (not flushed)", 2*cos.KiB, 1)
+	aoi = &apndOI{started: time.Now().UnixNano(), t: tgt, config: config, lom: lom, op: apc.FlushOp}
+	tassert.CheckFatal(t, aoi.parse(hdl))
+	_, err = aoi.flush()
+	tassert.CheckFatal(t, err)
+	expect("APPEND", 3*cos.KiB, 1)
+
+	// promote
+	src := filepath.Join(t.TempDir(), "quota-promote")
+	tassert.CheckFatal(t, os.WriteFile(src, bytes.Repeat([]byte{'a'}, cos.KiB), cos.PermRWR))
+	params := &core.PromoteParams{
+		Bck:         bck,
+		Config:      config,
+		PromoteArgs: apc.PromoteArgs{SrcFQN: src, ObjName: "quota-promote", OverwriteDst: true},
+	}
+	_, err = tgt.Promote(params)
+	tassert.CheckFatal(t, err)
+	expect("promote", 4*cos.KiB, 2)
+
+	// append to (a new, and then the same) archive
+	arch := newLOM("quota-arch.tar")
+	defer core.FreeLOM(arch)
+	for i, put := range []bool{true, false} {
+		arch.Lock(true)
+		if !put {
+			tassert.CheckFatal(t, arch.Load(false, true))
+		}
+		a := &putA2I{
+			started:  time.Now().UnixNano(),
+			t:        tgt,
+			lom:      arch,
+			r:        readers.NewBytes(bytes.Repeat([]byte{'b'}, cos.KiB)),
+			filename: "file" + string(rune('0'+i)),
+			mime:     archive.ExtTar,
+			size:     cos.KiB,
+			put:      put,
+		}
+		_, err = a.do()
+		arch.Unlock(true)
+		tassert.CheckFatal(t, err)
+	}
+	finfo, err := os.Stat(arch.FQN)
+	tassert.CheckFatal(t, err)
+	expect("append-to-archive", 4*cos.KiB+finfo.Size(), 3)
+
+	// DELETE
+	for _, l := range []*core.LOM{lom, arch} {
+		l.Lock(true)
+		_, err, _ = tgt.delobj(l, false /*evict*/)
+		l.Unlock(true)
+		tassert.CheckFatal(t, err)
+	}
+	expect("DELETE", cos.KiB, 1)
+
+}
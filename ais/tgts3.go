@@ -6,9 +6,10 @@ package ais
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -81,9 +82,10 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 	switch {
 	case q.Has(s3.QparamMptPartNo) && q.Has(s3.QparamMptUploadID):
 		if r.Header.Get(cos.S3HdrObjSrc) != "" {
-			// TODO: copy another object (or its range) => part of the specified multipart upload.
-			// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html
-			s3.WriteErr(w, r, errors.New("UploadPartCopy not implemented yet"), http.StatusNotImplemented)
+			if cmn.Rom.FastV(5, cos.SmoduleS3) {
+				nlog.Infoln("putMptPartCopy", bck.String(), items, q)
+			}
+			t.putMptPartCopy(w, r, items, q, bck)
 			return
 		}
 		if cmn.Rom.FastV(5, cos.SmoduleS3) {
@@ -103,36 +105,12 @@ func (t *target) putCopyMpt(w http.ResponseWriter, r *http.Request, config *cmn.
 // Copy object (maybe from another bucket)
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_CopyObject.html
 func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.Config, items []string) {
-	src := r.Header.Get(cos.S3HdrObjSrc)
-	src = strings.Trim(src, "/") // in AWS examples the path starts with "/"
-	parts := strings.SplitN(src, "/", 2)
-	if len(parts) < 2 {
-		s3.WriteErr(w, r, errS3Obj, 0)
-		return
-	}
-	// src
-	bckSrc, err, ecode := meta.InitByNameOnly(parts[0], t.owner.bmd)
+	lom, ecode, err := t.initCopySrc(r)
 	if err != nil {
 		s3.WriteErr(w, r, err, ecode)
 		return
 	}
-	objSrc := strings.Trim(parts[1], "/")
-	if err := bckSrc.Init(t.owner.bmd); err != nil {
-		s3.WriteErr(w, r, err, 0)
-		return
-	}
-	lom := core.AllocLOM(objSrc)
 	defer core.FreeLOM(lom)
-	if err := lom.InitBck(bckSrc.Bucket()); err != nil {
-		if cmn.IsErrRemoteBckNotFound(err) {
-			t.BMDVersionFixup(r)
-			err = lom.InitBck(bckSrc.Bucket())
-		}
-		if err != nil {
-			s3.WriteErr(w, r, err, 0)
-		}
-		return
-	}
 	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
@@ -180,6 +158,149 @@ func (t *target) copyObjS3(w http.ResponseWriter, r *http.Request, config *cmn.C
 	sgl.Free()
 }
 
+// parse `x-amz-copy-source`: "[/]<bucket-name>/<object-name>[?versionId=<id>]"
+func parseCopySrc(src string) (bucket, objName string, ecode int, err error) {
+	if i := strings.IndexByte(src, '?'); i >= 0 {
+		q, errV := url.ParseQuery(src[i+1:])
+		if errV != nil {
+			return "", "", 0, fmt.Errorf("invalid %s %q: %v", cos.S3HdrObjSrc, src, errV)
+		}
+		if q.Get(s3.QparamVersionID) != "" {
+			err = fmt.Errorf("%s[NotImplemented: copying a specific version (%s %q) is not supported]",
+				s3.ErrPrefix, cos.S3HdrObjSrc, src)
+			return "", "", http.StatusNotImplemented, err
+		}
+		src = src[:i]
+	}
+	if s, errV := url.PathUnescape(src); errV == nil {
+		src = s
+	}
+	src = strings.Trim(src, "/") // in AWS examples the path starts with "/"
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) < 2 || parts[0] == "" {
+		return "", "", 0, errS3Obj
+	}
+	objName = strings.Trim(parts[1], "/")
+	if objName == "" {
+		return "", "", 0, errS3Obj
+	}
+	return parts[0], objName, 0, nil
+}
+
+// init the source LOM (the caller must free it)
+func (t *target) initCopySrc(r *http.Request) (*core.LOM, int, error) {
+	bucket, objSrc, ecode, err := parseCopySrc(r.Header.Get(cos.S3HdrObjSrc))
+	if err != nil {
+		return nil, ecode, err
+	}
+	bckSrc, err, ecode := meta.InitByNameOnly(bucket, t.owner.bmd)
+	if err != nil {
+		return nil, ecode, err
+	}
+	if err := bckSrc.Init(t.owner.bmd); err != nil {
+		return nil, 0, err
+	}
+	lom := core.AllocLOM(objSrc)
+	if err := lom.InitBck(bckSrc.Bucket()); err != nil {
+		if cmn.IsErrRemoteBckNotFound(err) {
+			t.BMDVersionFixup(r)
+			err = lom.InitBck(bckSrc.Bucket())
+		}
+		if err != nil {
+			core.FreeLOM(lom)
+			return nil, 0, err
+		}
+	}
+	return lom, 0, nil
+}
+
+// `x-amz-copy-source-range` (e.g. "bytes=0-1023") => (offset, length) within the source object
+func parseCopySrcRange(rng string, size int64) (off, length int64, err error) {
+	ranges, err := parseMultiRange(rng, size)
+	if err == nil && len(ranges) != 1 {
+		err = fmt.Errorf("expecting a single range, got %d", len(ranges))
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %s %q: %v", cos.S3HdrObjSrcRange, rng, err)
+	}
+	return ranges[0].Start, ranges[0].Length, nil
+}
+
+// open the entire source object or its range (`x-amz-copy-source-range`) for reading:
+// - locally, if present and local
+// - otherwise, via GET request to the target that "owns" the source
+func (t *target) openCopySrc(lom *core.LOM, rng string) (io.ReadCloser, int64, int, error) {
+	smap := t.owner.smap.get()
+	tsi, local, err := lom.HrwTarget(&smap.Smap)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if local {
+		lom.Lock(false)
+		err := lom.Load(false /*cache it*/, true /*locked*/)
+		if err == nil {
+			var (
+				fh   cos.ReadOpenCloser
+				size = lom.Lsize()
+				off  int64
+			)
+			if rng != "" {
+				if off, size, err = parseCopySrcRange(rng, size); err != nil {
+					lom.Unlock(false)
+					return nil, 0, http.StatusRequestedRangeNotSatisfiable, err
+				}
+			}
+			fh, err = lom.OpenSection(off, size)
+			lom.Unlock(false)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			return fh, size, 0, nil
+		}
+		lom.Unlock(false)
+		if !cos.IsNotExist(err, 0) || lom.Bck().IsAIS() {
+			return nil, 0, 0, err
+		}
+		// not present - fall through to (self-) GET that'll cold-GET it
+	}
+
+	reqArgs := cmn.AllocHra()
+	{
+		reqArgs.Method = http.MethodGet
+		reqArgs.Base = tsi.URL(cmn.NetIntraData)
+		reqArgs.Header = http.Header{
+			apc.HdrCallerID:   []string{t.SID()},
+			apc.HdrCallerName: []string{t.callerName()},
+		}
+		if rng != "" {
+			reqArgs.Header.Set(cos.HdrRange, rng)
+		}
+		reqArgs.Path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
+		reqArgs.Query = lom.Bck().NewQuery()
+	}
+	req, err := reqArgs.Req()
+	cmn.FreeHra(reqArgs)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	resp, err := g.client.data.Do(req) //nolint:bodyclose // closed by the caller
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(resp.Body)
+		cos.Close(resp.Body)
+		return nil, 0, resp.StatusCode, fmt.Errorf("failed to read %s from %s: %s", lom.Cname(), tsi, cmn.Str2HTTPErr(string(b)))
+	}
+	// parts are written with their sizes known upfront
+	if resp.ContentLength < 0 {
+		cos.Close(resp.Body)
+		return nil, 0, 0, fmt.Errorf("failed to read %s from %s: unknown size (content length %d)",
+			lom.Cname(), tsi, resp.ContentLength)
+	}
+	return resp.Body, resp.ContentLength, 0, nil
+}
+
 func (t *target) putObjS3(w http.ResponseWriter, r *http.Request, bck *meta.Bck, config *cmn.Config, lom *core.LOM) {
 	if err := lom.InitBck(bck.Bucket()); err != nil {
 		if cmn.IsErrRemoteBckNotFound(err) {
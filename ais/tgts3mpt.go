@@ -71,7 +71,7 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 		uploadID = cos.GenUUID()
 	}
 
-	s3.InitUpload(uploadID, bck.Name, objName)
+	s3.InitUpload(uploadID, lom)
 	result := &s3.InitiateMptUploadResult{Bucket: bck.Name, Key: objName, UploadID: uploadID}
 
 	sgl := t.gmm.NewSGL(0)
@@ -90,76 +90,152 @@ func (t *target) startMpt(w http.ResponseWriter, r *http.Request, items []string
 // https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPart.html
 func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []string, q url.Values, bck *meta.Bck) {
 	// 1. parse/validate
-	uploadID := q.Get(s3.QparamMptUploadID)
-	if uploadID == "" {
-		s3.WriteErr(w, r, errors.New("empty uploadId"), 0)
+	uploadID, partNum, err := parseMptPart(q)
+	if err != nil {
+		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	part := q.Get(s3.QparamMptPartNo)
-	if part == "" {
-		s3.WriteErr(w, r, fmt.Errorf("upload %q: missing part number", uploadID), 0)
+
+	// 2. init lom
+	objName := s3.ObjName(items)
+	lom := &core.LOM{ObjName: objName}
+	if err := lom.InitBck(bck.Bucket()); err != nil {
+		s3.WriteErr(w, r, err, 0)
 		return
 	}
-	partNum, err := s3.ParsePartNum(part)
+
+	// 3. write and add the part
+	var (
+		partSHA = r.Header.Get(cos.S3HdrContentSHA256)
+		remote  = bck.IsRemoteS3()
+	)
+	if partSHA == cos.S3UnsignedPayload {
+		partSHA = ""
+	}
+	if remote {
+		debug.Assert(r.ContentLength > 0, "mpt upload: expecting positive content-length")
+	}
+	npart, ecode, err := t.writeMptPart(lom, uploadID, partNum, r.Body, r.ContentLength, partSHA, r, q)
 	if err != nil {
-		s3.WriteErr(w, r, err, 0)
+		s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
 		return
 	}
-	if partNum < 1 || partNum > s3.MaxPartsPerUpload {
-		err := fmt.Errorf("upload %q: invalid part number %d, must be between 1 and %d",
-			uploadID, partNum, s3.MaxPartsPerUpload)
+	w.Header().Set(cos.S3CksumHeader, npart.MD5) // s3cmd checks this one
+}
+
+// Upload part copy: copy another object (or its byte range) => part of the multipart upload.
+// The source is specified via `x-amz-copy-source` and, optionally, `x-amz-copy-source-range`.
+// The source may be stored by any target in the cluster or not be present at all, in which case
+// the target that "owns" it will cold-GET the source from the remote backend.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_UploadPartCopy.html
+func (t *target) putMptPartCopy(w http.ResponseWriter, r *http.Request, items []string, q url.Values, bck *meta.Bck) {
+	uploadID, partNum, err := parseMptPart(q)
+	if err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
-
-	// 2. init lom, create part file
 	objName := s3.ObjName(items)
 	lom := &core.LOM{ObjName: objName}
 	if err := lom.InitBck(bck.Bucket()); err != nil {
 		s3.WriteErr(w, r, err, 0)
 		return
 	}
+
+	// source
+	lomSrc, ecode, err := t.initCopySrc(r)
+	if err != nil {
+		s3.WriteErr(w, r, err, ecode)
+		return
+	}
+	reader, size, ecode, err := t.openCopySrc(lomSrc, r.Header.Get(cos.S3HdrObjSrcRange))
+	if err != nil {
+		core.FreeLOM(lomSrc)
+		s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
+		return
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleS3) {
+		nlog.Infoln("putMptPartCopy", lomSrc.Cname(), "=>", lom.Cname(), uploadID, partNum, size)
+	}
+
+	// write and add the part
+	npart, ecode, err := t.writeMptPart(lom, uploadID, partNum, reader, size, "" /*SHA*/, nil /*oreq*/, q)
+	cos.Close(reader)
+	core.FreeLOM(lomSrc)
+	if err != nil {
+		s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
+		return
+	}
+
+	result := &s3.CopyPartResult{
+		LastModified: cos.FormatNanoTime(time.Now().UnixNano(), cos.ISO8601),
+		ETag:         npart.MD5,
+	}
+	sgl := t.gmm.NewSGL(0)
+	result.MustMarshal(sgl)
+	w.Header().Set(cos.HdrContentType, cos.ContentXML)
+	sgl.WriteTo2(w)
+	sgl.Free()
+}
+
+func parseMptPart(q url.Values) (uploadID string, partNum int32, err error) {
+	uploadID = q.Get(s3.QparamMptUploadID)
+	if uploadID == "" {
+		return "", 0, errors.New("empty uploadId")
+	}
+	part := q.Get(s3.QparamMptPartNo)
+	if part == "" {
+		return "", 0, fmt.Errorf("upload %q: missing part number", uploadID)
+	}
+	if partNum, err = s3.ParsePartNum(part); err != nil {
+		return "", 0, err
+	}
+	if partNum < 1 || partNum > s3.MaxPartsPerUpload {
+		err = fmt.Errorf("upload %q: invalid part number %d, must be between 1 and %d",
+			uploadID, partNum, s3.MaxPartsPerUpload)
+	}
+	return uploadID, partNum, err
+}
+
+// write part's content into a workfile and (remote s3 bucket) upload it as well
+// - partSHA, if non-empty, is the expected SHA256 of the part
+// - oreq is the original request that, if present, may be used to presign remote upload
+func (t *target) writeMptPart(lom *core.LOM, uploadID string, partNum int32, r io.ReadCloser, size int64, partSHA string,
+	oreq *http.Request, q url.Values) (*s3.MptPart, int, error) {
 	// workfile name format: <upload-id>.<part-number>.<obj-name>
 	prefix := uploadID + "." + strconv.FormatInt(int64(partNum), 10)
 	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
 	partFh, errC := lom.CreatePart(wfqn)
 	if errC != nil {
-		s3.WriteMptErr(w, r, errC, 0, lom, uploadID)
-		return
+		return nil, 0, errC
 	}
 
 	var (
-		etag         string
-		size         int64
-		ecode        int
-		partSHA      = r.Header.Get(cos.S3HdrContentSHA256)
-		checkPartSHA = partSHA != "" && partSHA != cos.S3UnsignedPayload
-		cksumSHA     = &cos.CksumHash{}
-		cksumMD5     = &cos.CksumHash{}
-		remote       = bck.IsRemoteS3()
+		etag     string
+		ecode    int
+		err      error
+		cksumSHA = &cos.CksumHash{}
+		cksumMD5 = &cos.CksumHash{}
+		remote   = lom.Bck().IsRemoteS3()
 	)
-	if checkPartSHA {
+	if partSHA != "" {
 		cksumSHA = cos.NewCksumHash(cos.ChecksumSHA256)
 	}
 	if !remote {
 		cksumMD5 = cos.NewCksumHash(cos.ChecksumMD5)
 	}
 
-	// 3. write
+	// write
 	mw := multiWriter(cksumMD5.H, cksumSHA.H, partFh)
 
 	if !remote {
 		// write locally
 		buf, slab := t.gmm.Alloc()
-		size, err = io.CopyBuffer(mw, r.Body, buf)
+		size, err = io.CopyBuffer(mw, r, buf)
 		slab.Free(buf)
 	} else {
 		// write locally and utilize TeeReader to simultaneously send data to S3
-		tr := io.NopCloser(io.TeeReader(r.Body, mw))
-		size = r.ContentLength
-		debug.Assert(size > 0, "mpt upload: expecting positive content-length")
-
-		etag, ecode, err = backend.PutMptPart(lom, tr, r, q, uploadID, size, partNum)
+		tr := io.NopCloser(io.TeeReader(r, mw))
+		etag, ecode, err = backend.PutMptPart(lom, tr, oreq, q, uploadID, size, partNum)
 	}
 
 	cos.Close(partFh)
@@ -167,25 +243,26 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
 			nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
 		}
-		s3.WriteMptErr(w, r, err, ecode, lom, uploadID)
-		return
+		return nil, ecode, err
 	}
 
-	// 4. finalize the part (expecting the part's remote etag to be md5 checksum)
+	// finalize the part (expecting the part's remote etag to be md5 checksum)
 	md5 := etag
 	if cksumMD5.H != nil {
 		debug.Assert(etag == "")
 		cksumMD5.Finalize()
 		md5 = cksumMD5.Value()
 	}
-	if checkPartSHA {
+	if cksumSHA.H != nil {
 		cksumSHA.Finalize()
 		recvSHA := cos.NewCksum(cos.ChecksumSHA256, partSHA)
 		if !cksumSHA.Equal(recvSHA) {
 			detail := fmt.Sprintf("upload %q, %s, part %d", uploadID, lom, partNum)
 			err = cos.NewErrDataCksum(&cksumSHA.Cksum, recvSHA, detail)
-			s3.WriteMptErr(w, r, err, http.StatusInternalServerError, lom, uploadID)
-			return
+			if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+				nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
+			}
+			return nil, http.StatusInternalServerError, err
 		}
 	}
 	npart := &s3.MptPart{
@@ -195,10 +272,9 @@ func (t *target) putMptPart(w http.ResponseWriter, r *http.Request, items []stri
 		Num:  partNum,
 	}
 	if err := s3.AddPart(uploadID, npart); err != nil {
-		s3.WriteMptErr(w, r, err, 0, lom, uploadID)
-		return
+		return nil, 0, err
 	}
-	w.Header().Set(cos.S3CksumHeader, md5) // s3cmd checks this one
+	return npart, 0, nil
 }
 
 // Complete multipart upload.
@@ -274,7 +350,15 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 	// 2. <upload-id>.complete.<obj-name>
 	prefix := uploadID + ".complete"
 	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, prefix)
-	wfh, errC := lom.CreateWork(wfqn)
+	var (
+		wfh  cos.LomWriter
+		errC error
+	)
+	if csize := lom.Csize(&cmn.GCO.Get().Chunks, size); csize > 0 {
+		wfh, errC = lom.CreateChunked(wfqn, csize) // (see core/lchunk.go)
+	} else {
+		wfh, errC = lom.CreateWork(wfqn)
+	}
 	if errC != nil {
 		s3.WriteMptErr(w, r, errC, 0, lom, uploadID)
 		return
@@ -301,7 +385,7 @@ func (t *target) completeMpt(w http.ResponseWriter, r *http.Request, items []str
 		errA = fmt.Errorf("upload %q %q: expected full size=%d, got %d", uploadID, lom.Cname(), size, written)
 	}
 	if errA != nil {
-		if nerr := cos.RemoveFile(wfqn); nerr != nil && !os.IsNotExist(nerr) {
+		if nerr := lom.RemoveWork(wfqn); nerr != nil && !os.IsNotExist(nerr) {
 			nlog.Errorf(fmtNested, t, err, "remove", wfqn, nerr)
 		}
 		s3.WriteMptErr(w, r, errA, 0, lom, uploadID)
@@ -493,6 +577,10 @@ func (t *target) getMptPart(w http.ResponseWriter, r *http.Request, bck *meta.Bc
 	if err != nil {
 		s3.WriteErr(w, r, err, status)
 	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil {
+		s3.WriteErr(w, r, err, 0)
+		return
+	}
 	fh, err := lom.Open()
 	if err != nil {
 		s3.WriteErr(w, r, err, 0)
@@ -0,0 +1,82 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/aistore/ais/s3"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestParseMptPart(t *testing.T) {
+	q := url.Values{s3.QparamMptUploadID: []string{"abc"}, s3.QparamMptPartNo: []string{"7"}}
+	uploadID, partNum, err := parseMptPart(q)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, uploadID == "abc" && partNum == 7, "unexpected (%q, %d)", uploadID, partNum)
+
+	invalid := []url.Values{
+		{s3.QparamMptPartNo: []string{"1"}},                                          // no upload ID
+		{s3.QparamMptUploadID: []string{"abc"}},                                      // no part number
+		{s3.QparamMptUploadID: []string{"abc"}, s3.QparamMptPartNo: []string{"one"}}, // not a number
+		{s3.QparamMptUploadID: []string{"abc"}, s3.QparamMptPartNo: []string{"0"}},
+		{s3.QparamMptUploadID: []string{"abc"}, s3.QparamMptPartNo: []string{strconv.Itoa(s3.MaxPartsPerUpload + 1)}},
+	}
+	for _, q := range invalid {
+		_, _, err := parseMptPart(q)
+		tassert.Errorf(t, err != nil, "expected %v to fail", q)
+	}
+}
+
+func TestParseCopySrc(t *testing.T) {
+	tests := []struct {
+		src, bucket, objName string
+	}{
+		{"/src/obj", "src", "obj"},
+		{"src/dir/obj", "src", "dir/obj"},
+		{"/src/dir%20one/obj%2B1", "src", "dir one/obj+1"},
+		{"src/obj?partNumber=1", "src", "obj"}, // (other query parameters are ignored)
+	}
+	for _, test := range tests {
+		bucket, objName, _, err := parseCopySrc(test.src)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bucket == test.bucket && objName == test.objName, "%q: unexpected (%q, %q)",
+			test.src, bucket, objName)
+	}
+
+	for _, src := range []string{"", "/", "src", "/src/", "//obj"} {
+		_, _, _, err := parseCopySrc(src)
+		tassert.Errorf(t, err != nil, "expected %q to fail", src)
+	}
+
+	_, _, ecode, err := parseCopySrc("/src/obj?versionId=3HL4kqtJlcpXroDTDmJ")
+	tassert.Errorf(t, err != nil && ecode == http.StatusNotImplemented, "expected not-implemented, got %d: %v", ecode, err)
+}
+
+func TestParseCopySrcRange(t *testing.T) {
+	const size = 1000
+	tests := []struct {
+		rng         string
+		off, length int64
+	}{
+		{"bytes=0-99", 0, 100},
+		{"bytes=100-999", 100, 900},
+		{"bytes=900-2000", 900, 100}, // (clipped)
+		{"bytes=-10", 990, 10},       // suffix
+	}
+	for _, test := range tests {
+		off, length, err := parseCopySrcRange(test.rng, size)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, off == test.off && length == test.length, "%q: expected (%d, %d), got (%d, %d)",
+			test.rng, test.off, test.length, off, length)
+	}
+	for _, rng := range []string{"0-99", "bytes=1000-1001", "bytes=0-9,20-29", "bytes=abc"} {
+		_, _, err := parseCopySrcRange(rng, size)
+		tassert.Errorf(t, err != nil, "expected %q to fail", rng)
+	}
+}
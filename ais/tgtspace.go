@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/nl"
@@ -29,6 +30,11 @@ const (
 	// - note that an API call (e.g. CLI) will go through anyway
 	// - compare with cmn/cos/oom.go
 	minAutoDetectInterval = 10 * time.Minute
+
+	// how often to run bucket lifecycle (see also: cmn.LifecycleConf);
+	// the first run - shortly after startup (so that frequent restarts don't keep postponing it)
+	lcyInterval  = 24 * time.Hour
+	lcyInitDelay = 10 * time.Minute
 )
 
 var (
@@ -145,3 +151,45 @@ func (t *target) runStoreCleanup(id string, wg *sync.WaitGroup, bcks ...cmn.Bck)
 	})
 	return space.RunCleanup(&ini)
 }
+
+// housekeeping: periodically enforce bucket lifecycle rules, if any
+func (t *target) lcyHK() time.Duration {
+	var (
+		active bool
+		bmd    = t.owner.bmd.get()
+	)
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		active = bck.Props.Lifecycle.IsActive()
+		return active
+	})
+	if active {
+		t.runLifecycle("" /*uuid*/, nil /*wg*/)
+	}
+	return lcyInterval
+}
+
+func (t *target) runLifecycle(id string, wg *sync.WaitGroup, bcks ...cmn.Bck) {
+	regToIC := id == ""
+	if regToIC {
+		id = cos.GenUUID()
+	}
+	rns := xreg.RenewLifecycle(id, bcks)
+	if rns.Err != nil || rns.IsRunning() {
+		debug.Assert(rns.Err == nil || cmn.IsErrXactUsePrev(rns.Err))
+		if wg != nil {
+			wg.Done()
+		}
+		return
+	}
+	xlcy := rns.Entry.Get()
+	if regToIC && xlcy.ID() == id {
+		regMsg := xactRegMsg{UUID: id, Kind: apc.ActLifecycle, Srcs: []string{t.SID()}}
+		msg := t.newAmsgActVal(apc.ActRegGlobalXaction, regMsg)
+		t.bcastAsyncIC(msg)
+	}
+	xlcy.AddNotif(&xact.NotifXact{
+		Base: nl.Base{When: core.UponTerm, Dsts: []string{equalIC}, F: t.notifyTerm},
+		Xact: xlcy,
+	})
+	go xlcy.Run(wg)
+}
@@ -0,0 +1,151 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Undelete (see cmn.SoftDelConf):
+// - soft-deleted objects stay in the trash of the mountpath (and target) they were deleted from,
+//   and do not migrate with rebalance;
+// - therefore, when not found locally, the (HRW) target asks all other targets
+//   which of them has the most recently soft-deleted version ("probe"),
+// - and then asks that one to restore it and send it over.
+
+// (HRW) target
+func (t *target) undelete(lom *core.LOM, query url.Values) error {
+	lom.Lock(true)
+	err := lom.Undelete()
+	lom.Unlock(true)
+	if cos.IsNotExist(err, 0) {
+		err = t.undelBcast(lom, query)
+	}
+	if err != nil {
+		return err
+	}
+	if u := t.quota.get(lom.Bck()); u != nil {
+		if err := lom.Load(false /*cache it*/, false /*locked*/); err == nil {
+			u.add(lom.Lsize(), 1)
+		}
+	}
+	return nil
+}
+
+func (t *target) undelBcast(lom *core.LOM, query url.Values) error {
+	var (
+		smap = t.owner.smap.get()
+		q    = lom.Bck().NewQuery()
+		body = cos.MustMarshal(apc.ActMsg{Action: apc.ActUndelete})
+		path = apc.URLPathObjects.Join(lom.Bck().Name, lom.ObjName)
+	)
+	q.Set(apc.QparamProxyID, query.Get(apc.QparamProxyID)) // (see isRedirect)
+	q.Set(apc.QparamUndelTo, t.SID())
+	q.Set(apc.QparamUndelProbe, "true")
+
+	// 1. probe
+	args := allocBcArgs()
+	args.req = cmn.HreqArgs{Method: http.MethodPost, Path: path, Query: q, Body: body}
+	args.to = core.Targets
+	args.smap = smap
+	results := t.bcastGroup(args)
+	freeBcArgs(args)
+	var (
+		tsi   *meta.Snode
+		dtime int64
+	)
+	for _, res := range results {
+		if res.err != nil {
+			if res.status != http.StatusNotFound {
+				nlog.Warningln(t.String(), "undelete", lom.Cname(), "probe:", res.toErr())
+			}
+			continue
+		}
+		if d, err := strconv.ParseInt(string(res.bytes), 10, 64); err == nil && (tsi == nil || d > dtime) {
+			tsi, dtime = res.si, d
+		}
+	}
+	freeBcastRes(results)
+	if tsi == nil {
+		return cos.NewErrNotFound(t, "soft-deleted "+lom.Cname())
+	}
+
+	// 2. restore
+	q.Del(apc.QparamUndelProbe)
+	cargs := allocCargs()
+	{
+		cargs.si = tsi
+		cargs.req = cmn.HreqArgs{Method: http.MethodPost, Path: path, Query: q, Body: body}
+		cargs.timeout = apc.LongTimeout
+	}
+	res := t.call(cargs, smap)
+	err := res.toErr()
+	freeCargs(cargs)
+	freeCR(res)
+	return err
+}
+
+// (other) target: when probing, report the deletion time of the most recent
+// soft-deleted version; otherwise, restore it locally, send it over to `tid`, and cleanup
+func (t *target) undelPeer(w http.ResponseWriter, lom *core.LOM, tid string, probe bool) error {
+	if probe {
+		fqn, _, dtime := lom.SoftDeleted()
+		if fqn == "" {
+			return cos.NewErrNotFound(t, "soft-deleted "+lom.Cname())
+		}
+		s := strconv.FormatInt(dtime, 10)
+		w.Header().Set(cos.HdrContentLength, strconv.Itoa(len(s)))
+		w.Write([]byte(s))
+		return nil
+	}
+
+	smap := t.owner.smap.get()
+	tsi := smap.GetTarget(tid)
+	if tsi == nil {
+		return &errNodeNotFound{"undelete failure:", tid, t.si, smap}
+	}
+	lom.Lock(true)
+	_, _, dtime := lom.SoftDeleted()
+	err := lom.Undelete()
+	lom.Unlock(true)
+	if err != nil {
+		return err
+	}
+
+	coiParams := core.AllocCOI()
+	{
+		coiParams.BckTo = lom.Bck()
+		coiParams.OWT = cmn.OwtRebalance // (in effect, delayed migration)
+		coiParams.Config = cmn.GCO.Get()
+	}
+	coi := (*copyOI)(coiParams)
+	_, err = coi.send(t, nil /*DM*/, lom, lom.ObjName, tsi)
+	core.FreeCOI(coiParams)
+	if err == nil && !t.headt2t(lom, tsi, smap) {
+		err = fmt.Errorf("%s: failed to restore %s on %s", t, lom.Cname(), tsi.StringEx())
+	}
+
+	lom.Lock(true)
+	if err == nil {
+		err = lom.RemoveObj()
+	} else if errV := lom.SoftDelete(); errV == nil { // back to trash
+		fs.SetSoftDelTime(lom.Mountpath().SoftDelFQN(lom.Bucket(), lom.ObjName), dtime)
+	} else {
+		nlog.Errorln(t.String(), "undelete", lom.Cname(), "failed to soft-delete back:", errV)
+	}
+	lom.Unlock(true)
+	return err
+}
@@ -0,0 +1,100 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/readers"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+// soft-delete must keep the object's mtime (recording deletion time separately);
+// undelete must restore it, and report it to the (HRW) target that asks (see undelBcast)
+func TestUndelete(t *testing.T) {
+	var (
+		tgt = testTarget()
+		bck = meta.NewBck("softdel", apc.AIS, cmn.NsGlobal)
+	)
+	bmd := tgt.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, SoftDel: cmn.SoftDelConf{Enabled: true}})
+	tgt.owner.bmd.putPersist(bmd, nil)
+	errs := fs.CreateBucket(bck.Bucket(), false /*nilbmd*/)
+	tassert.Fatalf(t, len(errs) == 0, "failed to create %s: %v", bck, errs)
+	defer func() {
+		bmd := tgt.owner.bmd.get().clone()
+		bmd.del(bck)
+		tgt.owner.bmd.putPersist(bmd, nil)
+		fs.DestroyBucket("test", bck.Bucket(), bck.Props.BID)
+	}()
+
+	lom := core.AllocLOM("obj")
+	defer core.FreeLOM(lom)
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	r, _ := readers.NewRand(cos.KiB, cos.ChecksumNone)
+	poi := &putOI{
+		atime:   time.Now().UnixNano(),
+		t:       tgt,
+		lom:     lom,
+		r:       r,
+		workFQN: filepath.Join(testMountpath, "softdel.work"),
+		config:  cmn.GCO.Get(),
+		owt:     cmn.OwtPut,
+	}
+	_, err := poi.putObject()
+	tassert.CheckFatal(t, err)
+	mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tassert.CheckFatal(t, os.Chtimes(lom.FQN, mtime, mtime))
+
+	// soft-delete
+	started := time.Now()
+	lom.Lock(true)
+	_, err, _ = tgt.delobj(lom, false /*evict*/)
+	lom.Unlock(true)
+	tassert.CheckFatal(t, err)
+	fqn, _, dtime := lom.SoftDeleted()
+	tassert.Fatalf(t, fqn != "", "expected soft-deleted %s", lom.Cname())
+	finfo, err := os.Stat(fqn)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, finfo.ModTime().Equal(mtime), "expected mtime %v, got %v", mtime, finfo.ModTime())
+	tassert.Errorf(t, dtime >= started.UnixNano(), "expected deletion time >= %d, got %d", started.UnixNano(), dtime)
+
+	// probe
+	w := httptest.NewRecorder()
+	tassert.CheckFatal(t, tgt.undelPeer(w, lom, "ignored", true /*probe*/))
+	tassert.Errorf(t, w.Body.String() == strconv.FormatInt(dtime, 10), "expected %d, got %q", dtime, w.Body.String())
+
+	// undelete
+	tassert.CheckFatal(t, tgt.undelete(lom, url.Values{}))
+	finfo, err = os.Stat(lom.FQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, finfo.ModTime().Equal(mtime), "expected restored mtime %v, got %v", mtime, finfo.ModTime())
+	_, err = fs.GetXattr(lom.FQN, "user.ais.dtime")
+	tassert.Errorf(t, cos.IsErrXattrNotFound(err), "expected deletion time to be cleared, got %v", err)
+	fqn, _, _ = lom.SoftDeleted()
+	tassert.Errorf(t, fqn == "", "expected no soft-deleted %s, got %s", lom.Cname(), fqn)
+
+	// not found anywhere (no other targets)
+	lom.Lock(true)
+	tassert.CheckFatal(t, lom.RemoveObj())
+	lom.Unlock(true)
+	err = tgt.undelete(lom, url.Values{})
+	tassert.Errorf(t, cos.IsNotExist(err, 0), "expected not-found, got %v", err)
+	w = httptest.NewRecorder()
+	err = tgt.undelPeer(w, lom, "ignored", true /*probe*/)
+	tassert.Errorf(t, cos.IsNotExist(err, 0), "expected not-found, got %v", err)
+}
@@ -209,6 +209,11 @@ func (t *target) xstart(args *xact.ArgsMsg, bck *meta.Bck, msg *apc.ActMsg) (xid
 		wg.Add(1)
 		go t.runStoreCleanup(args.ID, wg, args.Buckets...)
 		wg.Wait()
+	case apc.ActLifecycle:
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		t.runLifecycle(args.ID, wg, args.Buckets...)
+		wg.Wait()
 	case apc.ActResilver:
 		if bck != nil {
 			nlog.Errorf(erfmb, args.Kind, bck)
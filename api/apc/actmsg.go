@@ -48,6 +48,7 @@ const (
 
 	ActLRU          = "lru"
 	ActStoreCleanup = "cleanup-store"
+	ActLifecycle    = "lifecycle"
 
 	ActEvictRemoteBck = "evict-remote-bck" // evict remote bucket's data
 	ActInvalListCache = "inval-listobj-cache"
@@ -56,6 +57,7 @@ const (
 	ActNewPrimary     = "new-primary"
 	ActPromote        = "promote"
 	ActRenameObject   = "rename-obj"
+	ActUndelete       = "undelete-obj"
 
 	// cp (reverse)
 	ActResetStats  = "reset-stats"
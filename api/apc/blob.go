@@ -16,10 +16,12 @@ import (
 const _bldl = "blob-downloader"
 
 type BlobMsg struct {
-	ChunkSize  int64 `json:"chunk-size"`
-	FullSize   int64 `json:"full-size"`
-	NumWorkers int   `json:"num-workers"`
-	LatestVer  bool  `json:"latest-ver"`
+	ChunkSize    int64        `json:"chunk-size"`
+	FullSize     int64        `json:"full-size"`
+	NumWorkers   int          `json:"num-workers"`
+	MaxRetries   int          `json:"max-retries,omitempty"`   // per chunk; zero: system default; negative: no retries
+	StallTimeout cos.Duration `json:"stall-timeout,omitempty"` // retry chunk read that makes no progress for this long
+	LatestVer    bool         `json:"latest-ver"`
 }
 
 // using textproto.CanonicalMIMEHeaderKey() to check presence -
@@ -37,6 +37,7 @@ const (
 	HdrS3Region   = HeaderPrefix + "cloud_region"
 	HdrS3Endpoint = HeaderPrefix + "endpoint"
 	HdrS3Profile  = HeaderPrefix + "profile"
+	HdrS3EpName   = HeaderPrefix + "endpoint_name"
 
 	// including BucketProps.Extra.HTTP
 	HdrOrigURLBck = HeaderPrefix + "original-url"
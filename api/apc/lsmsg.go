@@ -24,7 +24,7 @@ const (
 
 	LsMissing // include missing main obj (with copy existing)
 
-	LsDeleted // include obj-s marked for deletion (TODO: not implemented yet)
+	LsDeleted // list soft-deleted obj-s (see bucket property "soft_delete") - instead of existing ones
 
 	LsArchDir // expand archives as directories
 
@@ -108,6 +108,7 @@ const (
 	EntryIsArchive  = 1 << (EntryStatusBits + 4)
 	EntryVerChanged = 1 << (EntryStatusBits + 5) // see also: QparamLatestVer, et al.
 	EntryVerRemoved = 1 << (EntryStatusBits + 6) // ditto
+	EntryIsDeleted  = 1 << (EntryStatusBits + 7) // soft-deleted (see LsDeleted)
 )
 
 // ObjEntry.Flags field
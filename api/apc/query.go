@@ -186,6 +186,11 @@ const (
 	QparamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
 	QparamClusterInfo      = "cii" // true: /Health to return `cos.NodeStateInfo` including cluster metadata versions and state flags
 	QparamOWT              = "owt" // object write transaction enum { OwtPut, ..., OwtGet* }
+	QparamTraceparent      = "tpr" // W3C traceparent of the redirecting proxy's span (see tracing)
+	QparamUser             = "usr" // AuthN user ID of the redirected request (per-user metrics and audit; see cmn.MetricsConf, cmn.AuditConf)
+	QparamUserSig          = "usg" // redirecting proxy's signature of the QparamUser (and QparamUnixTime)
+	QparamUndelTo          = "udt" // ID of the target to restore soft-deleted object to, when not found there (e.g., after rebalance)
+	QparamUndelProbe       = "udp" // true: only report when (if at all) the object was soft-deleted (see QparamUndelTo)
 
 	QparamDontResilver = "dntres" // true: do not resilver data off of mountpaths that are being disabled/detached
 
@@ -205,6 +210,11 @@ const (
 
 	// Notification target's node ID (usually, the node that initiates the operation).
 	QparamNotifyMe = "nft"
+
+	// audit log query (WhatAudit); see also QparamUser
+	QparamAuditAction = "audit_action"
+	QparamAuditSince  = "audit_since" // Unix time (nanoseconds)
+	QparamAuditLimit  = "audit_limit" // max number of the most recent records
 )
 
 // QparamWhat enum.
@@ -224,6 +234,9 @@ const (
 
 	WhatMetricNames = "metrics"
 	WhatDiskStats   = "disk"
+	WhatQuota       = "quota"     // per-bucket storage usage (targets) and quotas (see cmn.QuotaConf)
+	WhatBckStats    = "bck_stats" // per-bucket and per-user GET and PUT metrics (see cmn.MetricsConf)
+	WhatAudit       = "audit"     // audit log records (see cmn.AuditConf)
 	// assorted
 	WhatMountpaths = "mountpaths"
 	WhatRemoteAIS  = "remote"
@@ -0,0 +1,44 @@
+// Package api provides native Go-based API/SDK over HTTP(S).
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
+)
+
+// GetAuditLog returns the most recent cluster-wide audit records (newest first)
+// that match the query (see cmn.AuditConf); requires admin permissions when AuthN is enabled.
+func GetAuditLog(bp BaseParams, q *audit.Query) (recs []*audit.Record, err error) {
+	bp.Method = http.MethodGet
+	query := url.Values{apc.QparamWhat: []string{apc.WhatAudit}}
+	if q != nil {
+		if q.User != "" {
+			query.Set(apc.QparamUser, q.User)
+		}
+		if q.Action != "" {
+			query.Set(apc.QparamAuditAction, q.Action)
+		}
+		if q.Since != 0 {
+			query.Set(apc.QparamAuditSince, strconv.FormatInt(q.Since, 10))
+		}
+		if q.Limit != 0 {
+			query.Set(apc.QparamAuditLimit, strconv.Itoa(q.Limit))
+		}
+	}
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = query
+	}
+	_, err = reqParams.DoReqAny(&recs)
+	FreeRp(reqParams)
+	return
+}
@@ -81,6 +81,28 @@ func LoginUser(bp api.BaseParams, userID, pass, clusterID string, expire *time.D
 	return token, nil
 }
 
+// Exchange ID token issued by (configured) OIDC provider for AuthN token.
+// The resulting permissions are determined by the roles mapped to the user's IdP groups.
+func LoginOIDC(bp api.BaseParams, idToken, clusterID string, expire *time.Duration) (token *TokenMsg, err error) {
+	bp.Method = http.MethodPost
+	rec := LoginMsg{IDToken: idToken, ExpiresIn: expire, ClusterID: clusterID}
+	reqParams := api.AllocRp()
+	defer api.FreeRp(reqParams)
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = URLPathOIDC
+		reqParams.Body = cos.MustMarshal(rec)
+		reqParams.Header = http.Header{cos.HdrContentType: []string{cos.ContentJSON}}
+	}
+	if _, err = reqParams.DoReqAny(&token); err != nil {
+		return nil, err
+	}
+	if token.Token == "" {
+		return nil, errors.New("login failed: empty response from AuthN server")
+	}
+	return token, nil
+}
+
 func RegisterCluster(bp api.BaseParams, cluSpec CluACL) error {
 	msg := cos.MustMarshal(cluSpec)
 	bp.Method = http.MethodPost
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,6 +24,7 @@ type (
 		Net          NetConf       `json:"net"`
 		Server       ServerConf    `json:"auth"`
 		Timeout      TimeoutConf   `json:"timeout"`
+		OIDC         OIDCConf      `json:"oidc"`
 	}
 	LogConf struct {
 		Dir   string `json:"dir"`
@@ -40,10 +42,33 @@ type (
 	ServerConf struct {
 		Secret       string       `json:"secret"`
 		ExpirePeriod cos.Duration `json:"expiration_time"`
+		// token signing method: "HS256" (default, shared secret), "RS256", or "ES256";
+		// with the latter two, AIS gateways verify tokens using AuthN's public keys (see URLPathJWKS)
+		SigningMethod string `json:"signing_method,omitempty"`
+		// how often to generate new signing key (RS256 and ES256 only; zero - never)
+		KeyRotation cos.Duration `json:"key_rotation,omitempty"`
 	}
 	TimeoutConf struct {
 		Default cos.Duration `json:"default_timeout"`
 	}
+	// external OpenID Connect identity provider (IdP): users log in with IdP-issued
+	// ID tokens that AuthN validates and exchanges for its own (see URLPathOIDC)
+	OIDCConf struct {
+		// e.g. "https://idp.example.com/realms/ais" (must match "iss" claim); empty - disabled
+		Issuer string `json:"issuer"`
+		// IdP's public keys; if omitted, discovered via "<issuer>/.well-known/openid-configuration"
+		JWKSURL string `json:"jwks_url,omitempty"`
+		// AuthN's client ID at the IdP (must be listed in "aud" claim)
+		ClientID string `json:"client_id"`
+		// claim that contains user ID (default: "sub"), e.g. "email" or "preferred_username"
+		UserClaim string `json:"user_claim,omitempty"`
+		// claim that contains user groups (default: "groups")
+		GroupsClaim string `json:"groups_claim,omitempty"`
+		// IdP group => existing AuthN roles
+		GroupRoles map[string][]string `json:"group_roles,omitempty"`
+		// roles assigned to all IdP users
+		DefaultRoles []string `json:"default_roles,omitempty"`
+	}
 	ConfigToUpdate struct {
 		Server *ServerConfToSet `json:"auth"`
 	}
@@ -58,6 +83,19 @@ type (
 	}
 )
 
+const (
+	// JSON Web Key Set (RFC 7517): public keys to verify RS256 and ES256 signed tokens
+	URLPathJWKS = "/.well-known/jwks.json"
+
+	// POST {"id_token": ..., "cluster_id": ...} to log in with an ID token issued by OIDC provider
+	URLPathOIDC = "/v1/oidc/login"
+)
+
+const (
+	DefaultUserClaim   = "sub"
+	DefaultGroupsClaim = "groups"
+)
+
 var (
 	_ jsp.Opts = (*Config)(nil)
 
@@ -74,6 +112,34 @@ func (c *Config) Secret() (secret string) {
 	return
 }
 
+func (c *Config) SigningMethod() (method string) {
+	c.RLock()
+	method = c.Server.SigningMethod
+	c.RUnlock()
+	if method == "" {
+		method = "HS256"
+	}
+	return
+}
+
+func (c *OIDCConf) Enabled() bool { return c.Issuer != "" }
+
+func (c *OIDCConf) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if !strings.HasPrefix(c.Issuer, "https://") && !strings.HasPrefix(c.Issuer, "http://") {
+		return fmt.Errorf("invalid oidc.issuer %q (expecting URL)", c.Issuer)
+	}
+	if c.ClientID == "" {
+		return errors.New("oidc.client_id is required")
+	}
+	if len(c.GroupRoles) == 0 && len(c.DefaultRoles) == 0 {
+		return errors.New("oidc: at least one of group_roles, default_roles must be defined")
+	}
+	return nil
+}
+
 func (c *Config) Verbose() bool {
 	level, err := strconv.Atoi(c.Log.Level)
 	debug.AssertNoErr(err)
@@ -41,6 +41,7 @@ type (
 		Password  string         `json:"password"`
 		ExpiresIn *time.Duration `json:"expires_in"`
 		ClusterID string         `json:"cluster_id"`
+		IDToken   string         `json:"id_token,omitempty"` // OIDC login (see URLPathOIDC)
 	}
 	RegisteredClusters struct {
 		M map[string]*CluACL `json:"clusters,omitempty"`
@@ -169,6 +169,21 @@ func GetRemoteAIS(bp BaseParams) (remais meta.RemAisVec, err error) {
 	return
 }
 
+// cluster-wide storage usage of the buckets and namespaces that have quotas
+// (see cmn.QuotaConf; note that proxies refresh the usage periodically)
+func GetQuotaUsage(bp BaseParams) (all cmn.AllQuotaUsages, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatQuota}}
+	}
+	_, err = reqParams.DoReqAny(&all)
+	FreeRp(reqParams)
+	return
+}
+
 // JoinCluster add a node to a cluster.
 func JoinCluster(bp BaseParams, nodeInfo *meta.Snode) (rebID, sid string, err error) {
 	bp.Method = http.MethodPost
@@ -60,6 +60,20 @@ func GetClusterStats(bp BaseParams) (res stats.Cluster, err error) {
 	return
 }
 
+// cluster-wide per-bucket and per-user GET and PUT metrics (see cmn.MetricsConf)
+func GetBckStats(bp BaseParams) (res stats.BckStats, err error) {
+	bp.Method = http.MethodGet
+	reqParams := AllocRp()
+	{
+		reqParams.BaseParams = bp
+		reqParams.Path = apc.URLPathClu.S
+		reqParams.Query = url.Values{apc.QparamWhat: []string{apc.WhatBckStats}}
+	}
+	_, err = reqParams.DoReqAny(&res)
+	FreeRp(reqParams)
+	return
+}
+
 //
 // node ----------------------
 //
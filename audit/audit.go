@@ -0,0 +1,321 @@
+// Package audit provides an append-only, size-limited audit trail (JSON lines) of
+// administrative and data-mutating operations: who did what, to which entity, when,
+// and with what outcome.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package audit
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Files (in the `audit` subdirectory of the node's log directory):
+// - audit.log                         - current (being appended)
+// - audit.<yyyymmdd-hhmmss.µs>.log    - rotated upon reaching `audit.max_size`
+// The oldest rotated files get removed once the total exceeds `audit.max_total`
+// (see cmn.AuditConf).
+
+const (
+	Subdir = "audit"
+
+	DfltLimit = 1000 // max number of records returned by a single query
+	MaxLimit  = 100_000
+
+	fcurrent = "audit.log"
+	fprefix  = "audit."
+	fsuffix  = ".log"
+	tsLayout = "20060102-150405.000000"
+
+	maxLineSize = 64 * cos.KiB
+)
+
+type (
+	Record struct {
+		Time   int64  `json:"time,string"`      // unix nanoseconds
+		Node   string `json:"node"`             // ID of the recording node
+		User   string `json:"user,omitempty"`   // AuthN user ID (empty when AuthN is disabled)
+		Method string `json:"method"`           // HTTP method
+		Action string `json:"action,omitempty"` // apc.ActMsg action, if any; otherwise, the last URL path item
+		Entity string `json:"entity"`           // bucket, object, node, or "cluster"
+		Name   string `json:"name,omitempty"`   // apc.ActMsg name, if any
+		Err    string `json:"err,omitempty"`    // failure
+		Status int    `json:"status"`           // HTTP status
+		// user ID could not be verified (targets only: redirect URL without a valid proxy signature)
+		Unverified bool `json:"unverified,omitempty"`
+	}
+
+	// (all fields are optional)
+	Query struct {
+		User   string // exact match
+		Action string // ditto
+		Since  int64  // unix nanoseconds
+		Limit  int    // max number of (the most recent) records (zero value defaults to DfltLimit)
+	}
+
+	alog struct {
+		file *os.File
+		dir  string
+		node string
+		size int64
+		mu   sync.Mutex
+	}
+)
+
+var g alog
+
+// is called once at startup
+func Init(logDir, nodeID string) {
+	g.dir = filepath.Join(logDir, Subdir)
+	g.node = nodeID
+}
+
+func Stop() {
+	g.mu.Lock()
+	if g.file != nil {
+		g.file.Sync()
+		cos.Close(g.file)
+		g.file = nil
+	}
+	g.mu.Unlock()
+}
+
+// Add a record; the caller is expected to check `audit.enabled`.
+// Records are appended (and, upon rotation, removed) in the order of calls.
+func Add(rec *Record) {
+	if rec.Time == 0 {
+		rec.Time = time.Now().UnixNano()
+	}
+	if rec.Node == "" {
+		rec.Node = g.node
+	}
+	b, err := jsoniter.Marshal(rec)
+	if err != nil {
+		nlog.Errorln("audit: failed to marshal", rec.Method, rec.Entity, err)
+		return
+	}
+	b = append(b, '\n')
+
+	conf := &cmn.GCO.Get().Audit
+	g.mu.Lock()
+	err = g.write(b, conf)
+	g.mu.Unlock()
+	if err != nil {
+		nlog.Errorln("audit:", err)
+	}
+}
+
+// Get returns the most recent records (newest first) that match the query.
+func Get(q *Query) ([]*Record, error) {
+	limit := q.limit()
+	g.mu.Lock()
+	fnames, err := g.list()
+	g.mu.Unlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Record{}, nil
+		}
+		return nil, err
+	}
+	out := make([]*Record, 0, min(limit, 128))
+	for _, fname := range fnames { // newest first
+		if out, err = read(filepath.Join(g.dir, fname), q, out, limit); err != nil {
+			return nil, err
+		}
+		if len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Merge (e.g., cluster-wide) query results: newest first, up to the limit.
+func Merge(all [][]*Record, q *Query) []*Record {
+	var n int
+	for _, recs := range all {
+		n += len(recs)
+	}
+	out := make([]*Record, 0, n)
+	for _, recs := range all {
+		out = append(out, recs...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Time > out[j].Time })
+	if limit := q.limit(); len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+///////////
+// Query //
+///////////
+
+func (q *Query) limit() int {
+	if q.Limit <= 0 {
+		return DfltLimit
+	}
+	return min(q.Limit, MaxLimit)
+}
+
+func (q *Query) match(rec *Record) bool {
+	return rec.Time >= q.Since &&
+		(q.User == "" || q.User == rec.User) &&
+		(q.Action == "" || q.Action == rec.Action)
+}
+
+//////////
+// alog //
+//////////
+
+// under lock
+func (l *alog) write(b []byte, conf *cmn.AuditConf) error {
+	if l.dir == "" {
+		return errors.New("not initialized")
+	}
+	if l.file == nil {
+		if err := l.open(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(b)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if l.size >= conf.SizeLimit() {
+		err = l.rotate(conf)
+	}
+	return err
+}
+
+func (l *alog) open() error {
+	if err := cos.CreateDir(l.dir); err != nil {
+		return err
+	}
+	fh, err := os.OpenFile(filepath.Join(l.dir, fcurrent), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	finfo, err := fh.Stat()
+	if err != nil {
+		cos.Close(fh)
+		return err
+	}
+	l.file, l.size = fh, finfo.Size()
+	return nil
+}
+
+func (l *alog) rotate(conf *cmn.AuditConf) error {
+	l.file.Sync()
+	cos.Close(l.file)
+	l.file = nil
+
+	rotated := fprefix + time.Now().Format(tsLayout) + fsuffix
+	if err := os.Rename(filepath.Join(l.dir, fcurrent), filepath.Join(l.dir, rotated)); err != nil {
+		return fmt.Errorf("failed to rotate: %w", err)
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+	return l.cleanup(conf)
+}
+
+// remove the oldest rotated files to keep the total below the configured max
+func (l *alog) cleanup(conf *cmn.AuditConf) error {
+	fnames, err := l.list()
+	if err != nil {
+		return err
+	}
+	var (
+		tot   = l.size
+		limit = conf.TotalLimit()
+		sizes = make([]int64, len(fnames))
+	)
+	for i, fname := range fnames[1:] { // skipping current
+		if finfo, err := os.Stat(filepath.Join(l.dir, fname)); err == nil {
+			sizes[i+1] = finfo.Size()
+			tot += sizes[i+1]
+		}
+	}
+	for i := len(fnames) - 1; i > 0 && tot > limit; i-- {
+		if err := cos.RemoveFile(filepath.Join(l.dir, fnames[i])); err != nil {
+			return err
+		}
+		tot -= sizes[i]
+	}
+	return nil
+}
+
+// current file (if exists) followed by the rotated ones, newest to oldest
+func (l *alog) list() ([]string, error) {
+	dentries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		current bool
+		fnames  = make([]string, 0, len(dentries)+1)
+	)
+	for _, dent := range dentries {
+		name := dent.Name()
+		switch {
+		case !dent.Type().IsRegular():
+		case name == fcurrent:
+			current = true
+		case strings.HasPrefix(name, fprefix) && strings.HasSuffix(name, fsuffix):
+			fnames = append(fnames, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(fnames)))
+	if current {
+		fnames = append([]string{fcurrent}, fnames...)
+	}
+	return fnames, nil
+}
+
+// append matching records (newest first)
+func read(fqn string, q *Query, out []*Record, limit int) ([]*Record, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		if os.IsNotExist(err) { // removed in the meantime
+			return out, nil
+		}
+		return out, err
+	}
+	defer cos.Close(fh)
+
+	var (
+		recs    []*Record
+		scanner = bufio.NewScanner(fh)
+	)
+	scanner.Buffer(make([]byte, 0, 4*cos.KiB), maxLineSize)
+	for scanner.Scan() {
+		rec := &Record{}
+		if err := jsoniter.Unmarshal(scanner.Bytes(), rec); err != nil {
+			continue // (e.g., partially written)
+		}
+		if q.match(rec) {
+			recs = append(recs, rec)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return out, fmt.Errorf("failed to read %s: %w", fqn, err)
+	}
+	for i := len(recs) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, recs[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+// Package audit_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package audit_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestAudit(t *testing.T) {
+	const num = 20_000 // (~3MB)
+	var (
+		dir    = t.TempDir()
+		config = cmn.GCO.BeginUpdate()
+	)
+	config.Audit = cmn.AuditConf{Enabled: true, MaxSize: cos.MiB, MaxTotal: 2 * cos.MiB}
+	cmn.GCO.CommitUpdate(config)
+
+	audit.Init(dir, "p1")
+	defer audit.Stop()
+
+	recs, err := audit.Get(&audit.Query{})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(recs) == 0, "expected no records, got %d", len(recs))
+
+	for i := range num {
+		rec := &audit.Record{
+			Time:   int64(i + 1),
+			User:   "alice",
+			Method: http.MethodDelete,
+			Action: apc.ActDestroyBck,
+			Entity: "ais://bck-" + strconv.Itoa(i),
+			Status: http.StatusOK,
+		}
+		if i%2 == 1 {
+			rec.User, rec.Method, rec.Action, rec.Status = "bob", http.MethodPost, apc.ActCreateBck, http.StatusForbidden
+		}
+		audit.Add(rec)
+	}
+
+	// rotated and size-limited
+	fnames, err := filepath.Glob(filepath.Join(dir, audit.Subdir, "audit.*"))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(fnames) >= 2, "expected rotation, got %v", fnames)
+	var tot int64
+	for _, fname := range fnames {
+		finfo, err := os.Stat(fname)
+		tassert.CheckFatal(t, err)
+		tot += finfo.Size()
+	}
+	tassert.Errorf(t, tot <= 2*cos.MiB, "total size %d exceeds the configured max", tot)
+
+	// query: newest first, across files
+	recs, err = audit.Get(&audit.Query{User: "bob", Limit: 10_000})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) > 1000 && len(recs) < num/2, "expected some (but not all) records, got %d", len(recs))
+	tassert.Errorf(t, recs[0].Time == num && recs[0].Node == "p1" && recs[0].Status == http.StatusForbidden,
+		"unexpected most recent %+v", recs[0])
+	for i := 1; i < len(recs); i++ {
+		tassert.Fatalf(t, recs[i].Time == recs[i-1].Time-2, "expected consecutive (newest first): %d, %d",
+			recs[i-1].Time, recs[i].Time)
+	}
+
+	recs, err = audit.Get(&audit.Query{Action: apc.ActDestroyBck, Since: num - 5})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(recs) == 3, "expected 3 records, got %d", len(recs))
+
+	// cluster-wide
+	recs, _ = audit.Get(&audit.Query{Limit: 3})
+	other := []*audit.Record{{Time: num - 1, Node: "t1"}, {Time: num + 1, Node: "t2"}}
+	merged := audit.Merge([][]*audit.Record{recs, other}, &audit.Query{Limit: 3})
+	tassert.Fatalf(t, len(merged) == 3, "expected 3 merged records, got %d", len(merged))
+	tassert.Errorf(t, merged[0].Node == "t2" && merged[1].Time == num && merged[2].Time == num-1,
+		"unexpected merged %+v, %+v, %+v", merged[0], merged[1], merged[2])
+}
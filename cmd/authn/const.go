@@ -17,6 +17,7 @@ const (
 	rolesCollection    = "role"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	keysCollection     = "key" // signing keys (RS256, ES256)
 
 	adminUserID   = "admin"
 	adminUserPass = "admin"
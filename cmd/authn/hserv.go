@@ -85,7 +85,9 @@ func (h *hserv) registerPublicHandlers() {
 	h.registerHandler(apc.URLPathTokens.S, h.tokenHandler)
 	h.registerHandler(apc.URLPathClusters.S, h.clusterHandler)
 	h.registerHandler(apc.URLPathRoles.S, h.roleHandler)
-	h.registerHandler(apc.URLPathDae.S, configHandler)
+	h.registerHandler(apc.URLPathDae.S, h.configHandler)
+	h.registerHandler(authn.URLPathJWKS, h.jwksHandler)
+	h.registerHandler(authn.URLPathOIDC, h.oidcLogin)
 }
 
 func (h *hserv) userHandler(w http.ResponseWriter, r *http.Request) {
@@ -140,8 +142,7 @@ func (h *hserv) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 		cmn.WriteErrMsg(w, r, "empty token")
 		return
 	}
-	secret := Conf.Secret()
-	if _, err := tok.DecryptToken(msg.Token, secret); err != nil {
+	if _, err := tok.DecryptToken(msg.Token, h.mgr.verifyKeys()); err != nil {
 		cmn.WriteErr(w, r, err)
 		return
 	}
@@ -153,7 +154,7 @@ func (h *hserv) httpUserDel(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = validateAdminPerms(w, r); err != nil {
+	if err = h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 	if err := h.mgr.delUser(apiItems[0]); err != nil {
@@ -180,7 +181,7 @@ func (h *hserv) httpUserPut(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = validateAdminPerms(w, r); err != nil {
+	if err = h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 	var (
@@ -203,7 +204,7 @@ func (h *hserv) httpUserPut(w http.ResponseWriter, r *http.Request) {
 
 // Adds h new user to user list
 func (h *hserv) userAdd(w http.ResponseWriter, r *http.Request) {
-	if err := validateAdminPerms(w, r); err != nil {
+	if err := h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 	info := &authn.User{}
@@ -264,14 +265,13 @@ func (h *hserv) httpUserGet(w http.ResponseWriter, r *http.Request) {
 
 // Checks if the request header contains valid admin credentials.
 // (admin is created at deployment time and cannot be modified via API)
-func validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
+func (h *hserv) validateAdminPerms(w http.ResponseWriter, r *http.Request) error {
 	token, err := tok.ExtractToken(r.Header)
 	if err != nil {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
 	}
-	secret := Conf.Secret()
-	tk, err := tok.DecryptToken(token, secret)
+	tk, err := tok.DecryptToken(token, h.mgr.verifyKeys())
 	if err != nil {
 		cmn.WriteErr(w, r, err, http.StatusUnauthorized)
 		return err
@@ -342,7 +342,7 @@ func (h *hserv) httpSrvPost(w http.ResponseWriter, r *http.Request) {
 	if _, err := parseURL(w, r, 0, apc.URLPathClusters.L); err != nil {
 		return
 	}
-	if err := validateAdminPerms(w, r); err != nil {
+	if err := h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 	cluConf := &authn.CluACL{}
@@ -359,7 +359,7 @@ func (h *hserv) httpSrvPut(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err := validateAdminPerms(w, r); err != nil {
+	if err := h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 	cluConf := &authn.CluACL{}
@@ -377,7 +377,7 @@ func (h *hserv) httpSrvDelete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = validateAdminPerms(w, r); err != nil {
+	if err = h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 
@@ -483,7 +483,7 @@ func (h *hserv) httpRoleDel(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = validateAdminPerms(w, r); err != nil {
+	if err = h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 
@@ -498,7 +498,7 @@ func (h *hserv) httpRolePost(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = validateAdminPerms(w, r); err != nil {
+	if err = h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 	info := &authn.Role{}
@@ -515,7 +515,7 @@ func (h *hserv) httpRolePut(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
-	if err = validateAdminPerms(w, r); err != nil {
+	if err = h.validateAdminPerms(w, r); err != nil {
 		return
 	}
 
@@ -0,0 +1,258 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"crypto"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Asymmetric (RS256, ES256) signing keys:
+// - generated on demand, persisted in the local DB, and rotated every `key_rotation`;
+// - retired keys are still used to verify tokens (and are published via JWKS) until
+//   all tokens signed with them expire - that is, for `expiration_time` after retirement.
+// With the default HS256, tokens are signed and verified using the shared secret.
+
+const keyCheckIval = time.Hour
+
+type (
+	// as stored in the DB
+	storedKey struct {
+		KID     string `json:"kid"`
+		Method  string `json:"method"`
+		DER     []byte `json:"der"` // PKCS #8
+		Created int64  `json:"created,string"`
+		Retired int64  `json:"retired,string"` // zero: current
+	}
+	keyRing struct {
+		current *tok.SigningKey
+		public  map[string]crypto.PublicKey // current and retired (not yet expired) keys
+		stored  []*storedKey                // ditto, in the order of creation
+		jwks    []byte                      // (cached) JWK set
+		mu      sync.RWMutex
+	}
+)
+
+// load persisted keys; generate the first one if need be
+func (m *mgr) initKeys() error {
+	method := Conf.SigningMethod()
+	if err := tok.ValidateSigningMethod(method); err != nil {
+		return err
+	}
+	if !tok.IsAsymmetric(method) {
+		m.keys.setJWKS() // (empty)
+		return nil
+	}
+	recs, err := m.db.GetAll(keysCollection, "")
+	if err != nil {
+		return err
+	}
+	stored := make([]*storedKey, 0, len(recs)+1)
+	for _, str := range recs {
+		k := &storedKey{}
+		if err := jsoniter.Unmarshal([]byte(str), k); err != nil {
+			return err
+		}
+		stored = append(stored, k)
+	}
+	if err := m.keys.load(stored, method); err != nil {
+		return err
+	}
+	m.deleteKeys(m.keys.prune(time.Now()))
+	if m.keys.current == nil {
+		if err := m.rotateKey(method); err != nil {
+			return err
+		}
+	}
+	go m.housekeepKeys(method)
+	return nil
+}
+
+// signing key: the current private key or the shared secret (HS256)
+func (m *mgr) signingKey() *tok.SigningKey {
+	m.keys.mu.RLock()
+	sk := m.keys.current
+	m.keys.mu.RUnlock()
+	if sk == nil {
+		sk = tok.NewHMACKey(Conf.Secret())
+	}
+	return sk
+}
+
+// verification keys: with RS256 and ES256, HMAC-signed tokens are no longer accepted
+func (m *mgr) verifyKeys() *tok.Keys {
+	if !tok.IsAsymmetric(Conf.SigningMethod()) {
+		return &tok.Keys{Secret: Conf.Secret()}
+	}
+	m.keys.mu.RLock()
+	keys := &tok.Keys{Public: m.keys.public}
+	m.keys.mu.RUnlock()
+	return keys
+}
+
+// generate and persist new key; retire the current one
+func (m *mgr) rotateKey(method string) error {
+	sk, err := tok.GenerateKey(method)
+	if err != nil {
+		return err
+	}
+	der, err := sk.MarshalPrivate()
+	if err != nil {
+		return err
+	}
+	var (
+		now = time.Now().UnixNano()
+		nk  = &storedKey{KID: sk.KID, Method: method, DER: der, Created: now}
+	)
+	if err := m.db.Set(keysCollection, nk.KID, nk); err != nil {
+		return err
+	}
+
+	m.keys.mu.Lock()
+	defer m.keys.mu.Unlock()
+	for _, k := range m.keys.stored {
+		if k.Retired == 0 {
+			k.Retired = now
+			if err := m.db.Set(keysCollection, k.KID, k); err != nil {
+				nlog.Errorln("failed to retire signing key", k.KID, err)
+			}
+		}
+	}
+	// copy-on-write (see verifyKeys)
+	public := make(map[string]crypto.PublicKey, len(m.keys.public)+1)
+	for kid, pub := range m.keys.public {
+		public[kid] = pub
+	}
+	public[sk.KID] = sk.Public()
+	m.keys.public = public
+	m.keys.stored = append(m.keys.stored, nk)
+	m.keys.current = sk
+	m.keys.setJWKS()
+
+	nlog.Infoln("new", method, "signing key", sk.KID)
+	return nil
+}
+
+// rotate (when configured) and remove expired keys
+func (m *mgr) housekeepKeys(method string) {
+	for {
+		time.Sleep(keyCheckIval)
+		var created time.Time
+		Conf.RLock()
+		rotation := time.Duration(Conf.Server.KeyRotation)
+		Conf.RUnlock()
+		m.keys.mu.RLock()
+		if l := len(m.keys.stored); l > 0 {
+			created = time.Unix(0, m.keys.stored[l-1].Created)
+		}
+		m.keys.mu.RUnlock()
+		if rotation > 0 && time.Since(created) > rotation {
+			if err := m.rotateKey(method); err != nil {
+				nlog.Errorln("failed to rotate signing key:", err)
+			}
+		}
+		m.deleteKeys(m.keys.prune(time.Now()))
+	}
+}
+
+func (m *mgr) deleteKeys(expired []*storedKey) {
+	for _, k := range expired {
+		nlog.Infoln("removing expired signing key", k.KID)
+		if err := m.db.Delete(keysCollection, k.KID); err != nil {
+			nlog.Errorln(err)
+		}
+	}
+}
+
+// GET /.well-known/jwks.json (public, no authentication required)
+func (h *hserv) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.WriteErr405(w, r, http.MethodGet)
+		return
+	}
+	h.mgr.keys.mu.RLock()
+	b := h.mgr.keys.jwks
+	h.mgr.keys.mu.RUnlock()
+	w.Header().Set(cos.HdrContentType, cos.ContentJSON)
+	writeBytes(w, b, "jwks")
+}
+
+/////////////
+// keyRing //
+/////////////
+
+func (kr *keyRing) load(stored []*storedKey, method string) error {
+	sort.Slice(stored, func(i, j int) bool { return stored[i].Created < stored[j].Created })
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.public = make(map[string]crypto.PublicKey, len(stored)+1)
+	for _, k := range stored {
+		sk, err := tok.ParseSigningKey(k.Method, k.KID, k.DER)
+		if err != nil {
+			return err
+		}
+		kr.public[k.KID] = sk.Public()
+		// NOTE: when the signing method changes, the (old) current key remains valid
+		// until the first rotation
+		if k.Retired == 0 && k.Method == method {
+			kr.current = sk
+		}
+	}
+	kr.stored = stored
+	kr.setJWKS()
+	return nil
+}
+
+// remove keys that were retired more than token expiration time ago
+func (kr *keyRing) prune(now time.Time) (expired []*storedKey) {
+	Conf.RLock()
+	expire := time.Duration(Conf.Server.ExpirePeriod)
+	Conf.RUnlock()
+	if expire == 0 {
+		expire = foreverTokenTime
+	}
+	kr.mu.Lock()
+	kept := make([]*storedKey, 0, len(kr.stored))
+	for _, k := range kr.stored {
+		if k.Retired != 0 && now.Sub(time.Unix(0, k.Retired)) > expire {
+			expired = append(expired, k)
+			continue
+		}
+		kept = append(kept, k)
+	}
+	if len(expired) > 0 {
+		public := make(map[string]crypto.PublicKey, len(kept))
+		for _, k := range kept {
+			public[k.KID] = kr.public[k.KID]
+		}
+		kr.public, kr.stored = public, kept
+		kr.setJWKS()
+	}
+	kr.mu.Unlock()
+	return expired
+}
+
+// (must be called under lock)
+func (kr *keyRing) setJWKS() {
+	jwks := tok.JWKS{Keys: make([]tok.JWK, 0, len(kr.stored))}
+	for _, k := range kr.stored {
+		jwk, err := tok.NewJWK(k.KID, kr.public[k.KID])
+		if err != nil {
+			nlog.Errorln(err)
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	kr.jwks = cos.MustMarshal(jwks)
+}
@@ -75,6 +75,9 @@ func main() {
 	if val := os.Getenv(secretKeyPodEnv); val != "" {
 		Conf.Server.Secret = val
 	}
+	if err := Conf.OIDC.Validate(); err != nil {
+		cos.ExitLogf("Invalid configuration %q: %v", configPath, err)
+	}
 	if err := updateLogOptions(); err != nil {
 		cos.ExitLogf("Failed to set up logger: %v", err)
 	}
@@ -27,6 +27,8 @@ type mgr struct {
 	clientH   *http.Client
 	clientTLS *http.Client
 	db        kvdb.Driver
+	keys      keyRing
+	oidc      oidcProvider
 }
 
 var (
@@ -49,7 +51,10 @@ func newMgr(driver kvdb.Driver) (m *mgr, err error) {
 		db: driver,
 	}
 	m.clientH, m.clientTLS = cmn.NewDefaultClients(time.Duration(Conf.Timeout.Default))
-	err = initializeDB(driver)
+	if err = initializeDB(driver); err != nil {
+		return
+	}
+	err = m.initKeys()
 	return
 }
 
@@ -366,22 +371,26 @@ func (m *mgr) delCluster(cluID string) error {
 // Token includes user ID, permissions, and token expiration time.
 // If a new token was generated then it sends the proxy a new valid token list
 func (m *mgr) issueToken(userID, pwd string, msg *authn.LoginMsg) (string, error) {
-	var (
-		err     error
-		expires time.Time
-		token   string
-		uInfo   = &authn.User{}
-		cid     string
-	)
-
-	err = m.db.Get(usersCollection, userID, uInfo)
-	if err != nil {
+	uInfo := &authn.User{}
+	if err := m.db.Get(usersCollection, userID, uInfo); err != nil {
 		nlog.Errorln(err)
 		return "", errInvalidCredentials
 	}
 	if !isSamePassword(pwd, uInfo.Password) {
 		return "", errInvalidCredentials
 	}
+	return m.userToken(uInfo, msg)
+}
+
+// Generates a token for an already authenticated user (local or OIDC)
+func (m *mgr) userToken(uInfo *authn.User, msg *authn.LoginMsg) (string, error) {
+	var (
+		err     error
+		expires time.Time
+		token   string
+		cid     string
+		userID  = uInfo.ID
+	)
 	if !uInfo.IsAdmin() {
 		if msg.ClusterID == "" {
 			return "", fmt.Errorf("Couldn't issue token for %q: cluster ID not set", userID)
@@ -406,6 +415,7 @@ func (m *mgr) issueToken(userID, pwd string, msg *authn.LoginMsg) (string, error
 	}
 
 	// generate token
+	sk := m.signingKey()
 	Conf.RLock()
 	defer Conf.RUnlock()
 	issued := time.Now()
@@ -422,10 +432,10 @@ func (m *mgr) issueToken(userID, pwd string, msg *authn.LoginMsg) (string, error
 	// when it expires and credentials to log in AWS, GCP etc.
 	// If a user is a super user, it is enough to pass only isAdmin marker
 	if uInfo.IsAdmin() {
-		token, err = tok.IssueAdminJWT(expires, userID, Conf.Server.Secret)
+		token, err = tok.IssueAdminJWT(expires, userID, sk)
 	} else {
 		m.fixClusterIDs(uInfo.ClusterACLs)
-		token, err = tok.IssueJWT(expires, userID, uInfo.BucketACLs, uInfo.ClusterACLs, Conf.Server.Secret)
+		token, err = tok.IssueJWT(expires, userID, uInfo.BucketACLs, uInfo.ClusterACLs, sk)
 	}
 	return token, err
 }
@@ -474,9 +484,9 @@ func (m *mgr) generateRevokedTokenList() ([]string, error) {
 
 	now := time.Now()
 	revokeList := make([]string, 0, len(tokens))
-	secret := Conf.Secret()
+	keys := m.verifyKeys()
 	for _, token := range tokens {
-		tk, err := tok.DecryptToken(token, secret)
+		tk, err := tok.DecryptToken(token, keys)
 		if err != nil {
 			m.db.Delete(revokedCollection, token)
 			continue
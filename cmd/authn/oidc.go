@@ -0,0 +1,251 @@
+// Package authn is authentication server for AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package main
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/authn"
+	"github.com/NVIDIA/aistore/cmd/authn/tok"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// OIDC login: a user presents an ID token issued by the configured identity provider (IdP);
+// AuthN validates the token (signature, issuer, audience, expiration), maps the user's
+// IdP groups to existing AuthN roles, and issues its own (regular) token.
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	oidcRefetchIval   = 10 * time.Second // min interval between (on-demand) refetches of IdP keys
+)
+
+var errOIDCDisabled = errors.New("OIDC login is not configured")
+
+type (
+	oidcProvider struct {
+		public  map[string]crypto.PublicKey // IdP keys
+		issuer  string                      // keys were fetched for
+		fetched time.Time
+		mu      sync.Mutex
+	}
+	// (subset of) OpenID provider metadata
+	oidcDiscovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+)
+
+// POST /v1/oidc/login
+func (h *hserv) oidcLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		cmn.WriteErr405(w, r, http.MethodPost)
+		return
+	}
+	msg := &authn.LoginMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if msg.IDToken == "" {
+		cmn.WriteErrMsg(w, r, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	tokenString, err := h.mgr.issueOIDCToken(msg)
+	if err != nil {
+		nlog.Errorln("OIDC login failed:", err)
+		status := http.StatusUnauthorized
+		if cos.IsErrNotFound(err) {
+			status = http.StatusNotFound
+		}
+		cmn.WriteErr(w, r, err, status)
+		return
+	}
+	repl := fmt.Sprintf(`{"token": %q}`, tokenString)
+	writeBytes(w, []byte(repl), "auth")
+}
+
+func (m *mgr) issueOIDCToken(msg *authn.LoginMsg) (string, error) {
+	Conf.RLock()
+	conf := Conf.OIDC
+	Conf.RUnlock()
+	if !conf.Enabled() {
+		return "", errOIDCDisabled
+	}
+	claims, err := m.verifyIDToken(&conf, msg.IDToken)
+	if err != nil {
+		return "", err
+	}
+	uInfo, err := m.oidcUser(&conf, claims)
+	if err != nil {
+		return "", err
+	}
+	return m.userToken(uInfo, msg)
+}
+
+func (m *mgr) verifyIDToken(conf *authn.OIDCConf, idToken string) (map[string]any, error) {
+	keys, err := m.oidcKeys(conf, false /*refetch*/)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := tok.ParseClaims(idToken, keys)
+	if err != nil && errors.Is(err, tok.ErrUnknownKey) {
+		// IdP may have rotated its keys
+		if keys, err = m.oidcKeys(conf, true); err != nil {
+			return nil, err
+		}
+		claims, err = tok.ParseClaims(idToken, keys)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if !claims.VerifyIssuer(conf.Issuer, true) {
+		return nil, fmt.Errorf("invalid ID token: unexpected issuer %v", claims["iss"])
+	}
+	if !claims.VerifyAudience(conf.ClientID, true) {
+		return nil, fmt.Errorf("invalid ID token: audience %v does not include %q", claims["aud"], conf.ClientID)
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("invalid ID token: expired or missing expiration time")
+	}
+	return claims, nil
+}
+
+// user ID from the configured claim, roles from IdP groups
+func (m *mgr) oidcUser(conf *authn.OIDCConf, claims map[string]any) (*authn.User, error) {
+	userClaim := cos.Either(conf.UserClaim, authn.DefaultUserClaim)
+	userID, _ := claims[userClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("invalid ID token: missing %q claim", userClaim)
+	}
+	var (
+		groups = claimStrings(claims[cos.Either(conf.GroupsClaim, authn.DefaultGroupsClaim)])
+		roles  = make([]string, 0, len(conf.DefaultRoles)+len(groups))
+		added  = make(cos.StrSet, len(roles))
+	)
+	addRole := func(role string) {
+		if added.Contains(role) {
+			return
+		}
+		added.Add(role)
+		if _, err := m.db.GetString(rolesCollection, role); err != nil {
+			nlog.Warningf("OIDC user %q: role %q does not exist - skipping", userID, role)
+			return
+		}
+		roles = append(roles, role)
+	}
+	for _, role := range conf.DefaultRoles {
+		addRole(role)
+	}
+	for _, group := range groups {
+		for _, role := range conf.GroupRoles[group] {
+			addRole(role)
+		}
+	}
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("not authorized: OIDC user %q (groups %v) has no roles", userID, groups)
+	}
+	return &authn.User{ID: userID, Roles: roles}, nil
+}
+
+// groups claim: either a list of strings or a single string
+func claimStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		l := make([]string, 0, len(v))
+		for _, s := range v {
+			if s, ok := s.(string); ok {
+				l = append(l, s)
+			}
+		}
+		return l
+	default:
+		return nil
+	}
+}
+
+//
+// IdP keys
+//
+
+// NOTE: non-nil (possibly empty) map of public keys - HMAC-signed ID tokens are never accepted
+func (m *mgr) oidcKeys(conf *authn.OIDCConf, refetch bool) (*tok.Keys, error) {
+	o := &m.oidc
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.public != nil && o.issuer == conf.Issuer {
+		if !refetch || time.Since(o.fetched) < oidcRefetchIval {
+			return &tok.Keys{Public: o.public}, nil
+		}
+	}
+	public, err := m.fetchOIDCKeys(conf)
+	if err != nil {
+		if o.public != nil && o.issuer == conf.Issuer {
+			nlog.Errorln(err)
+			return &tok.Keys{Public: o.public}, nil // keep using cached keys
+		}
+		return nil, err
+	}
+	o.public, o.issuer, o.fetched = public, conf.Issuer, time.Now()
+	if Conf.Verbose() {
+		nlog.Infoln("fetched", len(public), "OIDC key(s), issuer", conf.Issuer)
+	}
+	return &tok.Keys{Public: public}, nil
+}
+
+func (m *mgr) fetchOIDCKeys(conf *authn.OIDCConf) (map[string]crypto.PublicKey, error) {
+	jwksURL := conf.JWKSURL
+	if jwksURL == "" {
+		disc := &oidcDiscovery{}
+		if err := m.getJSON(strings.TrimSuffix(conf.Issuer, "/")+oidcDiscoveryPath, disc); err != nil {
+			return nil, err
+		}
+		if disc.Issuer != conf.Issuer {
+			return nil, fmt.Errorf("OIDC discovery: issuer mismatch (%q vs configured %q)", disc.Issuer, conf.Issuer)
+		}
+		if disc.JWKSURI == "" {
+			return nil, fmt.Errorf("OIDC discovery: %q provides no jwks_uri", conf.Issuer)
+		}
+		jwksURL = disc.JWKSURI
+	}
+	jwks := &tok.JWKS{}
+	if err := m.getJSON(jwksURL, jwks); err != nil {
+		return nil, err
+	}
+	return jwks.PublicKeys()
+}
+
+func (m *mgr) getJSON(u string, v any) error {
+	client := m.clientH
+	if strings.HasPrefix(u, "https://") {
+		client = m.clientTLS
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer cos.Close(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	if err := jsoniter.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("GET %s: %w", u, err)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+// Package tok provides AuthN token (structure and methods)
+// for validation by AIS gateways
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tok
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Tokens are signed either with a shared secret (HS256) or with a private key (RS256, ES256).
+// In the latter case, AuthN keeps (and periodically rotates) the private keys,
+// while everyone else verifies tokens using the public ones, served by AuthN as a JWK set (RFC 7517).
+
+// supported signing methods
+const (
+	SigningHS256 = "HS256" // default
+	SigningRS256 = "RS256"
+	SigningES256 = "ES256"
+)
+
+const rsaKeyBits = 2048
+
+type (
+	// signs tokens (AuthN)
+	SigningKey struct {
+		method jwt.SigningMethod
+		key    any    // []byte (HMAC secret), *rsa.PrivateKey, or *ecdsa.PrivateKey
+		KID    string // key ID (asymmetric only)
+	}
+
+	// verifies tokens (AuthN and AIS gateways)
+	Keys struct {
+		Public map[string]crypto.PublicKey // key ID => public key (RS256, ES256)
+		Secret string                      // HS256 (when empty and Public is non-nil, HMAC-signed tokens are rejected)
+	}
+
+	// JSON Web Key (RFC 7517) - public RSA and EC keys only
+	JWK struct {
+		Kty string `json:"kty"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg,omitempty"`
+		Kid string `json:"kid"`
+		// RSA
+		N string `json:"n,omitempty"`
+		E string `json:"e,omitempty"`
+		// EC
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		Y   string `json:"y,omitempty"`
+	}
+	JWKS struct {
+		Keys []JWK `json:"keys"`
+	}
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+func IsAsymmetric(method string) bool { return method == SigningRS256 || method == SigningES256 }
+
+func ValidateSigningMethod(method string) error {
+	switch method {
+	case "", SigningHS256, SigningRS256, SigningES256:
+		return nil
+	default:
+		return fmt.Errorf("invalid signing method %q (expecting one of: %s, %s, %s)",
+			method, SigningHS256, SigningRS256, SigningES256)
+	}
+}
+
+////////////////
+// SigningKey //
+////////////////
+
+func NewHMACKey(secret string) *SigningKey {
+	return &SigningKey{method: jwt.SigningMethodHS256, key: []byte(secret)}
+}
+
+// generate new private key with a random ID
+func GenerateKey(method string) (sk *SigningKey, err error) {
+	sk = &SigningKey{KID: cos.CryptoRandS(16)}
+	switch method {
+	case SigningRS256:
+		sk.method = jwt.SigningMethodRS256
+		sk.key, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case SigningES256:
+		sk.method = jwt.SigningMethodES256
+		sk.key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		err = fmt.Errorf("cannot generate %q key (expecting %s or %s)", method, SigningRS256, SigningES256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// restore private key from its PKCS #8 (DER) form (see MarshalPrivate)
+func ParseSigningKey(method, kid string, der []byte) (*SigningKey, error) {
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	sk := &SigningKey{KID: kid, key: key}
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		sk.method = jwt.SigningMethodRS256
+	case *ecdsa.PrivateKey:
+		sk.method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("key %q: unsupported private key type %T", kid, key)
+	}
+	if sk.method.Alg() != method {
+		return nil, fmt.Errorf("key %q: signing method mismatch (%s vs %s)", kid, sk.method.Alg(), method)
+	}
+	return sk, nil
+}
+
+func (sk *SigningKey) Method() string { return sk.method.Alg() }
+
+func (sk *SigningKey) MarshalPrivate() ([]byte, error) { return x509.MarshalPKCS8PrivateKey(sk.key) }
+
+func (sk *SigningKey) Public() crypto.PublicKey {
+	if signer, ok := sk.key.(crypto.Signer); ok {
+		return signer.Public()
+	}
+	return nil
+}
+
+func (sk *SigningKey) sign(claims jwt.MapClaims) (string, error) {
+	t := jwt.NewWithClaims(sk.method, claims)
+	if sk.KID != "" {
+		t.Header["kid"] = sk.KID
+	}
+	return t.SignedString(sk.key)
+}
+
+//////////
+// Keys //
+//////////
+
+func (k *Keys) keyFunc(t *jwt.Token) (any, error) {
+	switch t.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if k.Secret == "" && k.Public != nil {
+			return nil, fmt.Errorf("unexpected signing method: %v (secret not configured)", t.Header["alg"])
+		}
+		return []byte(k.Secret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		kid, _ := t.Header["kid"].(string)
+		pub, ok := k.Public[kid]
+		if !ok {
+			return nil, fmt.Errorf("%w %q (%v)", ErrUnknownKey, kid, t.Header["alg"])
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+}
+
+//////////
+// JWKS //
+//////////
+
+func NewJWK(kid string, pub crypto.PublicKey) (jwk JWK, err error) {
+	jwk = JWK{Kid: kid, Use: "sig"}
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty, jwk.Alg = "RSA", SigningRS256
+		jwk.N = b64(pub.N.Bytes())
+		jwk.E = b64(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return jwk, fmt.Errorf("key %q: unsupported curve %s", kid, pub.Curve.Params().Name)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty, jwk.Alg, jwk.Crv = "EC", SigningES256, "P-256"
+		jwk.X = b64(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = b64(pub.Y.FillBytes(make([]byte, size)))
+	default:
+		return jwk, fmt.Errorf("key %q: unsupported public key type %T", kid, pub)
+	}
+	return jwk, nil
+}
+
+func (jwk *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := unb64(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := unb64(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %q: invalid RSA exponent", jwk.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("key %q: unsupported curve %q", jwk.Kid, jwk.Crv)
+		}
+		x, err := unb64(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := unb64(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("key %q: invalid EC point", jwk.Kid)
+		}
+		return pub, nil
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %q", jwk.Kid, jwk.Kty)
+	}
+}
+
+// skipping encryption and unsupported keys (third-party JWK sets may contain both)
+func (jwks *JWKS) PublicKeys() (map[string]crypto.PublicKey, error) {
+	var (
+		m    = make(map[string]crypto.PublicKey, len(jwks.Keys))
+		rerr error
+	)
+	for i := range jwks.Keys {
+		jwk := &jwks.Keys[i]
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := jwk.PublicKey()
+		if err != nil {
+			rerr = err
+			continue
+		}
+		m[jwk.Kid] = pub
+	}
+	if len(m) == 0 && rerr != nil {
+		return nil, rerr
+	}
+	return m, nil
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func unb64(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
@@ -37,24 +37,22 @@ var (
 	ErrTokenRevoked  = errors.New("token revoked")
 )
 
-func IssueAdminJWT(expires time.Time, userID, secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+func IssueAdminJWT(expires time.Time, userID string, sk *SigningKey) (string, error) {
+	return sk.sign(jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"admin":    true,
 	})
-	return t.SignedString([]byte(secret))
 }
 
 func IssueJWT(expires time.Time, userID string, bucketACLs []*authn.BckACL, clusterACLs []*authn.CluACL,
-	secret string) (string, error) {
-	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	sk *SigningKey) (string, error) {
+	return sk.sign(jwt.MapClaims{
 		"expires":  expires,
 		"username": userID,
 		"buckets":  bucketACLs,
 		"clusters": clusterACLs,
 	})
-	return t.SignedString([]byte(secret))
 }
 
 // Header format: 'Authorization: Bearer <token>'
@@ -70,13 +68,9 @@ func ExtractToken(hdr http.Header) (string, error) {
 	return s[idx+1:], nil
 }
 
-func DecryptToken(tokenStr, secret string) (*Token, error) {
-	jwtToken, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(secret), nil
-	})
+// verify the signature (see Keys) and decode the claims
+func DecryptToken(tokenStr string, keys *Keys) (*Token, error) {
+	jwtToken, err := jwt.Parse(tokenStr, keys.keyFunc)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +85,20 @@ func DecryptToken(tokenStr, secret string) (*Token, error) {
 	return tk, nil
 }
 
+// verify the signature of a token issued by a third party (e.g., OIDC ID token)
+// and return its (validated) registered and custom claims
+func ParseClaims(tokenStr string, keys *Keys) (jwt.MapClaims, error) {
+	jwtToken, err := jwt.Parse(tokenStr, keys.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok || !jwtToken.Valid {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
 ///////////
 // Token //
 ///////////
@@ -159,6 +167,27 @@ func (tk *Token) CheckPermissions(clusterID string, bck *cmn.Bck, perms apc.Acce
 	return nil
 }
 
+// CheckCluster verifies that the (non-expired) token applies to the given cluster,
+// regardless of the permissions requested - that is, the part of CheckPermissions
+// that must hold even when access is granted otherwise (e.g., by bucket grants).
+func (tk *Token) CheckCluster(clusterID string) error {
+	if time.Now().After(tk.Expires) {
+		return fmt.Errorf("%v: %s", ErrTokenExpired, tk)
+	}
+	if tk.IsAdmin {
+		return nil
+	}
+	if _, ok := tk.aclForCluster(clusterID); ok {
+		return nil
+	}
+	for _, b := range tk.BucketACLs {
+		if b.Bck.Ns.UUID == clusterID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%v: [%s, not valid for cluster %s]", ErrNoPermissions, tk, clusterID)
+}
+
 //
 // private
 //
@@ -5,15 +5,23 @@
 package main
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/api/authn"
 	"github.com/NVIDIA/aistore/cmd/authn/tok"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/mock"
 	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/golang-jwt/jwt/v4"
+	jsoniter "github.com/json-iterator/go"
 )
 
 var (
@@ -141,9 +149,8 @@ func TestToken(t *testing.T) {
 		t.Skipf("skipping %s in short mode", t.Name())
 	}
 	var (
-		err    error
-		token  string
-		secret = Conf.Server.Secret
+		err   error
+		token string
 	)
 
 	driver := mock.NewDBDriver()
@@ -151,6 +158,7 @@ func TestToken(t *testing.T) {
 	tassert.CheckFatal(t, err)
 	createUsers(mgr, t)
 	defer deleteUsers(mgr, false, t)
+	keys := mgr.verifyKeys()
 
 	clu := authn.CluACL{
 		ID:    "ABCD",
@@ -169,7 +177,7 @@ func TestToken(t *testing.T) {
 	if err != nil || token == "" {
 		t.Errorf("Failed to generate token for %s: %v", users[1], err)
 	}
-	info, err := tok.DecryptToken(token, secret)
+	info, err := tok.DecryptToken(token, keys)
 	if err != nil {
 		t.Fatalf("Failed to decript token %v: %v", token, err)
 	}
@@ -186,13 +194,155 @@ func TestToken(t *testing.T) {
 
 	// expired token test
 	time.Sleep(shortExpiration)
-	tk, err := tok.DecryptToken(token, secret)
+	tk, err := tok.DecryptToken(token, keys)
 	tassert.CheckFatal(t, err)
 	if tk.Expires.After(time.Now()) {
 		t.Fatalf("Token must be expired: %s", token)
 	}
 }
 
+func TestSigningKeys(t *testing.T) {
+	for _, method := range []string{tok.SigningRS256, tok.SigningES256} {
+		t.Run(method, func(t *testing.T) {
+			Conf.Server.SigningMethod = method
+			defer func() { Conf.Server.SigningMethod = "" }()
+
+			driver := mock.NewDBDriver()
+			mgr, err := newMgr(driver)
+			tassert.CheckFatal(t, err)
+			token, err := mgr.issueToken(adminUserID, adminUserPass, &authn.LoginMsg{})
+			tassert.CheckFatal(t, err)
+
+			// retired key remains valid
+			tassert.CheckFatal(t, mgr.rotateKey(method))
+			_, err = tok.DecryptToken(token, mgr.verifyKeys())
+			tassert.CheckFatal(t, err)
+
+			// verify using published JWKS (HMAC-signed tokens are rejected)
+			var jwks tok.JWKS
+			tassert.CheckFatal(t, jsoniter.Unmarshal(mgr.keys.jwks, &jwks))
+			tassert.Fatalf(t, len(jwks.Keys) == 2, "expected 2 keys, got %d", len(jwks.Keys))
+			public, err := jwks.PublicKeys()
+			tassert.CheckFatal(t, err)
+			_, err = tok.DecryptToken(token, &tok.Keys{Public: public})
+			tassert.CheckFatal(t, err)
+
+			hmacToken, err := tok.IssueAdminJWT(time.Now().Add(time.Hour), adminUserID, tok.NewHMACKey("secret"))
+			tassert.CheckFatal(t, err)
+			_, err = tok.DecryptToken(hmacToken, mgr.verifyKeys())
+			tassert.Errorf(t, err != nil, "HMAC-signed token must be rejected")
+
+			// reload from DB
+			mgr2, err := newMgr(driver)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, mgr2.signingKey().KID == mgr.signingKey().KID, "current key mismatch")
+			_, err = tok.DecryptToken(token, mgr2.verifyKeys())
+			tassert.CheckFatal(t, err)
+
+			// unknown key
+			_, err = tok.DecryptToken(token, &tok.Keys{})
+			tassert.Errorf(t, errors.Is(err, tok.ErrUnknownKey), "expected unknown key, got %v", err)
+		})
+	}
+}
+
+// stub OIDC provider: discovery document and JWK set
+func newStubIdP(t *testing.T) (srv *httptest.Server, sign func(claims jwt.MapClaims) string) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	tassert.CheckFatal(t, err)
+	jwk, err := tok.NewJWK("idp-key", &priv.PublicKey)
+	tassert.CheckFatal(t, err)
+
+	mux := http.NewServeMux()
+	srv = httptest.NewServer(mux)
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &oidcDiscovery{Issuer: srv.URL, JWKSURI: srv.URL + "/keys"}, "discovery")
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &tok.JWKS{Keys: []tok.JWK{jwk}}, "jwks")
+	})
+	sign = func(claims jwt.MapClaims) string {
+		t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		t.Header["kid"] = jwk.Kid
+		s, err := t.SignedString(priv)
+		if err != nil {
+			panic(err)
+		}
+		return s
+	}
+	return srv, sign
+}
+
+func TestOIDCLogin(t *testing.T) {
+	idp, sign := newStubIdP(t)
+	defer idp.Close()
+
+	driver := mock.NewDBDriver()
+	mgr, err := newMgr(driver)
+	tassert.CheckFatal(t, err)
+	clu := authn.CluACL{ID: "ABCD", Alias: "cluster-test", URLs: []string{"http://localhost:8080"}}
+	tassert.CheckFatal(t, mgr.db.Set(clustersCollection, clu.ID, clu))
+
+	Conf.OIDC = authn.OIDCConf{
+		Issuer:       idp.URL,
+		ClientID:     "ais",
+		UserClaim:    "email",
+		GroupRoles:   map[string][]string{"admins": {authn.AdminRole}, "readers": {GuestRole + "-" + clu.ID}},
+		DefaultRoles: []string{"nonexistent"},
+	}
+	defer func() { Conf.OIDC = authn.OIDCConf{} }()
+	tassert.CheckFatal(t, Conf.OIDC.Validate())
+	tassert.CheckFatal(t, mgr.addRole(&authn.Role{
+		ID:          GuestRole + "-" + clu.ID,
+		ClusterACLs: []*authn.CluACL{{ID: clu.ID, Access: apc.AccessRO}},
+	}))
+
+	claims := func(groups ...any) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":    idp.URL,
+			"aud":    []string{"ais", "other"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"sub":    "1234",
+			"email":  "user@example.com",
+			"groups": groups,
+		}
+	}
+	keys := mgr.verifyKeys()
+
+	// reader
+	token, err := mgr.issueOIDCToken(&authn.LoginMsg{IDToken: sign(claims("readers")), ClusterID: clu.Alias})
+	tassert.CheckFatal(t, err)
+	tk, err := tok.DecryptToken(token, keys)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.UserID == "user@example.com" && !tk.IsAdmin, "unexpected token %s", tk)
+	bck := &cmn.Bck{Name: "bck", Provider: apc.AIS}
+	tassert.Errorf(t, tk.CheckPermissions(clu.ID, bck, apc.AceGET) == nil, "expected read access")
+	tassert.Errorf(t, tk.CheckPermissions(clu.ID, bck, apc.AcePUT) != nil, "expected no write access")
+
+	// admin
+	token, err = mgr.issueOIDCToken(&authn.LoginMsg{IDToken: sign(claims("admins", "readers"))})
+	tassert.CheckFatal(t, err)
+	tk, err = tok.DecryptToken(token, keys)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tk.IsAdmin, "expected admin token, got %s", tk)
+
+	// rejected
+	for name, c := range map[string]jwt.MapClaims{
+		"no-roles":     claims("others"),
+		"wrong-issuer": func() jwt.MapClaims { c := claims("admins"); c["iss"] = "https://evil.com"; return c }(),
+		"wrong-aud":    func() jwt.MapClaims { c := claims("admins"); c["aud"] = "other"; return c }(),
+		"expired":      func() jwt.MapClaims { c := claims("admins"); c["exp"] = time.Now().Add(-time.Minute).Unix(); return c }(),
+		"no-user":      func() jwt.MapClaims { c := claims("admins"); delete(c, "email"); return c }(),
+	} {
+		_, err := mgr.issueOIDCToken(&authn.LoginMsg{IDToken: sign(c), ClusterID: clu.Alias})
+		tassert.Errorf(t, err != nil, "%s: expected error", name)
+	}
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims("admins")).SignedString([]byte("secret"))
+	tassert.CheckFatal(t, err)
+	_, err = mgr.issueOIDCToken(&authn.LoginMsg{IDToken: hmacToken})
+	tassert.Errorf(t, err != nil, "HMAC-signed ID token must be rejected")
+}
+
 func TestMergeCluACLS(t *testing.T) {
 	tests := []struct {
 		title    string
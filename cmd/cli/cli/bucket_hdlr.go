@@ -118,6 +118,7 @@ var (
 		commandList: {
 			allObjsOrBcksFlag,
 			listObjCachedFlag,
+			listDeletedFlag,
 			nameOnlyFlag,
 			objPropsFlag,
 			regexLsAnyFlag,
@@ -65,6 +65,7 @@ const (
 	commandRemove    = "rm"
 	commandRename    = "mv"
 	commandSet       = "set"
+	commandUndelete  = "undelete"
 	commandStart     = apc.ActXactStart
 	commandStop      = apc.ActXactStop
 	commandWait      = "wait"
@@ -133,6 +134,7 @@ const (
 
 	// Show subcommands (not all)
 	cmdShowRemoteAIS  = "remote-cluster"
+	cmdShowAudit      = "audit"
 	cmdShowStats      = "stats"
 	cmdMountpath      = "mountpath"
 	cmdCapacity       = "capacity"
@@ -745,6 +747,38 @@ var (
 		Name:  "cached",
 		Usage: "list only in-cluster objects - only those objects from a remote bucket that are present (\"cached\")",
 	}
+	perfBucketFlag = cli.BoolFlag{
+		Name:  "bucket",
+		Usage: "show per-bucket GET and PUT counts, sizes, latencies, and errors - cluster-wide (see 'metrics.per_bucket' config)",
+	}
+	perfUserFlag = cli.BoolFlag{
+		Name:  "user",
+		Usage: "show per-user GET and PUT counts, sizes, latencies, and errors - cluster-wide (see 'metrics.per_user' config)",
+	}
+	// `show audit`
+	auditUserFlag = cli.StringFlag{
+		Name:  "user",
+		Usage: "show only the records of a given (AuthN) user ID (exact match)",
+	}
+	auditActionFlag = cli.StringFlag{
+		Name:  "action",
+		Usage: "show only the records of a given action (exact match), e.g.: 'destroy-bck', 'set-bprops', 'delete'",
+	}
+	auditSinceFlag = DurationFlag{
+		Name: "since",
+		Usage: "show only the records that were made during the specified time interval (ending now), e.g.: '--since 1h';\n" +
+			indent4 + "\tvalid time units: " + timeUnits,
+	}
+	auditLimitFlag = cli.IntFlag{
+		Name:  "limit",
+		Usage: "maximum number of (the most recent) records to show (0 - cluster default)",
+	}
+
+	listDeletedFlag = cli.BoolFlag{
+		Name: "deleted",
+		Usage: "list soft-deleted objects (instead of existing ones), whereby access time (atime) is the time of deletion;\n" +
+			indent1 + "\t(applies only to buckets with soft delete enabled - see bucket property 'soft_delete')",
+	}
 	getObjCachedFlag = cli.BoolFlag{
 		Name:  "cached",
 		Usage: "get only in-cluster objects - only those objects from a remote bucket that are present (\"cached\")",
@@ -295,6 +295,13 @@ func listObjects(c *cli.Context, bck cmn.Bck, prefix string, listArch bool) erro
 		addCachedCol = false // redundant
 	}
 
+	if flagIsSet(c, listDeletedFlag) {
+		if !bck.IsAIS() {
+			return fmt.Errorf("flag %s requires ais:// bucket (have: %s)", qflprn(listDeletedFlag), bck)
+		}
+		msg.SetFlag(apc.LsDeleted)
+	}
+
 	// NOTE: `--all` combines two separate meanings:
 	// - list missing obj-s (with existing copies)
 	// - list obj-s from a remote bucket outside cluster
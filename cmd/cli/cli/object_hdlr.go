@@ -31,7 +31,8 @@ var (
 			nonverboseFlag,
 			yesFlag,
 		),
-		commandRename: {},
+		commandRename:   {},
+		commandUndelete: {},
 		commandGet: {
 			offsetFlag,
 			lengthFlag,
@@ -228,6 +229,16 @@ var (
 				Action:       mvObjectHandler,
 				BashComplete: bucketCompletions(bcmplop{multiple: true, separator: true}),
 			},
+			{
+				Name: commandUndelete,
+				Usage: "restore soft-deleted object (see bucket property 'soft_delete'), e.g.:\n" +
+					indent1 + "\t- 'ais object undelete ais://nnn/obj'\t- restore the most recently deleted version of ais://nnn/obj;\n" +
+					indent1 + "\t- 'ais ls ais://nnn --deleted'\t- list soft-deleted objects that can be restored",
+				ArgsUsage:    objectArgument,
+				Flags:        objectCmdsFlags[commandUndelete],
+				Action:       undeleteHandler,
+				BashComplete: bucketCompletions(bcmplop{separator: true}),
+			},
 			{
 				Name:         commandCat,
 				Usage:        "cat an object (i.e., print its contents to STDOUT)",
@@ -287,6 +298,32 @@ func mvObjectHandler(c *cli.Context) (err error) {
 	return
 }
 
+func undeleteHandler(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, c.Command.ArgsUsage)
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "", c.Args()[1:])
+	}
+	uri := c.Args().Get(0)
+	bck, objName, err := parseBckObjURI(c, uri, false)
+	if err != nil {
+		return err
+	}
+	if !bck.IsAIS() {
+		return incorrectUsageMsg(c, "provider %q not supported (soft delete applies only to ais:// buckets)", bck.Provider)
+	}
+	if err := api.UndeleteObject(apiBP, bck, objName); err != nil {
+		if cmn.IsStatusNotFound(err) {
+			return fmt.Errorf("soft-deleted %s not found (see '%s --deleted')", bck.Cname(objName),
+				cliName+" "+commandList+" "+bck.Cname(""))
+		}
+		return V(err)
+	}
+	actionDone(c, "Restored "+bck.Cname(objName))
+	return nil
+}
+
 // main PUT handler: cases 1 through 4
 func putHandler(c *cli.Context) error {
 	if flagIsSet(c, appendConcatFlag) {
@@ -8,9 +8,12 @@ package cli
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
+	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmd/cli/teb"
 	"github.com/NVIDIA/aistore/cmn"
@@ -45,6 +48,8 @@ var (
 		averageSizeFlag,
 		nonverboseFlag,
 	)
+	// top-level only (compare with `showPerfFlags`)
+	showPerfTopFlags = append([]cli.Flag{perfBucketFlag, perfUserFlag}, showPerfFlags...)
 
 	// alias
 	perfCmd = cli.Command{
@@ -59,7 +64,7 @@ var (
 		Name:      commandPerf,
 		Usage:     showPerfArgument,
 		ArgsUsage: optionalTargetIDArgument,
-		Flags:     showPerfFlags,
+		Flags:     showPerfTopFlags,
 		Action:    showPerfHandler,
 		Subcommands: []cli.Command{
 			showCounters,
@@ -114,6 +119,10 @@ func showPerfHandler(c *cli.Context) error {
 			c.Args(), tabtab)
 	}
 
+	if flagIsSet(c, perfBucketFlag) || flagIsSet(c, perfUserFlag) {
+		return showBckPerf(c)
+	}
+
 	if err := showCountersHandler(c); err != nil {
 		return err
 	}
@@ -132,6 +141,72 @@ func showPerfHandler(c *cli.Context) error {
 	return nil
 }
 
+// per-bucket and/or per-user GET and PUT, summed up across all targets
+// (see `metrics` cluster config and stats.BckStats)
+func showBckPerf(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return incorrectUsageMsg(c, "per-bucket (per-user) view is cluster-wide and does not take %s argument",
+			optionalTargetIDArgument)
+	}
+	units, errU := parseUnitsFlag(c, unitsFlag)
+	if errU != nil {
+		return errU
+	}
+	res, err := api.GetBckStats(apiBP)
+	if err != nil {
+		return V(err)
+	}
+	if flagIsSet(c, perfBucketFlag) {
+		perfCptn(c, "BUCKET")
+		if len(res.Buckets) == 0 {
+			actionNote(c, "no per-bucket metrics (see 'metrics.per_bucket' cluster config)")
+		} else {
+			_bckPerfTab(c, "BUCKET", res.Buckets, units)
+		}
+	}
+	if flagIsSet(c, perfUserFlag) {
+		if flagIsSet(c, perfBucketFlag) {
+			fmt.Fprintln(c.App.Writer)
+		}
+		perfCptn(c, "USER")
+		if len(res.Users) == 0 {
+			actionNote(c, "no per-user metrics (see 'metrics.per_user' cluster config)")
+		} else {
+			_bckPerfTab(c, "USER", res.Users, units)
+		}
+	}
+	return nil
+}
+
+func _bckPerfTab(c *cli.Context, name string, m map[string]*stats.ReqStats, units string) {
+	var (
+		names = make([]string, 0, len(m))
+		tw    = &tabwriter.Writer{}
+	)
+	for n := range m {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, name+"\tGET(n)\tGET(size)\tGET(avg latency)\tGET(errors)\tPUT(n)\tPUT(size)\tPUT(avg latency)\tPUT(errors)")
+	}
+	for _, n := range names {
+		rs := m[n]
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", n, _opPerf(&rs.Get, units), _opPerf(&rs.Put, units))
+	}
+	tw.Flush()
+}
+
+func _opPerf(op *stats.OpStats, units string) string {
+	lat := teb.NotSetVal
+	if op.Count > 0 {
+		lat = teb.FmtDuration(op.Latency/op.Count, units)
+	}
+	return fmt.Sprintf("%d\t%s\t%s\t%d", op.Count, teb.FmtSize(op.Size, units, 2), lat, op.Errors)
+}
+
 func _warnThruLatIters(c *cli.Context) {
 	if flagIsSet(c, refreshFlag) || flagIsSet(c, nonverboseFlag) {
 		return
@@ -267,6 +342,8 @@ func _latency(c *cli.Context, metrics cos.StrKVs, mapBegin, mapEnd teb.StstMap,
 			actionWarn(c, warn)
 			continue
 		}
+		// percentiles are computed by targets (over the last stats interval) - use the most recent
+		begin.Latency = end.Latency
 		for name, v := range begin.Tracker {
 			if kind, ok := metrics[name]; !ok || kind != stats.KindLatency {
 				continue
@@ -316,6 +393,7 @@ func showPerfTab(c *cli.Context, metrics cos.StrKVs, cb perfcb, tag string, tota
 	if inclAvgSize {
 		avgSize = true // caller override
 	}
+	pctls := tag == cmdShowLatency // p50, p90, p99
 	var (
 		tid          string
 		node, _, err = arg0Node(c)
@@ -362,7 +440,7 @@ func showPerfTab(c *cli.Context, metrics cos.StrKVs, cb perfcb, tag string, tota
 		}
 		setLongRunParams(c, lfooter)
 
-		ctx := teb.PerfTabCtx{Smap: smap, Sid: tid, Metrics: metrics, Regex: regex, Units: units, AvgSize: avgSize}
+		ctx := teb.PerfTabCtx{Smap: smap, Sid: tid, Metrics: metrics, Regex: regex, Units: units, AvgSize: avgSize, Pctls: pctls}
 		table, num, err := teb.NewPerformanceTab(tstatusMap, &ctx)
 		if err != nil {
 			return err
@@ -429,7 +507,7 @@ func showPerfTab(c *cli.Context, metrics cos.StrKVs, cb perfcb, tag string, tota
 		}
 
 		ctx := teb.PerfTabCtx{Smap: smap, Sid: tid, Metrics: metrics, Regex: regex, Units: units,
-			Totals: totals, TotalsHdr: totalsHdr, AvgSize: avgSize, Idle: idle}
+			Totals: totals, TotalsHdr: totalsHdr, AvgSize: avgSize, Pctls: pctls, Idle: idle}
 		table, _, err := teb.NewPerformanceTab(mapBegin, &ctx)
 		if err != nil {
 			return err
@@ -17,6 +17,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/audit"
 	"github.com/NVIDIA/aistore/cmd/cli/teb"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -95,6 +96,14 @@ var (
 			verboseFlag,
 			jsonFlag,
 		},
+		cmdShowAudit: {
+			auditUserFlag,
+			auditActionFlag,
+			auditSinceFlag,
+			auditLimitFlag,
+			noHeaderFlag,
+			jsonFlag,
+		},
 	}
 
 	showCmd = cli.Command{
@@ -112,6 +121,7 @@ var (
 			showCmdRemoteAIS,
 			showCmdJob,
 			showCmdLog,
+			showCmdAudit,
 		},
 	}
 
@@ -194,6 +204,18 @@ var (
 		Action:    showRemoteAISHandler,
 	}
 
+	showCmdAudit = cli.Command{
+		Name: cmdShowAudit,
+		Usage: "show the most recent cluster-wide audit records (newest first) - bucket, object, and cluster-level\n" +
+			indent1 + "\tmodifications by user, e.g.:\n" +
+			indent1 + "\t- 'ais show audit --user alice --since 24h'\t- everything 'alice' did in the last 24 hours;\n" +
+			indent1 + "\t- 'ais show audit --action destroy-bck'\t- all (recorded) bucket destructions;\n" +
+			indent1 + "\tsee docs/audit.md for details",
+		ArgsUsage: "",
+		Flags:     showCmdsFlags[cmdShowAudit],
+		Action:    showAuditHandler,
+	}
+
 	showCmdJob = cli.Command{
 		Name:         commandJob,
 		Usage:        "show running and finished jobs ('--all' for all, or " + tabHelpOpt + ")",
@@ -848,6 +870,94 @@ func showNodeConfig(c *cli.Context) error {
 	return err
 }
 
+func showAuditHandler(c *cli.Context) error {
+	if c.NArg() > 0 {
+		return incorrectUsageMsg(c, "", c.Args())
+	}
+	q := &audit.Query{
+		User:   parseStrFlag(c, auditUserFlag),
+		Action: parseStrFlag(c, auditActionFlag),
+		Limit:  parseIntFlag(c, auditLimitFlag),
+	}
+	if flagIsSet(c, auditSinceFlag) {
+		q.Since = time.Now().Add(-parseDurationFlag(c, auditSinceFlag)).UnixNano()
+	}
+	recs, err := api.GetAuditLog(apiBP, q)
+	if err != nil {
+		return V(err)
+	}
+	if flagIsSet(c, jsonFlag) {
+		return teb.Print(recs, "", teb.Jopts(true))
+	}
+	if len(recs) == 0 {
+		actionNote(c, "no matching audit records (see 'audit.enabled' cluster config)")
+		return nil
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TIME\tNODE\tUSER\tMETHOD\tACTION\tENTITY\tNAME\tSTATUS\tERROR")
+	}
+	for _, rec := range recs {
+		user := rec.User
+		switch {
+		case user == "":
+			user = teb.NotSetVal
+		case rec.Unverified:
+			user += " (unverified)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			cos.FormatNanoTime(rec.Time, time.DateTime), rec.Node, user, rec.Method,
+			_orNotSet(rec.Action), rec.Entity, _orNotSet(rec.Name), rec.Status, _orNotSet(rec.Err))
+	}
+	tw.Flush()
+	return nil
+}
+
+func _orNotSet(s string) string {
+	if s == "" {
+		return teb.NotSetVal
+	}
+	return s
+}
+
+// one-word summary (see `meta.RemAis` health)
+func remAisHealth(ra *meta.RemAis) string {
+	switch {
+	case len(ra.URLs) == 0:
+		return teb.UnknownStatusVal
+	case len(ra.Down) == 0:
+		return "ok"
+	case len(ra.Down) == len(ra.URLs):
+		return "down"
+	default:
+		return fmt.Sprintf("degraded (%d/%d down)", len(ra.Down), len(ra.URLs))
+	}
+}
+
+func showRemAisHealth(c *cli.Context, ra *meta.RemAis) {
+	lastOK := teb.NotSetVal
+	if ra.LastOK != 0 {
+		lastOK = cos.FormatNanoTime(ra.LastOK, "") + " (" + time.Since(time.Unix(0, ra.LastOK)).Round(time.Second).String() + " ago)"
+	}
+	lastErr := teb.NotSetVal
+	if ra.LastErr != "" {
+		lastErr = ra.LastErr
+	}
+	down := teb.NotSetVal
+	if len(ra.Down) > 0 {
+		down = strings.Join(ra.Down, ", ")
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "Endpoints:\t%s\n", strings.Join(ra.URLs, ", "))
+	fmt.Fprintf(tw, "Down:\t%s\n", down)
+	fmt.Fprintf(tw, "Last contact:\t%s\n", lastOK)
+	fmt.Fprintf(tw, "Last error:\t%s\n", lastErr)
+	fmt.Fprintf(tw, "Failovers:\t%d\n", ra.Failovers)
+	tw.Flush()
+}
+
 func showRemoteAISHandler(c *cli.Context) error {
 	const (
 		warnRemAisOffline = `remote ais cluster at %s is currently unreachable.
@@ -863,7 +973,7 @@ For details and usage examples, see: docs/cli/config.md`
 	tw := &tabwriter.Writer{}
 	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
 	if !flagIsSet(c, noHeaderFlag) {
-		fmt.Fprintln(tw, "UUID\tURL\tAlias\tPrimary\tSmap\tTargets\tUptime")
+		fmt.Fprintln(tw, "UUID\tURL\tAlias\tPrimary\tSmap\tTargets\tUptime\tHealth")
 	}
 	for _, ra := range all.A {
 		uptime := teb.UnknownStatusVal
@@ -883,16 +993,16 @@ For details and usage examples, see: docs/cli/config.md`
 			uptime = time.Duration(ns).String()
 		}
 		if ra.Smap != nil {
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\tv%d\t%d\t%s\n",
-				ra.UUID, ra.URL, ra.Alias, ra.Smap.Primary, ra.Smap.Version, ra.Smap.CountTargets(), uptime)
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\tv%d\t%d\t%s\t%s\n",
+				ra.UUID, ra.URL, ra.Alias, ra.Smap.Primary, ra.Smap.Version, ra.Smap.CountTargets(), uptime, remAisHealth(ra))
 		} else {
 			url := ra.URL
 			if url != "" && url[0] == '[' && !strings.Contains(url, " ") {
 				url = strings.Replace(url, "[", "", 1)
 				url = strings.Replace(url, "]", "", 1)
 			}
-			fmt.Fprintf(tw, "<%s>\t%s\t%s\t%s\t%s\t%s\t%s\n", ra.UUID, url, ra.Alias,
-				teb.UnknownStatusVal, teb.UnknownStatusVal, teb.UnknownStatusVal, uptime)
+			fmt.Fprintf(tw, "<%s>\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", ra.UUID, url, ra.Alias,
+				teb.UnknownStatusVal, teb.UnknownStatusVal, teb.UnknownStatusVal, uptime, remAisHealth(ra))
 
 			warn := fmt.Sprintf(warnRemAisOffline, url)
 
@@ -908,6 +1018,9 @@ For details and usage examples, see: docs/cli/config.md`
 
 	if flagIsSet(c, verboseFlag) {
 		for _, ra := range all.A {
+			fmt.Fprintln(c.App.Writer)
+			actionCptn(c, ra.Alias+"["+ra.UUID+"]", " health:")
+			showRemAisHealth(c, ra)
 			if ra.Smap == nil {
 				continue
 			}
@@ -25,9 +25,15 @@ type PerfTabCtx struct {
 	Totals    map[string]int64 // metrics to sum up (name => sum(column)), where the name is IN and the sum is OUT
 	TotalsHdr string
 	AvgSize   bool // compute average size on the fly (and show it), e.g.: `get.size/get.n`
+	Pctls     bool // add latency percentiles, if available (see stats.Node.Latency)
 	Idle      bool // currently idle
 }
 
+// latency percentile columns: metric name + pctlSepa + percentile, e.g. "get.ns/p99"
+const pctlSepa = "/"
+
+var pctlNames = [...]string{"p50", "p90", "p99"}
+
 func NewPerformanceTab(st StstMap, c *PerfTabCtx) (*Table, int /*numNZ non-zero metrics OR bad status*/, error) {
 	var (
 		numNZ int        // num non-zero metrics
@@ -89,6 +95,11 @@ func NewPerformanceTab(st StstMap, c *PerfTabCtx) (*Table, int /*numNZ non-zero
 		}
 	})
 
+	// 3.1. latency percentiles next to the respective (average) latency columns
+	if c.Pctls {
+		cols = _addPctls(cols, st, c.Metrics)
+	}
+
 	// 4. add STATUS column unless all nodes are online (`NodeOnline`)
 	cols = _addStatus(cols, st)
 
@@ -141,6 +152,15 @@ func NewPerformanceTab(st StstMap, c *PerfTabCtx) (*Table, int /*numNZ non-zero
 				continue
 			}
 
+			if base, pct, ok := _splitPctl(h.name); ok {
+				printedValue := unknownVal
+				if p := ds.Latency[base]; p != nil {
+					printedValue = FmtStatValue(base, stats.KindLatency, _pctlValue(p, pct), c.Units)
+				}
+				row = append(row, printedValue)
+				continue
+			}
+
 			v, ok := ds.Tracker[h.name]
 			if !ok {
 				// t[tid] doesn't have this metric (likely, zero value)
@@ -238,6 +258,49 @@ func _zerout(cols []*header, st StstMap) []*header {
 	return cols
 }
 
+// insert percentile columns right after the latency metric that has them (at any target)
+func _addPctls(cols []*header, st StstMap, metrics cos.StrKVs) []*header {
+	out := make([]*header, 0, len(cols)+len(pctlNames)*2)
+	for _, h := range cols {
+		out = append(out, h)
+		if metrics[h.name] != stats.KindLatency {
+			continue
+		}
+		for _, ds := range st {
+			if ds.Status != NodeOnline || ds.Latency[h.name] == nil {
+				continue
+			}
+			for _, pct := range pctlNames {
+				out = append(out, &header{name: h.name + pctlSepa + pct, hide: false})
+			}
+			break
+		}
+	}
+	return out
+}
+
+func _splitPctl(name string) (base, pct string, ok bool) {
+	i := strings.LastIndex(name, pctlSepa)
+	if i < 0 {
+		return "", "", false
+	}
+	return name[:i], name[i+len(pctlSepa):], true
+}
+
+func _pctlValue(p *stats.Percentiles, pct string) int64 {
+	switch pct {
+	case "p50":
+		return p.P50
+	case "p90":
+		return p.P90
+	case "p99":
+		return p.P99
+	default:
+		debug.Assert(false, pct)
+		return 0
+	}
+}
+
 // (aternatively, could always add, conditionally hide)
 func _addStatus(cols []*header, st StstMap) []*header {
 	for _, ds := range st {
@@ -278,10 +341,16 @@ outer:
 func _metricsToColNames(cols []*header, metrics, n2n cos.StrKVs) (printedColumns []*header) {
 	printedColumns = make([]*header, len(cols)) // one to one
 	for colIdx, h := range cols {
-		var printedName string
-		if h.name == colTarget || h.name == colStatus {
+		var (
+			printedName   string
+			base, pct, ok = _splitPctl(h.name)
+		)
+		switch {
+		case h.name == colTarget || h.name == colStatus:
 			printedName = h.name
-		} else {
+		case ok:
+			printedName = strings.TrimSuffix(_metricToPrintedColName(base, cols, metrics, n2n), "(t)") + "(" + pct + ")"
+		default:
 			printedName = _metricToPrintedColName(h.name, cols, metrics, n2n)
 		}
 		printedColumns[colIdx] = &header{name: printedName, hide: cols[colIdx].hide}
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -44,6 +45,10 @@ type (
 	Bprops struct {
 		BackendBck  Bck             `json:"backend_bck,omitempty"` // makes remote bucket out of a given ais bucket
 		Extra       ExtraProps      `json:"extra,omitempty" list:"omitempty"`
+		Lifecycle   LifecycleConf   `json:"lifecycle,omitempty" list:"omitempty"`
+		SoftDel     SoftDelConf     `json:"soft_delete"`
+		Quota       QuotaConf       `json:"quota"`
+		Grants      AccessGrants    `json:"grants,omitempty" list:"omitempty"`
 		WritePolicy WritePolicyConf `json:"write_policy"`
 		Provider    string          `json:"provider" list:"readonly"`       // backend provider
 		Renamed     string          `list:"omit"`                           // non-empty if the bucket has been renamed
@@ -83,6 +88,10 @@ type (
 		// or AWS_DEFAULT_PROFILE if the Shared Config is enabled)."
 		Profile string `json:"profile,omitempty"`
 
+		// named S3 endpoint (see cmn.BackendConfAWS) that takes precedence
+		// over all of the above (except region, if specified)
+		EndpointName string `json:"endpoint_name,omitempty"`
+
 		// Amazon S3: 1000
 		// - https://docs.aws.amazon.com/cli/latest/userguide/cli-usage-pagination.html#cli-usage-pagination-serverside
 		// vs OpenStack Swift: 10,000
@@ -90,18 +99,25 @@ type (
 		MaxPageSize int64 `json:"max_pagesize,omitempty"`
 	}
 	ExtraPropsAWSToSet struct {
-		CloudRegion *string `json:"cloud_region"`
-		Endpoint    *string `json:"endpoint"`
-		Profile     *string `json:"profile"`
-		MaxPageSize *int64  `json:"max_pagesize"`
+		CloudRegion  *string `json:"cloud_region"`
+		Endpoint     *string `json:"endpoint"`
+		Profile      *string `json:"profile"`
+		EndpointName *string `json:"endpoint_name"`
+		MaxPageSize  *int64  `json:"max_pagesize"`
 	}
 
 	ExtraPropsHTTP struct {
 		// Original URL prior to hashing.
 		OrigURLBck string `json:"original_url,omitempty" list:"readonly"`
+
+		// Optional manifest to list the bucket: newline-separated object names (or URLs),
+		// or JSON array of names or {"name", "size"} objects; when empty, list-objects
+		// crawls index (autoindex) pages starting from the original URL
+		ManifestURL string `json:"manifest_url,omitempty"`
 	}
 	ExtraPropsHTTPToSet struct {
-		OrigURLBck *string `json:"original_url"`
+		OrigURLBck  *string `json:"original_url"`
+		ManifestURL *string `json:"manifest_url"`
 	}
 
 	ExtraPropsHDFS struct {
@@ -126,6 +142,10 @@ type (
 		Features    *feat.Flags           `json:"features,string,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Extra       *ExtraToSet           `json:"extra,omitempty"`
+		Lifecycle   *LifecycleConfToSet   `json:"lifecycle,omitempty"`
+		SoftDel     *SoftDelConfToSet     `json:"soft_delete,omitempty"`
+		Quota       *QuotaConfToSet       `json:"quota,omitempty"`
+		Grants      *AccessGrants         `json:"grants,omitempty"`
 		Force       bool                  `json:"force,omitempty" copy:"skip" list:"omit"`
 	}
 
@@ -133,8 +153,35 @@ type (
 		Name     *string `json:"name"`
 		Provider *string `json:"provider"`
 	}
+
+	// Soft-delete: when enabled, deleting an object moves it (data and metadata)
+	// into the mountpath's trash where it stays for the configured retention time
+	// and can be restored (undeleted) - see apc.ActUndelete.
+	// Only supported for ais buckets that do not have remote backends.
+	SoftDelConf struct {
+		Retention cos.Duration `json:"retention"` // zero value defaults to 24h
+		Enabled   bool         `json:"enabled"`
+	}
+	SoftDelConfToSet struct {
+		Retention *cos.Duration `json:"retention,omitempty"`
+		Enabled   *bool         `json:"enabled,omitempty"`
+	}
+
+	// Per-principal access grants (e.g., translated from S3 bucket policy - see ais/s3/policy.go).
+	// With AuthN enabled, permissions granted to a given user (or to any user - GrantAnyUser)
+	// suffice without the corresponding permissions in the user's token; denied permissions
+	// are denied regardless. Either way, the bucket's `Access` applies.
+	AccessGrants map[string]Grant // principal (AuthN user ID) => permissions
+	Grant        struct {
+		Allow apc.AccessAttrs `json:"allow,string,omitempty"`
+		Deny  apc.AccessAttrs `json:"deny,string,omitempty"`
+	}
 )
 
+const GrantAnyUser = "*" // any authenticated user
+
+const dfltSoftDelRetention = 24 * time.Hour
+
 /////////////////
 // Bprops //
 /////////////////
@@ -218,7 +265,7 @@ func (bp *Bprops) Validate(targetCnt int) error {
 
 	// run assorted props validators
 	var softErr error
-	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy} {
+	for _, pv := range []PropsValidator{&bp.Cksum, &bp.Mirror, &bp.EC, &bp.Extra, &bp.WritePolicy, &bp.Lifecycle, &bp.Quota} {
 		var err error
 		if pv == &bp.EC {
 			err = bp.EC.ValidateAsProps(targetCnt)
@@ -234,6 +281,12 @@ func (bp *Bprops) Validate(targetCnt int) error {
 			softErr = err
 		}
 	}
+	if err := bp.SoftDel.validate(bp); err != nil {
+		return err
+	}
+	if err := bp.Grants.validate(); err != nil {
+		return err
+	}
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		nlog.Warningln("n-way mirroring and EC are both enabled at the same time on the same bucket")
 	}
@@ -276,6 +329,65 @@ func NewBpropsToSet(nvs cos.StrKVs) (props *BpropsToSet, err error) {
 	return
 }
 
+//
+// AccessGrants
+//
+
+func (g AccessGrants) validate() error {
+	for principal, grant := range g {
+		if principal == "" {
+			return errors.New("access grants: empty principal")
+		}
+		if grant.Allow == 0 && grant.Deny == 0 {
+			return fmt.Errorf("access grants: no permissions for %q", principal)
+		}
+	}
+	return nil
+}
+
+// returns (all requested permissions explicitly allowed, any of them explicitly denied)
+func (g AccessGrants) Check(uid string, ace apc.AccessAttrs) (allowed, denied bool) {
+	if len(g) == 0 {
+		return false, false
+	}
+	var allow, deny apc.AccessAttrs
+	if grant, ok := g[uid]; ok {
+		allow, deny = grant.Allow, grant.Deny
+	}
+	if grant, ok := g[GrantAnyUser]; ok {
+		allow |= grant.Allow
+		deny |= grant.Deny
+	}
+	return allow&ace == ace, deny&ace != 0
+}
+
+//
+// SoftDelConf
+//
+
+func (c *SoftDelConf) validate(bp *Bprops) error {
+	if c.Retention < 0 {
+		return fmt.Errorf("soft-delete: invalid retention time %v", c.Retention)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if bp.Provider != apc.AIS || !bp.BackendBck.IsEmpty() {
+		return errors.New("soft-delete is only supported for ais buckets that do not have remote backends")
+	}
+	if bp.EC.Enabled {
+		return errors.New("soft-delete and erasure coding cannot be enabled at the same time")
+	}
+	return nil
+}
+
+func (c *SoftDelConf) RetentionD() time.Duration {
+	if c.Retention == 0 {
+		return dfltSoftDelRetention
+	}
+	return c.Retention.D()
+}
+
 func (c *ExtraProps) ValidateAsProps(arg ...any) error {
 	provider, ok := arg[0].(string)
 	debug.Assert(ok)
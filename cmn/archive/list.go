@@ -27,34 +27,39 @@ type Entry struct {
 }
 
 func List(fqn string) ([]*Entry, error) {
-	var (
-		lst   []*Entry
-		finfo os.FileInfo
-	)
 	fh, err := os.Open(fqn)
 	if err != nil {
 		return nil, err
 	}
-	mime, err := MimeFile(fh, nil /*NOTE: not reading file magic*/, "", fqn)
+	finfo, err := fh.Stat()
+	if err != nil {
+		cos.Close(fh)
+		return nil, err
+	}
+	lst, err := ListReader(fh, finfo.Size(), fqn)
+	cos.Close(fh)
+	return lst, err
+}
+
+// same as above given an open reader (e.g., chunked object - see core/lchunk.go)
+func ListReader(r cos.LomReader, size int64, archname string) ([]*Entry, error) {
+	var lst []*Entry
+	mime, err := MimeFile(r, nil /*NOTE: not reading file magic*/, "", archname)
 	if err != nil {
 		return nil, err
 	}
 	switch mime {
 	case ExtTar:
-		lst, err = lsTar(fh)
+		lst, err = lsTar(r)
 	case ExtTgz, ExtTarGz:
-		lst, err = lsTgz(fh)
+		lst, err = lsTgz(r)
 	case ExtZip:
-		finfo, err = os.Stat(fqn)
-		if err == nil {
-			lst, err = lsZip(fh, finfo.Size())
-		}
+		lst, err = lsZip(r, size)
 	case ExtTarLz4:
-		lst, err = lsLz4(fh)
+		lst, err = lsLz4(r)
 	default:
 		debug.Assert(false, mime)
 	}
-	cos.Close(fh)
 	if err != nil {
 		return nil, err
 	}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"os"
 	"path"
@@ -119,6 +120,18 @@ type (
 		// Transform (offline) or Copy src Bucket => dst bucket
 		TCB TCBConf `json:"tcb"`
 
+		// store large objects as chunks spread across mountpaths
+		Chunks ChunksConf `json:"chunks"`
+
+		// distributed tracing (OpenTelemetry)
+		Tracing TracingConf `json:"tracing"`
+
+		// per-bucket and per-user request metrics
+		Metrics MetricsConf `json:"metrics"`
+
+		// audit trail of administrative and data-mutating operations
+		Audit AuditConf `json:"audit"`
+
 		// metadata write policy: (immediate | delayed | never)
 		WritePolicy WritePolicyConf `json:"write_policy"`
 
@@ -130,6 +143,10 @@ type (
 		LastUpdated string `json:"lastupdate_time"`       // timestamp
 		UUID        string `json:"uuid"`                  // UUID
 		Version     int64  `json:"config_version,string"` // version
+
+		// cluster-internal key: generated by the primary, distributed via metasync;
+		// used to sign intra-cluster metadata (e.g., the user ID of a redirected request)
+		IntraKey string `json:"intra_key,omitempty"`
 	}
 	ConfigToSet struct {
 		// ClusterConfig
@@ -156,6 +173,10 @@ type (
 		Transport   *TransportConfToSet   `json:"transport,omitempty"`
 		Memsys      *MemsysConfToSet      `json:"memsys,omitempty"`
 		TCB         *TCBConfToSet         `json:"tcb,omitempty"`
+		Chunks      *ChunksConfToSet      `json:"chunks,omitempty"`
+		Tracing     *TracingConfToSet     `json:"tracing,omitempty"`
+		Metrics     *MetricsConfToSet     `json:"metrics,omitempty"`
+		Audit       *AuditConfToSet       `json:"audit,omitempty"`
 		WritePolicy *WritePolicyConfToSet `json:"write_policy,omitempty"`
 		Proxy       *ProxyConfToSet       `json:"proxy,omitempty"`
 		Features    *feat.Flags           `json:"features,string,omitempty"`
@@ -173,6 +194,32 @@ type (
 	}
 	BackendConfAIS map[string][]string // cluster alias -> [urls...]
 
+	// named S3 and S3-compatible (MinIO, Ceph RGW, etc.) endpoints;
+	// a bucket references one of them via `extra.aws.endpoint_name` bucket property
+	BackendConfAWS struct {
+		Endpoints map[string]*S3Endpoint `json:"endpoints,omitempty"`
+	}
+	S3Endpoint struct {
+		// endpoint URL; empty means Amazon S3 itself (e.g., to use a different set of credentials)
+		URL string `json:"url,omitempty"`
+		// default region (when the bucket's `extra.aws.cloud_region` is not set)
+		Region string `json:"region,omitempty"`
+		// shared config profile (~/.aws/config, ~/.aws/credentials)
+		Profile string `json:"profile,omitempty"`
+		// static credentials - when specified, take precedence over the profile and environment;
+		// the secret key is never returned via API (see ClusterConfig.Redact)
+		AccessKey string `json:"access_key,omitempty"`
+		SecretKey string `json:"secret_key,omitempty"`
+		// "path" | "virtual"; empty: per-bucket (or global) feat.S3UsePathStyle
+		Addressing string `json:"addressing,omitempty"`
+		// TLS: CA bundle to verify endpoint's certificate; skip verification altogether
+		CABundle   string `json:"ca_bundle,omitempty"`
+		SkipVerify bool   `json:"skip_verify,omitempty"`
+		// buckets that resolve to this endpoint even when `extra.aws.endpoint_name` is not (yet) set
+		// (e.g., upon the very first lookup)
+		Buckets []string `json:"buckets,omitempty"`
+	}
+
 	MirrorConf struct {
 		Copies  int64 `json:"copies"`       // num copies
 		Burst   int   `json:"burst_buffer"` // xaction channel (buffer) size
@@ -228,20 +275,24 @@ type (
 	}
 
 	LogConf struct {
-		Level     cos.LogLevel `json:"level"`      // log level (aka verbosity)
-		MaxSize   cos.SizeIEC  `json:"max_size"`   // exceeding this size triggers log rotation
-		MaxTotal  cos.SizeIEC  `json:"max_total"`  // (sum individual log sizes); exceeding this number triggers cleanup
-		FlushTime cos.Duration `json:"flush_time"` // log flush interval
-		StatsTime cos.Duration `json:"stats_time"` // (not used)
-		ToStderr  bool         `json:"to_stderr"`  // Log only to stderr instead of files.
+		Level        cos.LogLevel `json:"level"`          // log level (aka verbosity)
+		MaxSize      cos.SizeIEC  `json:"max_size"`       // exceeding this size triggers log rotation
+		MaxTotal     cos.SizeIEC  `json:"max_total"`      // (sum individual log sizes); exceeding this number triggers cleanup
+		FlushTime    cos.Duration `json:"flush_time"`     // log flush interval
+		StatsTime    cos.Duration `json:"stats_time"`     // (not used)
+		Format       string       `json:"format"`         // "text" (default, glog-style) or "json" (JSON lines)
+		ToStderr     bool         `json:"to_stderr"`      // Log only to stderr instead of files.
+		AlsoToStderr bool         `json:"also_to_stderr"` // Log to files and, in addition, mirror all lines to stderr.
 	}
 	LogConfToSet struct {
-		Level     *cos.LogLevel `json:"level,omitempty"`
-		ToStderr  *bool         `json:"to_stderr,omitempty"`
-		MaxSize   *cos.SizeIEC  `json:"max_size,omitempty"`
-		MaxTotal  *cos.SizeIEC  `json:"max_total,omitempty"`
-		FlushTime *cos.Duration `json:"flush_time,omitempty"`
-		StatsTime *cos.Duration `json:"stats_time,omitempty"`
+		Level        *cos.LogLevel `json:"level,omitempty"`
+		ToStderr     *bool         `json:"to_stderr,omitempty"`
+		MaxSize      *cos.SizeIEC  `json:"max_size,omitempty"`
+		MaxTotal     *cos.SizeIEC  `json:"max_total,omitempty"`
+		FlushTime    *cos.Duration `json:"flush_time,omitempty"`
+		StatsTime    *cos.Duration `json:"stats_time,omitempty"`
+		Format       *string       `json:"format,omitempty"`
+		AlsoToStderr *bool         `json:"also_to_stderr,omitempty"`
 	}
 
 	// NOTE: StatsTime is a one important timer
@@ -264,6 +315,10 @@ type (
 		Startup         cos.Duration `json:"startup_time"`
 		JoinAtStartup   cos.Duration `json:"join_startup_time"` // (join cluster at startup) timeout
 		SendFile        cos.Duration `json:"send_file_time"`
+		// S3 multipart upload that shows no activity (no new parts) for this long
+		// is considered abandoned and gets garbage collected
+		// (zero value defaults to 7 days)
+		MptAbandoned cos.Duration `json:"mpt_abandoned_time"`
 	}
 	TimeoutConfToSet struct {
 		CplaneOperation *cos.Duration `json:"cplane_operation,omitempty"`
@@ -272,6 +327,7 @@ type (
 		Startup         *cos.Duration `json:"startup_time,omitempty"`
 		JoinAtStartup   *cos.Duration `json:"join_startup_time,omitempty"`
 		SendFile        *cos.Duration `json:"send_file_time,omitempty"`
+		MptAbandoned    *cos.Duration `json:"mpt_abandoned_time,omitempty"`
 	}
 
 	ClientConf struct {
@@ -315,12 +371,16 @@ type (
 		// Out-of-Space: if exceeded, the target starts failing new PUTs and keeps
 		// failing them until its local used-cap gets back below HighWM (see above)
 		OOS int64 `json:"out_of_space"`
+
+		// per-namespace storage quotas (see also: bucket property "quota")
+		NsQuotas NsQuotas `json:"ns_quotas,omitempty" list:"readonly"`
 	}
 	SpaceConfToSet struct {
-		CleanupWM *int64 `json:"cleanupwm,omitempty"`
-		LowWM     *int64 `json:"lowwm,omitempty"`
-		HighWM    *int64 `json:"highwm,omitempty"`
-		OOS       *int64 `json:"out_of_space,omitempty"`
+		CleanupWM *int64    `json:"cleanupwm,omitempty"`
+		LowWM     *int64    `json:"lowwm,omitempty"`
+		HighWM    *int64    `json:"highwm,omitempty"`
+		OOS       *int64    `json:"out_of_space,omitempty"`
+		NsQuotas  *NsQuotas `json:"ns_quotas,omitempty"`
 	}
 
 	LRUConf struct {
@@ -486,12 +546,20 @@ type (
 	}
 
 	AuthConf struct {
-		Secret  string `json:"secret"`
-		Enabled bool   `json:"enabled"`
+		Secret string `json:"secret"`
+		// AuthN's JWK set (e.g. "http://authn:52001/.well-known/jwks.json") to verify
+		// RS256 and ES256 signed tokens; when empty, tokens are verified using the shared secret
+		JWKSURL string `json:"jwks_url,omitempty"`
+		// with jwks_url: also accept (HS256) tokens signed with the shared secret - e.g., while migrating;
+		// otherwise, HMAC-signed tokens are rejected
+		AllowHMAC bool `json:"allow_hmac,omitempty"`
+		Enabled   bool `json:"enabled"`
 	}
 	AuthConfToSet struct {
-		Secret  *string `json:"secret,omitempty"`
-		Enabled *bool   `json:"enabled,omitempty"`
+		Secret    *string `json:"secret,omitempty"`
+		JWKSURL   *string `json:"jwks_url,omitempty"`
+		AllowHMAC *bool   `json:"allow_hmac,omitempty"`
+		Enabled   *bool   `json:"enabled,omitempty"`
 	}
 
 	// keepalive tracker
@@ -519,9 +587,14 @@ type (
 
 	DownloaderConf struct {
 		Timeout cos.Duration `json:"timeout"`
+		// per origin host (and per target) limits that apply to all download jobs; zero means no limit
+		MaxHostConns int     `json:"max_host_conns"` // max concurrent downloads from a given host
+		MaxHostRPS   float64 `json:"max_host_rps"`   // max requests per second to a given host
 	}
 	DownloaderConfToSet struct {
-		Timeout *cos.Duration `json:"timeout,omitempty"`
+		Timeout      *cos.Duration `json:"timeout,omitempty"`
+		MaxHostConns *int          `json:"max_host_conns,omitempty"`
+		MaxHostRPS   *float64      `json:"max_host_rps,omitempty"`
 	}
 
 	DsortConf struct {
@@ -596,6 +669,66 @@ type (
 		SbundleMult *int    `json:"bundle_multiplier,omitempty"`
 	}
 
+	ChunksConf struct {
+		// objects of this size or larger get stored as chunks (zero value disables chunking)
+		ObjSizeLimit cos.SizeIEC `json:"objsize_limit"`
+		// size of each chunk except (possibly) the last one (zero value defaults to 64MiB)
+		ChunkSize cos.SizeIEC `json:"chunk_size"`
+	}
+	ChunksConfToSet struct {
+		ObjSizeLimit *cos.SizeIEC `json:"objsize_limit,omitempty"`
+		ChunkSize    *cos.SizeIEC `json:"chunk_size,omitempty"`
+	}
+
+	TracingConf struct {
+		// OTLP/HTTP collector endpoint, e.g. "http://localhost:4318" (spans are exported to <endpoint>/v1/traces)
+		ExporterEndpoint string `json:"exporter_endpoint"`
+		// service name: "<prefix>-proxy" or "<prefix>-target" (empty value defaults to "aistore")
+		ServiceNamePrefix string `json:"service_name_prefix"`
+		// probability to trace a request that is not part of an (already sampled) trace, in the range [0, 1]
+		SamplerProbability float64 `json:"sampler_probability"`
+		Enabled            bool    `json:"enabled"`
+		SkipVerify         bool    `json:"skip_verify"` // https collector: skip certificate verification
+	}
+	TracingConfToSet struct {
+		ExporterEndpoint   *string  `json:"exporter_endpoint,omitempty"`
+		ServiceNamePrefix  *string  `json:"service_name_prefix,omitempty"`
+		SamplerProbability *float64 `json:"sampler_probability,omitempty"`
+		Enabled            *bool    `json:"enabled,omitempty"`
+		SkipVerify         *bool    `json:"skip_verify,omitempty"`
+	}
+
+	// optional (and in addition to node-wide) GET and PUT metrics labeled by bucket and,
+	// when AuthN is enabled, by user; beyond the respective max, all new buckets (users)
+	// are accounted under a single "overflow" label
+	MetricsConf struct {
+		MaxBuckets int  `json:"max_buckets"` // max number of distinct buckets (zero value defaults to 256)
+		MaxUsers   int  `json:"max_users"`   // max number of distinct users (zero value defaults to 64)
+		PerBucket  bool `json:"per_bucket"`
+		PerUser    bool `json:"per_user"` // (requires AuthN; see `auth.enabled`)
+	}
+	MetricsConfToSet struct {
+		MaxBuckets *int  `json:"max_buckets,omitempty"`
+		MaxUsers   *int  `json:"max_users,omitempty"`
+		PerBucket  *bool `json:"per_bucket,omitempty"`
+		PerUser    *bool `json:"per_user,omitempty"`
+	}
+
+	// append-only audit log (JSON lines) of administrative and data-mutating API calls;
+	// recorded by proxies and, optionally, by targets (object PUT and DELETE)
+	AuditConf struct {
+		MaxSize  cos.SizeIEC `json:"max_size"`  // exceeding this size triggers rotation (zero value defaults to 16MiB)
+		MaxTotal cos.SizeIEC `json:"max_total"` // (sum of all audit logs); exceeding this number removes the oldest (zero value defaults to 256MiB)
+		Enabled  bool        `json:"enabled"`
+		Targets  bool        `json:"targets"` // targets: also record object PUT and DELETE
+	}
+	AuditConfToSet struct {
+		MaxSize  *cos.SizeIEC `json:"max_size,omitempty"`
+		MaxTotal *cos.SizeIEC `json:"max_total,omitempty"`
+		Enabled  *bool        `json:"enabled,omitempty"`
+		Targets  *bool        `json:"targets,omitempty"`
+	}
+
 	WritePolicyConf struct {
 		Data apc.WritePolicy `json:"data"`
 		MD   apc.WritePolicy `json:"md"`
@@ -609,6 +742,9 @@ type (
 // assorted named fields that require (cluster | node) restart for changes to make an effect
 var ConfigRestartRequired = []string{"auth", "memsys", "net"}
 
+// secrets in the API output (see ClusterConfig.Redact)
+const Redacted = "**********"
+
 // dsort
 const (
 	IgnoreReaction = "ignore"
@@ -661,6 +797,10 @@ var (
 	_ Validator = (*TransportConf)(nil)
 	_ Validator = (*MemsysConf)(nil)
 	_ Validator = (*TCBConf)(nil)
+	_ Validator = (*ChunksConf)(nil)
+	_ Validator = (*TracingConf)(nil)
+	_ Validator = (*MetricsConf)(nil)
+	_ Validator = (*AuditConf)(nil)
 	_ Validator = (*WritePolicyConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
@@ -810,6 +950,9 @@ func (c *LogConf) Validate() error {
 	if c.StatsTime.D() > 10*time.Minute {
 		return fmt.Errorf("invalid log.stats_time=%s (expected range [log.stats_time, 10m])", c.StatsTime)
 	}
+	if c.Format != "" && c.Format != nlog.FormatText && c.Format != nlog.FormatJSON {
+		return fmt.Errorf("invalid log.format=%q (expecting %q or %q)", c.Format, nlog.FormatText, nlog.FormatJSON)
+	}
 	return nil
 }
 
@@ -830,6 +973,33 @@ func (c *ClientConf) Validate() error {
 	return nil
 }
 
+// Redact hides secrets - AuthN secret and s3 endpoints' secret keys - from API output.
+// Modifies the (shallow) copy in place without affecting the original config.
+func (c *ClusterConfig) Redact() {
+	c.Auth.Secret = Redacted
+	if c.IntraKey != "" {
+		c.IntraKey = Redacted
+	}
+	awsConf := c.Backend.S3Conf()
+	if awsConf == nil || len(awsConf.Endpoints) == 0 {
+		return
+	}
+	conf := make(map[string]any, len(c.Backend.Conf))
+	for provider, v := range c.Backend.Conf {
+		conf[provider] = v
+	}
+	eps := make(map[string]*S3Endpoint, len(awsConf.Endpoints))
+	for name, ep := range awsConf.Endpoints {
+		redacted := *ep
+		if redacted.SecretKey != "" {
+			redacted.SecretKey = Redacted
+		}
+		eps[name] = &redacted
+	}
+	conf[apc.AWS] = BackendConfAWS{Endpoints: eps}
+	c.Backend.Conf = conf
+}
+
 /////////////////
 // BackendConf //
 /////////////////
@@ -864,6 +1034,16 @@ func (c *BackendConf) Validate() (err error) {
 				}
 			}
 			c.Conf[provider] = aisConf
+		case apc.AWS:
+			var awsConf BackendConfAWS
+			if err := jsoniter.Unmarshal(b, &awsConf); err != nil {
+				return fmt.Errorf("invalid %s backend specification: %v", provider, err)
+			}
+			if err := awsConf.Validate(); err != nil {
+				return err
+			}
+			c.Conf[provider] = awsConf
+			c.setProvider(provider)
 		case "":
 			continue
 		default:
@@ -938,6 +1118,23 @@ func (c *BackendConf) EqualRemAIS(o *BackendConf, sname string) bool {
 	return true
 }
 
+// returns nil when there's no aws backend
+func (c *BackendConf) S3Conf() *BackendConfAWS {
+	v, ok := c.Conf[apc.AWS]
+	if !ok {
+		return nil
+	}
+	if awsConf, ok := v.(BackendConfAWS); ok {
+		return &awsConf
+	}
+	awsConf := &BackendConfAWS{}
+	if err := cos.MorphMarshal(v, awsConf); err != nil {
+		nlog.Errorln("failed to unmarshal", apc.AWS, "backend config:", err)
+		debug.AssertNoErr(err)
+	}
+	return awsConf
+}
+
 func (c BackendConfAIS) String() (s string) {
 	for a, urls := range c {
 		if s != "" {
@@ -948,6 +1145,70 @@ func (c BackendConfAIS) String() (s string) {
 	return
 }
 
+////////////////////
+// BackendConfAWS //
+////////////////////
+
+const (
+	S3AddressingPath    = "path"
+	S3AddressingVirtual = "virtual"
+)
+
+func (c *BackendConfAWS) Validate() error {
+	owners := make(cos.StrKVs, 4) // bucket => endpoint name
+	for name, ep := range c.Endpoints {
+		if !cos.IsAlphaPlus(name) {
+			return fmt.Errorf("invalid s3 endpoint name %q: use only letters, numbers, dashes (-), and underscores (_)", name)
+		}
+		if ep == nil {
+			return fmt.Errorf("s3 endpoint %q: empty specification", name)
+		}
+		if ep.URL != "" {
+			u, err := url.Parse(ep.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("s3 endpoint %q: invalid URL %q (expecting http(s)://host[:port])", name, ep.URL)
+			}
+		}
+		switch ep.Addressing {
+		case "", S3AddressingPath, S3AddressingVirtual:
+		default:
+			return fmt.Errorf("s3 endpoint %q: invalid addressing style %q (expecting %q or %q)", name, ep.Addressing,
+				S3AddressingPath, S3AddressingVirtual)
+		}
+		if (ep.AccessKey == "") != (ep.SecretKey == "") {
+			return fmt.Errorf("s3 endpoint %q: access key and secret key must be specified together", name)
+		}
+		if ep.SecretKey == Redacted {
+			return fmt.Errorf("s3 endpoint %q: secret key is redacted (specify the actual key)", name)
+		}
+		for _, bname := range ep.Buckets {
+			if other, ok := owners[bname]; ok {
+				return fmt.Errorf("bucket %q cannot resolve to two different s3 endpoints: %q and %q", bname, other, name)
+			}
+			owners[bname] = name
+		}
+	}
+	return nil
+}
+
+// named endpoint for a given bucket: the one that's explicitly specified (bucket props), if any,
+// or else the one that lists the bucket
+func (c *BackendConfAWS) Resolve(bname, epname string) (string, *S3Endpoint, error) {
+	if epname != "" {
+		ep, ok := c.Endpoints[epname]
+		if !ok {
+			return "", nil, fmt.Errorf("s3 endpoint %q does not exist (bucket %q)", epname, bname)
+		}
+		return epname, ep, nil
+	}
+	for name, ep := range c.Endpoints {
+		if cos.StringInSlice(bname, ep.Buckets) {
+			return name, ep, nil
+		}
+	}
+	return "", nil, nil
+}
+
 //////////////
 // DiskConf //
 //////////////
@@ -976,13 +1237,17 @@ func (c *DiskConf) Validate() (err error) {
 
 func (c *SpaceConf) Validate() (err error) {
 	if c.CleanupWM <= 0 || c.LowWM < c.CleanupWM || c.HighWM < c.LowWM || c.OOS < c.HighWM || c.OOS > 100 {
-		err = fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
+		return fmt.Errorf("invalid %s (expecting: 0 < cleanup < low < high < OOS < 100)", c)
 	}
-	return
+	return c.NsQuotas.Validate()
 }
 
 func (c *SpaceConf) ValidateAsProps(...any) error { return c.Validate() }
 
+func (c *SpaceConf) EqualWMs(other *SpaceConf) bool {
+	return c.CleanupWM == other.CleanupWM && c.LowWM == other.LowWM && c.HighWM == other.HighWM && c.OOS == other.OOS
+}
+
 func (c *SpaceConf) String() string {
 	return fmt.Sprintf("space config: cleanup=%d%%, low=%d%%, high=%d%%, OOS=%d%%",
 		c.CleanupWM, c.LowWM, c.HighWM, c.OOS)
@@ -1568,6 +1833,134 @@ func (c *TCBConf) Validate() error {
 	return nil
 }
 
+////////////////
+// ChunksConf //
+////////////////
+
+const (
+	DfltChunkSize = 64 * cos.MiB
+	minChunkSize  = cos.MiB
+	maxChunkSize  = 4 * cos.GiB
+)
+
+func (c *ChunksConf) Validate() error {
+	if c.ObjSizeLimit < 0 || c.ChunkSize < 0 {
+		return fmt.Errorf("invalid chunks config: negative size (%d, %d)", c.ObjSizeLimit, c.ChunkSize)
+	}
+	if c.ChunkSize != 0 && (c.ChunkSize < minChunkSize || c.ChunkSize > maxChunkSize) {
+		return fmt.Errorf("invalid chunks.chunk_size: %s (expected range [%s, %s])", c.ChunkSize,
+			cos.ToSizeIEC(minChunkSize, 0), cos.ToSizeIEC(maxChunkSize, 0))
+	}
+	if c.ObjSizeLimit != 0 && int64(c.ObjSizeLimit) <= c.Csize(math.MaxInt64) {
+		return fmt.Errorf("invalid chunks.objsize_limit: %s (must be greater than chunk size %s)",
+			c.ObjSizeLimit, cos.ToSizeIEC(c.Csize(math.MaxInt64), 0))
+	}
+	return nil
+}
+
+// returns chunk size to store an object of a given size, or zero to store it as a single file
+func (c *ChunksConf) Csize(objSize int64) int64 {
+	if c.ObjSizeLimit == 0 || objSize < int64(c.ObjSizeLimit) {
+		return 0
+	}
+	if c.ChunkSize == 0 {
+		return DfltChunkSize
+	}
+	return int64(c.ChunkSize)
+}
+
+/////////////////
+// TracingConf //
+/////////////////
+
+func (c *TracingConf) Validate() error {
+	if c.SamplerProbability < 0 || c.SamplerProbability > 1 {
+		return fmt.Errorf("invalid tracing.sampler_probability=%g (expected range [0, 1])", c.SamplerProbability)
+	}
+	if c.ExporterEndpoint != "" {
+		u, err := url.Parse(c.ExporterEndpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid tracing.exporter_endpoint %q (expecting http(s)://host[:port])", c.ExporterEndpoint)
+		}
+	}
+	if c.Enabled && c.ExporterEndpoint == "" {
+		return errors.New("tracing is enabled but tracing.exporter_endpoint is not set")
+	}
+	return nil
+}
+
+/////////////////
+// MetricsConf //
+/////////////////
+
+const (
+	DfltMetricsMaxBuckets = 256
+	DfltMetricsMaxUsers   = 64
+
+	maxMetricsCardinality = 64 * 1024
+)
+
+func (c *MetricsConf) Validate() error {
+	if c.MaxBuckets < 0 || c.MaxBuckets > maxMetricsCardinality {
+		return fmt.Errorf("invalid metrics.max_buckets=%d (expected range [0, %d])", c.MaxBuckets, maxMetricsCardinality)
+	}
+	if c.MaxUsers < 0 || c.MaxUsers > maxMetricsCardinality {
+		return fmt.Errorf("invalid metrics.max_users=%d (expected range [0, %d])", c.MaxUsers, maxMetricsCardinality)
+	}
+	return nil
+}
+
+func (c *MetricsConf) BckLimit() int {
+	if c.MaxBuckets == 0 {
+		return DfltMetricsMaxBuckets
+	}
+	return c.MaxBuckets
+}
+
+func (c *MetricsConf) UserLimit() int {
+	if c.MaxUsers == 0 {
+		return DfltMetricsMaxUsers
+	}
+	return c.MaxUsers
+}
+
+///////////////
+// AuditConf //
+///////////////
+
+const (
+	DfltAuditMaxSize  = 16 * cos.MiB
+	DfltAuditMaxTotal = 256 * cos.MiB
+)
+
+func (c *AuditConf) Validate() error {
+	if c.MaxSize != 0 && (c.MaxSize < cos.MiB || c.MaxSize > cos.GiB) {
+		return fmt.Errorf("invalid audit.max_size=%s (expected range [1MB, 1GB])", c.MaxSize)
+	}
+	if c.MaxTotal != 0 && c.MaxTotal > 100*cos.GiB {
+		return fmt.Errorf("invalid audit.max_total=%s (expected range [2*audit.max_size, 100GB])", c.MaxTotal)
+	}
+	if c.SizeLimit() > c.TotalLimit()/2 {
+		return fmt.Errorf("invalid audit.max_total=%s, must be >= 2*(audit.max_size=%s)",
+			cos.ToSizeIEC(c.TotalLimit(), 0), cos.ToSizeIEC(c.SizeLimit(), 0))
+	}
+	return nil
+}
+
+func (c *AuditConf) SizeLimit() int64 {
+	if c.MaxSize == 0 {
+		return DfltAuditMaxSize
+	}
+	return int64(c.MaxSize)
+}
+
+func (c *AuditConf) TotalLimit() int64 {
+	if c.MaxTotal == 0 {
+		return DfltAuditMaxTotal
+	}
+	return int64(c.MaxTotal)
+}
+
 /////////////////
 // TimeoutConf //
 /////////////////
@@ -1593,6 +1986,9 @@ func (c *TimeoutConf) Validate() error {
 	if c.SendFile.D() < time.Minute {
 		return fmt.Errorf("invalid timeout.send_file_time=%s (cannot be less than 1m)", c.SendFile)
 	}
+	if c.MptAbandoned != 0 && c.MptAbandoned.D() < time.Hour {
+		return fmt.Errorf("invalid timeout.mpt_abandoned_time=%s (cannot be less than 1h)", c.MptAbandoned)
+	}
 	return nil
 }
 
@@ -1604,6 +2000,27 @@ func (c *DownloaderConf) Validate() error {
 	if j := c.Timeout.D(); j < time.Second || j > time.Hour {
 		return fmt.Errorf("invalid downloader.timeout=%s (expected range [1s, 1h])", j)
 	}
+	if c.MaxHostConns < 0 {
+		return fmt.Errorf("invalid downloader.max_host_conns=%d (expected non-negative)", c.MaxHostConns)
+	}
+	if c.MaxHostRPS < 0 {
+		return fmt.Errorf("invalid downloader.max_host_rps=%f (expected non-negative)", c.MaxHostRPS)
+	}
+	return nil
+}
+
+//////////////
+// AuthConf //
+//////////////
+
+func (c *AuthConf) Validate() error {
+	if c.JWKSURL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.JWKSURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid auth.jwks_url %q (expecting http(s)://host[:port]/path)", c.JWKSURL)
+	}
 	return nil
 }
 
@@ -1773,6 +2190,7 @@ func LoadConfig(globalConfPath, localConfPath, daeRole string, config *Config) e
 
 	// Set up logging.
 	nlog.Setup(config.Log.ToStderr, int64(config.Log.MaxSize))
+	nlog.SetFormat(config.Log.Format, config.Log.AlsoToStderr)
 
 	// initialize atomic part of the config including most often used timeouts and features
 	Rom.Set(&config.ClusterConfig)
@@ -20,4 +20,5 @@ const (
 	CtxReadWrapper contextID = "readWrapper" // context key for ReadWrapperFunc
 	CtxSetSize     contextID = "setSize"     // context key for SetSizeFunc
 	CtxOriginalURL contextID = "origURL"     // context key for OriginalURL for HTTP cloud
+	CtxHTTPHeaders contextID = "httpHeaders" // context key for additional request headers (http.Header) for HTTP cloud
 )
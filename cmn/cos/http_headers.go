@@ -62,8 +62,9 @@ const (
 	S3VersionHeader = "x-amz-version-id"
 
 	// s3 api request headers
-	S3HdrObjSrc = "x-amz-copy-source"
-	S3HdrMptCnt = "x-amz-mp-parts-count"
+	S3HdrObjSrc      = "x-amz-copy-source"
+	S3HdrObjSrcRange = "x-amz-copy-source-range" // UploadPartCopy: "bytes=first-last"
+	S3HdrMptCnt      = "x-amz-mp-parts-count"
 
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
 	S3UnsignedPayload  = "UNSIGNED-PAYLOAD"
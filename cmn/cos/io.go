@@ -64,6 +64,11 @@ type (
 	LomReader interface {
 		io.ReadCloser
 		io.ReaderAt
+		io.Seeker
+	}
+	LomHandle interface { // (re)openable LomReader, e.g. FileHandle
+		LomReader
+		Open() (ReadOpenCloser, error)
 	}
 	LomWriter interface {
 		io.WriteCloser
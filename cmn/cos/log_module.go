@@ -6,6 +6,7 @@ package cos
 
 import (
 	"fmt"
+	"math/bits"
 	"strconv"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -47,6 +48,15 @@ var Smodules = []string{
 	"s3",
 }
 
+// e.g., SmoduleXs => "xs" (structured logging)
+func SmoduleName(sm int) string {
+	i := bits.TrailingZeros(uint(sm))
+	if sm == 0 || i >= len(Smodules) {
+		return ""
+	}
+	return Smodules[i]
+}
+
 type LogLevel string
 
 func (l LogLevel) Parse() (level, modules int) {
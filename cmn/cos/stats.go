@@ -12,6 +12,8 @@ const (
 	StreamsOutObjSize  = "stream.out.size"
 	StreamsInObjCount  = "stream.in.n"
 	StreamsInObjSize   = "stream.in.size"
+
+	StreamsOutObjLatency = "stream.out.ns" // from Send() to the last byte sent
 )
 
 type (
@@ -207,9 +207,12 @@ type (
 var (
 	thisNodeName string
 	cleanPathErr func(error)
+	reqID        func(*http.Request) string // request ID (structured logging)
 )
 
-func InitErrs(a string, b func(error)) { thisNodeName, cleanPathErr = a, b }
+func InitErrs(a string, b func(error), c func(*http.Request) string) {
+	thisNodeName, cleanPathErr, reqID = a, b, c
+}
 
 var (
 	ErrSkip             = errors.New("skip")
@@ -964,7 +967,13 @@ func (e *ErrHTTP) write(w http.ResponseWriter, r *http.Request, silent bool) {
 				}
 			}
 		}
-		nlog.Errorln(s)
+		var f *nlog.Fields
+		if reqID != nil {
+			if id := reqID(r); id != "" {
+				f = &nlog.Fields{ReqID: id}
+			}
+		}
+		nlog.ErrorlnWith(f, s)
 	}
 	hdr := w.Header()
 	hdr.Set(cos.HdrContentType, cos.ContentJSON)
@@ -1063,6 +1072,8 @@ func WriteErr(w http.ResponseWriter, r *http.Request, err error, opts ...int /*[
 			status = http.StatusNotFound
 		case IsErrCapExceeded(err):
 			status = http.StatusInsufficientStorage
+		case IsErrQuotaExceeded(err):
+			status = StatusQuotaExceeded
 		case IsErrRangeNotSatisfiable(err):
 			status = http.StatusRequestedRangeNotSatisfiable
 		case isErrUnsupp(err), isErrNotImpl(err):
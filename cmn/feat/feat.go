@@ -38,6 +38,7 @@ const (
 	StreamingColdGET          // write and transmit cold-GET content back to user in parallel, without _finalizing_ in-cluster object
 	S3ReverseProxy            // use reverse proxy calls instead of HTTP-redirect for S3 API
 	S3UsePathStyle            // use older path-style addressing (as opposed to virtual-hosted style), e.g., https://s3.amazonaws.com/BUCKET/KEY
+	LocalProcessETL           // allow ETL transformers to run as local processes on targets (see etl.InitProcMsg)
 )
 
 var Cluster = []string{
@@ -56,6 +57,7 @@ var Cluster = []string{
 	"Streaming-Cold-GET",
 	"S3-Reverse-Proxy",
 	"S3-Use-Path-Style", // https://aws.amazon.com/blogs/aws/amazon-s3-path-deprecation-plan-the-rest-of-the-story
+	"Local-Process-ETL",
 	// "none" ====================
 }
 
@@ -43,10 +43,16 @@ const (
 	// Token
 	Token = "auth.token"
 
+	// Downloader: key to encrypt (persisted) request headers and credentials
+	DloadKey = ".ais.dload.key"
+
 	// Markers: per mountpath
 	MarkersDir          = ".ais.markers"
 	ResilverMarker      = "resilver"
 	RebalanceMarker     = "rebalance"
 	NodeRestartedMarker = "node_restarted"
 	NodeRestartedPrev   = "node_restarted.prev"
+
+	// S3 multipart uploads: persistent state (per mountpath)
+	MptDir = ".ais.mpt"
 )
@@ -11,6 +11,7 @@ import (
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
 )
 
 // GCO (Global Config Owner) is responsible for updating and notifying
@@ -45,6 +46,7 @@ func (gco *gco) Put(config *Config) {
 	gco.c.Store(config)
 	// update assorted read-mostly knobs
 	Rom.Set(&config.ClusterConfig)
+	nlog.SetFormat(config.Log.Format, config.Log.AlsoToStderr)
 }
 
 func (gco *gco) GetOverride() *ConfigToSet       { return gco.oc.Load() }
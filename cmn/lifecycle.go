@@ -0,0 +1,156 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Bucket lifecycle: a set of rules to expire (ie., delete) objects and abort
+// incomplete multipart uploads a given number of days after their respective creation.
+// The rules are enforced by the lifecycle xaction (apc.ActLifecycle) that periodically
+// runs on every target.
+//
+// Lifecycle can be configured natively (api.SetBucketProps) or via S3 API
+// (PutBucketLifecycleConfiguration).
+// Unlike all other bucket props, lifecycle rules are never inherited from cluster config.
+
+const MaxLifecycleRules = 1000 // (as per S3)
+
+type (
+	LifecycleConf struct {
+		Rules   LifecycleRules `json:"rules,omitempty" list:"readonly"`
+		Enabled bool           `json:"enabled"`
+	}
+	LifecycleConfToSet struct {
+		Rules   *LifecycleRules `json:"rules,omitempty"`
+		Enabled *bool           `json:"enabled,omitempty"`
+	}
+
+	LifecycleRules []LifecycleRule
+
+	// Each rule applies to objects matching both the prefix and all the tags, if specified.
+	// Object tags are user-defined key/value pairs (custom metadata) of the object.
+	LifecycleRule struct {
+		ID             string     `json:"id,omitempty"`
+		Prefix         string     `json:"prefix,omitempty"`
+		Tags           cos.StrKVs `json:"tags,omitempty"`
+		ExpirationDays int        `json:"expiration_days,omitempty"` // delete objects N days after creation
+		AbortMptDays   int        `json:"abort_mpt_days,omitempty"`  // abort multipart uploads N days after initiation
+		Disabled       bool       `json:"disabled,omitempty"`
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*LifecycleConf)(nil)
+
+///////////////////
+// LifecycleConf //
+///////////////////
+
+func (c *LifecycleConf) ValidateAsProps(...any) error {
+	if len(c.Rules) > MaxLifecycleRules {
+		return fmt.Errorf("lifecycle: number of rules (%d) exceeds the maximum (%d)", len(c.Rules), MaxLifecycleRules)
+	}
+	ids := make(cos.StrSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("lifecycle rule %q: %v", rule.ID, err)
+		}
+		if rule.ID == "" {
+			continue
+		}
+		if ids.Contains(rule.ID) {
+			return fmt.Errorf("lifecycle: duplicate rule ID %q", rule.ID)
+		}
+		ids.Add(rule.ID)
+	}
+	return nil
+}
+
+func (c *LifecycleConf) IsActive() bool {
+	if !c.Enabled {
+		return false
+	}
+	for i := range c.Rules {
+		if !c.Rules[i].Disabled {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////
+// LifecycleRules //
+////////////////////
+
+func (rules LifecycleRules) String() string {
+	if len(rules) == 0 {
+		return "[]"
+	}
+	s := make([]string, 0, len(rules))
+	for i := range rules {
+		s = append(s, rules[i].String())
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+///////////////////
+// LifecycleRule //
+///////////////////
+
+func (rule *LifecycleRule) validate() error {
+	if rule.ExpirationDays < 0 || rule.AbortMptDays < 0 {
+		return errors.New("number of days cannot be negative")
+	}
+	if rule.ExpirationDays == 0 && rule.AbortMptDays == 0 {
+		return errors.New("must specify expiration and/or abort-multipart number of days")
+	}
+	return nil
+}
+
+// whether a given object (name and custom metadata) falls under the rule
+func (rule *LifecycleRule) Match(objName string, md cos.StrKVs) bool {
+	if !strings.HasPrefix(objName, rule.Prefix) {
+		return false
+	}
+	for k, v := range rule.Tags {
+		if vv, ok := md[k]; !ok || vv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (rule *LifecycleRule) String() string {
+	var sb strings.Builder
+	if rule.ID != "" {
+		sb.WriteString(rule.ID)
+		sb.WriteByte(':')
+	}
+	if rule.Prefix != "" {
+		sb.WriteString("prefix=" + rule.Prefix + ",")
+	}
+	for k, v := range rule.Tags {
+		sb.WriteString("tag:" + k + "=" + v + ",")
+	}
+	if rule.ExpirationDays > 0 {
+		sb.WriteString("expire=" + strconv.Itoa(rule.ExpirationDays) + "d,")
+	}
+	if rule.AbortMptDays > 0 {
+		sb.WriteString("abort-mpt=" + strconv.Itoa(rule.AbortMptDays) + "d,")
+	}
+	if rule.Disabled {
+		sb.WriteString("disabled,")
+	}
+	return strings.TrimSuffix(sb.String(), ",")
+}
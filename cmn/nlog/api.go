@@ -7,6 +7,7 @@ package nlog
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn/mono"
@@ -21,14 +22,29 @@ const (
 var LogToStderr bool
 var MaxSize int64 = 4 * 1024 * 1024 // usually, config.log.max_size
 
-func InfoDepth(depth int, args ...any)    { log(sevInfo, depth, "", args...) }
-func Infoln(args ...any)                  { log(sevInfo, 0, "", args...) }
-func Infof(format string, args ...any)    { log(sevInfo, 0, format, args...) }
-func Warningln(args ...any)               { log(sevWarn, 0, "", args...) }
-func Warningf(format string, args ...any) { log(sevWarn, 0, format, args...) }
-func ErrorDepth(depth int, args ...any)   { log(sevErr, depth, "", args...) }
-func Errorln(args ...any)                 { log(sevErr, 0, "", args...) }
-func Errorf(format string, args ...any)   { log(sevErr, 0, format, args...) }
+var (
+	jsonFormat   atomic.Bool // config.log.format == FormatJSON
+	alsoToStderr atomic.Bool // config.log.also_to_stderr
+)
+
+func InfoDepth(depth int, args ...any)    { log(sevInfo, depth, nil, "", args...) }
+func Infoln(args ...any)                  { log(sevInfo, 0, nil, "", args...) }
+func Infof(format string, args ...any)    { log(sevInfo, 0, nil, format, args...) }
+func Warningln(args ...any)               { log(sevWarn, 0, nil, "", args...) }
+func Warningf(format string, args ...any) { log(sevWarn, 0, nil, format, args...) }
+func ErrorDepth(depth int, args ...any)   { log(sevErr, depth, nil, "", args...) }
+func Errorln(args ...any)                 { log(sevErr, 0, nil, "", args...) }
+func Errorf(format string, args ...any)   { log(sevErr, 0, nil, format, args...) }
+
+// with structured-logging fields
+func InfolnWith(f *Fields, args ...any)                  { log(sevInfo, 0, f, "", args...) }
+func InfofWith(f *Fields, format string, args ...any)    { log(sevInfo, 0, f, format, args...) }
+func WarninglnWith(f *Fields, args ...any)               { log(sevWarn, 0, f, "", args...) }
+func WarningfWith(f *Fields, format string, args ...any) { log(sevWarn, 0, f, format, args...) }
+func ErrorlnWith(f *Fields, args ...any)                 { log(sevErr, 0, f, "", args...) }
+func ErrorfWith(f *Fields, format string, args ...any)   { log(sevErr, 0, f, format, args...) }
+func InfoDepthWith(f *Fields, depth int, args ...any)    { log(sevInfo, depth, f, "", args...) }
+func ErrorDepthWith(f *Fields, depth int, args ...any)   { log(sevErr, depth, f, "", args...) }
 
 func Setup(logToStderr bool, maxSize int64) {
 	LogToStderr = logToStderr
@@ -39,6 +55,12 @@ func Setup(logToStderr bool, maxSize int64) {
 	}
 }
 
+// can be called at any time (e.g., upon config change); takes effect with the next line
+func SetFormat(format string, toStderr bool) {
+	jsonFormat.Store(format == FormatJSON)
+	alsoToStderr.Store(toStderr)
+}
+
 func SetLogDirRole(dir, role string) {
 	if logDir != "" && logDir != dir && unitTests.Load() {
 		msg := fmt.Sprintf("log dir %q != %q (using nlog _prior_ to loading config?)", logDir, dir)
@@ -47,7 +69,8 @@ func SetLogDirRole(dir, role string) {
 	logDir, aisrole = dir, role
 }
 
-func SetTitle(s string) { title = s }
+func SetTitle(s string)  { title = s }
+func SetNodeID(s string) { nodeID = s }
 
 func InfoLogName() string { return sname() + ".INFO" }
 func ErrLogName() string  { return sname() + ".ERROR" }
@@ -36,6 +36,7 @@ var (
 	arg0    string
 	aisrole string
 	title   string
+	nodeID  string
 
 	pid int
 
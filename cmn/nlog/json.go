@@ -0,0 +1,129 @@
+// Package nlog - aistore logger, provides buffering, timestamping, writing, and
+// flushing/syncing/rotating
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package nlog
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Structured (JSON lines) format - one JSON object per line, e.g.:
+// {"ts":"2024-05-14T15:04:05.123456-07:00","sev":"info","node":"t[fqWt8081]","file":"base:360","module":"xs","xid":"g8lYAUtDN","msg":"..."}
+//
+// Optional fields ("node", "file", "module", "xid", "req") are omitted when empty.
+// See also: config.log.format, Fields, and InfofWith et al.
+
+const (
+	FormatText = "text" // default
+	FormatJSON = "json"
+)
+
+const tsLayout = "2006-01-02T15:04:05.000000Z07:00"
+
+// structured-logging fields (JSON format only; ignored otherwise)
+type Fields struct {
+	Module string // cos.Smodules name, e.g. "xs" (see cos.SmoduleName)
+	Xid    string // xaction ID
+	ReqID  string // request ID (e.g., W3C trace-id)
+}
+
+var sevName = []string{sevInfo: "info", sevWarn: "warning", sevErr: "error"}
+
+func jsonf(sev severity, depth int, f *Fields, format string, fb *fixed, args ...any) {
+	var (
+		fileln  string
+		now     = time.Now()
+		scratch = alloc()
+	)
+	if fn, ln, ok := caller(3 + depth); ok {
+		if _, redact := redactFnames[fn]; !redact {
+			fileln = fn + ":" + strconv.Itoa(ln)
+		}
+	}
+	if format == "" {
+		fmt.Fprintln(scratch, args...)
+	} else {
+		fmt.Fprintf(scratch, format, args...)
+	}
+	jsonLine(sev, now, fileln, f, string(scratch.buf[:scratch.woff]), fb)
+	free(scratch)
+}
+
+func jsonLine(sev severity, now time.Time, fileln string, f *Fields, msg string, fb *fixed) {
+	fb.writeString(`{"ts":"`)
+	fb.writeString(now.Format(tsLayout))
+	fb.writeString(`","sev":"`)
+	fb.writeString(sevName[sev])
+	fb.writeByte('"')
+	jsonField(fb, "node", nodeID)
+	jsonField(fb, "file", fileln)
+	if f != nil {
+		jsonField(fb, "module", f.Module)
+		jsonField(fb, "xid", f.Xid)
+		jsonField(fb, "req", f.ReqID)
+	}
+	fb.writeString(`,"msg":"`)
+
+	// always terminate the line (truncating the message if need be)
+	const tail = len("\"}\n")
+	for len(msg) > 0 && msg[len(msg)-1] == '\n' {
+		msg = msg[:len(msg)-1]
+	}
+	jsonEscape(fb, msg, fb.avail()-tail)
+	fb.writeString("\"}\n")
+}
+
+func jsonField(fb *fixed, name, value string) {
+	if value == "" {
+		return
+	}
+	fb.writeString(`,"`)
+	fb.writeString(name)
+	fb.writeString(`":"`)
+	jsonEscape(fb, value, fb.avail())
+	fb.writeByte('"')
+}
+
+// write JSON-escaped string, up to `limit` bytes
+func jsonEscape(fb *fixed, s string, limit int) {
+	const hex = "0123456789abcdef"
+	var (
+		esc [6]byte
+		end = fb.woff + max(limit, 0)
+	)
+	for i := range len(s) {
+		var (
+			c = s[i]
+			p []byte
+		)
+		switch {
+		case c == '"' || c == '\\':
+			esc[0], esc[1] = '\\', c
+			p = esc[:2]
+		case c == '\n':
+			esc[0], esc[1] = '\\', 'n'
+			p = esc[:2]
+		case c == '\t':
+			esc[0], esc[1] = '\\', 't'
+			p = esc[:2]
+		case c < 0x20:
+			esc[0], esc[1], esc[2], esc[3], esc[4], esc[5] = '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf]
+			p = esc[:6]
+		default:
+			if fb.woff >= end {
+				return
+			}
+			fb.buf[fb.woff] = c
+			fb.woff++
+			continue
+		}
+		if fb.woff+len(p) > end {
+			return
+		}
+		fb.woff += copy(fb.buf[fb.woff:], p)
+	}
+}
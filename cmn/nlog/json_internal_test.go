@@ -0,0 +1,70 @@
+// Package nlog - aistore logger, provides buffering, timestamping, writing, and
+// flushing/syncing/rotating
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package nlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type jline struct {
+	Ts     string `json:"ts"`
+	Sev    string `json:"sev"`
+	Node   string `json:"node"`
+	File   string `json:"file"`
+	Module string `json:"module"`
+	Xid    string `json:"xid"`
+	Req    string `json:"req"`
+	Msg    string `json:"msg"`
+}
+
+func TestJSONLine(t *testing.T) {
+	var (
+		fb  = &fixed{buf: make([]byte, maxLineSize)}
+		msg = "quoted \"x\", back\\slash, tab\t, ctl\x01, unicode ü, multi\nline\n"
+		now = time.Now()
+	)
+	nodeID = "t[abc]"
+	jsonLine(sevWarn, now, "base:360", &Fields{Module: "xs", Xid: "g8lYAUtDN", ReqID: "r1"}, msg, fb)
+	nodeID = ""
+
+	var jl jline
+	if err := json.Unmarshal(fb.buf[:fb.woff], &jl); err != nil {
+		t.Fatalf("invalid JSON %q: %v", fb.buf[:fb.woff], err)
+	}
+	if jl.Sev != "warning" || jl.Node != "t[abc]" || jl.File != "base:360" ||
+		jl.Module != "xs" || jl.Xid != "g8lYAUtDN" || jl.Req != "r1" {
+		t.Errorf("unexpected %+v", jl)
+	}
+	if jl.Msg != strings.TrimSuffix(msg, "\n") {
+		t.Errorf("expected message %q, got %q", msg, jl.Msg)
+	}
+	if ts, err := time.Parse(tsLayout, jl.Ts); err != nil || !ts.Equal(now.Truncate(time.Microsecond)) {
+		t.Errorf("unexpected timestamp %q (%v)", jl.Ts, err)
+	}
+	if fb.buf[fb.woff-1] != '\n' || bytes.Count(fb.buf[:fb.woff], []byte{'\n'}) != 1 {
+		t.Errorf("expected a single line, got %q", fb.buf[:fb.woff])
+	}
+}
+
+func TestJSONLineTruncated(t *testing.T) {
+	fb := &fixed{buf: make([]byte, 256)}
+	jsonLine(sevErr, time.Now(), "", nil, strings.Repeat("\"", 1000), fb)
+
+	var jl jline
+	if err := json.Unmarshal(fb.buf[:fb.woff], &jl); err != nil {
+		t.Fatalf("invalid JSON %q: %v", fb.buf[:fb.woff], err)
+	}
+	if jl.Sev != "error" || jl.Node != "" || jl.File != "" || len(jl.Msg) == 0 {
+		t.Errorf("unexpected %+v", jl)
+	}
+	if fb.buf[fb.woff-1] != '\n' {
+		t.Errorf("expected terminated line, got %q", fb.buf[:fb.woff])
+	}
+}
@@ -50,7 +50,7 @@ type (
 )
 
 // main function
-func log(sev severity, depth int, format string, args ...any) {
+func log(sev severity, depth int, f *Fields, format string, args ...any) {
 	onceInitFiles.Do(initFiles)
 
 	switch {
@@ -59,13 +59,13 @@ func log(sev severity, depth int, format string, args ...any) {
 		fallthrough
 	case LogToStderr:
 		fb := alloc()
-		sprintf(sev, depth, format, fb, args...)
+		sprintf(sev, depth, f, format, fb, args...)
 		fb.flush(os.Stderr)
 		free(fb)
 	case sev >= sevWarn:
 		fb := alloc()
-		sprintf(sev, depth, format, fb, args...)
-		if sev >= sevErr {
+		sprintf(sev, depth, f, format, fb, args...)
+		if sev >= sevErr || alsoToStderr.Load() {
 			fb.flush(os.Stderr)
 		}
 		if sev >= sevWarn {
@@ -81,7 +81,7 @@ func log(sev severity, depth int, format string, args ...any) {
 		free(fb)
 	default:
 		// fast path
-		nlogs[sevInfo].printf(sev, depth, format, args...)
+		nlogs[sevInfo].printf(sev, depth, f, format, args...)
 	}
 }
 
@@ -103,11 +103,14 @@ func newNlog(sev severity) *nlog {
 
 func (nlog *nlog) since(now int64) time.Duration { return time.Duration(now - nlog.last.Load()) }
 
-func (nlog *nlog) printf(sev severity, depth int, format string, args ...any) {
+func (nlog *nlog) printf(sev severity, depth int, f *Fields, format string, args ...any) {
 	nlog.mw.Lock()
 	nlog.line.reset()
-	sprintf(sev, depth+1, format, &nlog.line, args...)
+	sprintf(sev, depth+1, f, format, &nlog.line, args...)
 	nlog.write(&nlog.line)
+	if alsoToStderr.Load() {
+		nlog.line.flush(os.Stderr)
+	}
 	nlog.mw.Unlock()
 }
 
@@ -232,9 +235,22 @@ func (nlog *nlog) rotate(now time.Time) (err error) {
 	nlog.erred.Store(false)
 	if title == "" {
 		line1 = "Started up at " + snow + ", " + s
-		_, err = nlog.file.WriteString(line1)
 	} else {
 		line1 = "Rotated at " + snow + ", " + s
+	}
+	if jsonFormat.Load() {
+		// (one JSON line each)
+		fb := &fixed{buf: make([]byte, maxLineSize)}
+		jsonLine(sevInfo, now, "", nil, line1, fb)
+		if title != "" {
+			jsonLine(sevInfo, now, "", nil, title, fb)
+		}
+		_, err = nlog.file.Write(fb.buf[:fb.woff])
+		return
+	}
+	if title == "" {
+		_, err = nlog.file.WriteString(line1)
+	} else {
 		nlog.file.WriteString(line1)
 		_, err = nlog.file.WriteString(title)
 	}
@@ -260,9 +276,8 @@ func logfname(tag string, t time.Time) (name, link string) {
 	return name, s + "." + tag
 }
 
-func formatHdr(s severity, depth int, fb *fixed) {
-	const char = "IWE"
-	_, fn, ln, ok := runtime.Caller(3 + depth)
+func caller(depth int) (fn string, ln int, ok bool) {
+	_, fn, ln, ok = runtime.Caller(depth + 1)
 	if !ok {
 		return
 	}
@@ -273,6 +288,15 @@ func formatHdr(s severity, depth int, fb *fixed) {
 	if l := len(fn); l > 3 {
 		fn = fn[:l-3]
 	}
+	return
+}
+
+func formatHdr(s severity, depth int, fb *fixed) {
+	const char = "IWE"
+	fn, ln, ok := caller(3 + depth)
+	if !ok {
+		return
+	}
 	fb.writeByte(char[s])
 	fb.writeByte(' ')
 	now := time.Now()
@@ -288,7 +312,11 @@ func formatHdr(s severity, depth int, fb *fixed) {
 	fb.writeByte(' ')
 }
 
-func sprintf(sev severity, depth int, format string, fb *fixed, args ...any) {
+func sprintf(sev severity, depth int, f *Fields, format string, fb *fixed, args ...any) {
+	if jsonFormat.Load() {
+		jsonf(sev, depth+1, f, format, fb, args...)
+		return
+	}
 	formatHdr(sev, depth+1, fb)
 	if format == "" {
 		fmt.Fprintln(fb, args...)
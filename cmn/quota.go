@@ -0,0 +1,165 @@
+// Package cmn provides common constants, types, and utilities for AIS clients
+// and AIStore.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+)
+
+// Storage quotas: limit the total size (bytes) and/or number of objects per bucket
+// (bucket property "quota") and per namespace (cluster config "space.ns_quotas").
+//
+// - hard quota: PUT, APPEND, and copy into the bucket (namespace) fail with ErrQuotaExceeded
+//   once the limit is reached;
+// - soft quota: a warning gets logged but the operation proceeds.
+//
+// Targets track usage incrementally, proxies aggregate it cluster-wide (on demand)
+// and enforce the limits. Therefore, quotas are approximate (but not by much).
+// Zero means unlimited.
+
+type (
+	QuotaConf struct {
+		Size     int64 `json:"size,string"`         // hard limit: total size in bytes
+		SoftSize int64 `json:"soft_size,string"`    // soft limit: ditto
+		Objs     int64 `json:"objects,string"`      // hard limit: number of objects
+		SoftObjs int64 `json:"soft_objects,string"` // soft limit: ditto
+		Enabled  bool  `json:"enabled"`
+	}
+	QuotaConfToSet struct {
+		Size     *int64 `json:"size,string,omitempty"`
+		SoftSize *int64 `json:"soft_size,string,omitempty"`
+		Objs     *int64 `json:"objects,string,omitempty"`
+		SoftObjs *int64 `json:"soft_objects,string,omitempty"`
+		Enabled  *bool  `json:"enabled,omitempty"`
+	}
+
+	// namespace (see Ns.Uname) => quota
+	NsQuotas map[string]QuotaConf
+
+	QuotaUsage struct {
+		Size int64 `json:"size,string"`
+		Objs int64 `json:"objects,string"`
+	}
+	// bucket (see Bck.MakeUname) or namespace (see Ns.Uname) => usage
+	QuotaUsages map[string]*QuotaUsage
+
+	// cluster-wide (as aggregated by proxy)
+	AllQuotaUsages struct {
+		Buckets    QuotaUsages `json:"buckets"`
+		Namespaces QuotaUsages `json:"namespaces"`
+	}
+
+	ErrQuotaExceeded struct {
+		what  string // bucket or namespace
+		usage QuotaUsage
+		quota QuotaConf
+	}
+)
+
+// interface guard
+var _ PropsValidator = (*QuotaConf)(nil)
+
+///////////////
+// QuotaConf //
+///////////////
+
+func (c *QuotaConf) ValidateAsProps(...any) error {
+	if c.Size < 0 || c.SoftSize < 0 || c.Objs < 0 || c.SoftObjs < 0 {
+		return errors.New("quota: limits cannot be negative")
+	}
+	if c.Size > 0 && c.SoftSize > c.Size {
+		return fmt.Errorf("quota: soft size limit (%s) exceeds hard limit (%s)",
+			cos.ToSizeIEC(c.SoftSize, 2), cos.ToSizeIEC(c.Size, 2))
+	}
+	if c.Objs > 0 && c.SoftObjs > c.Objs {
+		return fmt.Errorf("quota: soft number of objects (%d) exceeds hard limit (%d)", c.SoftObjs, c.Objs)
+	}
+	return nil
+}
+
+func (c *QuotaConf) IsActive() bool {
+	return c.Enabled && (c.Size > 0 || c.SoftSize > 0 || c.Objs > 0 || c.SoftObjs > 0)
+}
+
+// check usage against (hard and soft) limits, given the size that is about to be added
+func (c *QuotaConf) Check(what string, u *QuotaUsage, size int64) (soft bool, err error) {
+	if !c.IsActive() {
+		return false, nil
+	}
+	if (c.Size > 0 && u.Size+size > c.Size) || (c.Objs > 0 && u.Objs >= c.Objs) {
+		return false, &ErrQuotaExceeded{what: what, usage: *u, quota: *c}
+	}
+	soft = (c.SoftSize > 0 && u.Size+size > c.SoftSize) || (c.SoftObjs > 0 && u.Objs >= c.SoftObjs)
+	return soft, nil
+}
+
+func (c *QuotaConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return fmt.Sprintf("size=%s(soft %s), objects=%d(soft %d)",
+		cos.ToSizeIEC(c.Size, 2), cos.ToSizeIEC(c.SoftSize, 2), c.Objs, c.SoftObjs)
+}
+
+//////////////
+// NsQuotas //
+//////////////
+
+func (m NsQuotas) Validate() error {
+	for uname, q := range m {
+		ns := ParseNsUname(uname)
+		if err := ns.validate(); err != nil {
+			return fmt.Errorf("namespace quota %q: %v", uname, err)
+		}
+		if err := q.ValidateAsProps(); err != nil {
+			return fmt.Errorf("namespace quota %q: %v", uname, err)
+		}
+	}
+	return nil
+}
+
+func (m NsQuotas) Get(ns Ns) (q QuotaConf, ok bool) {
+	if len(m) == 0 {
+		return
+	}
+	q, ok = m[ns.Uname()]
+	return
+}
+
+/////////////////
+// QuotaUsages //
+/////////////////
+
+func (m QuotaUsages) Add(uname string, size, objs int64) {
+	u, ok := m[uname]
+	if !ok {
+		u = &QuotaUsage{}
+		m[uname] = u
+	}
+	u.Size += size
+	u.Objs += objs
+}
+
+//////////////////////
+// ErrQuotaExceeded //
+//////////////////////
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("%s: quota exceeded (used %s in %d object%s, quota %s)", e.what,
+		cos.ToSizeIEC(e.usage.Size, 2), e.usage.Objs, cos.Plural(int(e.usage.Objs)), e.quota.String())
+}
+
+func IsErrQuotaExceeded(err error) bool {
+	_, ok := err.(*ErrQuotaExceeded)
+	return ok
+}
+
+// distinct HTTP status (compare with http.StatusInsufficientStorage - out of space)
+const StatusQuotaExceeded = http.StatusRequestEntityTooLarge
@@ -72,3 +72,64 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestBackendConfAWS(t *testing.T) {
+	valid := cmn.BackendConfAWS{Endpoints: map[string]*cmn.S3Endpoint{
+		"minio": {URL: "http://minio:9000", AccessKey: "ak", SecretKey: "sk", Addressing: cmn.S3AddressingPath,
+			Buckets: []string{"abc"}},
+		"aws-prod": {Region: "us-west-2", Profile: "prod"},
+	}}
+	tassert.CheckFatal(t, valid.Validate())
+
+	invalid := map[string]*cmn.S3Endpoint{
+		"bad name":  {},
+		"nil":       nil,
+		"no-scheme": {URL: "minio:9000"},
+		"ftp":       {URL: "ftp://minio"},
+		"style":     {Addressing: "dns"},
+		"no-secret": {AccessKey: "ak"},
+		"redacted":  {AccessKey: "ak", SecretKey: cmn.Redacted},
+	}
+	for name, ep := range invalid {
+		c := cmn.BackendConfAWS{Endpoints: map[string]*cmn.S3Endpoint{name: ep}}
+		tassert.Errorf(t, c.Validate() != nil, "expected endpoint %q (%+v) to fail validation", name, ep)
+	}
+	dup := cmn.BackendConfAWS{Endpoints: map[string]*cmn.S3Endpoint{
+		"one": {Buckets: []string{"abc"}},
+		"two": {Buckets: []string{"xyz", "abc"}},
+	}}
+	tassert.Errorf(t, dup.Validate() != nil, "expected bucket listed by two endpoints to fail validation")
+
+	// resolve: explicitly named (bucket props) takes precedence over the endpoint's list of buckets
+	name, ep, err := valid.Resolve("abc", "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, name == "minio" && ep.URL == "http://minio:9000", "abc: unexpected (%q, %+v)", name, ep)
+	name, ep, err = valid.Resolve("abc", "aws-prod")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, name == "aws-prod" && ep.Profile == "prod", "abc: unexpected (%q, %+v)", name, ep)
+	name, ep, err = valid.Resolve("xyz", "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, name == "" && ep == nil, "xyz: expected no endpoint, got (%q, %+v)", name, ep)
+	_, _, err = valid.Resolve("xyz", "nonexistent")
+	tassert.Errorf(t, err != nil, "expected error resolving nonexistent endpoint")
+}
+
+func TestConfigRedact(t *testing.T) {
+	config := cmn.ClusterConfig{}
+	config.Auth.Secret = "authn-secret"
+	config.Backend.Conf = map[string]any{
+		apc.AWS: cmn.BackendConfAWS{Endpoints: map[string]*cmn.S3Endpoint{
+			"minio": {URL: "http://minio:9000", AccessKey: "ak", SecretKey: "sk"},
+		}},
+	}
+	c := config
+	c.Redact()
+
+	ep := c.Backend.S3Conf().Endpoints["minio"]
+	tassert.Errorf(t, c.Auth.Secret == cmn.Redacted && ep.SecretKey == cmn.Redacted && ep.AccessKey == "ak",
+		"expected redacted secrets, got %q, %+v", c.Auth.Secret, ep)
+
+	// the original remains intact
+	ep = config.Backend.S3Conf().Endpoints["minio"]
+	tassert.Errorf(t, config.Auth.Secret == "authn-secret" && ep.SecretKey == "sk", "original modified: %+v", ep)
+}
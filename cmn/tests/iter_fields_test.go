@@ -91,10 +91,20 @@ var _ = Describe("IterFields", func() {
 					"lru.dont_evict_time":   cos.Duration(0),
 					"lru.capacity_upd_time": cos.Duration(0),
 
-					"extra.aws.cloud_region": "us-central",
-					"extra.aws.endpoint":     "",
-					"extra.aws.profile":      "",
-					"extra.aws.max_pagesize": int64(0),
+					"soft_delete.enabled":   false,
+					"soft_delete.retention": cos.Duration(0),
+
+					"quota.size":         int64(0),
+					"quota.soft_size":    int64(0),
+					"quota.objects":      int64(0),
+					"quota.soft_objects": int64(0),
+					"quota.enabled":      false,
+
+					"extra.aws.cloud_region":  "us-central",
+					"extra.aws.endpoint":      "",
+					"extra.aws.profile":       "",
+					"extra.aws.endpoint_name": "",
+					"extra.aws.max_pagesize":  int64(0),
 
 					"access":   apc.AccessAttrs(0),
 					"features": feat.Flags(0),
@@ -150,6 +160,20 @@ var _ = Describe("IterFields", func() {
 					"lru.dont_evict_time":   (*cos.Duration)(nil),
 					"lru.capacity_upd_time": (*cos.Duration)(nil),
 
+					"lifecycle.rules":   (*cmn.LifecycleRules)(nil),
+					"lifecycle.enabled": (*bool)(nil),
+
+					"soft_delete.enabled":   (*bool)(nil),
+					"soft_delete.retention": (*cos.Duration)(nil),
+
+					"grants": (*cmn.AccessGrants)(nil),
+
+					"quota.size":         (*int64)(nil),
+					"quota.soft_size":    (*int64)(nil),
+					"quota.objects":      (*int64)(nil),
+					"quota.soft_objects": (*int64)(nil),
+					"quota.enabled":      (*bool)(nil),
+
 					"access":   apc.Ptr[apc.AccessAttrs](1024),
 					"features": apc.Ptr[feat.Flags](1024),
 
@@ -160,8 +184,10 @@ var _ = Describe("IterFields", func() {
 					"extra.aws.cloud_region":   (*string)(nil),
 					"extra.aws.endpoint":       (*string)(nil),
 					"extra.aws.profile":        (*string)(nil),
+					"extra.aws.endpoint_name":  (*string)(nil),
 					"extra.aws.max_pagesize":   (*int64)(nil),
 					"extra.http.original_url":  (*string)(nil),
+					"extra.http.manifest_url":  (*string)(nil),
 				},
 			),
 			Entry("check for omit tag",
@@ -0,0 +1,56 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests_test
+
+import (
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Quota", func() {
+	quota := cmn.QuotaConf{Size: 10 * cos.MiB, SoftSize: 8 * cos.MiB, Objs: 100, SoftObjs: 90, Enabled: true}
+
+	DescribeTable("check usage against hard and soft limits",
+		func(u cmn.QuotaUsage, size int64, expectSoft, expectErr bool) {
+			soft, err := quota.Check("bucket", &u, size)
+			Expect(soft).To(Equal(expectSoft))
+			if expectErr {
+				Expect(cmn.IsErrQuotaExceeded(err)).To(BeTrue())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("within limits", cmn.QuotaUsage{Size: cos.MiB, Objs: 1}, int64(cos.MiB), false, false),
+		Entry("soft size", cmn.QuotaUsage{Size: 8 * cos.MiB, Objs: 1}, int64(cos.MiB), true, false),
+		Entry("soft objects", cmn.QuotaUsage{Size: cos.MiB, Objs: 90}, int64(cos.MiB), true, false),
+		Entry("hard size", cmn.QuotaUsage{Size: 9 * cos.MiB, Objs: 1}, int64(2*cos.MiB), false, true),
+		Entry("hard objects", cmn.QuotaUsage{Size: cos.MiB, Objs: 100}, int64(0), false, true),
+	)
+
+	It("should not check disabled quota", func() {
+		disabled := quota
+		disabled.Enabled = false
+		soft, err := disabled.Check("bucket", &cmn.QuotaUsage{Size: cos.GiB, Objs: 1000}, 0)
+		Expect(soft).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should validate", func() {
+		Expect(quota.ValidateAsProps()).NotTo(HaveOccurred())
+		invalid := quota
+		invalid.SoftSize = 20 * cos.MiB
+		Expect(invalid.ValidateAsProps()).To(HaveOccurred())
+
+		nsq := cmn.NsQuotas{"@#ns1": quota}
+		Expect(nsq.Validate()).NotTo(HaveOccurred())
+		q, ok := nsq.Get(cmn.Ns{Name: "ns1"})
+		Expect(ok).To(BeTrue())
+		Expect(q).To(Equal(quota))
+		nsq["@#ns2"] = invalid
+		Expect(nsq.Validate()).To(HaveOccurred())
+	})
+})
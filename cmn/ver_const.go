@@ -42,6 +42,8 @@ const (
 	MetaverLOM   = 1 // LOM
 	MetaverChunk = 2 // LOM chunk
 
+	MetaverMpt = 1 // S3 multipart upload state (jsp)
+
 	MetaverConfig      = 4 // Global Configuration (jsp)
 	MetaverAuthNConfig = 1 // Authn config (jsp) // ditto
 	MetaverAuthTokens  = 1 // Authn tokens (jsp) // ditto
@@ -0,0 +1,514 @@
+// Package core provides core metadata and in-cluster API
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Chunked objects:
+// - an object of size greater or equal `chunks.objsize_limit` (cluster config) is stored
+//   as a sequence of fixed-size chunks (with the last chunk being possibly shorter) -
+//   unless the bucket is mirrored (see lom.Csize);
+// - chunk #N resides at <mountpath>/<bucket>/%ch/<object-name>.N, where the mountpath is
+//   selected via HRW(uname, N) - chunks are, therefore, spread across all available mountpaths;
+// - the main file (lom.FQN) is empty and carries object metadata that, in turn, includes
+//   the chunk size (the number of chunks is then derived from the object size);
+// - readers locate a given chunk at its HRW mountpath, and fall back to searching all
+//   available mountpaths (e.g., when mountpaths change and resilvering is still running);
+// - writers write work chunks sequentially, rolling over every chunk size bytes;
+//   work chunks get committed (renamed) when the object is finalized - see RenameToMain.
+
+type (
+	chunkLoc struct {
+		bck     cmn.Bck
+		objName string
+		uname   string
+		size    int64
+		csize   int64
+	}
+	chunkFile struct {
+		mi  *fs.Mountpath
+		fqn string
+	}
+
+	// reads chunked object or its section [off, end); implements cos.LomHandle;
+	// NOTE: offsets passed to ReadAt and Seek are relative to the section
+	chunkReader struct {
+		fh  *os.File // currently open chunk
+		loc chunkLoc
+		num int   // its number
+		off int64 // section
+		end int64
+		pos int64 // (Read and Seek)
+		mu  sync.Mutex
+	}
+
+	// writes work chunks; implements cos.LomWriter
+	chunkWriter struct {
+		lom    *LOM
+		fh     *os.File // current work chunk
+		wfqn   string   // main workfile
+		loc    chunkLoc
+		works  []chunkFile
+		woff   int64 // offset within the current chunk
+		fsync  bool
+		closed bool
+	}
+	chunkWork struct {
+		works []chunkFile
+		csize int64
+	}
+)
+
+// interface guard
+var (
+	_ cos.LomHandle = (*chunkReader)(nil)
+	_ cos.LomWriter = (*chunkWriter)(nil)
+)
+
+// main workfile FQN => work chunks written but not yet committed
+var chunksWork sync.Map
+
+//////////////
+// chunkLoc //
+//////////////
+
+func (lom *LOM) chunkLoc(csize int64) chunkLoc {
+	return chunkLoc{bck: *lom.Bucket(), objName: lom.ObjName, uname: lom.Uname(), size: lom.md.Size, csize: csize}
+}
+
+func (loc *chunkLoc) count() int {
+	return int((loc.size + loc.csize - 1) / loc.csize)
+}
+
+func (loc *chunkLoc) hrw(num int) (*fs.Mountpath, error) {
+	mi, _, err := fs.Hrw(cos.UnsafeB(loc.uname + "." + strconv.Itoa(num)))
+	return mi, err
+}
+
+func (loc *chunkLoc) fqn(mi *fs.Mountpath, num int) string {
+	return mi.MakePathFQN(&loc.bck, fs.ChunkType, loc.objName+"."+strconv.Itoa(num))
+}
+
+// open chunk at its HRW location or, failing that, any available mountpath
+func (loc *chunkLoc) open(num int) (*os.File, error) {
+	mi, err := loc.hrw(num)
+	if err != nil {
+		return nil, err
+	}
+	fh, err := os.Open(loc.fqn(mi, num))
+	if err == nil || !os.IsNotExist(err) {
+		return fh, err
+	}
+	for _, mpi := range fs.GetAvail() {
+		if mpi.Path == mi.Path {
+			continue
+		}
+		if fh, errN := os.Open(loc.fqn(mpi, num)); errN == nil {
+			return fh, nil
+		}
+	}
+	return nil, err
+}
+
+// remove chunk from all available mountpaths (normally, there's only one)
+func (loc *chunkLoc) remove(num int) (err error) {
+	for _, mi := range fs.GetAvail() {
+		if errN := cos.RemoveFile(loc.fqn(mi, num)); errN != nil {
+			err = errN
+		}
+	}
+	return err
+}
+
+/////////////////
+// chunkReader //
+/////////////////
+
+// (compare with lom.Open)
+func (lom *LOM) NewHandle() (cos.LomHandle, error) {
+	if lom.IsChunked() {
+		return lom.newChunkReader(0, lom.md.Size), nil
+	}
+	fh, err := cos.NewFileHandle(lom.FQN)
+	if err != nil {
+		return nil, err
+	}
+	return fh, nil
+}
+
+// open object's section [off, off+size) for reading
+func (lom *LOM) OpenSection(off, size int64) (cos.ReadOpenCloser, error) {
+	if lom.IsChunked() {
+		debug.Assert(off >= 0 && off+size <= lom.md.Size, off, size, lom.md.Size)
+		return lom.newChunkReader(off, size), nil
+	}
+	return cos.NewFileSectionHandle(lom.FQN, off, size)
+}
+
+func (lom *LOM) newChunkReader(off, size int64) *chunkReader {
+	return &chunkReader{loc: lom.chunkLoc(lom.md.csize), num: -1, off: off, end: off + size}
+}
+
+func (r *chunkReader) Open() (cos.ReadOpenCloser, error) {
+	return &chunkReader{loc: r.loc, num: -1, off: r.off, end: r.end}, nil
+}
+
+func (r *chunkReader) ReadAt(b []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, errors.New("chunk-reader: negative offset")
+	}
+	r.mu.Lock()
+	n, err = r._read(b, r.off+off)
+	r.mu.Unlock()
+	return n, err
+}
+
+func (r *chunkReader) _read(b []byte, abs int64) (n int, err error) {
+	csize := r.loc.csize
+	for n < len(b) {
+		if abs >= r.end {
+			return n, io.EOF
+		}
+		var (
+			num  = int(abs / csize)
+			coff = abs - int64(num)*csize
+			want = min(int64(len(b)-n), r.end-abs, csize-coff)
+			m    int
+		)
+		if num != r.num {
+			if r.fh != nil {
+				cos.Close(r.fh)
+				r.fh = nil
+			}
+			if r.fh, err = r.loc.open(num); err != nil {
+				r.num = -1
+				return n, err
+			}
+			r.num = num
+		}
+		m, err = r.fh.ReadAt(b[n:n+int(want)], coff)
+		n += m
+		abs += int64(m)
+		if err != nil {
+			if err == io.EOF {
+				err = fmt.Errorf("chunk-reader: %s chunk %d is short: %w", r.loc.objName, num, io.ErrUnexpectedEOF)
+			}
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (r *chunkReader) Read(b []byte) (n int, err error) {
+	r.mu.Lock()
+	n, err = r._read(b, r.off+r.pos)
+	r.pos += int64(n)
+	r.mu.Unlock()
+	return n, err
+}
+
+func (r *chunkReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.pos
+	case io.SeekEnd:
+		offset += r.end - r.off
+	default:
+		return 0, errors.New("chunk-reader: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("chunk-reader: negative position")
+	}
+	r.pos = offset
+	return offset, nil
+}
+
+func (r *chunkReader) Close() (err error) {
+	r.mu.Lock()
+	if r.fh != nil {
+		err = r.fh.Close()
+		r.fh = nil
+	}
+	r.mu.Unlock()
+	return err
+}
+
+/////////////////
+// chunkWriter //
+/////////////////
+
+// returns chunk size to store the object of a given size, or zero to store it as a single file;
+// NOTE: objects in mirrored buckets are never chunked - n-way mirroring copies whole files
+// (see mirror/utils.go)
+func (lom *LOM) Csize(conf *cmn.ChunksConf, size int64) int64 {
+	if mconf := lom.MirrorConf(); mconf.Enabled && mconf.Copies > 1 {
+		return 0
+	}
+	return conf.Csize(size)
+}
+
+// create chunked workfile that is subsequently committed via lom.RenameFinalize (or lom.RenameToMain)
+// and discarded via lom.RemoveWork
+func (lom *LOM) CreateChunked(wfqn string, csize int64) (cos.LomWriter, error) {
+	debug.Assert(csize > 0)
+	bdir := lom.mi.MakePathBck(lom.Bucket())
+	if err := cos.Stat(bdir); err != nil {
+		return nil, fmt.Errorf("%s (bdir %s): %w", lom, bdir, err)
+	}
+	w := &chunkWriter{
+		lom:   lom,
+		wfqn:  wfqn,
+		loc:   lom.chunkLoc(csize),
+		fsync: lom.IsFeatureSet(feat.FsyncPUT),
+	}
+	return w, nil
+}
+
+func (w *chunkWriter) Write(b []byte) (n int, err error) {
+	for n < len(b) {
+		if w.fh == nil || w.woff == w.loc.csize {
+			if err = w.next(); err != nil {
+				return n, err
+			}
+		}
+		var (
+			m    int
+			want = min(int64(len(b)-n), w.loc.csize-w.woff)
+		)
+		m, err = w.fh.Write(b[n : n+int(want)])
+		n += m
+		w.woff += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// roll over to the next work chunk
+func (w *chunkWriter) next() (err error) {
+	if w.fh != nil {
+		if w.fsync {
+			err = w.fh.Sync()
+		}
+		if errC := w.fh.Close(); err == nil {
+			err = errC
+		}
+		w.fh = nil
+		if err != nil {
+			return err
+		}
+	}
+	num := len(w.works)
+	mi, err := w.loc.hrw(num)
+	if err != nil {
+		return err
+	}
+	var (
+		base = fs.CSM.Resolver(fs.WorkfileType).GenUniqueFQN(w.loc.objName+"."+strconv.Itoa(num), fs.WorkfileChunk)
+		fqn  = mi.MakePathFQN(&w.loc.bck, fs.WorkfileType, base)
+	)
+	if w.fh, err = cos.CreateFile(fqn); err != nil {
+		return err
+	}
+	w.works = append(w.works, chunkFile{mi: mi, fqn: fqn})
+	w.woff = 0
+	return nil
+}
+
+func (w *chunkWriter) Sync() error {
+	if w.fh == nil {
+		return nil
+	}
+	return w.fh.Sync()
+}
+
+// close the last work chunk and create (empty) main workfile
+func (w *chunkWriter) Close() (err error) {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.fh != nil {
+		err = w.fh.Close()
+		w.fh = nil
+	}
+	// (always - to be either committed or removed)
+	chunksWork.Store(w.wfqn, &chunkWork{works: w.works, csize: w.loc.csize})
+
+	fh, errM := w.lom._cf(w.wfqn)
+	if errM == nil {
+		errM = fh.Close()
+	}
+	if err == nil {
+		err = errM
+	}
+	return err
+}
+
+/////////
+// LOM //
+/////////
+
+// is called by RenameToMain upon finalization of a chunked workfile
+func (lom *LOM) commitChunks(wfqn string, cw *chunkWork) error {
+	loc := lom.chunkLoc(cw.csize)
+	if n := loc.count(); n != len(cw.works) {
+		_rmWorks(cw.works)
+		cos.RemoveFile(wfqn)
+		return fmt.Errorf("%s: invalid number of chunks %d (expecting %d, size %d, chunk size %d)",
+			lom, len(cw.works), n, loc.size, loc.csize)
+	}
+	for num, cf := range cw.works {
+		if err := cos.Rename(cf.fqn, loc.fqn(cf.mi, num)); err != nil {
+			// the previous version (if any) is no longer consistent
+			_rmWorks(cw.works[num:])
+			cos.RemoveFile(wfqn)
+			lom.Uncache()
+			if errV := lom.RemoveMain(); errV != nil {
+				nlog.Errorln("nested err:", errV)
+			}
+			return err
+		}
+	}
+	if err := cos.Rename(wfqn, lom.FQN); err != nil {
+		return err
+	}
+	lom.md.csize = cw.csize
+
+	// cleanup the remaining (now stale) chunks of the previous version, if any
+	for num := len(cw.works); ; num++ {
+		mi, err := loc.hrw(num)
+		if err != nil {
+			break
+		}
+		if err := os.Remove(loc.fqn(mi, num)); err != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func _rmWorks(works []chunkFile) {
+	for _, cf := range works {
+		if err := cos.RemoveFile(cf.fqn); err != nil {
+			nlog.Errorln("nested err:", err)
+		}
+	}
+}
+
+// remove all chunks; is called under wlock
+func (lom *LOM) delChunks() (err error) {
+	loc := lom.chunkLoc(lom.md.csize)
+	for num := range loc.count() {
+		if errN := loc.remove(num); errN != nil {
+			err = errN
+		}
+	}
+	return err
+}
+
+// copy chunked object => another (chunked) object
+func (lom *LOM) copyChunks(dst *LOM, wfqn string, buf []byte, cksumType string) (cksum *cos.CksumHash, err error) {
+	var (
+		lmfh = lom.newChunkReader(0, lom.md.Size)
+		wfh  cos.LomWriter
+	)
+	if wfh, err = dst.CreateChunked(wfqn, lom.md.csize); err != nil {
+		cos.Close(lmfh)
+		return nil, err
+	}
+	_, cksum, err = cos.CopyAndChecksum(wfh, lmfh, buf, cksumType)
+	cos.Close(lmfh)
+	if errC := wfh.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		if errV := dst.RemoveWork(wfqn); errV != nil {
+			nlog.Errorln("nested err:", errV)
+		}
+	}
+	return cksum, err
+}
+
+// make sure all chunks are stored at their respective HRW locations;
+// returns the number of relocated chunks
+// (is called by resilver under wlock)
+func (lom *LOM) ResilverChunks(buf []byte) (moved int, err error) {
+	debug.Assert(lom.IsChunked())
+	loc := lom.chunkLoc(lom.md.csize)
+	for num := range loc.count() {
+		mi, errH := loc.hrw(num)
+		if errH != nil {
+			return moved, errH
+		}
+		hfqn := loc.fqn(mi, num)
+		if cos.Stat(hfqn) == nil {
+			continue
+		}
+		var src string
+		for _, mpi := range fs.GetAvail() {
+			if fqn := loc.fqn(mpi, num); mpi.Path != mi.Path && cos.Stat(fqn) == nil {
+				src = fqn
+				break
+			}
+		}
+		if src == "" {
+			return moved, fmt.Errorf("%s: chunk %d not found", lom, num)
+		}
+		var (
+			tag  = fs.CSM.Resolver(fs.WorkfileType).GenUniqueFQN(lom.ObjName+"."+strconv.Itoa(num), fs.WorkfileChunk)
+			work = mi.MakePathFQN(lom.Bucket(), fs.WorkfileType, tag)
+		)
+		if _, _, err = cos.CopyFile(src, work, buf, cos.ChecksumNone); err != nil {
+			return moved, err
+		}
+		if err = cos.Rename(work, hfqn); err != nil {
+			cos.RemoveFile(work)
+			return moved, err
+		}
+		if errV := cos.RemoveFile(src); errV != nil {
+			nlog.Errorln("nested err:", errV)
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// remove work (main) file along with uncommitted work chunks, if any
+func (*LOM) RemoveWork(wfqn string) error {
+	if v, ok := chunksWork.LoadAndDelete(wfqn); ok {
+		_rmWorks(v.(*chunkWork).works)
+	}
+	return cos.RemoveFile(wfqn)
+}
+
+// (space cleanup) determine whether a given chunk is part of the current object version;
+// returns false when the object does not exist (is not loadable), is not chunked,
+// or has fewer chunks
+func (lom *LOM) HasChunk(num int) bool {
+	if !lom.IsChunked() {
+		return false
+	}
+	loc := lom.chunkLoc(lom.md.csize)
+	return num < loc.count()
+}
@@ -0,0 +1,274 @@
+// Package core_test provides tests for cluster package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package core_test
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chunked LOM", func() {
+	const (
+		tmpDir    = "/tmp/lchunk_test"
+		numMpaths = 4
+		csize     = 1000
+
+		bucketLocal    = "LOM_TEST_Chunked"
+		bucketMirrored = "LOM_TEST_Mirrored"
+		objName        = "chunked/test-obj.ext"
+	)
+
+	var (
+		localBck = cmn.Bck{Name: bucketLocal, Provider: apc.AIS, Ns: cmn.NsGlobal}
+		mpaths   []string
+		bmd      = mock.NewBaseBownerMock(
+			meta.NewBck(
+				bucketLocal, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}, BID: 301},
+			),
+			meta.NewBck(
+				bucketMirrored, apc.AIS, cmn.NsGlobal,
+				&cmn.Bprops{Mirror: cmn.MirrorConf{Enabled: true, Copies: 2}, BID: 302},
+			),
+		)
+	)
+	for i := range numMpaths {
+		mpaths = append(mpaths, fmt.Sprintf("%s/mpath%d", tmpDir, i))
+	}
+
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	fs.CSM.Reg(fs.ChunkType, &fs.ChunkContentResolver{}, true)
+
+	BeforeEach(func() {
+		for _, mpath := range mpaths {
+			_ = cos.CreateDir(mpath)
+			_, _ = fs.Add(mpath, "daeID")
+		}
+		_ = mock.NewTarget(bmd)
+		for _, mi := range fs.GetAvail() {
+			_ = cos.CreateDir(mi.MakePathBck(&localBck))
+		}
+	})
+
+	AfterEach(func() {
+		for _, mpath := range mpaths {
+			_, _ = fs.Remove(mpath)
+		}
+		_ = os.RemoveAll(tmpDir)
+	})
+
+	// write (in odd-sized pieces), commit, and persist
+	putChunked := func(data []byte) *core.LOM {
+		lom := &core.LOM{ObjName: objName}
+		Expect(lom.InitBck(&localBck)).NotTo(HaveOccurred())
+		lom.Lock(true)
+		defer lom.Unlock(true)
+
+		wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "chunk-test")
+		w, err := lom.CreateChunked(wfqn, csize)
+		Expect(err).NotTo(HaveOccurred())
+		for off := 0; off < len(data); off += 777 {
+			_, err := w.Write(data[off:min(off+777, len(data))])
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(w.Close()).NotTo(HaveOccurred())
+
+		lom.SetSize(int64(len(data)))
+		Expect(lom.RenameFinalize(wfqn)).NotTo(HaveOccurred())
+		Expect(persist(lom)).NotTo(HaveOccurred())
+		lom.UncacheUnless()
+		return lom
+	}
+
+	reload := func() *core.LOM {
+		lom := &core.LOM{ObjName: objName}
+		Expect(lom.InitBck(&localBck)).NotTo(HaveOccurred())
+		Expect(lom.Load(false /*cache it*/, false /*locked*/)).NotTo(HaveOccurred())
+		return lom
+	}
+
+	// chunk #num, wherever it is
+	findChunk := func(num int) (found []string) {
+		for _, mi := range fs.GetAvail() {
+			fqn := mi.MakePathFQN(&localBck, fs.ChunkType, objName+"."+strconv.Itoa(num))
+			if cos.Stat(fqn) == nil {
+				found = append(found, fqn)
+			}
+		}
+		return found
+	}
+
+	randData := func(size int) []byte {
+		data := make([]byte, size)
+		_, _ = cryptorand.Read(data)
+		return data
+	}
+
+	It("should store, load, and read chunked object", func() {
+		data := randData(3500)
+		putChunked(data)
+
+		lom := reload()
+		Expect(lom.IsChunked()).To(BeTrue())
+		Expect(lom.Lsize()).To(BeEquivalentTo(len(data)))
+		finfo, err := os.Stat(lom.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finfo.Size()).To(BeZero())
+
+		for num := range 4 {
+			Expect(findChunk(num)).To(HaveLen(1))
+		}
+		Expect(findChunk(4)).To(BeEmpty())
+
+		lmfh, err := lom.Open()
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(lmfh)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes.Equal(b, data)).To(BeTrue())
+
+		// seek and read across chunk boundary
+		_, err = lmfh.Seek(990, io.SeekStart)
+		Expect(err).NotTo(HaveOccurred())
+		b = make([]byte, 20)
+		_, err = io.ReadFull(lmfh, b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(data[990:1010]))
+		Expect(lmfh.Close()).NotTo(HaveOccurred())
+
+		// section (offsets are relative)
+		sec, err := lom.OpenSection(900, 1200)
+		Expect(err).NotTo(HaveOccurred())
+		b, err = io.ReadAll(sec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(data[900:2100]))
+		rat := sec.(io.ReaderAt)
+		b = make([]byte, 300)
+		n, err := rat.ReadAt(b, 1000)
+		Expect(err).To(Equal(io.EOF))
+		Expect(n).To(Equal(200))
+		Expect(b[:n]).To(Equal(data[1900:2100]))
+		Expect(sec.Close()).NotTo(HaveOccurred())
+
+		// checksum is computed over the entire content
+		cksum, err := lom.ComputeCksum(cos.ChecksumXXHash)
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := cos.ChecksumBytes(data, cos.ChecksumXXHash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cksum.Value()).To(Equal(expected.Value()))
+	})
+
+	It("should overwrite chunked object and remove stale chunks", func() {
+		putChunked(randData(3500))
+		data := randData(1500)
+		putChunked(data)
+
+		lom := reload()
+		Expect(lom.IsChunked()).To(BeTrue())
+		Expect(lom.HasChunk(1)).To(BeTrue())
+		Expect(lom.HasChunk(2)).To(BeFalse())
+		Expect(findChunk(2)).To(BeEmpty())
+		Expect(findChunk(3)).To(BeEmpty())
+
+		lmfh, err := lom.Open()
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(lmfh)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes.Equal(b, data)).To(BeTrue())
+		cos.Close(lmfh)
+	})
+
+	It("should read misplaced chunks and resilver them", func() {
+		data := randData(3500)
+		putChunked(data)
+
+		// move chunk #1 away from its HRW mountpath
+		src := findChunk(1)[0]
+		var dst string
+		for _, mi := range fs.GetAvail() {
+			if fqn := mi.MakePathFQN(&localBck, fs.ChunkType, objName+".1"); fqn != src {
+				dst = fqn
+				break
+			}
+		}
+		Expect(cos.Rename(src, dst)).NotTo(HaveOccurred())
+
+		lom := reload()
+		lmfh, err := lom.Open()
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(lmfh)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bytes.Equal(b, data)).To(BeTrue())
+		cos.Close(lmfh)
+
+		lom.Lock(true)
+		moved, err := lom.ResilverChunks(nil)
+		lom.Unlock(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(moved).To(Equal(1))
+		Expect(findChunk(1)).To(Equal([]string{src}))
+	})
+
+	It("should discard uncommitted work chunks", func() {
+		lom := &core.LOM{ObjName: objName}
+		Expect(lom.InitBck(&localBck)).NotTo(HaveOccurred())
+		wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "chunk-test")
+		w, err := lom.CreateChunked(wfqn, csize)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = w.Write(randData(2500))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Close()).NotTo(HaveOccurred())
+
+		Expect(lom.RemoveWork(wfqn)).NotTo(HaveOccurred())
+		for _, mi := range fs.GetAvail() {
+			var works []string
+			_ = filepath.WalkDir(mi.MakePathCT(&localBck, fs.WorkfileType), func(path string, de os.DirEntry, err error) error {
+				if err == nil && !de.IsDir() {
+					works = append(works, path)
+				}
+				return nil
+			})
+			Expect(works).To(BeEmpty())
+		}
+	})
+
+	It("should remove chunks along with the object", func() {
+		lom := putChunked(randData(2500))
+		Expect(lom.Load(false /*cache it*/, false /*locked*/)).NotTo(HaveOccurred())
+		lom.Lock(true)
+		Expect(lom.RemoveObj()).NotTo(HaveOccurred())
+		lom.Unlock(true)
+		for num := range 3 {
+			Expect(findChunk(num)).To(BeEmpty())
+		}
+	})
+
+	It("should not chunk objects in mirrored buckets", func() {
+		conf := &cmn.ChunksConf{ObjSizeLimit: 2 * cos.MiB}
+		lom := &core.LOM{ObjName: objName}
+		Expect(lom.InitBck(&localBck)).NotTo(HaveOccurred())
+		Expect(lom.Csize(conf, cos.MiB)).To(BeZero())
+		Expect(lom.Csize(conf, 2*cos.MiB)).To(BeEquivalentTo(cmn.DfltChunkSize))
+
+		lom = &core.LOM{ObjName: objName}
+		Expect(lom.InitBck(&cmn.Bck{Name: bucketMirrored, Provider: apc.AIS, Ns: cmn.NsGlobal})).NotTo(HaveOccurred())
+		Expect(lom.Csize(conf, 2*cos.MiB)).To(BeZero())
+	})
+})
@@ -58,6 +58,10 @@ func (lom *LOM) isMirror(dst *LOM) bool {
 		lom.Bck().Equal(dst.Bck(), true /* must have same BID*/, true /* same backend */)
 }
 
+func (lom *LOM) sameObj(dst *LOM) bool {
+	return lom.ObjName == dst.ObjName && lom.Bck().Equal(dst.Bck(), true /*same BID*/, true /*same backend*/)
+}
+
 func (lom *LOM) delCopyMd(copyFQN string) {
 	delete(lom.md.copies, copyFQN)
 	if len(lom.md.copies) <= 1 {
@@ -294,13 +298,22 @@ func (lom *LOM) copy2fqn(dst *LOM, buf []byte) (err error) {
 	}
 
 	workFQN := fs.CSM.Gen(dst, fs.WorkfileType, fs.WorkfileCopy)
-	_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
+	if lom.IsChunked() && !lom.sameObj(dst) {
+		dstCksum, err = lom.copyChunks(dst, workFQN, buf, cksumType)
+	} else {
+		// (chunks, if any, are shared by all copies of the same object)
+		if lom.IsChunked() {
+			cksumType = cos.ChecksumNone
+		}
+		_, dstCksum, err = cos.CopyFile(lom.FQN, workFQN, buf, cksumType)
+		dst.md.csize = lom.md.csize
+	}
 	if err != nil {
 		return
 	}
 
-	if err = cos.Rename(workFQN, dstFQN); err != nil {
-		if errRemove := cos.RemoveFile(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
+	if err = dst.RenameToMain(workFQN); err != nil {
+		if errRemove := dst.RemoveWork(workFQN); errRemove != nil && !os.IsNotExist(errRemove) {
 			nlog.Errorln("nested err:", errRemove)
 		}
 		return
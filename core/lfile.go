@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 const (
@@ -23,7 +26,11 @@ const (
 // open
 //
 
+// (compare with lom.NewHandle and lom.OpenSection)
 func (lom *LOM) Open() (cos.LomReader, error) {
+	if lom.IsChunked(true) {
+		return lom.newChunkReader(0, lom.md.Size), nil
+	}
 	return os.Open(lom.FQN)
 }
 
@@ -33,6 +40,7 @@ func (lom *LOM) Open() (cos.LomReader, error) {
 
 func (lom *LOM) Create() (cos.LomWriter, error) {
 	debug.Assert(lom.isLockedExcl(), lom.Cname()) // caller must wlock
+	lom.md.csize = 0
 	return lom._cf(lom.FQN)
 }
 
@@ -91,10 +99,121 @@ func (lom *LOM) RemoveObj(force ...bool) (err error) {
 			err = erc
 		}
 	}
+	if lom.md.csize > 0 {
+		if erc := lom.delChunks(); erc != nil {
+			err = erc
+		}
+		lom.md.csize = 0
+	}
+	lom.md.lid = 0
+	return err
+}
+
+//
+// soft-delete and undelete (see cmn.SoftDelConf)
+//
+
+// move the object (data and metadata) into its mountpath's trash; remove copies, if any
+// NOTE: must be w-locked
+// NOTE: chunked objects (spread across mountpaths) cannot be soft-deleted - fails
+// with ErrUnsupp leaving the object intact
+func (lom *LOM) SoftDelete() (err error) {
+	debug.Assert(lom.isLockedExcl())
+	if lom.md.csize > 0 {
+		return cmn.NewErrUnsupp("soft-delete chunked object", lom.Cname())
+	}
+	dst := lom.mi.SoftDelFQN(lom.Bucket(), lom.ObjName)
+	if err = cos.CreateDir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	lom.Uncache()
+	if err = os.Rename(lom.FQN, dst); err != nil {
+		return err
+	}
+	// deletion time (see fs.PurgeSoftDeleted)
+	if erc := fs.SetSoftDelTime(dst, time.Now().UnixNano()); erc != nil {
+		nlog.Errorln(lom.String(), "failed to set deletion time:", erc)
+	}
+	for copyFQN := range lom.md.copies {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if erc := cos.RemoveFile(copyFQN); erc != nil && !os.IsNotExist(erc) {
+			err = erc
+		}
+	}
 	lom.md.lid = 0
 	return err
 }
 
+// find the most recently soft-deleted version of the object across all mountpaths;
+// returns empty `fqn` when not found
+func (lom *LOM) SoftDeleted() (fqn string, mi *fs.Mountpath, dtime int64) {
+	for _, avail := range fs.GetAvail() {
+		sfqn := avail.SoftDelFQN(lom.Bucket(), lom.ObjName)
+		finfo, err := os.Stat(sfqn)
+		if err != nil || finfo.IsDir() {
+			continue
+		}
+		if t := fs.SoftDelTime(sfqn, finfo); fqn == "" || t > dtime {
+			fqn, mi, dtime = sfqn, avail, t
+		}
+	}
+	return fqn, mi, dtime
+}
+
+// restore the most recently soft-deleted version of the object
+// NOTE: must be w-locked
+func (lom *LOM) Undelete() error {
+	debug.Assert(lom.isLockedExcl())
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		return fmt.Errorf("cannot undelete %s: object exists", lom.Cname())
+	}
+	src, srcMi, _ := lom.SoftDeleted()
+	if src == "" {
+		return cos.NewErrNotFound(T, "soft-deleted "+lom.Cname())
+	}
+
+	// when found on a different mountpath: first, restore it there
+	dst := lom.FQN
+	if srcMi.Path != lom.mi.Path {
+		dst = srcMi.MakePathFQN(lom.Bucket(), fs.ObjectType, lom.ObjName)
+	}
+	if err := cos.CreateDir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return err
+	}
+	if err := fs.ClearSoftDelTime(dst); err != nil {
+		nlog.Warningln(lom.String(), "failed to clear deletion time:", err)
+	}
+	if dst != lom.FQN {
+		// and then copy to the default location
+		buf, slab := g.pmm.Alloc()
+		dlom, err := lom._restore(dst, buf)
+		slab.Free(buf)
+		if dlom != nil {
+			FreeLOM(dlom)
+		}
+		if err != nil {
+			return err
+		}
+		if err := cos.RemoveFile(dst); err != nil {
+			nlog.Errorln(err)
+		}
+	}
+	if err := lom.Load(false /*cache it*/, true /*locked*/); err != nil {
+		return err
+	}
+	if lom.HasCopies() {
+		// copies were removed upon deletion
+		lom.md.copies = nil
+		return lom.PersistMain()
+	}
+	return nil
+}
+
 //
 // rename
 //
@@ -103,7 +222,11 @@ func (lom *LOM) RenameMainTo(wfqn string) error {
 	return cos.Rename(lom.FQN, wfqn)
 }
 
+// NOTE: commits work chunks when `wfqn` was created via lom.CreateChunked
 func (lom *LOM) RenameToMain(wfqn string) error {
+	if v, ok := chunksWork.LoadAndDelete(wfqn); ok {
+		return lom.commitChunks(wfqn, v.(*chunkWork))
+	}
 	return cos.Rename(wfqn, lom.FQN)
 }
 
@@ -112,6 +235,7 @@ func (lom *LOM) RenameFinalize(wfqn string) error {
 	if err := cos.Stat(bdir); err != nil {
 		return fmt.Errorf("%s(bdir: %s): %w", lom, bdir, err)
 	}
+	lom.md.csize = 0 // (is set when committing chunks)
 	if err := lom.RenameToMain(wfqn); err != nil {
 		return cmn.NewErrFailedTo(T, "finalize", lom.Cname(), err)
 	}
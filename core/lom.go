@@ -52,6 +52,7 @@ type (
 		cmn.ObjAttrs
 		atimefs uint64 // (high bit `lomDirtyMask` | int64: atime)
 		lid     lomBID
+		csize   int64 // chunk size iff stored as chunks (see lchunk.go)
 	}
 	LOM struct {
 		mi      *fs.Mountpath
@@ -139,11 +140,12 @@ func (lom *LOM) Lsize(special ...bool) int64 {
 	return lom.md.Size
 }
 
-// low-level access to the os.FileInfo of a chunk or whole file
+// low-level access to the os.FileInfo of the main file
+// (NOTE: when chunked, the returned size is zero)
 func (lom *LOM) Fstat(getAtime bool) (size, atimefs int64, mtime time.Time, _ error) {
 	finfo, err := os.Stat(lom.FQN)
 	if err == nil {
-		size = finfo.Size() // NOTE: chunk?
+		size = finfo.Size()
 		mtime = finfo.ModTime()
 		if getAtime {
 			atimefs = ios.GetATime(finfo).UnixNano()
@@ -208,10 +210,10 @@ func (lom *LOM) Bucket() *cmn.Bck         { return (*cmn.Bck)(&lom.bck) }
 func (lom *LOM) Mountpath() *fs.Mountpath { return lom.mi }
 func (lom *LOM) Location() string         { return T.String() + apc.LocationPropSepa + lom.mi.String() }
 
-// chunks vs whole // TODO -- FIXME: NIY
+// chunks vs whole
 func (lom *LOM) IsChunked(special ...bool) bool {
 	debug.Assert(len(special) > 0 || lom.loaded())
-	return false
+	return lom.md.csize > 0
 }
 
 func ParseObjLoc(loc string) (tname, mpname string) {
@@ -564,6 +566,10 @@ func (lom *LOM) FromFS() error {
 		return err
 	}
 	// fstat & atime
+	if lom.md.csize > 0 {
+		// chunked: the main file is empty and only carries metadata
+		size = lom.md.Size
+	}
 	if lom.md.Size != size { // corruption or tampering
 		return cmn.NewErrLmetaCorrupted(lom.whingeSize(size))
 	}
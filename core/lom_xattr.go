@@ -330,6 +330,7 @@ func (md *lmeta) unpack(buf []byte) error {
 		return fmt.Errorf("%s: unknown checksum %d", badLmeta, buf[1])
 	}
 	payload = buf[prefLen:]
+	md.csize = 0
 	actualCksum = xxhash.Checksum64S(buf[prefLen:], cos.MLCG32)
 	expectedCksum = binary.BigEndian.Uint64(buf[2:])
 	if expectedCksum != actualCksum {
@@ -409,6 +410,11 @@ func (md *lmeta) unpack(buf []byte) error {
 				custom[entries[i]] = entries[i+1]
 			}
 			md.SetCustomMD(custom)
+		case packedChunk:
+			if len(record) != cos.SizeofI16+cos.SizeofI64 {
+				return errors.New(badLmeta + " #9")
+			}
+			md.csize = int64(binary.BigEndian.Uint64(record[cos.SizeofI16:]))
 		default:
 			return errors.New(badLmeta + " #6")
 		}
@@ -456,6 +462,13 @@ func (md *lmeta) pack(mdSize int64) (buf []byte) {
 		buf = _packCustom(buf, custom)
 	}
 
+	// chunk size
+	if md.csize > 0 {
+		binary.BigEndian.PutUint64(b8[:], uint64(md.csize))
+		buf = g.smm.Append(buf, recordSepa)
+		buf = _packRecord(buf, packedChunk, cos.UnsafeS(b8[:]), false)
+	}
+
 	// checksum, prepend, and return
 	buf[0] = cmn.MetaverLOM
 	buf[1] = mdCksumTyXXHash
@@ -1,6 +1,6 @@
 // Package meta: cluster-level metadata
 /*
- * Copyright (c) 2018-2023, NVIDIA CORPORATION. All rights reserved.
+ * Copyright (c) 2018-2024, NVIDIA CORPORATION. All rights reserved.
  */
 package meta
 
@@ -10,6 +10,12 @@ type (
 		Alias string `json:"alias"`
 		UUID  string `json:"uuid"` // Smap.UUID
 		Smap  *Smap  `json:"smap"`
+		// health
+		URLs      []string `json:"urls,omitempty"`      // all endpoints: configured and discovered (remote gateways)
+		Down      []string `json:"down,omitempty"`      // endpoints currently considered unreachable
+		LastErr   string   `json:"last_err,omitempty"`  // most recent connectivity error, if any
+		LastOK    int64    `json:"last_ok,omitempty"`   // last successful contact (Unix nanoseconds)
+		Failovers int64    `json:"failovers,omitempty"` // total number of times a request failed over to another endpoint
 	}
 	RemAisVec struct {
 		A   []*RemAis `json:"a"`
@@ -5,6 +5,7 @@
 package mock
 
 import (
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/stats"
@@ -34,3 +35,5 @@ func (*StatsTracker) GetStats() *stats.Node                               { retu
 func (*StatsTracker) GetStatsV322() *stats.NodeV322                       { return nil }
 func (*StatsTracker) ResetStats(bool)                                     {}
 func (*StatsTracker) IsPrometheus() bool                                  { return false }
+func (*StatsTracker) AddReq(int, *cmn.Bck, string, int64, int64, bool)    {}
+func (*StatsTracker) GetBckStats() *stats.BckStats                        { return nil }
@@ -32,7 +32,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"max_size":  "4mb",
 		"max_total": "128mb",
 		"flush_time": "40s",
-		"stats_time": "60s"
+		"stats_time": "60s",
+		"format":    "${AIS_LOG_FORMAT:-text}"
 	},
 	"periodic": {
 		"stats_time":        "10s",
@@ -45,7 +46,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"max_host_busy":        "20s",
 		"startup_time":         "1m",
 		"join_startup_time":    "3m",
-		"send_file_time":       "5m"
+		"send_file_time":       "5m",
+		"mpt_abandoned_time":   "168h"
 	},
 	"client": {
 		"client_timeout":      "10s",
@@ -153,7 +155,9 @@ cat > $AIS_CONF_FILE <<EOL
 		"retry_factor":   4
 	},
 	"downloader": {
-		"timeout": "1h"
+		"timeout":        "1h",
+		"max_host_conns": 0,
+		"max_host_rps":   0
 	},
 	"distributed_sort": {
 		"duplicated_records":    "ignore",
@@ -170,6 +174,29 @@ cat > $AIS_CONF_FILE <<EOL
 		"compression":		"never",
 		"bundle_multiplier":	2
 	},
+	"chunks": {
+		"objsize_limit":	"0",
+		"chunk_size":		"64MiB"
+	},
+	"tracing": {
+		"exporter_endpoint":	"${AIS_TRACING_ENDPOINT:-}",
+		"service_name_prefix":	"aistore",
+		"sampler_probability":	${AIS_TRACING_SAMPLER_PROBABILITY:-1},
+		"enabled":		${AIS_TRACING_ENABLED:-false},
+		"skip_verify":		false
+	},
+	"metrics": {
+		"max_buckets":	256,
+		"max_users":	64,
+		"per_bucket":	${AIS_METRICS_PER_BUCKET:-false},
+		"per_user":	${AIS_METRICS_PER_USER:-false}
+	},
+	"audit": {
+		"max_size":	"16mb",
+		"max_total":	"256mb",
+		"enabled":	${AIS_AUDIT:-false},
+		"targets":	false
+	},
 	"write_policy": {
 		"data": "${WRITE_POLICY_DATA:-}",
 		"md": "${WRITE_POLICY_MD:-}"
@@ -364,6 +364,8 @@ func freeObject(r any) {
 		if handle != nil {
 			cos.Close(handle)
 		}
+	case cos.LomHandle: // chunked object (see core/lchunk.go)
+		_ = handle.Close()
 	default:
 		debug.FailTypeCast(r)
 	}
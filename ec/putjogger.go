@@ -31,7 +31,7 @@ type (
 	encodeCtx struct {
 		lom          *core.LOM        // replica
 		meta         *Metadata        //
-		fh           *cos.FileHandle  // file handle for the replica
+		fh           cos.LomHandle    // handle for the replica (file or chunks)
 		sliceSize    int64            // calculated slice size
 		padSize      int64            // zero tail of the last object's data slice
 		dataSlices   int              // the number of data slices
@@ -91,7 +91,7 @@ func (*putJogger) newCtx(lom *core.LOM, meta *Metadata) (ctx *encodeCtx, err err
 	ctx.slices = make([]*slice, totalCnt)
 	ctx.padSize = ctx.sliceSize*int64(ctx.dataSlices) - ctx.lom.Lsize()
 
-	ctx.fh, err = cos.NewFileHandle(lom.FQN)
+	ctx.fh, err = lom.NewHandle()
 	return ctx, err
 }
 
@@ -136,7 +136,7 @@ func newReplicaResponse(attrs *cmn.ObjAttrs, bck *meta.Bck, objName string) (rea
 		nlog.Warningln(err)
 		return nil, err
 	}
-	reader, err = cos.NewFileHandle(lom.FQN)
+	reader, err = lom.NewHandle()
 	if err != nil {
 		return nil, err
 	}
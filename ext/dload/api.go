@@ -5,14 +5,17 @@
 package dload
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"path"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -30,6 +33,12 @@ const (
 
 const PrefixJobID = "dnl-"
 
+// HTTP authentication types (see Auth)
+const (
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+)
+
 const DownloadProgressInterval = 10 * time.Second
 
 type (
@@ -79,6 +88,18 @@ type (
 		Timeout          string  `json:"timeout"`
 		ProgressInterval string  `json:"progress_interval"`
 		Limits           Limits  `json:"limits"`
+		// Custom request headers (e.g., "Cookie") and credentials to download the job's objects.
+		// Both are never returned via job status and are stored encrypted (see secret.go).
+		Headers cos.StrKVs `json:"headers,omitempty"`
+		Auth    *Auth      `json:"auth,omitempty"`
+	}
+
+	// HTTP authentication: bearer token or basic (username and password)
+	Auth struct {
+		Type     string `json:"type"` // one of: AuthBearer, AuthBasic
+		Token    string `json:"token,omitempty"`
+		Username string `json:"username,omitempty"`
+		Password string `json:"password,omitempty"`
 	}
 
 	SingleObj struct {
@@ -301,6 +322,55 @@ func (b *Base) Validate() error {
 	if b.Limits.BytesPerHour < 0 {
 		return fmt.Errorf("'limit.bytes_per_hour' must be non-negative (got: %d)", b.Limits.BytesPerHour)
 	}
+	for name := range b.Headers {
+		if name == "" || strings.ContainsAny(name, ": \t\r\n") {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+	}
+	if b.Auth != nil {
+		return b.Auth.Validate()
+	}
+	return nil
+}
+
+// request headers, if any (including authentication)
+func (b *Base) header() http.Header {
+	if len(b.Headers) == 0 && b.Auth == nil {
+		return nil
+	}
+	hdr := make(http.Header, len(b.Headers)+1)
+	for name, val := range b.Headers {
+		hdr.Set(name, val)
+	}
+	if a := b.Auth; a != nil {
+		switch a.Type {
+		case AuthBearer:
+			hdr.Set(apc.HdrAuthorization, apc.AuthenticationTypeBearer+" "+a.Token)
+		case AuthBasic:
+			cred := base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + a.Password))
+			hdr.Set(apc.HdrAuthorization, "Basic "+cred)
+		}
+	}
+	return hdr
+}
+
+//////////
+// Auth //
+//////////
+
+func (a *Auth) Validate() error {
+	switch a.Type {
+	case AuthBearer:
+		if a.Token == "" {
+			return errors.New("missing 'auth.token'")
+		}
+	case AuthBasic:
+		if a.Username == "" {
+			return errors.New("missing 'auth.username'")
+		}
+	default:
+		return fmt.Errorf("invalid 'auth.type' %q (expecting %q or %q)", a.Type, AuthBearer, AuthBasic)
+	}
 	return nil
 }
 
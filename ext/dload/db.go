@@ -12,11 +12,13 @@ import (
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
 	downloaderErrors     = "errors"
 	downloaderTasks      = "tasks"
+	downloaderJobs       = "jobs"
 	downloaderCollection = "downloads"
 
 	// Number of errors stored in memory. When the number of errors exceeds
@@ -30,6 +32,14 @@ const (
 
 var errJobNotFound = errors.New("job not found")
 
+// persistent job: original request (to resume) and its state
+// (request headers and credentials, if any, are stored separately and encrypted - see secrets)
+type jobRecord struct {
+	Body   Body   `json:"body"`
+	Secret []byte `json:"secret,omitempty"`
+	Job    Job    `json:"job"`
+}
+
 type downloaderDB struct {
 	mtx    sync.RWMutex
 	driver kvdb.Driver
@@ -177,11 +187,38 @@ func (db *downloaderDB) flush(id string) error {
 	return nil
 }
 
+func (db *downloaderDB) persistJob(rec *jobRecord) error {
+	key := path.Join(downloaderJobs, rec.Job.ID)
+	return db.driver.Set(downloaderCollection, key, rec)
+}
+
+func (db *downloaderDB) jobs() (recs []*jobRecord, err error) {
+	all, err := db.driver.GetAll(downloaderCollection, downloaderJobs)
+	if err != nil {
+		if cos.IsErrNotFound(err) {
+			err = nil
+		}
+		return nil, err
+	}
+	recs = make([]*jobRecord, 0, len(all))
+	for key, val := range all {
+		rec := &jobRecord{}
+		if err := jsoniter.Unmarshal([]byte(val), rec); err != nil {
+			nlog.Errorln("failed to load download job", key+":", err)
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
 func (db *downloaderDB) delete(id string) {
 	db.mtx.Lock()
 	key := path.Join(downloaderErrors, id)
 	db.driver.Delete(downloaderCollection, key)
 	key = path.Join(downloaderTasks, id)
 	db.driver.Delete(downloaderCollection, key)
+	key = path.Join(downloaderJobs, id)
+	db.driver.Delete(downloaderCollection, key)
 	db.mtx.Unlock()
 }
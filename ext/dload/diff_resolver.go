@@ -5,6 +5,8 @@
 package dload
 
 import (
+	"net/http"
+
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -46,11 +48,13 @@ type (
 	}
 
 	WebResource struct {
+		Header  http.Header // request headers, if any
 		ObjName string
 		Link    string
 	}
 
 	DstElement struct {
+		Header  http.Header
 		ObjName string
 		Version string
 		Link    string
@@ -172,6 +176,7 @@ func (dr *DiffResolver) PushDst(v any) {
 		d = &DstElement{
 			ObjName: x.ObjName,
 			Link:    x.Link,
+			Header:  x.Header,
 		}
 	default:
 		debug.FailTypeCast(v)
@@ -266,6 +271,7 @@ func (dr *DiffResolver) push(job jobif, d *dispatcher) {
 				dr.PushDst(&WebResource{
 					ObjName: obj.objName,
 					Link:    obj.link,
+					Header:  job.base().hdr,
 				})
 			} else {
 				dr.PushDst(&BackendResource{
@@ -21,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xact/xreg"
 	"golang.org/x/sync/errgroup"
@@ -49,6 +50,7 @@ type (
 		tstats stats.Tracker
 		db     kvdb.Driver
 		store  *infoStore
+		hosts  *hosts // per origin host limits
 
 		// Downloader selects one of the two clients (below) by the destination URL.
 		// Certification check is disabled for now and does not depend on cluster settings.
@@ -61,6 +63,7 @@ var g global
 
 func Init(tstats stats.Tracker, db kvdb.Driver, clientConf *cmn.ClientConf) {
 	g.clientH, g.clientTLS = cmn.NewDefaultClients(clientConf.TimeoutLong.D())
+	g.hosts = newHosts()
 
 	if db == nil { // unit tests only
 		return
@@ -70,6 +73,7 @@ func Init(tstats stats.Tracker, db kvdb.Driver, clientConf *cmn.ClientConf) {
 		g.db = db
 		g.store = newInfoStore(db)
 	}
+	hk.Reg("downloader-hosts"+hk.NameSuffix, g.hosts.housekeep, hostIdleTime)
 	xreg.RegNonBckXact(&factory{})
 }
 
@@ -213,6 +217,10 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 
 	go diffResolver.push(job, d)
 
+	var done cos.StrSet // resuming interrupted job
+	if dljob, err := g.store.getJob(job.ID()); err == nil {
+		done = dljob.done
+	}
 	for {
 		result, err := diffResolver.Next()
 		if err != nil {
@@ -237,6 +245,9 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 				}
 			}
 
+			if done != nil && done.Contains(obj.objName) {
+				continue
+			}
 			g.store.incScheduled(job.ID())
 
 			if result.Action == DiffResolverSkip {
@@ -264,11 +275,15 @@ func (d *dispatcher) dispatchDownload(job jobif) (ok bool) {
 			ok, err := d.doSingle(task)
 			if err != nil {
 				nlog.Errorln(job.String(), "failed to download", obj.objName+":", err)
-				g.store.setAborted(job.ID()) // TODO -- FIXME: pass (report, handle) error, here and elsewhere
+				if !nlog.Stopping() {
+					g.store.setAborted(job.ID()) // TODO -- FIXME: pass (report, handle) error, here and elsewhere
+				}
 				return ok
 			}
 			if !ok {
-				g.store.setAborted(job.ID())
+				if !nlog.Stopping() { // otherwise, interrupted (see baseDlJob.cleanup)
+					g.store.setAborted(job.ID())
+				}
 				return false
 			}
 		case DiffResolverSend:
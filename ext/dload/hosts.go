@@ -0,0 +1,153 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Per origin host limits (and backoff) shared by all download jobs:
+// - max number of concurrent downloads from a given host (`downloader.max_host_conns`);
+// - max number of requests per second to a given host (`downloader.max_host_rps`);
+// - 429 ("too many requests") and 503 responses make the host "back off" for the
+//   time specified by the `Retry-After` header (or dfltHostBackoff, if not specified).
+// A task that cannot be admitted gets deferred by its jogger (see jogger.next) - it does
+// not block other hosts.
+
+const (
+	dfltHostBackoff = 5 * time.Second
+	maxHostBackoff  = 5 * time.Minute
+	hostIdleTime    = 10 * time.Minute // remove idle host state
+	hostPollTime    = 100 * time.Millisecond
+)
+
+type (
+	hostState struct {
+		active int   // downloads in progress
+		next   int64 // mono-time: earliest time for the next request
+		used   int64 // mono-time: last used
+	}
+	hosts struct {
+		m  map[string]*hostState
+		mu sync.Mutex
+	}
+)
+
+func newHosts() *hosts { return &hosts{m: make(map[string]*hostState, 8)} }
+
+func linkHost(link string) string {
+	if link == "" {
+		return ""
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func (hs *hosts) get(host string, now int64) *hostState {
+	h, ok := hs.m[host]
+	if !ok {
+		h = &hostState{}
+		hs.m[host] = h
+	}
+	h.used = now
+	return h
+}
+
+// try to admit new download from a given host;
+// if not admitted, return (approximate) time to wait
+func (hs *hosts) acquire(host string, config *cmn.Config) (bool, time.Duration) {
+	var (
+		conf = &config.Downloader
+		now  = mono.NanoTime()
+	)
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	h := hs.get(host, now)
+	if conf.MaxHostConns > 0 && h.active >= conf.MaxHostConns {
+		return false, hostPollTime
+	}
+	if h.next > now {
+		return false, time.Duration(h.next - now)
+	}
+	h.active++
+	if conf.MaxHostRPS > 0 {
+		h.next = now + int64(float64(time.Second)/conf.MaxHostRPS)
+	}
+	return true, 0
+}
+
+func (hs *hosts) release(host string) {
+	hs.mu.Lock()
+	if h, ok := hs.m[host]; ok && h.active > 0 {
+		h.active--
+	}
+	hs.mu.Unlock()
+}
+
+// (admitted download that is about to retry) reserve the next request slot and return the time to wait
+func (hs *hosts) reserve(host string, config *cmn.Config) (wait time.Duration) {
+	now := mono.NanoTime()
+	hs.mu.Lock()
+	h := hs.get(host, now)
+	base := now
+	if h.next > now {
+		wait = time.Duration(h.next - now)
+		base = h.next
+	}
+	if rps := config.Downloader.MaxHostRPS; rps > 0 {
+		h.next = base + int64(float64(time.Second)/rps)
+	}
+	hs.mu.Unlock()
+	return wait
+}
+
+func (hs *hosts) backoff(host string, d time.Duration) {
+	d = min(d, maxHostBackoff)
+	now := mono.NanoTime()
+	hs.mu.Lock()
+	h := hs.get(host, now)
+	h.next = max(h.next, now+int64(d))
+	hs.mu.Unlock()
+}
+
+func (hs *hosts) housekeep() time.Duration {
+	now := mono.NanoTime()
+	hs.mu.Lock()
+	for host, h := range hs.m {
+		if h.active == 0 && time.Duration(now-h.used) > hostIdleTime {
+			delete(hs.m, host)
+		}
+	}
+	hs.mu.Unlock()
+	return hostIdleTime
+}
+
+// Retry-After: <delay-seconds> | <http-date>
+func retryAfter(hdr http.Header) time.Duration {
+	val := hdr.Get("Retry-After")
+	if val == "" {
+		return dfltHostBackoff
+	}
+	if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return dfltHostBackoff
+}
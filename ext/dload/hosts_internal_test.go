@@ -0,0 +1,88 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestHostsLimits(t *testing.T) {
+	var (
+		hs     = newHosts()
+		config = &cmn.Config{}
+	)
+	config.Downloader.MaxHostConns = 2
+	config.Downloader.MaxHostRPS = 10 // i.e., one request per 100ms
+
+	ok, _ := hs.acquire("a.com", config)
+	tassert.Fatalf(t, ok, "expected first download to be admitted")
+	ok, wait := hs.acquire("a.com", config)
+	tassert.Fatalf(t, !ok && wait > 0 && wait <= 100*time.Millisecond, "expected rate-limited (wait %v)", wait)
+	ok, _ = hs.acquire("b.com", config)
+	tassert.Fatalf(t, ok, "other hosts must not be affected")
+
+	time.Sleep(wait)
+	ok, _ = hs.acquire("a.com", config)
+	tassert.Fatalf(t, ok, "expected second download to be admitted")
+
+	time.Sleep(100 * time.Millisecond)
+	ok, _ = hs.acquire("a.com", config)
+	tassert.Fatalf(t, !ok, "expected max-conns limit")
+	hs.release("a.com")
+	ok, _ = hs.acquire("a.com", config)
+	tassert.Fatalf(t, ok, "expected admitted upon release")
+
+	// 429 w/ Retry-After
+	hs.release("a.com")
+	hs.backoff("a.com", time.Minute)
+	ok, wait = hs.acquire("a.com", config)
+	tassert.Fatalf(t, !ok && wait > 50*time.Second, "expected backoff (wait %v)", wait)
+	wait = hs.reserve("a.com", config)
+	tassert.Fatalf(t, wait > 50*time.Second, "expected retry to wait (wait %v)", wait)
+}
+
+func TestRetryAfter(t *testing.T) {
+	hdr := http.Header{}
+	tassert.Errorf(t, retryAfter(hdr) == dfltHostBackoff, "expected default")
+	hdr.Set("Retry-After", "7")
+	tassert.Errorf(t, retryAfter(hdr) == 7*time.Second, "expected 7s, got %v", retryAfter(hdr))
+	hdr.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	d := retryAfter(hdr)
+	tassert.Errorf(t, d > 50*time.Second && d <= time.Minute, "expected ~1m, got %v", d)
+	hdr.Set("Retry-After", "garbage")
+	tassert.Errorf(t, retryAfter(hdr) == dfltHostBackoff, "expected default")
+}
+
+func TestDeferredRoundRobin(t *testing.T) {
+	var (
+		config = &cmn.Config{}
+		dq     = deferred{m: make(map[string][]*singleTask)}
+	)
+	g.hosts = newHosts()
+	config.Downloader.MaxHostConns = 1
+
+	for _, host := range []string{"a", "a", "a", "b", "c"} {
+		dq.push(&singleTask{host: host})
+	}
+	seen := make(map[string]int)
+	for range 3 {
+		task, _ := dq.pop(config)
+		tassert.Fatalf(t, task != nil && task.admitted, "expected admitted task")
+		seen[task.host]++
+	}
+	tassert.Fatalf(t, seen["a"] == 1 && seen["b"] == 1 && seen["c"] == 1, "expected fair scheduling, got %v", seen)
+	task, wait := dq.pop(config)
+	tassert.Fatalf(t, task == nil && wait > 0, "expected all hosts at their limits")
+
+	g.hosts.release("a")
+	task, _ = dq.pop(config)
+	tassert.Fatalf(t, task != nil && task.host == "a", "expected host 'a' task")
+	tassert.Errorf(t, dq.n == 1, "expected 1 deferred, got %d", dq.n)
+}
@@ -6,21 +6,32 @@ package dload
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/hk"
 )
 
-// TODO: stored only in memory, should be persisted at some point (powercycle)
+// how often to persist the state of running jobs (see also errCacheSize, taskInfoCacheSize)
+const persistInterval = time.Minute
+
+// Job definitions, their (aggregated) state, finished tasks and errors are all persisted
+// in the target's kvdb. Jobs that did not finish prior to node shutdown (or crash) get
+// loaded in the "interrupted" state and are then resumed by the target - see InterruptedJobs.
 type infoStore struct {
 	*downloaderDB
-	dljobs map[string]*dljob
+	dljobs  map[string]*dljob
+	secrets secrets
 	sync.RWMutex
 }
 
@@ -30,10 +41,72 @@ func newInfoStore(driver kvdb.Driver) *infoStore {
 		downloaderDB: db,
 		dljobs:       make(map[string]*dljob),
 	}
+	is.secrets.fpath = filepath.Join(cmn.GCO.Get().ConfigDir, fname.DloadKey)
+	is.load()
 	hk.Reg("downloader"+hk.NameSuffix, is.housekeep, hk.DayInterval)
+	hk.Reg("downloader-persist"+hk.NameSuffix, is.persistRunning, persistInterval)
 	return is
 }
 
+func (is *infoStore) load() {
+	recs, err := is.jobs()
+	if err != nil {
+		nlog.Errorln("failed to load download jobs:", err)
+		return
+	}
+	for _, rec := range recs {
+		dljob := newDljob(rec)
+		dlb, err := is.secrets.openBody(rec.Body, rec.Secret)
+		if err == nil {
+			dljob.dlb = dlb
+			if dljob.interrupted {
+				is.restore(dljob)
+			}
+		}
+		is.dljobs[dljob.id] = dljob
+		if err != nil && dljob.interrupted {
+			is.abandon(dljob.id, fmt.Errorf("failed to decrypt request headers: %w", err))
+		}
+	}
+	if n := len(recs); n > 0 {
+		nlog.Infoln("loaded", n, "download job(s)")
+	}
+}
+
+// interrupted job: recompute its state from the persisted tasks and errors;
+// finished (or failed) tasks won't be redone upon resumption
+func (is *infoStore) restore(dljob *dljob) {
+	tasks, err := is.getTasks(dljob.id)
+	if err != nil {
+		nlog.Errorln(err)
+	}
+	errs, err := is.getErrors(dljob.id)
+	if err != nil {
+		nlog.Errorln(err)
+	}
+	var (
+		failed = make(cos.StrSet, len(errs))
+		done   = make(cos.StrSet, len(tasks)+len(errs))
+		cnt    int32
+	)
+	for i := range errs {
+		failed.Set(errs[i].Name)
+		done.Set(errs[i].Name)
+	}
+	for i := range tasks {
+		name := tasks[i].Name
+		if !failed.Contains(name) && !done.Contains(name) {
+			cnt++
+		}
+		done.Set(name)
+	}
+	dljob.done = done
+	dljob.finishedCnt.Store(cnt)
+	dljob.errorCnt.Store(int32(len(errs)))
+	dljob.scheduledCnt.Store(cnt + int32(len(errs)))
+	dljob.skippedCnt.Store(0) // skipped objects get skipped again
+}
+
 func (is *infoStore) getJob(id string) (*dljob, error) {
 	is.RLock()
 	defer is.RUnlock()
@@ -59,19 +132,62 @@ func (is *infoStore) getList(req *request) (jobs []*dljob) {
 }
 
 func (is *infoStore) setJob(job jobif) (njob *dljob) {
-	njob = &dljob{
-		id:          job.ID(),
-		xid:         job.XactID(),
-		total:       job.Len(),
-		description: job.Description(),
-		startedTime: time.Now(),
-	}
 	is.Lock()
-	is.dljobs[job.ID()] = njob
+	if ojob, ok := is.dljobs[job.ID()]; ok && ojob.interrupted {
+		// resuming (keep the state)
+		njob = ojob
+		njob.interrupted = false
+		njob.xid = job.XactID()
+		njob.total = job.Len()
+	} else {
+		njob = &dljob{
+			id:          job.ID(),
+			xid:         job.XactID(),
+			total:       job.Len(),
+			description: job.Description(),
+			startedTime: time.Now(),
+			dlb:         job.base().dlb,
+		}
+		var err error
+		if njob.pub, njob.secret, err = is.secrets.sealBody(njob.dlb); err != nil {
+			// persist without the request (the job won't be resumable)
+			nlog.Errorln("failed to encrypt download job", njob.id, "request headers:", err)
+			njob.pub = Body{}
+		}
+		is.dljobs[job.ID()] = njob
+	}
 	is.Unlock()
+	is.persist(njob)
 	return
 }
 
+func (is *infoStore) persist(dljob *dljob) {
+	rec := &jobRecord{Body: dljob.pub, Secret: dljob.secret, Job: dljob.clone()}
+	if err := is.persistJob(rec); err != nil {
+		nlog.Errorln("failed to persist download job", dljob.id+":", err)
+	}
+}
+
+// periodically persist running jobs (so that a crash would not lose much)
+func (is *infoStore) persistRunning() time.Duration {
+	is.RLock()
+	running := make([]*dljob, 0, len(is.dljobs))
+	for _, dljob := range is.dljobs {
+		if !dljob.interrupted && _isRunning(dljob.finishedTime.Load()) {
+			running = append(running, dljob)
+		}
+	}
+	is.RUnlock()
+
+	for _, dljob := range running {
+		if err := is.flush(dljob.id); err != nil {
+			nlog.Errorln(err)
+		}
+		is.persist(dljob)
+	}
+	return persistInterval
+}
+
 func (is *infoStore) incFinished(id string) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
@@ -110,9 +226,54 @@ func (is *infoStore) markFinished(id string) (error, bool /*aborted*/) {
 		return err, false
 	}
 	dljob.finishedTime.Store(time.Now())
+	is.persist(dljob)
 	return dljob.valid(), dljob.aborted.Load()
 }
 
+// node is shutting down: keep the job unfinished so it can be resumed upon restart;
+// returns false if the job was (user-)aborted prior to that
+func (is *infoStore) markInterrupted(id string) bool {
+	dljob, err := is.getJob(id)
+	if err != nil || dljob.aborted.Load() {
+		return false
+	}
+	if err := is.flush(id); err != nil {
+		nlog.Errorln(err)
+	}
+	is.persist(dljob)
+	return true
+}
+
+// interrupted job that cannot be resumed
+func (is *infoStore) abandon(id string, cause error) {
+	dljob, err := is.getJob(id)
+	if err != nil {
+		return
+	}
+	is.Lock()
+	dljob.interrupted = false
+	is.Unlock()
+	dljob.aborted.Store(true)
+	is.persistError(id, "", cause.Error())
+	dljob.errorCnt.Inc()
+	dljob.finishedTime.Store(time.Now())
+	if err := is.flush(id); err != nil {
+		nlog.Errorln(err)
+	}
+	is.persist(dljob)
+}
+
+func (is *infoStore) interruptedJobs() (jobs []*dljob) {
+	is.RLock()
+	for _, dljob := range is.dljobs {
+		if dljob.interrupted {
+			jobs = append(jobs, dljob)
+		}
+	}
+	is.RUnlock()
+	return
+}
+
 func (is *infoStore) setAborted(id string) {
 	dljob, err := is.getJob(id)
 	debug.AssertNoErr(err)
@@ -120,6 +281,7 @@ func (is *infoStore) setAborted(id string) {
 	// NOTE: Don't set `FinishedTime` yet as we are not fully done.
 	//       The job now can be removed but there's no guarantee
 	//       that all tasks have been stopped and all resources were freed.
+	is.persist(dljob)
 }
 
 func (is *infoStore) delJob(id string) {
@@ -132,7 +294,8 @@ func (is *infoStore) housekeep() time.Duration {
 
 	is.Lock()
 	for id, dljob := range is.dljobs {
-		if time.Since(dljob.finishedTime.Load()) > interval {
+		fintime := dljob.finishedTime.Load()
+		if !_isRunning(fintime) && time.Since(fintime) > interval {
 			is.delJob(id)
 		}
 	}
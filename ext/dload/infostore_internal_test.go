@@ -0,0 +1,157 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn/kvdb"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func newTestStore(driver kvdb.Driver, keyPath string) *infoStore {
+	is := &infoStore{downloaderDB: newDownloadDB(driver), dljobs: make(map[string]*dljob)}
+	is.secrets.fpath = keyPath
+	is.load()
+	return is
+}
+
+func TestInfoStoreRestore(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		keyPath = filepath.Join(dir, "dl.key")
+	)
+	driver, err := kvdb.NewBuntDB(filepath.Join(dir, "dl.db"))
+	tassert.CheckFatal(t, err)
+	defer driver.Close()
+
+	is := newTestStore(driver, keyPath)
+	tassert.Fatalf(t, len(is.dljobs) == 0, "expected no jobs, got %d", len(is.dljobs))
+
+	body := Body{Type: TypeMulti, RawMessage: []byte(`{"bucket":{"name":"b","provider":"ais"},"objects":["a","b","c"]}`)}
+
+	// running job: 2 tasks done (one of which failed), plus another failure
+	running := &dljob{id: "dnl-running", xid: "xid1", dlb: body, pub: body, startedTime: time.Now(), total: 5}
+	is.dljobs[running.id] = running
+	is.taskInfoCache[running.id] = []TaskDlInfo{{Name: "obj1"}, {Name: "obj2"}}
+	is.persistError(running.id, "obj2", "failed")
+	is.persistError(running.id, "obj3", "failed")
+	running.scheduledCnt.Store(4)
+	running.finishedCnt.Store(2)
+	running.skippedCnt.Store(1)
+	running.errorCnt.Store(2)
+	tassert.CheckFatal(t, is.flush(running.id))
+	is.persist(running)
+
+	// finished job
+	finished := &dljob{id: "dnl-finished", xid: "xid2", dlb: body, pub: body, startedTime: time.Now(), total: 1}
+	is.dljobs[finished.id] = finished
+	finished.scheduledCnt.Store(1)
+	finished.finishedCnt.Store(1)
+	finished.allDispatched.Store(true)
+	finished.finishedTime.Store(time.Now())
+	is.persist(finished)
+
+	// "restart"
+	is = newTestStore(driver, keyPath)
+	tassert.Fatalf(t, len(is.dljobs) == 2, "expected 2 jobs, got %d", len(is.dljobs))
+
+	job, err := is.getJob(finished.id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !job.interrupted, "finished job must not be resumed")
+	tassert.Errorf(t, !_isRunning(job.finishedTime.Load()), "expected finished job")
+	tassert.Errorf(t, job.finishedCnt.Load() == 1, "expected 1 finished, got %d", job.finishedCnt.Load())
+
+	job, err = is.getJob(running.id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, job.interrupted, "expecting interrupted job")
+	tassert.Errorf(t, job.xid == "xid1" && job.dlb.Type == TypeMulti, "unexpected %q, %q", job.xid, job.dlb.Type)
+	payload := &MultiBody{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal(job.dlb.RawMessage, payload))
+	objs, err := payload.ExtractPayload()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(objs) == 3 && payload.Bck.Name == "b", "unexpected body %s", job.dlb.RawMessage)
+	for _, name := range []string{"obj1", "obj2", "obj3"} {
+		tassert.Errorf(t, job.done.Contains(name), "expecting %q done", name)
+	}
+	tassert.Errorf(t, !job.done.Contains("obj4"), "obj4 is not done")
+	tassert.Errorf(t, job.finishedCnt.Load() == 1, "expected 1 finished, got %d", job.finishedCnt.Load())
+	tassert.Errorf(t, job.errorCnt.Load() == 2, "expected 2 errors, got %d", job.errorCnt.Load())
+	tassert.Errorf(t, job.scheduledCnt.Load() == 3, "expected 3 scheduled, got %d", job.scheduledCnt.Load())
+	tassert.Errorf(t, job.skippedCnt.Load() == 0, "expected no skipped, got %d", job.skippedCnt.Load())
+
+	jobs := is.interruptedJobs()
+	tassert.Fatalf(t, len(jobs) == 1 && jobs[0].id == running.id, "expected %q to resume", running.id)
+
+	// removal
+	is.Lock()
+	is.delJob(running.id)
+	is.Unlock()
+	is = newTestStore(driver, keyPath)
+	tassert.Errorf(t, len(is.dljobs) == 1, "expected 1 job, got %d", len(is.dljobs))
+}
+
+func TestInfoStoreSecrets(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		keyPath = filepath.Join(dir, "dl.key")
+		raw     = `{"link":"https://example.com/obj","bucket":{"name":"b","provider":"ais"},` +
+			`"headers":{"Cookie":"session=xyz"},"auth":{"type":"basic","username":"joe","password":"s3cr3t"}}`
+	)
+	driver, err := kvdb.NewBuntDB(filepath.Join(dir, "dl.db"))
+	tassert.CheckFatal(t, err)
+	defer driver.Close()
+
+	is := newTestStore(driver, keyPath)
+	body := Body{Type: TypeSingle, RawMessage: []byte(raw)}
+	job := &dljob{id: "dnl-secret", xid: "xid", dlb: body, startedTime: time.Now(), total: 1}
+	job.pub, job.secret, err = is.secrets.sealBody(body)
+	tassert.CheckFatal(t, err)
+	is.dljobs[job.id] = job
+	is.persist(job)
+
+	// nothing in plain text
+	recs, err := is.jobs()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(recs) == 1 && len(recs[0].Secret) > 0, "expected encrypted secrets")
+	for _, s := range []string{"Cookie", "session=xyz", "joe", "s3cr3t"} {
+		tassert.Errorf(t, !strings.Contains(string(recs[0].Body.RawMessage), s), "%q stored in plain text", s)
+		tassert.Errorf(t, !strings.Contains(string(recs[0].Secret), s), "%q stored in plain text", s)
+	}
+
+	// "restart"
+	is = newTestStore(driver, keyPath)
+	job, err = is.getJob(job.id)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, job.interrupted, "expecting interrupted job")
+	payload := &SingleBody{}
+	tassert.CheckFatal(t, jsoniter.Unmarshal(job.dlb.RawMessage, payload))
+	tassert.Errorf(t, payload.Link == "https://example.com/obj", "unexpected link %q", payload.Link)
+	hdr := payload.header()
+	tassert.Errorf(t, hdr.Get("Cookie") == "session=xyz", "unexpected cookie %q", hdr.Get("Cookie"))
+	tassert.Errorf(t, hdr.Get(apc.HdrAuthorization) == "Basic am9lOnMzY3IzdA==", "unexpected auth %q", hdr.Get(apc.HdrAuthorization))
+
+	// lost key: cannot resume
+	tassert.CheckFatal(t, os.Remove(keyPath))
+	is = newTestStore(driver, keyPath)
+	job, err = is.getJob(job.id)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !job.interrupted && job.aborted.Load(), "expecting abandoned job")
+
+	// backend download: same headers and credentials
+	bb := &BackendBody{}
+	raw = `{"bucket":{"name":"b","provider":"aws"},"headers":{"x-amz-request-payer":"requester"},"auth":{"type":"bearer","token":"t"}}`
+	tassert.CheckFatal(t, jsoniter.Unmarshal([]byte(raw), bb))
+	tassert.CheckFatal(t, bb.Validate())
+	hdr = bb.header()
+	tassert.Errorf(t, hdr.Get("X-Amz-Request-Payer") == "requester" && hdr.Get(apc.HdrAuthorization) == "Bearer t",
+		"unexpected backend download headers %v", hdr)
+}
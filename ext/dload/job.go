@@ -7,6 +7,7 @@ package dload
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"path"
 	"strings"
 	"time"
@@ -70,12 +71,16 @@ type (
 
 		// job cleanup
 		cleanup()
+
+		base() *baseDlJob
 	}
 
 	baseDlJob struct {
 		bck         *meta.Bck
 		notif       *NotifDownload
 		xdl         *Xact
+		dlb         Body        // original request (persisted to resume the job)
+		hdr         http.Header // request headers, if any (see Base.Headers, Base.Auth)
 		id          string
 		description string
 		timeout     time.Duration
@@ -117,6 +122,10 @@ type (
 		id            string
 		xid           string
 		description   string
+		dlb           Body       // original request (to resume the job)
+		pub           Body       // persisted part of the request (sans secrets)
+		secret        []byte     // encrypted request headers and credentials, if any
+		done          cos.StrSet // when resumed: objects that were already downloaded (or failed)
 		startedTime   time.Time
 		finishedTime  atomic.Time
 		finishedCnt   atomic.Int32
@@ -126,6 +135,7 @@ type (
 		total         int
 		aborted       atomic.Bool
 		allDispatched atomic.Bool
+		interrupted   bool // loaded unfinished (node restart) and not yet resumed
 	}
 )
 
@@ -133,13 +143,14 @@ type (
 // baseDlJob //
 ///////////////
 
-func (j *baseDlJob) init(id string, bck *meta.Bck, timeout, desc string, limits Limits, xdl *Xact) {
+func (j *baseDlJob) init(id string, bck *meta.Bck, base *Base, desc string, xdl *Xact) {
 	// TODO: this might be inaccurate if we download 1 or 2 objects because then
 	//  other targets will have limits but will not use them.
+	limits := base.Limits
 	if limits.BytesPerHour > 0 {
 		limits.BytesPerHour /= core.T.Sowner().Get().CountActiveTs()
 	}
-	td, _ := time.ParseDuration(timeout)
+	td, _ := time.ParseDuration(base.Timeout)
 	{
 		j.id = id
 		j.bck = bck
@@ -147,6 +158,7 @@ func (j *baseDlJob) init(id string, bck *meta.Bck, timeout, desc string, limits
 		j.description = desc
 		j.throt.init(limits)
 		j.xdl = xdl
+		j.hdr = base.header()
 	}
 }
 
@@ -189,9 +201,14 @@ func (j *baseDlJob) ActiveStats() (*StatusResp, error) {
 
 func (*baseDlJob) checkObj(string) bool    { debug.Assert(false); return false }
 func (j *baseDlJob) throttler() *throttler { return &j.throt }
+func (j *baseDlJob) base() *baseDlJob      { return j }
 
 func (j *baseDlJob) cleanup() {
 	j.throttler().stop()
+	if nlog.Stopping() && g.store.markInterrupted(j.ID()) {
+		nlog.Infoln(j.String(), "interrupted - will resume upon restart")
+		return
+	}
 	err, aborted := g.store.markFinished(j.ID())
 	aborted = aborted || j.xdl.IsAborted() // TODO: assert equality
 	if err != nil {
@@ -235,7 +252,7 @@ func newMultiDlJob(id string, bck *meta.Bck, payload *MultiBody, xdl *Xact) (mj
 	var objs cos.StrKVs
 
 	mj = &multiDlJob{}
-	mj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	mj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -250,7 +267,7 @@ func newSingleDlJob(id string, bck *meta.Bck, payload *SingleBody, xdl *Xact) (s
 	var objs cos.StrKVs
 
 	sj = &singleDlJob{}
-	sj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	sj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if objs, err = payload.ExtractPayload(); err != nil {
 		return nil, err
@@ -273,7 +290,7 @@ func newRangeDlJob(id string, bck *meta.Bck, payload *RangeBody, xdl *Xact) (rj
 	if rj.pt, err = cos.ParseBashTemplate(payload.Template); err != nil {
 		return nil, err
 	}
-	rj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	rj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 
 	if rj.count, err = countObjects(rj.pt, payload.Subdir, rj.bck); err != nil {
 		return nil, err
@@ -336,7 +353,7 @@ func newBackendDlJob(id string, bck *meta.Bck, payload *BackendBody, xdl *Xact)
 		return nil, errors.New("bucket download does not support HTTP buckets")
 	}
 	bj = &backendDlJob{}
-	bj.baseDlJob.init(id, bck, payload.Timeout, payload.Describe(), payload.Limits, xdl)
+	bj.baseDlJob.init(id, bck, &payload.Base, payload.Describe(), xdl)
 	{
 		bj.sync = payload.Sync
 		bj.prefix = payload.Prefix
@@ -411,6 +428,34 @@ func (j *backendDlJob) getNextObjs() error {
 // dljob //
 ///////////
 
+func newDljob(rec *jobRecord) *dljob {
+	job := &rec.Job
+	dljob := &dljob{
+		id:          job.ID,
+		xid:         job.XactID,
+		description: job.Description,
+		pub:         rec.Body,
+		secret:      rec.Secret,
+		startedTime: job.StartedTime,
+		total:       job.Total,
+		interrupted: _isRunning(job.FinishedTime) && !job.Aborted,
+	}
+	if !_isRunning(job.FinishedTime) {
+		dljob.finishedTime.Store(job.FinishedTime)
+	}
+	dljob.finishedCnt.Store(int32(job.FinishedCnt))
+	dljob.scheduledCnt.Store(int32(job.ScheduledCnt))
+	dljob.skippedCnt.Store(int32(job.SkippedCnt))
+	dljob.errorCnt.Store(int32(job.ErrorCnt))
+	dljob.aborted.Store(job.Aborted)
+	dljob.allDispatched.Store(job.AllDispatched)
+	if _isRunning(job.FinishedTime) && job.Aborted {
+		// aborted but did not get to finish prior to shutdown
+		dljob.finishedTime.Store(time.Now())
+	}
+	return dljob
+}
+
 func (j *dljob) clone() Job {
 	return Job{
 		ID:            j.id,
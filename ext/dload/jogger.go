@@ -6,6 +6,7 @@ package dload
 
 import (
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
@@ -24,6 +25,14 @@ type (
 		mu sync.RWMutex
 	}
 
+	// tasks that were not admitted by their respective hosts (see hosts.go)
+	deferred struct {
+		m     map[string][]*singleTask // host => tasks (FIFO)
+		hosts []string                 // round-robin
+		rr    int
+		n     int
+	}
+
 	// Each jogger corresponds to an mpath. All types of download requests
 	// corresponding to the jogger's mpath are forwarded to the jogger. Joggers
 	// exist in the Downloader's jogger member variable, and run only when there
@@ -33,6 +42,7 @@ type (
 		terminateCh cos.StopCh // synchronizes termination
 		parent      *dispatcher
 		q           *queue
+		dq          deferred
 		task        *singleTask // currently running download task
 		mtx         sync.Mutex
 		stopAgent   bool
@@ -41,13 +51,14 @@ type (
 
 func newJogger(d *dispatcher, mpath string) (j *jogger) {
 	j = &jogger{mpath: mpath, parent: d, q: newQueue()}
+	j.dq.m = make(map[string][]*singleTask, 4)
 	j.terminateCh.Init()
 	return
 }
 
 func (j *jogger) jog() {
 	for {
-		t := j.q.get()
+		t := j.next()
 		if t == nil {
 			break
 		}
@@ -57,6 +68,7 @@ func (j *jogger) jog() {
 		// we waited on the queue. We must do it under the jogger's lock to ensure that
 		// there is no race between aborting job and marking it as being handled.
 		if !j.taskExists(t) {
+			t.releaseHost()
 			t.job.throttler().release()
 			j.mtx.Unlock()
 			continue
@@ -67,6 +79,7 @@ func (j *jogger) jog() {
 			// `break` here because we want to drain the queue, otherwise some
 			// of the tasks may be in the queue and therefore the finished
 			// counter won't be correct.
+			t.releaseHost()
 			t.job.throttler().release()
 			t.markFailed(internalErrorMsg)
 			j.mtx.Unlock()
@@ -85,6 +98,7 @@ func (j *jogger) jog() {
 		core.FreeLOM(lom)
 		t.cancel()
 
+		t.releaseHost()
 		t.job.throttler().release()
 
 		j.mtx.Lock()
@@ -96,10 +110,68 @@ func (j *jogger) jog() {
 		}
 	}
 
+	// (stopped)
+	for t := j.dq.popAny(); t != nil; t = j.dq.popAny() {
+		t.job.throttler().release()
+		t.markFailed(internalErrorMsg)
+	}
 	j.q.cleanup()
 	j.terminateCh.Close()
 }
 
+// next task to run: previously deferred tasks first (round-robin across their hosts),
+// then the queue; tasks from hosts that are currently at their limits get deferred
+// so that a single slow (or rate-limited) host does not hold up the rest
+func (j *jogger) next() *singleTask {
+	config := cmn.GCO.Get()
+	for {
+		if j.stopping() {
+			if t := j.dq.popAny(); t != nil {
+				return t
+			}
+			return j.q.get()
+		}
+		t, wait := j.dq.pop(config)
+		if t != nil {
+			return t
+		}
+		if j.dq.n >= queueChSize {
+			time.Sleep(wait) // too many deferred - stop reading the queue
+			continue
+		}
+		if j.dq.n == 0 {
+			if t = j.q.get(); t == nil {
+				return nil
+			}
+		} else {
+			var (
+				ok    bool
+				timer = time.NewTimer(wait)
+			)
+			select {
+			case t, ok = <-j.q.ch:
+				timer.Stop()
+				if !ok {
+					return nil
+				}
+			case <-timer.C:
+				continue
+			}
+		}
+		if t.admit(config) {
+			return t
+		}
+		j.dq.push(t)
+	}
+}
+
+func (j *jogger) stopping() bool {
+	j.mtx.Lock()
+	stopping := j.stopAgent
+	j.mtx.Unlock()
+	return stopping
+}
+
 // stop terminates the jogger and waits for it to finish.
 func (j *jogger) stop() {
 	nlog.Infof("Stopping jogger for mpath: %s", j.mpath)
@@ -283,3 +355,65 @@ func (q *queue) close() {
 		close(q.ch)
 	}
 }
+
+//////////////
+// deferred //
+//////////////
+
+func (dq *deferred) push(t *singleTask) {
+	tasks, ok := dq.m[t.host]
+	if !ok {
+		dq.hosts = append(dq.hosts, t.host)
+	}
+	dq.m[t.host] = append(tasks, t)
+	dq.n++
+}
+
+// returns admitted task, if any; otherwise, time to wait
+func (dq *deferred) pop(config *cmn.Config) (*singleTask, time.Duration) {
+	wait := time.Second
+	for range dq.hosts {
+		dq.rr = (dq.rr + 1) % len(dq.hosts)
+		host := dq.hosts[dq.rr]
+		ok, w := g.hosts.acquire(host, config)
+		if !ok {
+			wait = min(wait, w)
+			continue
+		}
+		t := dq.m[host][0]
+		t.admitted = true
+		dq.del(host)
+		return t, 0
+	}
+	return nil, max(wait, 10*time.Millisecond)
+}
+
+func (dq *deferred) popAny() *singleTask {
+	if dq.n == 0 {
+		return nil
+	}
+	host := dq.hosts[0]
+	t := dq.m[host][0]
+	dq.del(host)
+	return t
+}
+
+func (dq *deferred) del(host string) {
+	tasks := dq.m[host]
+	tasks[0] = nil
+	dq.n--
+	if len(tasks) > 1 {
+		dq.m[host] = tasks[1:]
+		return
+	}
+	delete(dq.m, host)
+	for i, h := range dq.hosts {
+		if h == host {
+			dq.hosts = append(dq.hosts[:i], dq.hosts[i+1:]...)
+			break
+		}
+	}
+	if dq.rr >= len(dq.hosts) {
+		dq.rr = 0
+	}
+}
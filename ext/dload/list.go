@@ -28,3 +28,28 @@ ex:
 	rsp := req.response
 	return rsp.value, rsp.statusCode, rsp.err
 }
+
+// Interrupted job (loaded unfinished from the persistent store upon node restart)
+// to be resumed by the target.
+type Interrupted struct {
+	ID     string
+	XactID string
+	Body   Body
+}
+
+func InterruptedJobs() (jobs []*Interrupted) {
+	if g.store == nil {
+		return nil
+	}
+	for _, dljob := range g.store.interruptedJobs() {
+		jobs = append(jobs, &Interrupted{ID: dljob.id, XactID: dljob.xid, Body: dljob.dlb})
+	}
+	return jobs
+}
+
+// interrupted job that cannot be resumed: mark it aborted (and finished)
+func AbandonJob(id string, cause error) {
+	if g.store != nil {
+		g.store.abandon(id, cause)
+	}
+}
@@ -0,0 +1,151 @@
+// Package dload implements functionality to download resources into AIS cluster from external source.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package dload
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Per-job request headers and credentials (see Base.Headers, Base.Auth) are never
+// persisted in plain text: prior to storing download jobs (to resume them upon restart)
+// they get separated from the rest of the request and encrypted (AES-256-GCM)
+// with a per-target key that is generated upon first use (fname.DloadKey).
+//
+// NOTE: the key is stored in the target's config directory alongside the kvdb that
+// contains the ciphertext - it protects against leaking the db file alone (backups,
+// copies) but not against anyone with read access to the config directory.
+
+const secretKeySize = 32
+
+// request fields that are not to be stored in plain text (or shown)
+var secretFields = [...]string{"headers", "auth"}
+
+type secrets struct {
+	aead  cipher.AEAD
+	err   error
+	fpath string
+	once  sync.Once
+}
+
+func (s *secrets) init() error {
+	s.once.Do(s._init)
+	return s.err
+}
+
+func (s *secrets) _init() {
+	key, err := os.ReadFile(s.fpath)
+	switch {
+	case err == nil:
+		if len(key) != secretKeySize {
+			s.err = fmt.Errorf("invalid downloader key %q (size %d)", s.fpath, len(key))
+			return
+		}
+	case os.IsNotExist(err):
+		key = make([]byte, secretKeySize)
+		if _, err = cryptorand.Read(key); err == nil {
+			if err = cos.CreateDir(filepath.Dir(s.fpath)); err == nil {
+				err = os.WriteFile(s.fpath, key, 0o600)
+			}
+		}
+		if err != nil {
+			s.err = fmt.Errorf("failed to generate downloader key %q: %w", s.fpath, err)
+			return
+		}
+	default:
+		s.err = err
+		return
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		s.err = err
+		return
+	}
+	s.aead, s.err = cipher.NewGCM(block)
+}
+
+// returns nonce + ciphertext
+func (s *secrets) seal(plain []byte) ([]byte, error) {
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(plain)+s.aead.Overhead())
+	if _, err := cryptorand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+func (s *secrets) open(sealed []byte) ([]byte, error) {
+	if err := s.init(); err != nil {
+		return nil, err
+	}
+	ns := s.aead.NonceSize()
+	if len(sealed) < ns {
+		return nil, errors.New("invalid (truncated) download job secrets")
+	}
+	return s.aead.Open(nil, sealed[:ns], sealed[ns:], nil)
+}
+
+// separate secret fields from the rest of the request and encrypt them
+func (s *secrets) sealBody(dlb Body) (pub Body, sealed []byte, err error) {
+	var m map[string]jsoniter.RawMessage
+	if err = jsoniter.Unmarshal(dlb.RawMessage, &m); err != nil {
+		return
+	}
+	sec := make(map[string]jsoniter.RawMessage, len(secretFields))
+	for _, name := range secretFields {
+		if v, ok := m[name]; ok {
+			sec[name] = v
+			delete(m, name)
+		}
+	}
+	if len(sec) == 0 {
+		return dlb, nil, nil
+	}
+	pub.Type = dlb.Type
+	if pub.RawMessage, err = jsoniter.Marshal(m); err != nil {
+		return
+	}
+	plain, err := jsoniter.Marshal(sec)
+	if err != nil {
+		return
+	}
+	sealed, err = s.seal(plain)
+	return
+}
+
+// (reverse sealBody)
+func (s *secrets) openBody(pub Body, sealed []byte) (dlb Body, err error) {
+	if len(sealed) == 0 {
+		return pub, nil
+	}
+	plain, err := s.open(sealed)
+	if err != nil {
+		return
+	}
+	var m, sec map[string]jsoniter.RawMessage
+	if err = jsoniter.Unmarshal(pub.RawMessage, &m); err != nil {
+		return
+	}
+	if err = jsoniter.Unmarshal(plain, &sec); err != nil {
+		return
+	}
+	for name, v := range sec {
+		m[name] = v
+	}
+	dlb.Type = pub.Type
+	dlb.RawMessage, err = jsoniter.Marshal(m)
+	return
+}
@@ -43,6 +43,8 @@ type singleTask struct {
 	downloadCtx context.Context    // w/ cancel function
 	getCtx      context.Context    // w/ timeout and size
 	cancel      context.CancelFunc // to cancel in-progress download
+	host        string             // origin host (empty when downloading via remote backend)
+	admitted    bool               // by the host (see hosts.go)
 }
 
 // List of HTTP status codes which we shouldn'task retry (just report the job failed).
@@ -114,6 +116,8 @@ func (task *singleTask) _dlocal(lom *core.LOM, timeout time.Duration) (bool /*er
 		return true, err
 	}
 
+	setHeader(req, task.job.base().hdr)
+
 	// Set "User-Agent" header when doing requests to Google Cloud Storage.
 	// This should increase the number of connections to GCS.
 	if cos.IsGoogleStorageURL(req.URL) {
@@ -132,6 +136,9 @@ func (task *singleTask) _dlocal(lom *core.LOM, timeout time.Duration) (bool /*er
 
 func (task *singleTask) _dput(lom *core.LOM, req *http.Request, resp *http.Response) (bool /*err is fatal*/, error) {
 	if resp.StatusCode >= http.StatusBadRequest {
+		if task.host != "" && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			g.hosts.backoff(task.host, retryAfter(resp.Header))
+		}
 		if resp.StatusCode == http.StatusNotFound {
 			return false, cmn.NewErrHTTP(req, fmt.Errorf("%q does not exist", task.obj.link), http.StatusNotFound)
 		}
@@ -170,6 +177,12 @@ func (task *singleTask) downloadLocal(lom *core.LOM) (err error) {
 		fatal   bool
 	)
 	for i := range retryCnt {
+		if i > 0 && task.host != "" {
+			// honor per-host rate limit and Retry-After (if any)
+			if err = task.waitHost(); err != nil {
+				return err
+			}
+		}
 		fatal, err = task._dlocal(lom, timeout)
 		if err == nil || fatal {
 			return err
@@ -198,6 +211,40 @@ func (task *singleTask) downloadLocal(lom *core.LOM) (err error) {
 	return err
 }
 
+// admit (or not) the task by its origin host
+func (task *singleTask) admit(config *cmn.Config) bool {
+	if task.obj.fromRemote {
+		return true
+	}
+	if task.host = linkHost(task.obj.link); task.host == "" {
+		return true
+	}
+	task.admitted, _ = g.hosts.acquire(task.host, config)
+	return task.admitted
+}
+
+func (task *singleTask) releaseHost() {
+	if task.admitted {
+		g.hosts.release(task.host)
+		task.admitted = false
+	}
+}
+
+func (task *singleTask) waitHost() error {
+	wait := g.hosts.reserve(task.host, cmn.GCO.Get())
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	select {
+	case <-timer.C:
+		return nil
+	case <-task.downloadCtx.Done():
+		timer.Stop()
+		return context.Canceled
+	}
+}
+
 func (task *singleTask) setTotalSize(size int64) {
 	if size > 0 {
 		task.totalSize.Store(size)
@@ -216,6 +263,9 @@ func (task *singleTask) downloadRemote(lom *core.LOM) error {
 
 	ctx = context.WithValue(ctx, cos.CtxReadWrapper, cos.ReadWrapperFunc(task.wrapReader))
 	ctx = context.WithValue(ctx, cos.CtxSetSize, cos.SetSizeFunc(task.setTotalSize))
+	if hdr := task.job.base().hdr; hdr != nil {
+		ctx = context.WithValue(ctx, cos.CtxHTTPHeaders, hdr) // (added to the backend's GET request)
+	}
 	task.getCtx = ctx
 
 	// Do final GET (prefetch) request.
@@ -246,15 +296,21 @@ func (task *singleTask) wrapReader(r io.ReadCloser) io.ReadCloser {
 	return r
 }
 
-// Probably we need to extend the persistent database (db.go) so that it will contain
-// also information about specific tasks.
+// NOTE: when the node is shutting down (and the task was most likely canceled)
+// neither failure nor task info get recorded - the task will be redone upon resumption
 func (task *singleTask) markFailed(statusMsg string) {
+	if nlog.Stopping() {
+		return
+	}
 	g.tstats.IncErr(stats.ErrDownloadCount)
 	g.store.persistError(task.jobID(), task.obj.objName, statusMsg)
 	g.store.incErrorCnt(task.jobID())
 }
 
 func (task *singleTask) persist() {
+	if nlog.Stopping() {
+		return
+	}
 	if err := g.store.persistTaskInfo(task); err != nil {
 		nlog.Errorln(err)
 	}
@@ -117,7 +117,14 @@ func NormalizeObjName(objName string) (string, error) {
 	return url.PathUnescape(u.Path)
 }
 
-func ParseStartRequest(bck *meta.Bck, id string, dlb Body, xdl *Xact) (jobif, error) {
+func ParseStartRequest(bck *meta.Bck, id string, dlb Body, xdl *Xact) (job jobif, err error) {
+	if job, err = newJob(bck, id, dlb, xdl); err == nil {
+		job.base().dlb = dlb
+	}
+	return job, err
+}
+
+func newJob(bck *meta.Bck, id string, dlb Body, xdl *Xact) (jobif, error) {
 	switch dlb.Type {
 	case TypeBackend:
 		dp := &BackendBody{}
@@ -226,13 +233,14 @@ func parseGoogleCksumHeader(hdr []string) cos.StrKVs {
 	return cksums
 }
 
-func headLink(link string) (resp *http.Response, err error) {
+func headLink(link string, hdr http.Header) (resp *http.Response, err error) {
 	var (
 		req         *http.Request
 		ctx, cancel = context.WithTimeout(context.Background(), headReqTimeout)
 	)
 	req, err = http.NewRequestWithContext(ctx, http.MethodHead, link, http.NoBody)
 	if err == nil {
+		setHeader(req, hdr)
 		resp, err = clientForURL(link).Do(req)
 	}
 	cancel()
@@ -248,7 +256,7 @@ func CompareObjects(lom *core.LOM, dst *DstElement) (bool /*equal*/, error) {
 		// TODO: make use of res.ObjAttrs
 	}
 
-	resp, err := headLink(dst.Link) //nolint:bodyclose // cos.Close
+	resp, err := headLink(dst.Link, dst.Header) //nolint:bodyclose // cos.Close
 	if err != nil {
 		return false, err
 	}
@@ -260,6 +268,12 @@ func CompareObjects(lom *core.LOM, dst *DstElement) (bool /*equal*/, error) {
 	return lom.Equal(oa), nil
 }
 
+func setHeader(req *http.Request, hdr http.Header) {
+	for name, vals := range hdr {
+		req.Header[name] = vals
+	}
+}
+
 // called via ais/prxnotifs generic mechanism
 func AbortReq(jobID string) cmn.HreqArgs {
 	var (
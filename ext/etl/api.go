@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -25,6 +26,8 @@ const PrefixXactID = "etl-"
 const (
 	Spec = "spec"
 	Code = "code"
+	Proc = "command"     // local process (see InitProcMsg)
+	Bltn = "transformer" // built-in, in-process (see InitBuiltinMsg)
 )
 
 // consistent with rfc2396.txt "Uniform Resource Identifiers (URI): Generic Syntax"
@@ -58,7 +61,7 @@ const (
 type (
 	InitMsg interface {
 		Name() string
-		MsgType() string // Code or Spec
+		MsgType() string // Code, Spec, Proc, or Bltn
 		CommType() string
 		ArgType() string
 		Validate() error
@@ -96,6 +99,27 @@ type (
 		// bitwise flags: (streaming | debug | strict | ...) future enhancements
 		Flags int64 `json:"flags"`
 	}
+
+	// InitProcMsg runs the transformer as a local process (one per target) - no Kubernetes required.
+	// With `hpush://`, `hpull://`, and `hrev://` the command must start an HTTP server
+	// listening on the port given by the `PORT` environment variable; with `io://` the command
+	// gets executed once per object, reading the latter from stdin and writing the result to stdout.
+	InitProcMsg struct {
+		InitMsgBase
+		Command []string          `json:"command"`
+		Env     map[string]string `json:"env,omitempty"`
+		// readiness and health probe (e.g. "/health"); if empty, the target only checks
+		// that the port accepts TCP connections
+		HealthPath string `json:"health_path,omitempty"`
+	}
+
+	// InitBuiltinMsg selects one of the registered Go transformers (see builtin.go) that run
+	// inside the target itself, without any network hops.
+	InitBuiltinMsg struct {
+		InitMsgBase
+		Transformer string            `json:"transformer"`    // e.g. "gzip", "regex"
+		Args        map[string]string `json:"args,omitempty"` // transformer-specific
+	}
 )
 
 type (
@@ -141,13 +165,23 @@ var (
 var (
 	_ InitMsg = (*InitCodeMsg)(nil)
 	_ InitMsg = (*InitSpecMsg)(nil)
+	_ InitMsg = (*InitProcMsg)(nil)
+	_ InitMsg = (*InitBuiltinMsg)(nil)
 )
 
-func (m InitMsgBase) CommType() string { return m.CommTypeX }
-func (m InitMsgBase) ArgType() string  { return m.ArgTypeX }
-func (m InitMsgBase) Name() string     { return m.IDX }
-func (*InitCodeMsg) MsgType() string   { return Code }
-func (*InitSpecMsg) MsgType() string   { return Spec }
+func (m InitMsgBase) CommType() string  { return m.CommTypeX }
+func (m InitMsgBase) ArgType() string   { return m.ArgTypeX }
+func (m InitMsgBase) Name() string      { return m.IDX }
+func (*InitCodeMsg) MsgType() string    { return Code }
+func (*InitSpecMsg) MsgType() string    { return Spec }
+func (*InitProcMsg) MsgType() string    { return Proc }
+func (*InitBuiltinMsg) MsgType() string { return Bltn }
+
+// whether a given ETL runs in K8s pod(s)
+func IsK8sRuntime(msg InitMsg) bool {
+	ty := msg.MsgType()
+	return ty == Spec || ty == Code
+}
 
 func (m *InitCodeMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s-%s-%s]", Code, m.IDX, m.CommTypeX, m.ArgTypeX, m.Runtime)
@@ -157,6 +191,14 @@ func (m *InitSpecMsg) String() string {
 	return fmt.Sprintf("init-%s[%s-%s-%s]", Spec, m.IDX, m.CommTypeX, m.ArgTypeX)
 }
 
+func (m *InitProcMsg) String() string {
+	return fmt.Sprintf("init-proc[%s-%s-%s-%s]", m.IDX, m.CommTypeX, m.ArgTypeX, strings.Join(m.Command, " "))
+}
+
+func (m *InitBuiltinMsg) String() string {
+	return fmt.Sprintf("init-%s[%s-%s]", Bltn, m.IDX, m.Transformer)
+}
+
 // TODO: double-take, unmarshaling-wise. To avoid, include (`Spec`, `Code`) in API calls
 func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 	var msgInf map[string]json.RawMessage
@@ -173,6 +215,16 @@ func UnmarshalInitMsg(b []byte) (msg InitMsg, err error) {
 		err = jsoniter.Unmarshal(b, msg)
 		return
 	}
+	if _, ok := msgInf[Proc]; ok {
+		msg = &InitProcMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
+	if _, ok := msgInf[Bltn]; ok {
+		msg = &InitBuiltinMsg{}
+		err = jsoniter.Unmarshal(b, msg)
+		return
+	}
 	err = fmt.Errorf("invalid etl.InitMsg: %+v", msgInf)
 	return
 }
@@ -297,6 +349,46 @@ func (m *InitSpecMsg) Validate() (err error) {
 	return nil
 }
 
+func (m *InitProcMsg) Validate() error {
+	if err := m.InitMsgBase.validate(m.String()); err != nil {
+		return err
+	}
+	errCtx := &cmn.ETLErrCtx{ETLName: m.Name()}
+	if len(m.Command) == 0 || m.Command[0] == "" {
+		return cmn.NewErrETL(errCtx, "command is empty (comm-type %q)", m.CommTypeX)
+	}
+	if m.HealthPath != "" && !strings.HasPrefix(m.HealthPath, "/") {
+		return cmn.NewErrETL(errCtx, "invalid health path %q (expecting absolute URL path)", m.HealthPath)
+	}
+	if m.HealthPath != "" && m.CommTypeX == HpushStdin {
+		return cmn.NewErrETL(errCtx, "health path does not apply to comm-type %q", HpushStdin)
+	}
+	for k := range m.Env {
+		if k == "" || k == envTargetURL || k == envPort {
+			return cmn.NewErrETL(errCtx, "invalid or reserved environment variable %q", k)
+		}
+	}
+	return nil
+}
+
+func (m *InitBuiltinMsg) Validate() error {
+	errCtx := &cmn.ETLErrCtx{ETLName: m.Name()}
+	// communication and argument types do not apply
+	if m.CommTypeX != "" && m.CommTypeX != Hpush {
+		return cmn.NewErrETL(errCtx, "comm-type %q does not apply to built-in transformer %q", m.CommTypeX, m.Transformer)
+	}
+	if m.ArgTypeX != ArgTypeDefault {
+		return cmn.NewErrETL(errCtx, "arg-type %q does not apply to built-in transformer %q", m.ArgTypeX, m.Transformer)
+	}
+	if err := m.InitMsgBase.validate(m.String()); err != nil {
+		return err
+	}
+	if _, err := newTransformer(m.Transformer, m.Args); err != nil {
+		return cmn.NewErrETL(errCtx, "%v", err)
+	}
+	return nil
+}
+
 func ParsePodSpec(errCtx *cmn.ETLErrCtx, spec []byte) (*corev1.Pod, error) {
 	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(spec, nil, nil)
 	if err != nil {
@@ -36,6 +36,7 @@ type etlBootstrapper struct {
 	xctn            core.Xact
 	pod             *corev1.Pod
 	svc             *corev1.Service
+	proc            *etlProc // local process (see proc.go) - in place of pod and service
 	uri             string
 	originalPodName string
 	originalCommand []string
@@ -331,7 +332,7 @@ func (b *etlBootstrapper) _setPodEnv() {
 	debug.Assert(len(containers) > 0)
 	for idx := range containers {
 		containers[idx].Env = append(containers[idx].Env, corev1.EnvVar{
-			Name:  "AIS_TARGET_URL",
+			Name:  envTargetURL,
 			Value: core.T.Snode().URL(cmn.NetPublic) + apc.URLPathETLObject.Join(reqSecret),
 		})
 		for k, v := range b.env {
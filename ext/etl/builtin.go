@@ -0,0 +1,187 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+)
+
+// Built-in (in-process) transformers: Go code that runs inside the target
+// at disk speed - no containers, no network hops (compare with communicator.go).
+// Stock transformers are registered in xforms.go; more can be added via RegisterTransformer.
+
+type (
+	// Transformer reads the original object's content and writes the transformed one.
+	// Must be safe for concurrent use.
+	Transformer interface {
+		Transform(w io.Writer, r io.Reader, objName string) error
+	}
+
+	// constructs transformer given user-specified (InitBuiltinMsg) arguments
+	NewTransformer func(args map[string]string) (Transformer, error)
+
+	builtinComm struct {
+		baseComm
+		xform Transformer
+		name  string // transformer
+	}
+)
+
+// interface guard
+var _ Communicator = (*builtinComm)(nil)
+
+var (
+	xforms   = make(map[string]NewTransformer, 8)
+	xformsMu sync.RWMutex
+)
+
+func RegisterTransformer(name string, f NewTransformer) {
+	xformsMu.Lock()
+	_, ok := xforms[name]
+	cos.AssertMsg(!ok, "duplicate transformer "+name)
+	xforms[name] = f
+	xformsMu.Unlock()
+}
+
+func Transformers() (names []string) {
+	xformsMu.RLock()
+	names = make([]string, 0, len(xforms))
+	for name := range xforms {
+		names = append(names, name)
+	}
+	xformsMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+func newTransformer(name string, args map[string]string) (Transformer, error) {
+	xformsMu.RLock()
+	f, ok := xforms[name]
+	xformsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown transformer %q (registered: %v)", name, Transformers())
+	}
+	return f(args)
+}
+
+// InitBuiltin "starts" built-in transformer - the counterpart of InitSpec
+func InitBuiltin(msg *InitBuiltinMsg, xid string) error {
+	xform, err := newTransformer(msg.Transformer, msg.Args)
+	if err != nil {
+		return cmn.NewErrETL(&cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}, "%v", err)
+	}
+	boot := &etlBootstrapper{
+		errCtx:          &cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX},
+		config:          cmn.GCO.Get(),
+		msg:             InitSpecMsg{InitMsgBase: msg.InitMsgBase},
+		originalPodName: msg.IDX,
+	}
+	boot.setupXaction(xid)
+
+	comm := &builtinComm{xform: xform, name: msg.Transformer}
+	comm.listener, comm.boot = newAborter(msg.IDX), boot
+	if err := reg.add(msg.IDX, comm); err != nil {
+		boot.xctn.Finish()
+		return err
+	}
+	core.T.Sowner().Listeners().Reg(comm)
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("started etl[%s], msg %s", msg.IDX, msg)
+	}
+	return nil
+}
+
+/////////////////
+// builtinComm //
+/////////////////
+
+// no pods, no services
+func (*builtinComm) PodName() string { return "" }
+func (*builtinComm) SvcName() string { return "" }
+
+func (bc *builtinComm) String() string {
+	return fmt.Sprintf("%s[%s]-builtin-%s", bc.boot.originalPodName, bc.boot.xctn.ID(), bc.name)
+}
+
+func (bc *builtinComm) InlineTransform(w http.ResponseWriter, _ *http.Request, lom *core.LOM) error {
+	fh, size, err := bc.open(lom)
+	if err != nil {
+		return err
+	}
+	err = bc.transform(w, fh, lom, size)
+	cos.Close(fh)
+	return err
+}
+
+// NOTE: runs at local disk speed - `timeout` does not apply
+func (bc *builtinComm) OfflineTransform(lom *core.LOM, _ time.Duration) (cos.ReadCloseSizer, error) {
+	clone := *lom
+	fh, size, err := bc.open(&clone)
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		err := bc.transform(pw, fh, &clone, size)
+		cos.Close(fh)
+		pw.CloseWithError(err)
+	}()
+	return cos.NewReaderWithArgs(cos.ReaderArgs{R: pr, Size: -1}), nil
+}
+
+func (bc *builtinComm) open(lom *core.LOM) (fh cos.LomHandle, size int64, err error) {
+	if err = bc.boot.xctn.AbortErr(); err != nil {
+		return nil, 0, err
+	}
+	if err = lom.InitBck(lom.Bucket()); err != nil {
+		return nil, 0, err
+	}
+	fh, size, err = bc._open(lom)
+	if err != nil && cos.IsNotExist(err, 0) && lom.Bucket().IsRemote() {
+		if _, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetLock); err != nil {
+			return nil, 0, err
+		}
+		fh, size, err = bc._open(lom)
+	}
+	return fh, size, err
+}
+
+func (*builtinComm) _open(lom *core.LOM) (fh cos.LomHandle, size int64, err error) {
+	lom.Lock(false)
+	if err = lom.Load(false /*cache it*/, true /*locked*/); err == nil {
+		size = lom.Lsize()
+		fh, err = lom.NewHandle()
+	}
+	lom.Unlock(false)
+	return fh, size, err
+}
+
+func (bc *builtinComm) transform(w io.Writer, r io.Reader, lom *core.LOM, size int64) error {
+	var (
+		xctn = bc.boot.xctn
+		cw   = &cbWriter{w: w, writeCb: func(n int) { xctn.InObjsAdd(0, int64(n)) }}
+	)
+	err := bc.xform.Transform(cw, r, lom.ObjName)
+	xctn.InObjsAdd(1, 0)
+	xctn.OutObjsAdd(1, size) // see also: `coi.objsAdd`
+	if err != nil {
+		return fmt.Errorf("%s: %s(%s): %w", bc, bc.name, lom.Cname(), err)
+	}
+	if cmn.Rom.FastV(5, cos.SmoduleETL) {
+		nlog.Infoln(bc.name, lom.Cname())
+	}
+	return nil
+}
@@ -59,6 +59,9 @@ type (
 		Stop()
 
 		CommStats
+
+		// local process runtime (nil when running in K8s pod)
+		process() *etlProc
 	}
 
 	baseComm struct {
@@ -137,8 +140,16 @@ func newCommunicator(listener meta.Slistener, boot *etlBootstrapper) Communicato
 }
 
 func (c *baseComm) Name() string    { return c.boot.originalPodName }
-func (c *baseComm) PodName() string { return c.boot.pod.Name }
-func (c *baseComm) SvcName() string { return c.boot.pod.Name /*same as pod name*/ }
+func (c *baseComm) SvcName() string { return c.PodName() /*same as pod name*/ }
+
+func (c *baseComm) PodName() string {
+	if c.boot.proc != nil {
+		return c.boot.proc.name
+	}
+	return c.boot.pod.Name
+}
+
+func (c *baseComm) process() *etlProc { return c.boot.proc }
 
 func (c *baseComm) ListenSmapChanged() { c.listener.ListenSmapChanged() }
 
@@ -244,7 +255,7 @@ func (pc *pushComm) do(lom *core.LOM, timeout time.Duration) (_ cos.ReadCloseSiz
 		debug.Assertf(lom.Bck().Ns.IsGlobal(), lom.Bck().Cname("")+" - bucket with namespace")
 		u = pc.boot.uri + "/" + lom.Bck().Name + "/" + lom.ObjName
 
-		fh, err := cos.NewFileHandle(lom.FQN)
+		fh, err := lom.NewHandle()
 		if err != nil {
 			return nil, 0, err
 		}
@@ -102,6 +102,10 @@ func (e *MD) UnmarshalJSON(data []byte) (err error) {
 			e.ETLs[k] = &InitCodeMsg{}
 		case Spec:
 			e.ETLs[k] = &InitSpecMsg{}
+		case Proc:
+			e.ETLs[k] = &InitProcMsg{}
+		case Bltn:
+			e.ETLs[k] = &InitBuiltinMsg{}
 		default:
 			err = fmt.Errorf("invalid InitMsg type %q", v.Type)
 			debug.AssertNoErr(err)
@@ -0,0 +1,381 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/k8s"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+// Local-process runtime (see InitProcMsg): each target runs its own transformer
+// as a child process - bare-metal and development deployments, no Kubernetes required.
+//
+// - hpush://, hpull://, hrev://: the (long-running) process listens on $PORT;
+//   the target waits for it to become ready and then communicates with it
+//   via the respective (regular) communicator;
+// - io://: the target itself listens on a loopback port and executes the command
+//   once per object (stdin => stdout), while the regular `pushComm` is used to push the objects.
+//
+// In both cases, the process' output (stderr and, for long-running processes, stdout)
+// is retained (the tail of it) for `ais etl view-logs`.
+//
+// The command executes with the privileges of the target (aisnode) process - hence,
+// the runtime is disabled unless AuthN is enabled (and ETL init requires admin access)
+// or the cluster has the feat.LocalProcessETL feature flag set (see ProcAllowed).
+
+const (
+	envTargetURL = "AIS_TARGET_URL"
+	envPort      = "PORT"
+)
+
+const (
+	procLogsSize    = 256 * cos.KiB
+	procStopTimeout = 5 * time.Second
+)
+
+// health status (compare with K8s pod phase)
+const (
+	procRunning   = "Running"
+	procUnhealthy = "Unhealthy"
+	procExited    = "Exited"
+)
+
+type (
+	etlProc struct {
+		msg     *InitProcMsg
+		cmd     *exec.Cmd    // long-running process (nil with io://)
+		srv     *http.Server // io:// only
+		exited  chan struct{}
+		exitErr error
+		name    string // <etl-name>-<target-id> (compare with the pod name)
+		uri     string
+		env     []string
+		logs    procLogs
+		started time.Time
+		stopped atomic.Bool
+	}
+
+	// retains the last `procLogsSize` bytes
+	procLogs struct {
+		buf []byte
+		mu  sync.Mutex
+	}
+)
+
+var ErrProcDisabled = errors.New("local-process ETL runtime is disabled (requires AuthN or feature flag \"Local-Process-ETL\")")
+
+// ProcAllowed is checked by the proxy (upon ETL init) and, again, by each target
+func ProcAllowed() error {
+	if cmn.Rom.AuthEnabled() || cmn.Rom.Features().IsSet(feat.LocalProcessETL) {
+		return nil
+	}
+	return ErrProcDisabled
+}
+
+// InitProc starts local transformer process - the counterpart of InitSpec
+func InitProc(msg *InitProcMsg, xid string) error {
+	if err := ProcAllowed(); err != nil {
+		return cmn.NewErrETL(&cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}, "%v", err)
+	}
+	var (
+		errCtx = &cmn.ETLErrCtx{TID: core.T.SID(), ETLName: msg.IDX}
+		proc   = newProc(msg)
+		boot   = &etlBootstrapper{
+			errCtx: errCtx,
+			config: cmn.GCO.Get(),
+			msg:    InitSpecMsg{InitMsgBase: msg.InitMsgBase},
+		}
+	)
+	errCtx.PodName = proc.name
+	boot.proc, boot.originalPodName = proc, msg.IDX
+	if msg.CommTypeX == HpushStdin {
+		boot.originalCommand = msg.Command
+	}
+
+	if err := proc.start(boot.msg.Timeout.D()); err != nil {
+		proc.stop()
+		err = cmn.NewErrETL(errCtx, "failed to start local process: %v", err)
+		nlog.Warningln(err)
+		return err
+	}
+	boot.uri = proc.uri
+	boot.setupXaction(xid)
+
+	comm := newCommunicator(newAborter(msg.IDX), boot)
+	if err := reg.add(msg.IDX, comm); err != nil {
+		proc.stop()
+		boot.xctn.Finish()
+		return err
+	}
+	core.T.Sowner().Listeners().Reg(comm)
+	go proc.watch(boot.xctn)
+
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("started etl[%s], msg %s, uri %s", msg.IDX, msg, proc.uri)
+	}
+	return nil
+}
+
+/////////////
+// etlProc //
+/////////////
+
+func newProc(msg *InitProcMsg) *etlProc {
+	p := &etlProc{
+		msg:    msg,
+		name:   k8s.CleanName(msg.IDX + "-" + core.T.SID()),
+		exited: make(chan struct{}),
+	}
+	p.env = append(os.Environ(),
+		envTargetURL+"="+core.T.Snode().URL(cmn.NetPublic)+apc.URLPathETLObject.Join(reqSecret))
+	for k, v := range msg.Env {
+		p.env = append(p.env, k+"="+v)
+	}
+	return p
+}
+
+func (p *etlProc) start(timeout time.Duration) error {
+	p.started = time.Now()
+	if p.msg.CommTypeX == HpushStdin {
+		return p.serveIO()
+	}
+
+	host := core.T.Snode().PubNet.Hostname
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+	p.uri = "http://" + net.JoinHostPort(host, port)
+
+	p.cmd = exec.Command(p.msg.Command[0], p.msg.Command[1:]...) //nolint:gosec // see ProcAllowed
+	p.cmd.Env = append(p.env, envPort+"="+port)
+	p.cmd.Stdout, p.cmd.Stderr = &p.logs, &p.logs
+	p.cmd.SysProcAttr = sysProcAttr()
+	p.cmd.WaitDelay = procStopTimeout // in case children keep holding stdout/stderr
+	if err := p.cmd.Start(); err != nil {
+		close(p.exited)
+		return err
+	}
+	go p.wait()
+
+	return p.waitReady(net.JoinHostPort(host, port), timeout)
+}
+
+func (p *etlProc) wait() {
+	p.exitErr = p.cmd.Wait()
+	if p.exitErr == nil {
+		p.exitErr = errors.New("exit status 0")
+	}
+	close(p.exited)
+}
+
+// upon unexpected exit, abort the ETL xaction (but keep the ETL registered
+// so that the logs can still be viewed)
+func (p *etlProc) watch(xctn core.Xact) {
+	<-p.exited
+	if p.stopped.Load() {
+		return
+	}
+	err := fmt.Errorf("etl[%s]: local process %q terminated unexpectedly: %v", p.msg.IDX, p.name, p.exitErr)
+	nlog.Errorln(err)
+	xctn.Abort(err)
+}
+
+// poll until either ready or timed-out; fail early if the process exits
+func (p *etlProc) waitReady(socketAddr string, timeout time.Duration) error {
+	var (
+		interval = cos.ProbingFrequency(timeout)
+		deadline = time.Now().Add(timeout)
+		err      error
+	)
+	if cmn.Rom.FastV(4, cos.SmoduleETL) {
+		nlog.Infof("waiting local process %q ready (%s) timeout=%v ival=%v", p.name, p.msg, timeout, interval)
+	}
+	for {
+		if err = p.probe(socketAddr, interval); err == nil {
+			return nil
+		}
+		select {
+		case <-p.exited:
+			return fmt.Errorf("process %q exited: %v", p.name, p.exitErr)
+		case <-time.After(interval):
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("process %q is not ready after %v: %v", p.name, timeout, err)
+		}
+	}
+}
+
+func (p *etlProc) probe(socketAddr string, timeout time.Duration) error {
+	if p.msg.HealthPath == "" {
+		conn, err := net.DialTimeout("tcp", socketAddr, timeout)
+		if err != nil {
+			return err
+		}
+		cos.Close(conn)
+		return nil
+	}
+	client := cmn.NewClient(cmn.TransportArgs{Timeout: timeout})
+	resp, err := client.Get(p.uri + p.msg.HealthPath)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check %q: %s", p.msg.HealthPath, resp.Status)
+	}
+	return nil
+}
+
+func (p *etlProc) stop() {
+	p.stopped.Store(true)
+	if p.srv != nil {
+		p.srv.Close()
+		return
+	}
+	if p.cmd == nil || p.cmd.Process == nil {
+		return
+	}
+	select {
+	case <-p.exited:
+		return
+	default:
+	}
+	// terminate the entire process group (e.g., `sh -c ...` with its children)
+	syscall.Kill(-p.cmd.Process.Pid, syscall.SIGTERM)
+	select {
+	case <-p.exited:
+	case <-time.After(procStopTimeout):
+		nlog.Warningln("local process", p.name, "did not terminate in", procStopTimeout, "- killing it")
+		syscall.Kill(-p.cmd.Process.Pid, syscall.SIGKILL)
+		<-p.exited
+	}
+}
+
+func (p *etlProc) health() string {
+	if p.srv != nil {
+		if p.stopped.Load() {
+			return procExited
+		}
+		return procRunning
+	}
+	select {
+	case <-p.exited:
+		return procExited + ": " + p.exitErr.Error()
+	default:
+	}
+	if p.msg.HealthPath != "" {
+		if err := p.probe("", cmn.Rom.MaxKeepalive()); err != nil {
+			return procUnhealthy + ": " + err.Error()
+		}
+	}
+	return procRunning
+}
+
+func (p *etlProc) metrics() (*CPUMemUsed, error) {
+	if p.cmd == nil {
+		return nil, fmt.Errorf("etl[%s]: metrics are not supported with comm-type %q", p.msg.IDX, HpushStdin)
+	}
+	stats, err := sys.ProcessStats(p.cmd.Process.Pid)
+	if err != nil {
+		return nil, err
+	}
+	// average number of cores used since the start (compare with K8s metrics)
+	var cpu float64
+	if elapsed := time.Since(p.started); elapsed > 0 {
+		cpu = float64(stats.CPU.Total) / float64(elapsed.Milliseconds()+1)
+	}
+	return &CPUMemUsed{TargetID: core.T.SID(), CPU: cpu, Mem: int64(stats.Mem.Resident)}, nil
+}
+
+//
+// io:// - execute the command once per object
+//
+
+func (p *etlProc) serveIO() error {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	p.uri = "http://" + ln.Addr().String()
+	p.srv = &http.Server{Handler: http.HandlerFunc(p.transformIO), ReadHeaderTimeout: apc.DefaultTimeout}
+	go func() {
+		if err := p.srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+			nlog.Errorln(p.name, "io:// server failed:", err)
+		}
+	}()
+	return nil
+}
+
+// NOTE: ignores the `command` query (see pushComm) and always runs the configured command
+func (p *etlProc) transformIO(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		cmn.WriteErr405(w, r, http.MethodPut)
+		return
+	}
+	var (
+		stdout bytes.Buffer
+		cmd    = exec.CommandContext(r.Context(), p.msg.Command[0], p.msg.Command[1:]...) //nolint:gosec // ditto
+	)
+	cmd.Env = p.env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = r.Body, &stdout, &p.logs
+	if err := cmd.Run(); err != nil {
+		cmn.WriteErr(w, r, fmt.Errorf("etl[%s]: %q failed: %v", p.msg.IDX, p.msg.Command[0], err))
+		return
+	}
+	w.Header().Set(cos.HdrContentLength, strconv.Itoa(stdout.Len()))
+	w.Write(stdout.Bytes())
+}
+
+func freePort() (string, error) {
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return "", err
+	}
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	cos.Close(ln)
+	return port, err
+}
+
+//////////////
+// procLogs //
+//////////////
+
+func (l *procLogs) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	l.buf = append(l.buf, b...)
+	if n := len(l.buf); n > procLogsSize {
+		l.buf = l.buf[:copy(l.buf, l.buf[n-procLogsSize:])]
+	}
+	l.mu.Unlock()
+	return len(b), nil
+}
+
+func (l *procLogs) Bytes() (b []byte) {
+	l.mu.Lock()
+	b = bytes.Clone(l.buf)
+	l.mu.Unlock()
+	return b
+}
@@ -0,0 +1,97 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/feat"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalProcess", func() {
+	It("should unmarshal and validate init-proc message", func() {
+		msg := &InitProcMsg{
+			InitMsgBase: InitMsgBase{IDX: "local-echo", CommTypeX: Hpush},
+			Command:     []string{"python3", "server.py"},
+			HealthPath:  "/health",
+		}
+		initMsg, err := UnmarshalInitMsg(cos.MustMarshal(msg))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(initMsg.MsgType()).To(Equal(Proc))
+		Expect(initMsg.Validate()).NotTo(HaveOccurred())
+		Expect(initMsg.(*InitProcMsg).Command).To(Equal(msg.Command))
+
+		invalid := *msg
+		invalid.Command = nil
+		Expect(invalid.Validate()).To(HaveOccurred())
+		invalid = *msg
+		invalid.Env = map[string]string{envPort: "8000"}
+		Expect(invalid.Validate()).To(HaveOccurred())
+		invalid = *msg
+		invalid.CommTypeX = HpushStdin
+		Expect(invalid.Validate()).To(HaveOccurred())
+	})
+
+	It("should be disabled by default", func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Auth.Enabled, config.Features = false, 0
+		cmn.GCO.CommitUpdate(config)
+		cmn.Rom.Set(&config.ClusterConfig)
+		Expect(ProcAllowed()).To(MatchError(ErrProcDisabled))
+
+		config = cmn.GCO.BeginUpdate()
+		config.Features = feat.LocalProcessETL
+		cmn.GCO.CommitUpdate(config)
+		cmn.Rom.Set(&config.ClusterConfig)
+		Expect(ProcAllowed()).NotTo(HaveOccurred())
+
+		config = cmn.GCO.BeginUpdate()
+		config.Auth.Enabled, config.Features = true, 0
+		cmn.GCO.CommitUpdate(config)
+		cmn.Rom.Set(&config.ClusterConfig)
+		Expect(ProcAllowed()).NotTo(HaveOccurred())
+	})
+
+	It("should retain the tail of the logs", func() {
+		var logs procLogs
+		chunk := bytes.Repeat([]byte("a"), procLogsSize/2)
+		logs.Write(chunk)
+		logs.Write(chunk)
+		logs.Write([]byte("tail"))
+		b := logs.Bytes()
+		Expect(b).To(HaveLen(procLogsSize))
+		Expect(string(b)).To(HaveSuffix("tail"))
+	})
+
+	It("should transform via io:// (command per object)", func() {
+		p := &etlProc{
+			msg: &InitProcMsg{
+				InitMsgBase: InitMsgBase{IDX: "local-io", CommTypeX: HpushStdin},
+				Command:     []string{"tr", "a-z", "A-Z"},
+			},
+			exited: make(chan struct{}),
+		}
+		Expect(p.serveIO()).NotTo(HaveOccurred())
+		defer p.stop()
+		Expect(p.health()).To(Equal(procRunning))
+
+		req, err := http.NewRequest(http.MethodPut, p.uri+"/bck/obj", strings.NewReader("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(string(b)).To(Equal("HELLO"))
+	})
+})
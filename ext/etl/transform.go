@@ -313,7 +313,9 @@ func Stop(id string, errCause error) error {
 	errCtx.PodName = c.PodName()
 	errCtx.SvcName = c.SvcName()
 
-	if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
+	if proc := c.process(); proc != nil {
+		proc.stop()
+	} else if err := cleanupEntities(errCtx, c.PodName(), c.SvcName()); err != nil {
 		return err
 	}
 
@@ -328,9 +330,6 @@ func Stop(id string, errCause error) error {
 
 // StopAll terminates all running ETLs.
 func StopAll() {
-	if !k8s.IsK8s() {
-		return
-	}
 	for _, e := range List() {
 		if err := Stop(e.Name, nil); err != nil {
 			nlog.Errorln(err)
@@ -353,6 +352,12 @@ func PodLogs(transformID string) (logs Logs, err error) {
 	if err != nil {
 		return logs, err
 	}
+	if proc := c.process(); proc != nil {
+		return Logs{TargetID: core.T.SID(), Logs: proc.logs.Bytes()}, nil
+	}
+	if _, ok := c.(*builtinComm); ok {
+		return Logs{TargetID: core.T.SID()}, nil // (see target log)
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return logs, err
@@ -372,6 +377,12 @@ func PodHealth(etlName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if proc := c.process(); proc != nil {
+		return proc.health(), nil
+	}
+	if _, ok := c.(*builtinComm); ok {
+		return procRunning, nil
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return "", err
@@ -384,6 +395,12 @@ func PodMetrics(etlName string) (*CPUMemUsed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if proc := c.process(); proc != nil {
+		return proc.metrics()
+	}
+	if _, ok := c.(*builtinComm); ok {
+		return nil, fmt.Errorf("etl[%s]: metrics are not supported with built-in transformers (see target metrics)", etlName)
+	}
 	client, err := k8s.GetClient()
 	if err != nil {
 		return nil, err
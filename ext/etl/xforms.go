@@ -0,0 +1,222 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// stock built-in transformers (see builtin.go)
+const (
+	XformGzip     = "gzip"      // args: "level" (optional, 1..9)
+	XformGunzip   = "gunzip"    //
+	XformTar2JSON = "tar2jsonl" // args: "text" (optional, "true" - file contents as strings rather than base64)
+	XformCksum    = "checksum"  // args: "type" (optional, default md5) - outputs hex-encoded checksum
+	XformRegex    = "regex"     // args: "pattern", "replace" - line-by-line rewrite
+)
+
+type (
+	xgzip struct {
+		level int
+	}
+	xgunzip struct{}
+	xtar2j  struct {
+		text bool
+	}
+	xcksum struct {
+		ty string
+	}
+	xregex struct {
+		re      *regexp.Regexp
+		replace []byte
+	}
+
+	// one line of tar2jsonl output
+	tarEntry struct {
+		Name string `json:"name"`
+		Size int64  `json:"size,string"`
+		Mode int64  `json:"mode"`
+		Data any    `json:"data"` // []byte (base64) or string
+	}
+)
+
+// interface guard
+var (
+	_ Transformer = (*xgzip)(nil)
+	_ Transformer = (*xgunzip)(nil)
+	_ Transformer = (*xtar2j)(nil)
+	_ Transformer = (*xcksum)(nil)
+	_ Transformer = (*xregex)(nil)
+)
+
+func init() {
+	RegisterTransformer(XformGzip, newGzip)
+	RegisterTransformer(XformGunzip, func(map[string]string) (Transformer, error) { return &xgunzip{}, nil })
+	RegisterTransformer(XformTar2JSON, newTar2JSON)
+	RegisterTransformer(XformCksum, newCksum)
+	RegisterTransformer(XformRegex, newRegex)
+}
+
+//
+// gzip and gunzip
+//
+
+func newGzip(args map[string]string) (Transformer, error) {
+	xf := &xgzip{level: gzip.DefaultCompression}
+	if s, ok := args["level"]; ok {
+		level, err := strconv.Atoi(s)
+		if err != nil || level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, fmt.Errorf("%s: invalid compression level %q (expecting %d to %d)",
+				XformGzip, s, gzip.BestSpeed, gzip.BestCompression)
+		}
+		xf.level = level
+	}
+	return xf, nil
+}
+
+func (xf *xgzip) Transform(w io.Writer, r io.Reader, _ string) error {
+	gzw, err := gzip.NewWriterLevel(w, xf.level)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(gzw, r); err != nil {
+		gzw.Close()
+		return err
+	}
+	return gzw.Close()
+}
+
+func (*xgunzip) Transform(w io.Writer, r io.Reader, _ string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, gzr)
+	cos.Close(gzr)
+	return err
+}
+
+//
+// tar to JSON Lines: one line per regular file
+//
+
+func newTar2JSON(args map[string]string) (Transformer, error) {
+	xf := &xtar2j{}
+	if s, ok := args["text"]; ok {
+		text, err := cos.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid \"text\" argument %q", XformTar2JSON, s)
+		}
+		xf.text = text
+	}
+	return xf, nil
+}
+
+func (xf *xtar2j) Transform(w io.Writer, r io.Reader, _ string) error {
+	var (
+		tr  = tar.NewReader(r)
+		enc = jsoniter.NewEncoder(w) // (appends newline)
+	)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		entry := tarEntry{Name: hdr.Name, Size: hdr.Size, Mode: hdr.Mode, Data: b}
+		if xf.text {
+			entry.Data = string(b)
+		}
+		if err := enc.Encode(&entry); err != nil {
+			return err
+		}
+	}
+}
+
+//
+// checksum (recompute)
+//
+
+func newCksum(args map[string]string) (Transformer, error) {
+	xf := &xcksum{ty: cos.ChecksumMD5}
+	if ty, ok := args["type"]; ok {
+		if err := cos.ValidateCksumType(ty); err != nil || ty == cos.ChecksumNone {
+			return nil, fmt.Errorf("%s: invalid checksum type %q", XformCksum, ty)
+		}
+		xf.ty = ty
+	}
+	return xf, nil
+}
+
+func (xf *xcksum) Transform(w io.Writer, r io.Reader, _ string) error {
+	cksum := cos.NewCksumHash(xf.ty)
+	if _, err := io.Copy(cksum.H, r); err != nil {
+		return err
+	}
+	cksum.Finalize()
+	_, err := io.WriteString(w, cksum.Value())
+	return err
+}
+
+//
+// regex (line-by-line) rewrite
+//
+
+func newRegex(args map[string]string) (Transformer, error) {
+	pattern, ok := args["pattern"]
+	if !ok || pattern == "" {
+		return nil, fmt.Errorf("%s: missing \"pattern\" argument", XformRegex)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", XformRegex, err)
+	}
+	return &xregex{re: re, replace: []byte(args["replace"])}, nil
+}
+
+func (xf *xregex) Transform(w io.Writer, r io.Reader, _ string) error {
+	var (
+		br = bufio.NewReader(r)
+		bw = bufio.NewWriter(w)
+	)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			var eol bool
+			if line[len(line)-1] == '\n' {
+				line, eol = line[:len(line)-1], true
+			}
+			bw.Write(xf.re.ReplaceAll(line, xf.replace))
+			if eol {
+				bw.WriteByte('\n')
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return bw.Flush()
+			}
+			return err
+		}
+	}
+}
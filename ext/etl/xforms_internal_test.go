@@ -0,0 +1,85 @@
+// Package etl provides utilities to initialize and use transformation pods.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package etl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn/cos"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuiltinTransformers", func() {
+	transform := func(name string, args map[string]string, in []byte) []byte {
+		xf, err := newTransformer(name, args)
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(xf.Transform(&out, bytes.NewReader(in), "obj")).NotTo(HaveOccurred())
+		return out.Bytes()
+	}
+
+	It("should gzip and gunzip", func() {
+		in := bytes.Repeat([]byte("hello world "), 1000)
+		zipped := transform(XformGzip, map[string]string{"level": "9"}, in)
+		Expect(len(zipped)).To(BeNumerically("<", len(in)))
+
+		gzr, err := gzip.NewReader(bytes.NewReader(zipped))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := io.ReadAll(gzr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(in))
+
+		Expect(transform(XformGunzip, nil, zipped)).To(Equal(in))
+	})
+
+	It("should convert tar to jsonl", func() {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, name := range []string{"a.txt", "b.txt"} {
+			Expect(tw.WriteHeader(&tar.Header{Name: name, Size: 3, Mode: 0o644, Typeflag: tar.TypeReg})).To(Succeed())
+			_, err := tw.Write([]byte("abc"))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tw.Close()).To(Succeed())
+
+		out := transform(XformTar2JSON, map[string]string{"text": "true"}, buf.Bytes())
+		lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(lines[0]).To(ContainSubstring(`"name":"a.txt"`))
+		Expect(lines[1]).To(ContainSubstring(`"data":"abc"`))
+	})
+
+	It("should recompute checksum", func() {
+		in := []byte("checksum me")
+		cksum := cos.NewCksumHash(cos.ChecksumSHA256)
+		cksum.H.Write(in)
+		cksum.Finalize()
+		Expect(string(transform(XformCksum, map[string]string{"type": cos.ChecksumSHA256}, in))).To(Equal(cksum.Value()))
+	})
+
+	It("should rewrite line by line", func() {
+		in := []byte("foo=1\nbar=2\nfoo=3")
+		out := transform(XformRegex, map[string]string{"pattern": `^foo=(\d)`, "replace": "baz=$1"}, in)
+		Expect(string(out)).To(Equal("baz=1\nbar=2\nbaz=3"))
+	})
+
+	It("should fail to construct with invalid args", func() {
+		for name, args := range map[string]map[string]string{
+			XformGzip:     {"level": "42"},
+			XformCksum:    {"type": "none"},
+			XformRegex:    {"pattern": "("},
+			XformTar2JSON: {"text": "maybe"},
+			"nonexistent": nil,
+		} {
+			_, err := newTransformer(name, args)
+			Expect(err).To(HaveOccurred(), name)
+		}
+	})
+})
@@ -38,6 +38,7 @@ const (
 	WorkfileType = "wk"
 	ECSliceType  = "ec"
 	ECMetaType   = "mt"
+	ChunkType    = "ch"
 )
 
 type (
@@ -178,6 +179,7 @@ type (
 	WorkfileContentResolver struct{}
 	ECSliceContentResolver  struct{}
 	ECMetaContentResolver   struct{}
+	ChunkContentResolver    struct{}
 )
 
 func (*ObjectContentResolver) PermToMove() bool                   { return true }
@@ -252,3 +254,26 @@ func (*ECMetaContentResolver) GenUniqueFQN(base, _ string) string { return base
 func (*ECMetaContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
 	return base, false, true
 }
+
+// chunks of a (large) object stored as multiple files across mountpaths:
+// <object-name>.<chunk-number>, where the numbering starts from zero
+// (see also: core/lchunk.go)
+
+func (*ChunkContentResolver) PermToMove() bool    { return false }
+func (*ChunkContentResolver) PermToEvict() bool   { return false }
+func (*ChunkContentResolver) PermToProcess() bool { return false }
+
+func (*ChunkContentResolver) GenUniqueFQN(base, prefix string) string {
+	return base + "." + prefix
+}
+
+func (*ChunkContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	i := strings.LastIndexByte(base, '.')
+	if i <= 0 {
+		return "", false, false
+	}
+	if _, err := strconv.Atoi(base[i+1:]); err != nil {
+		return "", false, false
+	}
+	return base[:i], false, true
+}
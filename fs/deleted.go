@@ -5,29 +5,44 @@
 package fs
 
 import (
+	"container/heap"
+	"context"
 	"errors"
 	"fmt"
+	iofs "io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/fname"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
+	"golang.org/x/sync/errgroup"
 )
 
-// TODO: undelete (feature)
-
 const (
 	deletedRoot = ".$deleted"
+	softdelDir  = "$softdel"       // soft-deleted objects (see cmn.SoftDelConf)
+	xattrDtime  = "user.ais.dtime" // when soft-deleted (the object's own mtime remains intact)
 	desleep     = 256 * time.Millisecond
 	deretries   = 3
 )
 
+type SoftDelEnt struct {
+	Mi      *Mountpath
+	FQN     string
+	ObjName string
+	Size    int64
+	Dtime   int64 // when deleted
+}
+
 func (mi *Mountpath) DeletedRoot() string {
 	return filepath.Join(mi.Path, deletedRoot)
 }
@@ -47,6 +62,9 @@ func (mi *Mountpath) RemoveDeleted(who string) (rerr error) {
 		return err
 	}
 	for _, dent := range dentries {
+		if dent.Name() == softdelDir {
+			continue // (see PurgeSoftDeleted)
+		}
 		fqn := filepath.Join(delroot, dent.Name())
 		if !dent.IsDir() {
 			err := fmt.Errorf("%s: unexpected non-directory item %q in 'deleted'", who, fqn)
@@ -117,6 +135,196 @@ rm:
 	return err
 }
 
+//
+// soft-deleted objects: <mountpath>/.$deleted/$softdel/<bucket>/<object>
+//
+
+func (mi *Mountpath) SoftDelDir(bck *cmn.Bck) string {
+	bdir := mi.MakePathBck(bck)
+	return filepath.Join(mi.Path, deletedRoot, softdelDir, bdir[len(mi.Path):])
+}
+
+func (mi *Mountpath) SoftDelFQN(bck *cmn.Bck, objName string) string {
+	return filepath.Join(mi.SoftDelDir(bck), objName)
+}
+
+// record deletion time
+func SetSoftDelTime(fqn string, dtime int64) error {
+	return SetXattr(fqn, xattrDtime, []byte(strconv.FormatInt(dtime, 10)))
+}
+
+// remove deletion time (upon undelete)
+func ClearSoftDelTime(fqn string) error {
+	return removeXattr(fqn, xattrDtime)
+}
+
+// deletion time, or mtime when not recorded
+func SoftDelTime(fqn string, finfo os.FileInfo) int64 {
+	var buf [24]byte
+	if b, err := GetXattrBuf(fqn, xattrDtime, buf[:]); err == nil {
+		if dtime, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return dtime
+		}
+	}
+	return finfo.ModTime().UnixNano()
+}
+
+// walk soft-deleted objects across all available mountpaths in object name order
+// (merging per-mountpath walks - see WalkBck), and call `cb` for each, one at a time;
+// when the same object is found on multiple mountpaths the most recently deleted wins;
+// a non-nil error returned by `cb` stops the walk and gets returned
+func WalkSoftDeleted(bck *cmn.Bck, prefix string, cb func(*SoftDelEnt) error) error {
+	var (
+		avail      = GetAvail()
+		chs        = make([]chan *SoftDelEnt, 0, len(avail))
+		group, ctx = errgroup.WithContext(context.Background())
+	)
+	for _, mi := range avail {
+		ch := make(chan *SoftDelEnt, mpathQueueSize)
+		chs = append(chs, ch)
+		group.Go(func() error {
+			defer close(ch)
+			return mi.walkSoftDeleted(ctx, bck, prefix, ch)
+		})
+	}
+	group.Go(func() error {
+		var (
+			h    = &sdeHeap{}
+			last *SoftDelEnt
+		)
+		for i, ch := range chs {
+			if ent, ok := <-ch; ok {
+				heap.Push(h, sdeInfo{ent, i})
+			}
+		}
+		for h.Len() > 0 {
+			info := heap.Pop(h).(sdeInfo)
+			if ent, ok := <-chs[info.idx]; ok {
+				heap.Push(h, sdeInfo{ent, info.idx})
+			}
+			switch {
+			case last == nil:
+				last = info.ent
+			case last.ObjName == info.ent.ObjName:
+				if info.ent.Dtime > last.Dtime {
+					last = info.ent
+				}
+			default:
+				if err := cb(last); err != nil {
+					return err
+				}
+				last = info.ent
+			}
+		}
+		if last != nil {
+			return cb(last)
+		}
+		return nil
+	})
+	return group.Wait()
+}
+
+// NOTE: WalkDir visits directory entries in lexical order, which is not the same as the full
+// object name order (e.g., "a/b" gets visited before "a.b") - hence, collecting and sorting
+// prior to merging (see WalkSoftDeleted)
+func (mi *Mountpath) walkSoftDeleted(ctx context.Context, bck *cmn.Bck, prefix string, ch chan *SoftDelEnt) error {
+	var (
+		dir  = mi.SoftDelDir(bck)
+		ents []*SoftDelEnt
+	)
+	err := filepath.WalkDir(dir, func(fqn string, de iofs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fqn == dir {
+			return nil
+		}
+		objName := filepath.ToSlash(fqn[len(dir)+1:])
+		if de.IsDir() {
+			if !cmn.DirHasOrIsPrefix(objName, prefix) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(objName, prefix) {
+			return nil
+		}
+		finfo, err := de.Info()
+		if err != nil {
+			return nil // (undeleted or purged in the meantime)
+		}
+		ent := &SoftDelEnt{Mi: mi, FQN: fqn, ObjName: objName, Size: finfo.Size(), Dtime: SoftDelTime(fqn, finfo)}
+		ents = append(ents, ent)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(ents, func(i, j int) bool { return ents[i].ObjName < ents[j].ObjName })
+	for _, ent := range ents {
+		select {
+		case ch <- ent:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+type (
+	sdeInfo struct {
+		ent *SoftDelEnt
+		idx int // mountpath
+	}
+	sdeHeap []sdeInfo
+)
+
+func (h sdeHeap) Len() int           { return len(h) }
+func (h sdeHeap) Less(i, j int) bool { return h[i].ent.ObjName < h[j].ent.ObjName }
+func (h sdeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *sdeHeap) Push(x any)        { *h = append(*h, x.(sdeInfo)) }
+
+func (h *sdeHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[0 : n-1]
+	return x
+}
+
+// remove soft-deleted objects that are older than the specified retention time
+func (mi *Mountpath) PurgeSoftDeleted(bck *cmn.Bck, retention time.Duration, now time.Time) (n int, size int64, _ error) {
+	dir := mi.SoftDelDir(bck)
+	err := filepath.WalkDir(dir, func(fqn string, de iofs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if de.IsDir() {
+			return nil
+		}
+		finfo, err := de.Info()
+		if err != nil || now.Sub(time.Unix(0, SoftDelTime(fqn, finfo))) < retention {
+			return nil
+		}
+		if err := os.Remove(fqn); err != nil {
+			if !os.IsNotExist(err) {
+				nlog.Errorln(mi.String(), "failed to purge soft-deleted:", err)
+			}
+			return nil
+		}
+		n++
+		size += finfo.Size()
+		return nil
+	})
+	return n, size, err
+}
+
 func (mi *Mountpath) ClearMDs(inclBMD bool) (rerr error) {
 	for _, mdfd := range mdFilesDirs {
 		if !inclBMD && mdfd == fname.Bmd {
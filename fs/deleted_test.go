@@ -0,0 +1,112 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestSoftDeleted(t *testing.T) {
+	var (
+		bck    = cmn.Bck{Name: "softdel", Provider: apc.AIS, Ns: cmn.NsGlobal}
+		now    = time.Now()
+		mpaths = make([]string, 0, 2)
+	)
+	fs.TestNew(mock.NewIOS())
+	defer func() {
+		for _, mpath := range mpaths {
+			os.RemoveAll(mpath)
+		}
+	}()
+	for range 2 {
+		mpath, err := os.MkdirTemp("", "testsoftdel")
+		tassert.CheckFatal(t, err)
+		_, err = fs.Add(mpath, "daeID")
+		tassert.CheckFatal(t, err)
+		mpaths = append(mpaths, mpath)
+	}
+
+	// same object deleted twice (on different mountpaths) plus two more
+	var (
+		avail = fs.GetAvail()
+		mis   = make([]*fs.Mountpath, 0, len(avail))
+	)
+	for _, mi := range avail {
+		mis = append(mis, mi)
+	}
+	create := func(mi *fs.Mountpath, objName string, size int, dtime time.Time) {
+		fqn := mi.SoftDelFQN(&bck, objName)
+		tassert.CheckFatal(t, cos.CreateDir(filepath.Dir(fqn)))
+		tassert.CheckFatal(t, os.WriteFile(fqn, make([]byte, size), cos.PermRWR))
+		// the object's own mtime (that must not be confused with its deletion time)
+		mtime := now.Add(-72 * time.Hour)
+		tassert.CheckFatal(t, os.Chtimes(fqn, mtime, mtime))
+		tassert.CheckFatal(t, fs.SetSoftDelTime(fqn, dtime.UnixNano()))
+	}
+	create(mis[0], "a/obj1", 10, now.Add(-time.Hour))
+	create(mis[1], "a/obj1", 20, now.Add(-time.Minute))
+	create(mis[0], "a/obj2", 30, now.Add(-48*time.Hour))
+	create(mis[1], "b/obj3", 40, now)
+
+	ents, err := listSoftDeleted(&bck, "a/")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(ents) == 2, "expected 2 soft-deleted objects, got %d", len(ents))
+	tassert.Errorf(t, ents[0].ObjName == "a/obj1" && ents[0].Size == 20, "expected the latest a/obj1, got %+v", ents[0])
+	tassert.Errorf(t, ents[0].Dtime == now.Add(-time.Minute).UnixNano(), "expected a/obj1 deletion time, got %d", ents[0].Dtime)
+	tassert.Errorf(t, ents[1].ObjName == "a/obj2", "expected a/obj2, got %s", ents[1].ObjName)
+
+	// full object name order (as opposed to the order of visiting directories - "c/d" before "c.d")
+	create(mis[0], "c/d", 1, now)
+	create(mis[0], "c.d", 1, now)
+	create(mis[1], "c-d", 1, now)
+	ents, err = listSoftDeleted(&bck, "c")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(ents) == 3, "expected 3 soft-deleted objects, got %d", len(ents))
+	for i, name := range []string{"c-d", "c.d", "c/d"} {
+		tassert.Errorf(t, ents[i].ObjName == name, "expected %s at %d, got %s", name, i, ents[i].ObjName)
+	}
+	for _, mi := range mis {
+		for _, name := range []string{"c/d", "c.d", "c-d"} {
+			os.Remove(mi.SoftDelFQN(&bck, name))
+		}
+	}
+
+	// purge
+	var total int
+	for _, mi := range mis {
+		n, _, err := mi.PurgeSoftDeleted(&bck, 24*time.Hour, now)
+		tassert.CheckFatal(t, err)
+		total += n
+	}
+	tassert.Errorf(t, total == 1, "expected 1 purged object, got %d", total)
+	ents, err = listSoftDeleted(&bck, "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(ents) == 2, "expected 2 soft-deleted objects after purge, got %d", len(ents))
+
+	// stop walking
+	errStop := errors.New("stop")
+	n := 0
+	err = fs.WalkSoftDeleted(&bck, "", func(*fs.SoftDelEnt) error { n++; return errStop })
+	tassert.Errorf(t, err == errStop && n == 1, "expected walk to stop after 1 entry, got %d (%v)", n, err)
+}
+
+func listSoftDeleted(bck *cmn.Bck, prefix string) (ents []fs.SoftDelEnt, err error) {
+	err = fs.WalkSoftDeleted(bck, prefix, func(ent *fs.SoftDelEnt) error {
+		ents = append(ents, *ent)
+		return nil
+	})
+	return ents, err
+}
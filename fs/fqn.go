@@ -34,6 +34,7 @@ const (
 	WorkfileAppend       = "append"         // APPEND to object (as file)
 	WorkfileAppendToArch = "append-to-arch" // APPEND to existing archive
 	WorkfileCreateArch   = "create-arch"    // CREATE multi-object archive
+	WorkfileChunk        = "chunk"          // chunk of a (large) object being written
 )
 
 type ParsedFQN struct {
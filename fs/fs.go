@@ -901,6 +901,10 @@ func DestroyBucket(op string, bck *cmn.Bck, bid uint64) (err error) {
 		} else {
 			n++
 		}
+		// along with its soft-deleted objects, if any
+		if errMv := mi.MoveToDeleted(mi.SoftDelDir(bck)); errMv != nil {
+			nlog.Errorf("%s %q: failed to rm soft-deleted: %v", op, bck, errMv)
+		}
 	}
 	if n < count {
 		err = fmt.Errorf("%s %q: failed to destroy %d out of %d dirs", op, bck, count-n, count)
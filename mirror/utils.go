@@ -7,6 +7,7 @@ package mirror
 import (
 	"fmt"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/fs"
@@ -58,6 +59,11 @@ func addCopies(lom *core.LOM, copies int, buf []byte) (size int64, err error) {
 	if lom.NumCopies() >= copies {
 		return 0, nil
 	}
+	// Objects in mirrored buckets are never chunked (see lom.Csize); those that were
+	// chunked prior to enabling mirroring cannot be mirrored.
+	if lom.IsChunked() {
+		return 0, cmn.NewErrUnsupp("mirror chunked object", lom.Cname())
+	}
 
 	//  While copying we may find out that some copies do not exist -
 	//  these copies will be removed and `NumCopies()` will decrease.
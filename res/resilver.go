@@ -348,6 +348,18 @@ redo:
 		break
 	}
 ret:
+	// 4. fix chunks (see core/lchunk.go)
+	if lom.IsChunked() {
+		n, err := lom.ResilverChunks(buf)
+		if err != nil {
+			errV := fmt.Errorf("%s: failed to resilver %s chunks: %w", xname, lom, err)
+			nlog.Infoln("Warning:", errV)
+			jg.xres.AddErr(errV)
+		} else if n > 0 {
+			copied = true
+		}
+	}
+
 	// EC: remove old metafile
 	if metaOldPath != "" {
 		if err := os.Remove(metaOldPath); err != nil {
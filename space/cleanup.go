@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -314,6 +316,10 @@ func (j *clnJ) jogBcks(bcks []cmn.Bck) (size int64, rerr error) {
 			}
 			continue
 		}
+		if b.IsAIS() {
+			sz = j.purgeSoftDeleted(b)
+			size += sz
+		}
 		sz, err = j.jogBck()
 		size += sz
 		if err != nil && rerr == nil {
@@ -343,11 +349,25 @@ func (j *clnJ) removeDeleted() (err error) {
 	return
 }
 
+// remove soft-deleted objects that have outlived the bucket's retention time
+// (also when soft-delete has been disabled in the meantime)
+func (j *clnJ) purgeSoftDeleted(bck *meta.Bck) int64 {
+	n, size, err := j.mi.PurgeSoftDeleted(bck.Bucket(), bck.Props.SoftDel.RetentionD(), time.Now())
+	if err != nil {
+		j.ini.Xaction.AddErr(err)
+		nlog.Errorf("%s: failed to purge soft-deleted %s: %v", j, bck, err)
+	}
+	if n > 0 {
+		nlog.Infof("%s: purged %d soft-deleted object%s (%s) from %s", j, n, cos.Plural(n), cos.ToSizeIEC(size, 1), bck)
+	}
+	return size
+}
+
 func (j *clnJ) jogBck() (size int64, err error) {
 	opts := &fs.WalkOpts{
 		Mi:       j.mi,
 		Bck:      j.bck,
-		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType},
+		CTs:      []string{fs.WorkfileType, fs.ObjectType, fs.ECSliceType, fs.ECMetaType, fs.ChunkType},
 		Callback: j.walk,
 		Sorted:   false,
 	}
@@ -410,11 +430,49 @@ func (j *clnJ) visitCT(parsedFQN *fs.ParsedFQN, fqn string) {
 			return
 		}
 		j.oldWork = append(j.oldWork, fqn)
+	case fs.ChunkType:
+		j.visitChunk(parsedFQN, fqn)
 	default:
 		debug.Assertf(false, "Unsupported content type: %s", parsedFQN.ContentType)
 	}
 }
 
+// chunks (see core/lchunk.go): remove old chunks that do not belong to any current object
+// (e.g., leftovers of an overwritten or deleted object)
+func (j *clnJ) visitChunk(parsedFQN *fs.ParsedFQN, fqn string) {
+	i := strings.LastIndexByte(parsedFQN.ObjName, '.')
+	if i <= 0 {
+		return
+	}
+	num, err := strconv.Atoi(parsedFQN.ObjName[i+1:])
+	if err != nil {
+		return
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil || finfo.ModTime().UnixNano()+int64(j.config.LRU.DontEvictTime) > j.now {
+		return
+	}
+	lom := core.AllocLOM(parsedFQN.ObjName[:i])
+	defer core.FreeLOM(lom)
+	if lom.InitBck(&j.bck) != nil {
+		return
+	}
+	if err := lom.Load(false /*cache it*/, false /*locked*/); err != nil {
+		if !cos.IsNotExist(err, 0) {
+			return
+		}
+		// the object may be misplaced (e.g., mountpath added and resilvering is pending)
+		for _, mi := range fs.GetAvail() {
+			if cos.Stat(mi.MakePathFQN(&j.bck, fs.ObjectType, lom.ObjName)) == nil {
+				return
+			}
+		}
+	} else if lom.HasChunk(num) {
+		return
+	}
+	j.oldWork = append(j.oldWork, fqn)
+}
+
 // TODO: add stats error counters (stats.ErrLmetaCorruptedCount, ...)
 // TODO: revisit rm-ed byte counting
 func (j *clnJ) visitObj(fqn string, lom *core.LOM) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -43,6 +44,10 @@ type (
 		GetStatsV322() *NodeV322 // [backward compatibility]
 
 		ResetStats(errorsOnly bool)
+
+		// per-bucket and per-user (see cmn.MetricsConf)
+		AddReq(op int, bck *cmn.Bck, user string, size, latency int64, failed bool)
+		GetBckStats() *BckStats
 		GetMetricNames() cos.StrKVs // (name, kind) pairs
 
 		RegMetrics(node *meta.Snode) // + init Prometheus, if configured
@@ -50,9 +55,10 @@ type (
 
 	// REST API
 	Node struct {
-		Snode     *meta.Snode  `json:"snode"`
-		Tracker   copyTracker  `json:"tracker"`
-		TargetCDF fs.TargetCDF `json:"capacity"`
+		Snode     *meta.Snode             `json:"snode"`
+		Tracker   copyTracker             `json:"tracker"`
+		Latency   map[string]*Percentiles `json:"latency,omitempty"` // by metric name, e.g. "get.ns"
+		TargetCDF fs.TargetCDF            `json:"capacity"`
 	}
 	Cluster struct {
 		Proxy  *Node            `json:"proxy"`
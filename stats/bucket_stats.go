@@ -0,0 +1,328 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"strings"
+	"sync"
+	ratomic "sync/atomic"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Per-bucket and per-user (target) request metrics - in addition to the node-wide
+// GetCount, PutCount, et al. Enabled and limited by the `metrics` config (see cmn.MetricsConf):
+// once the number of tracked buckets (users) reaches the configured max, requests to
+// (by) all the rest get accounted under the single OtherLabel.
+//
+// Prometheus, e.g.:
+//   ais_target_bucket_get_n{bucket="ais://abc",node_id="fqWt8081"}
+//   ais_target_user_err_put_n{user="alice",node_id="fqWt8081"}
+//   ais_target_bucket_get_latency_seconds_bucket{bucket="s3://xyz",le="0.05",node_id="fqWt8081"}
+
+// request kinds
+const (
+	ReqGet = iota
+	ReqPut
+
+	numReqs
+)
+
+const OtherLabel = "_other"
+
+type (
+	// REST API (apc.WhatBckStats)
+	OpStats struct {
+		Hist    []int64 `json:"hist"`           // counts per latency bucket (see LatencyBounds; the last one is +Inf)
+		Count   int64   `json:"count,string"`   // successful requests
+		Errors  int64   `json:"errors,string"`  // failed requests
+		Size    int64   `json:"size,string"`    // bytes
+		Latency int64   `json:"latency,string"` // total (nanoseconds); average = Latency / Count
+	}
+	ReqStats struct {
+		Get OpStats `json:"get"`
+		Put OpStats `json:"put"`
+	}
+	BckStats struct {
+		Buckets map[string]*ReqStats `json:"buckets"` // by bucket cname
+		Users   map[string]*ReqStats `json:"users"`   // by AuthN user ID
+	}
+)
+
+type (
+	opCounters struct {
+		hist [numLatBuckets]int64
+		n    int64
+		errs int64
+		size int64
+		lat  int64
+	}
+	reqCounters [numReqs]opCounters
+
+	// counters by label (bucket or user)
+	labeled struct {
+		m     map[string]*reqCounters
+		other reqCounters
+		mu    sync.RWMutex
+	}
+
+	promOp struct {
+		n, errs, size, lat *prometheus.Desc
+	}
+	bckStats struct {
+		bcks  labeled
+		users labeled
+		prom  [2][numReqs]promOp // [bucket, user][ReqGet, ReqPut]
+	}
+)
+
+var reqNames = [numReqs]string{"get", "put"}
+
+//////////////
+// bckStats //
+//////////////
+
+func newBckStats() *bckStats {
+	s := &bckStats{}
+	s.bcks.m = make(map[string]*reqCounters, 16)
+	s.users.m = make(map[string]*reqCounters, 16)
+	return s
+}
+
+func (s *bckStats) add(conf *cmn.MetricsConf, op int, bck *cmn.Bck, user string, size, latency int64, failed bool) {
+	debug.Assert(op >= 0 && op < numReqs, op)
+	if conf.PerBucket && bck != nil {
+		c := s.bcks.get(bck.Cname(""), conf.BckLimit())
+		c[op].add(size, latency, failed)
+	}
+	if conf.PerUser && user != "" {
+		c := s.users.get(user, conf.UserLimit())
+		c[op].add(size, latency, failed)
+	}
+}
+
+func (s *bckStats) snap() *BckStats {
+	return &BckStats{Buckets: s.bcks.snap(), Users: s.users.snap()}
+}
+
+func (s *bckStats) reset(errorsOnly bool) {
+	s.bcks.reset(errorsOnly)
+	s.users.reset(errorsOnly)
+}
+
+// compare with coreStats.initProm()
+func (s *bckStats) initProm(snode *meta.Snode) {
+	id := strings.ReplaceAll(snode.ID(), ".", "_")
+	constLabels := prometheus.Labels{"node_id": id}
+	for i, dim := range [2]string{"bucket", "user"} {
+		for op := range numReqs {
+			var (
+				name = reqNames[op]
+				fq   = func(metric string) string { return prometheus.BuildFQName("ais", snode.Type(), dim+"_"+metric) }
+				vl   = []string{dim}
+			)
+			s.prom[i][op] = promOp{
+				n:    prometheus.NewDesc(fq(name+"_n"), "total number of operations", vl, constLabels),
+				errs: prometheus.NewDesc(fq("err_"+name+"_n"), "total number of failed operations", vl, constLabels),
+				size: prometheus.NewDesc(fq(name+"_size"), "total size (bytes)", vl, constLabels),
+				lat:  prometheus.NewDesc(fq(name+"_latency_seconds"), "latency (seconds)", vl, constLabels),
+			}
+		}
+	}
+}
+
+func (s *bckStats) describe(ch chan<- *prometheus.Desc) {
+	for i := range s.prom {
+		for op := range s.prom[i] {
+			p := &s.prom[i][op]
+			if p.n == nil {
+				return // not Prometheus
+			}
+			ch <- p.n
+			ch <- p.errs
+			ch <- p.size
+			ch <- p.lat
+		}
+	}
+}
+
+func (s *bckStats) collect(ch chan<- prometheus.Metric, conf *cmn.MetricsConf) {
+	if s.prom[0][0].n == nil {
+		return
+	}
+	if conf.PerBucket {
+		s.bcks.collect(ch, &s.prom[0])
+	}
+	if conf.PerUser {
+		s.users.collect(ch, &s.prom[1])
+	}
+}
+
+/////////////
+// labeled //
+/////////////
+
+func (l *labeled) get(label string, limit int) *reqCounters {
+	l.mu.RLock()
+	c, ok := l.m[label]
+	if !ok && len(l.m) >= limit {
+		c, ok = &l.other, true
+	}
+	l.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	l.mu.Lock()
+	if c, ok = l.m[label]; !ok {
+		if len(l.m) >= limit {
+			c = &l.other
+		} else {
+			c = &reqCounters{}
+			l.m[label] = c
+		}
+	}
+	l.mu.Unlock()
+	return c
+}
+
+func (l *labeled) snap() map[string]*ReqStats {
+	l.mu.RLock()
+	out := make(map[string]*ReqStats, len(l.m)+1)
+	for label, c := range l.m {
+		out[label] = c.snap()
+	}
+	if rs := l.other.snap(); rs.Get.Count+rs.Get.Errors+rs.Put.Count+rs.Put.Errors > 0 {
+		out[OtherLabel] = rs
+	}
+	l.mu.RUnlock()
+	return out
+}
+
+func (l *labeled) reset(errorsOnly bool) {
+	l.mu.Lock()
+	if errorsOnly {
+		for _, c := range l.m {
+			c.resetErrs()
+		}
+		l.other.resetErrs()
+	} else {
+		clear(l.m)
+		l.other = reqCounters{}
+	}
+	l.mu.Unlock()
+}
+
+func (l *labeled) collect(ch chan<- prometheus.Metric, descs *[numReqs]promOp) {
+	l.mu.RLock()
+	for label, c := range l.m {
+		c.collect(ch, descs, label)
+	}
+	l.other.collect(ch, descs, OtherLabel)
+	l.mu.RUnlock()
+}
+
+/////////////////
+// reqCounters //
+/////////////////
+
+func (c *reqCounters) snap() *ReqStats {
+	return &ReqStats{Get: c[ReqGet].snap(), Put: c[ReqPut].snap()}
+}
+
+func (c *reqCounters) resetErrs() {
+	for op := range c {
+		ratomic.StoreInt64(&c[op].errs, 0)
+	}
+}
+
+func (c *reqCounters) collect(ch chan<- prometheus.Metric, descs *[numReqs]promOp, label string) {
+	for op := range c {
+		var (
+			oc   = &c[op]
+			d    = &descs[op]
+			n    = ratomic.LoadInt64(&oc.n)
+			errs = ratomic.LoadInt64(&oc.errs)
+		)
+		if n == 0 && errs == 0 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(d.n, prometheus.CounterValue, float64(n), label)
+		ch <- prometheus.MustNewConstMetric(d.errs, prometheus.CounterValue, float64(errs), label)
+		ch <- prometheus.MustNewConstMetric(d.size, prometheus.CounterValue, float64(ratomic.LoadInt64(&oc.size)), label)
+		ch <- promHist(d.lat, &oc.hist, ratomic.LoadInt64(&oc.lat), label)
+	}
+}
+
+////////////////
+// opCounters //
+////////////////
+
+func (oc *opCounters) add(size, latency int64, failed bool) {
+	if failed {
+		ratomic.AddInt64(&oc.errs, 1)
+		return
+	}
+	ratomic.AddInt64(&oc.n, 1)
+	ratomic.AddInt64(&oc.size, size)
+	ratomic.AddInt64(&oc.lat, latency)
+	ratomic.AddInt64(&oc.hist[latBucket(latency)], 1)
+}
+
+func (oc *opCounters) snap() (out OpStats) {
+	out.Count = ratomic.LoadInt64(&oc.n)
+	out.Errors = ratomic.LoadInt64(&oc.errs)
+	out.Size = ratomic.LoadInt64(&oc.size)
+	out.Latency = ratomic.LoadInt64(&oc.lat)
+	out.Hist = make([]int64, numLatBuckets)
+	for i := range oc.hist {
+		out.Hist[i] = ratomic.LoadInt64(&oc.hist[i])
+	}
+	return out
+}
+
+//////////////
+// BckStats //
+//////////////
+
+// aggregate (e.g., cluster-wide) stats
+func (out *BckStats) Merge(in *BckStats) {
+	if out.Buckets == nil {
+		out.Buckets = make(map[string]*ReqStats, len(in.Buckets))
+	}
+	if out.Users == nil {
+		out.Users = make(map[string]*ReqStats, len(in.Users))
+	}
+	_merge(out.Buckets, in.Buckets)
+	_merge(out.Users, in.Users)
+}
+
+func _merge(out, in map[string]*ReqStats) {
+	for label, rs := range in {
+		if o, ok := out[label]; ok {
+			o.Get.merge(&rs.Get)
+			o.Put.merge(&rs.Put)
+		} else {
+			out[label] = rs
+		}
+	}
+}
+
+func (out *OpStats) merge(in *OpStats) {
+	out.Count += in.Count
+	out.Errors += in.Errors
+	out.Size += in.Size
+	out.Latency += in.Latency
+	if len(out.Hist) < len(in.Hist) {
+		out.Hist = append(out.Hist, make([]int64, len(in.Hist)-len(out.Hist))...)
+	}
+	for i, v := range in.Hist {
+		out.Hist[i] += v
+	}
+}
@@ -0,0 +1,97 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestBckStatsLimits(t *testing.T) {
+	var (
+		s    = newBckStats()
+		conf = &cmn.MetricsConf{PerBucket: true, PerUser: true, MaxBuckets: 2, MaxUsers: 1}
+		bcks = []cmn.Bck{
+			{Name: "a", Provider: apc.AIS},
+			{Name: "b", Provider: apc.AWS},
+			{Name: "c", Provider: apc.AIS},
+			{Name: "d", Provider: apc.AIS},
+		}
+	)
+	for i := range bcks {
+		s.add(conf, ReqGet, &bcks[i], "alice", 10, int64(3*time.Millisecond), false)
+	}
+	s.add(conf, ReqPut, &bcks[0], "bob", 0, 0, true)
+
+	snap := s.snap()
+	tassert.Fatalf(t, len(snap.Buckets) == 3, "expected 2 buckets + overflow, got %v", snap.Buckets)
+	tassert.Errorf(t, snap.Buckets[bcks[0].Cname("")].Put.Errors == 1, "expected PUT error")
+	o := snap.Buckets[OtherLabel]
+	tassert.Fatalf(t, o != nil && o.Get.Count == 2 && o.Get.Size == 20, "unexpected overflow %+v", o)
+	tassert.Errorf(t, o.Get.Hist[2] == 2 && o.Get.Latency == int64(6*time.Millisecond), "unexpected latency %+v", o.Get)
+
+	tassert.Fatalf(t, len(snap.Users) == 2, "expected 1 user + overflow, got %v", snap.Users)
+	tassert.Errorf(t, snap.Users["alice"].Get.Count == 4, "unexpected %+v", snap.Users["alice"])
+	tassert.Errorf(t, snap.Users[OtherLabel].Put.Errors == 1, "unexpected %+v", snap.Users[OtherLabel])
+
+	// cluster-wide
+	all := &BckStats{}
+	all.Merge(s.snap())
+	all.Merge(snap)
+	tassert.Errorf(t, all.Buckets[OtherLabel].Get.Count == 4 && all.Buckets[OtherLabel].Get.Hist[2] == 4,
+		"unexpected merged %+v", all.Buckets[OtherLabel])
+
+	s.reset(true /*errors only*/)
+	snap = s.snap()
+	tassert.Errorf(t, snap.Buckets[bcks[0].Cname("")].Put.Errors == 0, "expected errors reset")
+	tassert.Errorf(t, snap.Buckets[bcks[0].Cname("")].Get.Count == 1, "expected counters intact")
+	s.reset(false)
+	snap = s.snap()
+	tassert.Errorf(t, len(snap.Buckets) == 0 && len(snap.Users) == 0, "expected all reset")
+}
+
+func TestBckStatsProm(t *testing.T) {
+	var (
+		s    = newBckStats()
+		conf = &cmn.MetricsConf{PerBucket: true}
+		bck  = cmn.Bck{Name: "abc", Provider: apc.AIS}
+	)
+	s.initProm(&meta.Snode{DaeID: "t1", DaeType: apc.Target})
+	s.add(conf, ReqGet, &bck, "", 100, int64(700*time.Microsecond), false)
+	s.add(conf, ReqGet, &bck, "", 100, int64(20*time.Second), false)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(&testCollector{s, conf})
+	mfs, err := reg.Gather()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(mfs) == 4, "expected 4 metrics (count, errors, size, latency), got %d", len(mfs))
+	for _, mf := range mfs {
+		tassert.Fatalf(t, len(mf.GetMetric()) == 1, "%s: expected a single bucket", mf.GetName())
+		m := mf.GetMetric()[0]
+		if mf.GetName() == "ais_target_bucket_get_n" {
+			tassert.Errorf(t, m.GetCounter().GetValue() == 2, "expected 2 GETs, got %v", m.GetCounter().GetValue())
+		}
+		if h := m.GetHistogram(); h != nil {
+			tassert.Errorf(t, h.GetSampleCount() == 2, "expected 2 samples, got %d", h.GetSampleCount())
+			tassert.Errorf(t, h.GetBucket()[0].GetCumulativeCount() == 1, "expected 1 sample <= 1ms")
+			tassert.Errorf(t, h.GetBucket()[len(LatencyBounds)-1].GetCumulativeCount() == 1, "expected 1 sample > 10s")
+		}
+	}
+}
+
+type testCollector struct {
+	s    *bckStats
+	conf *cmn.MetricsConf
+}
+
+func (c *testCollector) Describe(ch chan<- *prometheus.Desc) { c.s.describe(ch) }
+func (c *testCollector) Collect(ch chan<- prometheus.Metric) { c.s.collect(ch, c.conf) }
@@ -72,6 +72,7 @@ const (
 	ErrHTTPWriteCount = errPrefix + "http.write.n"
 	ErrDownloadCount  = errPrefix + "dl.n"
 	ErrPutMirrorCount = errPrefix + "put.mirror.n"
+	ErrQuotaCount     = errPrefix + "quota.n" // rejected by storage quota (see cmn.QuotaConf)
 
 	// KindLatency
 	GetLatency       = "get.ns"
@@ -109,8 +110,9 @@ type (
 			stsd string // StatsD label
 			prom string // Prometheus label
 		}
-		Value      int64 `json:"v,string"`
-		numSamples int64 // (log + StatsD) only
+		hist       *histogram // select latencies only (see regHist)
+		Value      int64      `json:"v,string"`
+		numSamples int64      // (log + StatsD) only
 		cumulative int64
 	}
 	copyValue struct {
@@ -138,6 +140,7 @@ type (
 		ticker    *time.Ticker
 		core      *coreStats
 		ctracker  copyTracker // to avoid making it at runtime
+		bstats    *bckStats   // per-bucket and per-user (target only)
 		sorted    []string    // sorted names
 		name      string      // this stats-runner's name
 		prev      string      // prev ctracker.write
@@ -310,6 +313,12 @@ func (s *coreStats) initProm(snode *meta.Snode) {
 		fullqn := prometheus.BuildFQName("ais", snode.Type(), v.label.prom)
 		// e.g. metric: ais_target_disk_avg_wsize{disk="nvme0n1",node_id="fqWt8081"}
 		s.promDesc[name] = prometheus.NewDesc(fullqn, help, variableLabels, prometheus.Labels{"node_id": id})
+
+		// e.g. histogram: ais_target_get_latency_seconds_bucket{le="0.005",node_id="fqWt8081"}
+		if v.hist != nil {
+			fullqn = prometheus.BuildFQName("ais", snode.Type(), strings.TrimSuffix(v.label.prom, "_ms")+"_latency_seconds")
+			v.hist.desc = prometheus.NewDesc(fullqn, "latency (seconds)", nil, prometheus.Labels{"node_id": id})
+		}
 	}
 }
 
@@ -333,6 +342,9 @@ func (s *coreStats) update(nv cos.NamedVal64) {
 	switch v.kind {
 	case KindLatency:
 		ratomic.AddInt64(&v.numSamples, 1)
+		if v.hist != nil {
+			v.hist.add(nv.Value)
+		}
 		fallthrough
 	case KindThroughput:
 		ratomic.AddInt64(&v.Value, nv.Value)
@@ -372,6 +384,12 @@ func (s *coreStats) copyT(out copyTracker, diskLowUtil ...int64) bool {
 			if !s.isPrometheus() && millis > 0 {
 				s.statsdC.AppMetric(metric{Type: statsd.Timer, Name: v.label.stsd, Value: float64(millis)}, s.sgl)
 			}
+			if v.hist != nil {
+				p := v.hist.rotate()
+				if p != nil && !s.isPrometheus() {
+					v.hist.statsd(s.statsdC, s.sgl, p)
+				}
+			}
 		case KindThroughput:
 			var throughput int64
 			if throughput = ratomic.SwapInt64(&v.Value, 0); throughput > 0 {
@@ -465,6 +483,22 @@ func (s *coreStats) copyCumulative(ctracker copyTracker) {
 	}
 }
 
+// REST API: latency percentiles over the last stats interval
+func (s *coreStats) percentiles() (out map[string]*Percentiles) {
+	for name, v := range s.Tracker {
+		if v.hist == nil {
+			continue
+		}
+		if p := v.hist.last.Load(); p != nil {
+			if out == nil {
+				out = make(map[string]*Percentiles, 4)
+			}
+			out[name] = p
+		}
+	}
+	return out
+}
+
 func (s *coreStats) reset(errorsOnly bool) {
 	if errorsOnly {
 		for name, v := range s.Tracker {
@@ -480,6 +514,9 @@ func (s *coreStats) reset(errorsOnly bool) {
 		switch v.kind {
 		case KindLatency:
 			ratomic.StoreInt64(&v.numSamples, 0)
+			if v.hist != nil {
+				v.hist.reset()
+			}
 			fallthrough
 		case KindThroughput:
 			ratomic.StoreInt64(&v.Value, 0)
@@ -589,7 +626,7 @@ var (
 func (r *runner) GetStats() *Node {
 	ctracker := make(copyTracker, 48)
 	r.core.copyCumulative(ctracker)
-	return &Node{Tracker: ctracker}
+	return &Node{Tracker: ctracker, Latency: r.core.percentiles()}
 }
 
 func (r *runner) GetStatsV322() (out *NodeV322) {
@@ -603,6 +640,27 @@ func (r *runner) GetStatsV322() (out *NodeV322) {
 
 func (r *runner) ResetStats(errorsOnly bool) {
 	r.core.reset(errorsOnly)
+	if r.bstats != nil {
+		r.bstats.reset(errorsOnly)
+	}
+}
+
+// per-bucket and per-user GET and PUT (see bucket_stats.go)
+func (r *runner) AddReq(op int, bck *cmn.Bck, user string, size, latency int64, failed bool) {
+	if r.bstats == nil {
+		return
+	}
+	conf := &cmn.GCO.Get().Metrics
+	if conf.PerBucket || conf.PerUser {
+		r.bstats.add(conf, op, bck, user, size, latency, failed)
+	}
+}
+
+func (r *runner) GetBckStats() *BckStats {
+	if r.bstats == nil {
+		return &BckStats{}
+	}
+	return r.bstats.snap()
 }
 
 func (r *runner) GetMetricNames() cos.StrKVs {
@@ -635,10 +693,11 @@ func (r *runner) regCommon(snode *meta.Snode) {
 	r.reg(snode, ErrHTTPWriteCount, KindCounter)
 	r.reg(snode, ErrDownloadCount, KindCounter)
 	r.reg(snode, ErrPutMirrorCount, KindCounter)
+	r.reg(snode, ErrQuotaCount, KindCounter)
 
 	// latency
-	r.reg(snode, GetLatency, KindLatency)
-	r.reg(snode, ListLatency, KindLatency)
+	r.regHist(snode, GetLatency)
+	r.regHist(snode, ListLatency)
 	r.reg(snode, KeepAliveLatency, KindLatency)
 
 	// special uptime
@@ -689,6 +748,13 @@ func (r *runner) reg(snode *meta.Snode, name, kind string) {
 	r.core.Tracker[name] = v
 }
 
+// latency with histogram (and percentiles)
+func (r *runner) regHist(snode *meta.Snode, name string) {
+	r.reg(snode, name, KindLatency)
+	v := r.core.Tracker[name]
+	v.hist = newHistogram(v.label.stsd)
+}
+
 //
 // as cos.StatsUpdater
 //
@@ -741,6 +807,14 @@ func (r *runner) Describe(ch chan<- *prometheus.Desc) {
 	for _, desc := range r.core.promDesc {
 		ch <- desc
 	}
+	for _, v := range r.core.Tracker {
+		if v.hist != nil && v.hist.desc != nil {
+			ch <- v.hist.desc
+		}
+	}
+	if r.bstats != nil {
+		r.bstats.describe(ch)
+	}
 }
 
 func (r *runner) Collect(ch chan<- prometheus.Metric) {
@@ -796,6 +870,15 @@ func (r *runner) Collect(ch chan<- prometheus.Metric) {
 		ch <- m
 	}
 	r.core.promRUnlock()
+
+	for _, v := range r.core.Tracker {
+		if v.hist != nil && v.hist.desc != nil {
+			ch <- promHist(v.hist.desc, &v.hist.prom, ratomic.LoadInt64(&v.hist.sum))
+		}
+	}
+	if r.bstats != nil {
+		r.bstats.collect(ch, &cmn.GCO.Get().Metrics)
+	}
 }
 
 func (r *runner) Name() string { return r.name }
@@ -0,0 +1,203 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"math/bits"
+	"strings"
+	ratomic "sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/stats/statsd"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Latency histograms (select KindLatency metrics, see regHist):
+//
+// 1. compact, log-linear (HDR-like) histogram: 2^hdrSubBits sub-buckets per each power of two,
+//    with relative error <= 1/2^hdrSubBits (6.25%) in the range [2^hdrMinExp, 2^(hdrMaxExp+1)) ns,
+//    or approx. [1µs, 36min); counts are reset every `periodic.stats_time` interval to produce
+//    the interval's percentiles (see Percentiles, Node.Latency, StatsD);
+// 2. cumulative Prometheus histogram with LatencyBounds buckets.
+
+const (
+	hdrSubBits = 4
+	hdrMinExp  = 10
+	hdrMaxExp  = 40
+	hdrSubMask = 1<<hdrSubBits - 1
+
+	// bucket 0: below 2^hdrMinExp; the last one: 2^(hdrMaxExp+1) and above
+	hdrNum = (hdrMaxExp-hdrMinExp+1)<<hdrSubBits + 2
+)
+
+var pctQuantiles = [...]float64{0.5, 0.9, 0.99, 0.999}
+
+// Prometheus (and per-bucket) latency histograms: upper bounds (milliseconds)
+// of all buckets except the last (+Inf) one
+var LatencyBounds = [...]int64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+const numLatBuckets = len(LatencyBounds) + 1
+
+type (
+	// REST API: latency percentiles (nanoseconds) over the last stats interval
+	Percentiles struct {
+		P50   int64 `json:"p50,string"`
+		P90   int64 `json:"p90,string"`
+		P99   int64 `json:"p99,string"`
+		P999  int64 `json:"p999,string"`
+		Max   int64 `json:"max,string"`
+		Count int64 `json:"n,string"` // number of samples
+	}
+
+	histogram struct {
+		last ratomic.Pointer[Percentiles]
+		desc *prometheus.Desc
+		stsd [len(pctQuantiles)]string // StatsD names, e.g. "aistarget.<ID>.get.p99.ms"
+		// HDR (current interval)
+		cur  [hdrNum]int64
+		snap [hdrNum]int64 // (serial context)
+		max  int64
+		// Prometheus (cumulative)
+		prom [numLatBuckets]int64
+		sum  int64
+	}
+)
+
+func newHistogram(stsd string) *histogram {
+	h := &histogram{}
+	prefix := strings.TrimSuffix(stsd, ".ms")
+	for i, pct := range [len(pctQuantiles)]string{"p50", "p90", "p99", "p999"} {
+		h.stsd[i] = prefix + "." + pct + ".ms"
+	}
+	return h
+}
+
+func (h *histogram) add(latency int64) {
+	ratomic.AddInt64(&h.cur[hdrIndex(latency)], 1)
+	for {
+		m := ratomic.LoadInt64(&h.max)
+		if latency <= m || ratomic.CompareAndSwapInt64(&h.max, m, latency) {
+			break
+		}
+	}
+	ratomic.AddInt64(&h.prom[latBucket(latency)], 1)
+	ratomic.AddInt64(&h.sum, latency)
+}
+
+// compute the interval's percentiles and start the next interval
+func (h *histogram) rotate() *Percentiles {
+	var total int64
+	for i := range h.cur {
+		h.snap[i] = ratomic.SwapInt64(&h.cur[i], 0)
+		total += h.snap[i]
+	}
+	maxLat := ratomic.SwapInt64(&h.max, 0)
+	if total == 0 {
+		h.last.Store(nil)
+		return nil
+	}
+	p := &Percentiles{Count: total, Max: maxLat}
+	pcts := [len(pctQuantiles)]*int64{&p.P50, &p.P90, &p.P99, &p.P999}
+	var (
+		cnt int64
+		j   int
+	)
+	for i := 0; i < hdrNum && j < len(pctQuantiles); i++ {
+		cnt += h.snap[i]
+		for j < len(pctQuantiles) && cnt >= rank(pctQuantiles[j], total) {
+			if i == hdrNum-1 {
+				*pcts[j] = maxLat
+			} else {
+				*pcts[j] = min(hdrUpper(i), maxLat)
+			}
+			j++
+		}
+	}
+	h.last.Store(p)
+	return p
+}
+
+func (h *histogram) statsd(c *statsd.Client, sgl *memsys.SGL, p *Percentiles) {
+	for i, val := range [len(pctQuantiles)]int64{p.P50, p.P90, p.P99, p.P999} {
+		ms := float64(val) / float64(time.Millisecond)
+		c.AppMetric(metric{Type: statsd.Gauge, Name: h.stsd[i], Value: ms}, sgl)
+	}
+}
+
+func (h *histogram) reset() {
+	for i := range h.cur {
+		ratomic.StoreInt64(&h.cur[i], 0)
+	}
+	for i := range h.prom {
+		ratomic.StoreInt64(&h.prom[i], 0)
+	}
+	ratomic.StoreInt64(&h.max, 0)
+	ratomic.StoreInt64(&h.sum, 0)
+	h.last.Store(nil)
+}
+
+// Prometheus histogram from (non-cumulative) LatencyBounds counts
+func promHist(desc *prometheus.Desc, counts *[numLatBuckets]int64, sum int64, labels ...string) prometheus.Metric {
+	var (
+		cnt     uint64
+		buckets = make(map[float64]uint64, len(LatencyBounds))
+	)
+	for i, ms := range LatencyBounds {
+		cnt += uint64(ratomic.LoadInt64(&counts[i]))
+		buckets[float64(ms)/1000] = cnt // (cumulative)
+	}
+	cnt += uint64(ratomic.LoadInt64(&counts[numLatBuckets-1]))
+	secs := time.Duration(sum).Seconds()
+	return prometheus.MustNewConstHistogram(desc, cnt, secs, buckets, labels...)
+}
+
+func latBucket(latency int64) int {
+	ms := latency / int64(time.Millisecond)
+	if latency%int64(time.Millisecond) != 0 {
+		ms++ // (upper bounds are inclusive)
+	}
+	for i, bound := range LatencyBounds {
+		if ms <= bound {
+			return i
+		}
+	}
+	return numLatBuckets - 1
+}
+
+// (1-based) rank of the q-quantile sample
+func rank(q float64, total int64) int64 {
+	r := int64(q*float64(total) + 0.999999)
+	return max(r, 1)
+}
+
+func hdrIndex(latency int64) int {
+	if latency < 1<<hdrMinExp {
+		return 0
+	}
+	exp := bits.Len64(uint64(latency)) - 1
+	if exp > hdrMaxExp {
+		return hdrNum - 1
+	}
+	sub := int(uint64(latency)>>(exp-hdrSubBits)) & hdrSubMask
+	return 1 + (exp-hdrMinExp)<<hdrSubBits + sub
+}
+
+// upper bound (exclusive) of the values in a given bucket
+func hdrUpper(idx int) int64 {
+	switch idx {
+	case 0:
+		return 1 << hdrMinExp
+	case hdrNum - 1:
+		return 1 << (hdrMaxExp + 1)
+	}
+	var (
+		i   = idx - 1
+		exp = hdrMinExp + i>>hdrSubBits
+		sub = int64(i & hdrSubMask)
+	)
+	return 1<<exp + (sub+1)<<(exp-hdrSubBits)
+}
@@ -0,0 +1,74 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/tools/tassert"
+)
+
+func TestHdrBuckets(t *testing.T) {
+	prev := int64(0)
+	for idx := range hdrNum - 1 {
+		upper := hdrUpper(idx)
+		tassert.Fatalf(t, upper > prev, "bucket %d: upper %d <= previous %d", idx, upper, prev)
+		tassert.Fatalf(t, hdrIndex(upper-1) == idx, "bucket %d: index(%d) = %d", idx, upper-1, hdrIndex(upper-1))
+		tassert.Fatalf(t, hdrIndex(upper) == idx+1, "bucket %d: index(%d) = %d", idx, upper, hdrIndex(upper))
+		if idx > 0 {
+			// relative error
+			tassert.Fatalf(t, float64(upper-prev)/float64(prev) <= 1.0/(1<<hdrSubBits),
+				"bucket %d: [%d, %d) too wide", idx, prev, upper)
+		}
+		prev = upper
+	}
+	tassert.Errorf(t, hdrIndex(0) == 0 && hdrIndex(-1) == 0, "expected bucket 0")
+	tassert.Errorf(t, hdrIndex(1<<62) == hdrNum-1, "expected the last bucket")
+}
+
+func TestHistogramPercentiles(t *testing.T) {
+	h := newHistogram("aistarget.t1.get.ms")
+	tassert.Errorf(t, h.stsd[2] == "aistarget.t1.get.p99.ms", "unexpected StatsD name %q", h.stsd[2])
+	tassert.Errorf(t, h.rotate() == nil, "expected no percentiles when idle")
+
+	// uniform [1ms, 100ms]
+	const num = 100_000
+	for range num {
+		h.add(int64(time.Millisecond) + rand.Int64N(int64(99*time.Millisecond)))
+	}
+	h.add(int64(time.Minute)) // outlier
+	p := h.rotate()
+	tassert.Fatalf(t, p != nil && p.Count == num+1, "unexpected %+v", p)
+	for _, tc := range []struct {
+		val, expected int64
+		name          string
+	}{
+		{p.P50, int64(50.5 * float64(time.Millisecond)), "p50"},
+		{p.P90, int64(90.1 * float64(time.Millisecond)), "p90"},
+		{p.P99, int64(99.01 * float64(time.Millisecond)), "p99"},
+	} {
+		d := float64(tc.val-tc.expected) / float64(tc.expected)
+		tassert.Errorf(t, d > -0.02 && d < 0.08, "%s: expected ~%v, got %v", tc.name,
+			time.Duration(tc.expected), time.Duration(tc.val))
+	}
+	tassert.Errorf(t, p.Max == int64(time.Minute), "expected max 1m, got %v", time.Duration(p.Max))
+	tassert.Errorf(t, h.last.Load() == p, "expected last interval's percentiles")
+
+	// next interval
+	h.add(int64(3 * time.Millisecond))
+	p = h.rotate()
+	tassert.Fatalf(t, p.Count == 1 && p.P999 == int64(3*time.Millisecond), "unexpected %+v", p)
+
+	// (cumulative) Prometheus
+	var cnt int64
+	for i := range h.prom {
+		cnt += h.prom[i]
+	}
+	tassert.Errorf(t, cnt == num+2, "expected %d cumulative samples, got %d", num+2, cnt)
+	tassert.Errorf(t, h.prom[numLatBuckets-1] == 1, "expected the outlier in +Inf bucket")
+}
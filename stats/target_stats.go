@@ -138,6 +138,7 @@ func (r *Trunner) Init(t core.Target) *atomic.Bool {
 	r.regCommon(t.Snode())
 
 	r.ctracker = make(copyTracker, numTargetStats) // these two are allocated once and only used in serial context
+	r.bstats = newBckStats()
 	r.lines = make([]string, 0, 16)
 	r.disk = make(ios.AllDiskStats, 16)
 
@@ -212,11 +213,11 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 	r.reg(snode, VerChangeCount, KindCounter)
 	r.reg(snode, VerChangeSize, KindSize)
 
-	r.reg(snode, PutLatency, KindLatency)
+	r.regHist(snode, PutLatency)
 	r.reg(snode, AppendLatency, KindLatency)
 	r.reg(snode, GetRedirLatency, KindLatency)
 	r.reg(snode, PutRedirLatency, KindLatency)
-	r.reg(snode, GetColdRwLatency, KindLatency)
+	r.regHist(snode, GetColdRwLatency)
 
 	// bps
 	r.reg(snode, GetThroughput, KindThroughput)
@@ -237,6 +238,7 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 	r.reg(snode, cos.StreamsOutObjSize, KindSize)
 	r.reg(snode, cos.StreamsInObjCount, KindCounter)
 	r.reg(snode, cos.StreamsInObjSize, KindSize)
+	r.regHist(snode, cos.StreamsOutObjLatency)
 
 	// download
 	r.reg(snode, DownloadSize, KindSize)
@@ -258,6 +260,9 @@ func (r *Trunner) RegMetrics(snode *meta.Snode) {
 
 	// Prometheus
 	r.core.initProm(snode)
+	if r.core.isPrometheus() {
+		r.bstats.initProm(snode)
+	}
 }
 
 func (r *Trunner) RegDiskMetrics(snode *meta.Snode, disk string) {
@@ -0,0 +1,313 @@
+// Package tracing provides distributed tracing: spans that get exported via
+// OTLP/HTTP (JSON encoding) to a configured OpenTelemetry collector, and
+// W3C Trace Context (`traceparent`) propagation.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Exporter: ended spans are queued and then exported in batches - when the batch
+// is full or every exportIval, whatever comes first - via OTLP/HTTP JSON POST
+// to <tracing.exporter_endpoint>/v1/traces. Spans are dropped (and counted) when
+// the queue is full or the collector is unreachable - tracing never blocks the datapath.
+
+const (
+	exportBatch   = 512
+	exportQueue   = 8 * exportBatch
+	exportIval    = 5 * time.Second
+	exportTimeout = 10 * time.Second
+	errLogIval    = time.Minute
+
+	tracesPath = "/v1/traces"
+
+	DfltServiceNamePrefix = "aistore"
+	scopeName             = "github.com/NVIDIA/aistore/tracing"
+)
+
+type (
+	exporter struct {
+		ch        chan *Span
+		stopCh    cos.StopCh
+		clientH   *http.Client
+		clientTLS *http.Client
+		resource  []kv
+		wg        sync.WaitGroup
+		errTime   int64 // mono-time of the last logged error
+		dropped   atomic.Int64
+		running   atomic.Bool
+	}
+
+	// OTLP JSON (see https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding)
+	otlpReq struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+	resourceSpans struct {
+		Resource   resource     `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	resource struct {
+		Attributes []kv `json:"attributes"`
+	}
+	scopeSpans struct {
+		Scope scope      `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	scope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID      string     `json:"traceId"`
+		SpanID       string     `json:"spanId"`
+		ParentSpanID string     `json:"parentSpanId,omitempty"`
+		Name         string     `json:"name"`
+		Start        string     `json:"startTimeUnixNano"`
+		End          string     `json:"endTimeUnixNano"`
+		Attributes   []kv       `json:"attributes,omitempty"`
+		Status       otlpStatus `json:"status"`
+		Kind         int        `json:"kind"`
+	}
+	otlpStatus struct {
+		Message string `json:"message,omitempty"`
+		Code    int    `json:"code,omitempty"` // 0: unset, 1: ok, 2: error
+	}
+	kv struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"` // (int64 is a JSON string)
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+const statusError = 2
+
+var exp exporter
+
+// Init starts the exporter; whether spans are actually produced and exported is
+// determined by the current `tracing` configuration (and can change at runtime)
+func Init(role, nodeID string) {
+	exp.init(role, nodeID)
+	exp.wg.Add(1)
+	go exp.run()
+	exp.running.Store(true)
+}
+
+// Stop exports pending spans and terminates the exporter
+func Stop() {
+	if !exp.running.CAS(true, false) {
+		return
+	}
+	exp.stopCh.Close()
+	exp.wg.Wait()
+}
+
+// number of spans dropped so far
+func Dropped() int64 { return exp.dropped.Load() }
+
+func IsEnabled() bool {
+	if !exp.running.Load() {
+		return false
+	}
+	conf := &cmn.GCO.Get().Tracing
+	return conf.Enabled && conf.ExporterEndpoint != ""
+}
+
+//////////////
+// exporter //
+//////////////
+
+func (e *exporter) init(role, nodeID string) {
+	conf := &cmn.GCO.Get().Tracing
+	prefix := conf.ServiceNamePrefix
+	if prefix == "" {
+		prefix = DfltServiceNamePrefix
+	}
+	e.ch = make(chan *Span, exportQueue)
+	e.stopCh.Init()
+	e.clientH = cmn.NewClient(cmn.TransportArgs{Timeout: exportTimeout})
+	e.clientTLS = cmn.NewClientTLS(cmn.TransportArgs{Timeout: exportTimeout}, cmn.TLSArgs{SkipVerify: conf.SkipVerify})
+	e.resource = []kv{
+		strKV("service.name", prefix+"-"+role),
+		strKV("service.instance.id", nodeID),
+		strKV("telemetry.sdk.name", "aistore"),
+		strKV("telemetry.sdk.language", "go"),
+	}
+}
+
+func (e *exporter) push(span *Span) {
+	if !e.running.Load() {
+		return
+	}
+	select {
+	case e.ch <- span:
+	default:
+		e.dropped.Inc()
+	}
+}
+
+func (e *exporter) run() {
+	var (
+		batch  = make([]*Span, 0, exportBatch)
+		ticker = time.NewTicker(exportIval)
+	)
+	defer func() {
+		ticker.Stop()
+		e.wg.Done()
+	}()
+	for {
+		select {
+		case span := <-e.ch:
+			batch = append(batch, span)
+			if len(batch) == exportBatch {
+				batch = e.export(batch)
+			}
+		case <-ticker.C:
+			batch = e.export(batch)
+		case <-e.stopCh.Listen():
+			for {
+				select {
+				case span := <-e.ch:
+					batch = append(batch, span)
+					if len(batch) == exportBatch {
+						batch = e.export(batch)
+					}
+				default:
+					e.export(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// export and return emptied batch
+func (e *exporter) export(batch []*Span) []*Span {
+	if len(batch) == 0 {
+		return batch
+	}
+	conf := &cmn.GCO.Get().Tracing
+	if conf.ExporterEndpoint == "" {
+		e.dropped.Add(int64(len(batch)))
+		return batch[:0]
+	}
+	err := e.post(conf.ExporterEndpoint, batch)
+	if err != nil {
+		e.dropped.Add(int64(len(batch)))
+		if now := mono.NanoTime(); time.Duration(now-e.errTime) > errLogIval {
+			e.errTime = now
+			nlog.Warningln("tracing: failed to export", len(batch), "span(s):", err, "[ dropped total:", e.dropped.Load(), "]")
+		}
+	}
+	clear(batch)
+	return batch[:0]
+}
+
+func (e *exporter) post(endpoint string, batch []*Span) error {
+	spans := make([]otlpSpan, len(batch))
+	for i, span := range batch {
+		span.toOTLP(&spans[i])
+	}
+	req := otlpReq{
+		ResourceSpans: []resourceSpans{{
+			Resource:   resource{Attributes: e.resource},
+			ScopeSpans: []scopeSpans{{Scope: scope{Name: scopeName}, Spans: spans}},
+		}},
+	}
+	body, err := jsoniter.Marshal(&req)
+	if err != nil {
+		return err
+	}
+	u := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(u, tracesPath) {
+		u += tracesPath
+	}
+	hreq, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set(cos.HdrContentType, cos.ContentJSON)
+	client := e.clientH
+	if strings.HasPrefix(u, "https://") {
+		client = e.clientTLS
+	}
+	resp, err := client.Do(hreq)
+	if err != nil {
+		return err
+	}
+	cos.DrainReader(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s: status %d", u, resp.StatusCode)
+	}
+	return nil
+}
+
+//////////
+// OTLP //
+//////////
+
+func (s *Span) toOTLP(o *otlpSpan) {
+	o.TraceID = hex.EncodeToString(s.sc.TraceID[:])
+	o.SpanID = hex.EncodeToString(s.sc.SpanID[:])
+	if s.parent != (SpanID{}) {
+		o.ParentSpanID = hex.EncodeToString(s.parent[:])
+	}
+	o.Name = s.name
+	o.Kind = s.kind
+	o.Start = strconv.FormatInt(s.start, 10)
+	o.End = strconv.FormatInt(s.end, 10)
+	if len(s.attrs) > 0 {
+		o.Attributes = make([]kv, 0, len(s.attrs))
+		for _, a := range s.attrs {
+			o.Attributes = append(o.Attributes, toKV(a))
+		}
+	}
+	if s.err != nil {
+		o.Status = otlpStatus{Code: statusError, Message: s.err.Error()}
+	}
+}
+
+func strKV(key, val string) kv { return kv{Key: key, Value: anyValue{StringValue: &val}} }
+
+func toKV(a Attr) kv {
+	var v anyValue
+	switch val := a.Val.(type) {
+	case string:
+		v.StringValue = &val
+	case bool:
+		v.BoolValue = &val
+	case int:
+		s := strconv.Itoa(val)
+		v.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(val, 10)
+		v.IntValue = &s
+	case float64:
+		v.DoubleValue = &val
+	default:
+		s := fmt.Sprint(val)
+		v.StringValue = &s
+	}
+	return kv{Key: a.Key, Value: v}
+}
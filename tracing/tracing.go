@@ -0,0 +1,290 @@
+// Package tracing provides distributed tracing: spans that get exported via
+// OTLP/HTTP (JSON encoding) to a configured OpenTelemetry collector, and
+// W3C Trace Context (`traceparent`) propagation.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
+)
+
+// W3C Trace Context: https://www.w3.org/TR/trace-context/
+const HdrTraceparent = "traceparent"
+
+// span kinds (as per OTLP)
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+	KindProducer = 4
+	KindConsumer = 5
+)
+
+type (
+	TraceID [16]byte
+	SpanID  [8]byte
+
+	// propagated part of the span
+	SpanContext struct {
+		TraceID TraceID
+		SpanID  SpanID
+		Sampled bool
+	}
+
+	Attr struct {
+		Val any // one of: string, bool, int, int64, float64
+		Key string
+	}
+
+	// NOTE: all methods are nil-safe - non-sampled (or disabled) tracing returns nil span
+	Span struct {
+		err    error
+		name   string
+		attrs  []Attr
+		sc     SpanContext
+		parent SpanID
+		start  int64 // unix nanoseconds
+		end    int64
+		kind   int
+		remote bool // parent propagated from another node or client (never exported)
+		ended  atomic.Bool
+	}
+)
+
+type ctxKey struct{}
+
+var errBadTraceparent = errors.New("invalid traceparent")
+
+//
+// spans
+//
+
+// Start a new span that is a child of the span (local or remote) carried by ctx, if any.
+// Parent-based sampling: the child of a sampled span is always sampled, the child
+// of a non-sampled one never is; root spans are sampled with the configured probability.
+func Start(ctx context.Context, name string, kind int) (context.Context, *Span) {
+	if !IsEnabled() {
+		return ctx, nil
+	}
+	var (
+		span       = &Span{name: name, kind: kind, start: time.Now().UnixNano()}
+		parent, ok = ctx.Value(ctxKey{}).(*Span)
+	)
+	if ok {
+		if !parent.sc.Sampled {
+			return ctx, nil
+		}
+		span.sc.TraceID = parent.sc.TraceID
+		span.parent = parent.sc.SpanID
+	} else {
+		if p := cmn.GCO.Get().Tracing.SamplerProbability; p < 1 && rand.Float64() >= p {
+			return ctx, nil
+		}
+		span.sc.TraceID = newTraceID()
+	}
+	span.sc.SpanID = newSpanID()
+	span.sc.Sampled = true
+	return context.WithValue(ctx, ctxKey{}, span), span
+}
+
+func (s *Span) SetAttr(key string, val any) {
+	if s != nil {
+		s.attrs = append(s.attrs, Attr{Key: key, Val: val})
+	}
+}
+
+func (s *Span) Context() (sc SpanContext) {
+	if s != nil {
+		sc = s.sc
+	}
+	return sc
+}
+
+// set the span's (error) status
+func (s *Span) SetErr(err error) {
+	if s != nil && err != nil {
+		s.err = err
+	}
+}
+
+// end the span (only the first call counts) and queue it for export;
+// non-nil error sets the span's status
+func (s *Span) End(err error) {
+	if s == nil || s.remote || !s.ended.CAS(false, true) {
+		return
+	}
+	s.end = time.Now().UnixNano()
+	s.SetErr(err)
+	exp.push(s)
+}
+
+// returns the trace-context carried by ctx
+func FromContext(ctx context.Context) (sc SpanContext, ok bool) {
+	if ctx == nil {
+		return
+	}
+	var span *Span
+	if span, ok = ctx.Value(ctxKey{}).(*Span); ok {
+		sc = span.sc
+	}
+	return
+}
+
+// returns the current (local) span, if any
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	if span, ok := ctx.Value(ctxKey{}).(*Span); ok && !span.remote {
+		return span
+	}
+	return nil
+}
+
+// returns ctx that carries a given span (for the span's children)
+func WithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, span)
+}
+
+// returns non-cancelable context that carries the same trace-context (if any) -
+// for the work that must not be canceled along with the (e.g., client's) request
+func Detach(ctx context.Context) context.Context {
+	span, ok := ctx.Value(ctxKey{}).(*Span)
+	if !ok {
+		return context.Background()
+	}
+	return context.WithValue(context.Background(), ctxKey{}, span)
+}
+
+//
+// propagation
+//
+
+// parent trace-context from the request's `traceparent` header, or a given
+// (e.g., query parameter) value
+func Extract(ctx context.Context, hdr http.Header, traceparent ...string) context.Context {
+	var tp string
+	if len(traceparent) > 0 && traceparent[0] != "" {
+		tp = traceparent[0]
+	} else if hdr != nil {
+		tp = hdr.Get(HdrTraceparent)
+	}
+	if tp == "" {
+		return ctx
+	}
+	sc, err := ParseTraceparent(tp)
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, &Span{sc: sc, remote: true})
+}
+
+// set `traceparent` header, if ctx carries a trace-context
+func Inject(ctx context.Context, hdr http.Header) {
+	if tp := Traceparent(ctx); tp != "" {
+		hdr.Set(HdrTraceparent, tp)
+	}
+}
+
+func Traceparent(ctx context.Context) string {
+	sc, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return sc.String()
+}
+
+// request ID for structured logging (see nlog.Fields): trace-id carried by ctx or,
+// failing that, by the request's parent trace-context (see Extract)
+func ReqID(ctx context.Context, hdr http.Header, traceparent ...string) string {
+	if sc, ok := FromContext(ctx); ok {
+		return sc.TraceID.String()
+	}
+	var tp string
+	if len(traceparent) > 0 && traceparent[0] != "" {
+		tp = traceparent[0]
+	} else if hdr != nil {
+		tp = hdr.Get(HdrTraceparent)
+	}
+	if tp == "" {
+		return ""
+	}
+	sc, err := ParseTraceparent(tp)
+	if err != nil {
+		return ""
+	}
+	return sc.TraceID.String()
+}
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+
+// version "00": "00-<32 hex trace-id>-<16 hex parent-id>-<2 hex flags>"
+func (sc SpanContext) String() string {
+	var b [55]byte
+	b[0], b[1], b[2] = '0', '0', '-'
+	hex.Encode(b[3:35], sc.TraceID[:])
+	b[35] = '-'
+	hex.Encode(b[36:52], sc.SpanID[:])
+	b[52], b[53], b[54] = '-', '0', '0'
+	if sc.Sampled {
+		b[54] = '1'
+	}
+	return string(b[:])
+}
+
+func ParseTraceparent(tp string) (sc SpanContext, err error) {
+	if len(tp) < 55 || tp[2] != '-' || tp[35] != '-' || tp[52] != '-' || (len(tp) > 55 && tp[55] != '-') {
+		return sc, errBadTraceparent
+	}
+	if tp[:2] == "ff" || (tp[:2] == "00" && len(tp) != 55) {
+		return sc, errBadTraceparent
+	}
+	var flags [1]byte
+	if _, err = hex.Decode(sc.TraceID[:], []byte(tp[3:35])); err != nil {
+		return sc, errBadTraceparent
+	}
+	if _, err = hex.Decode(sc.SpanID[:], []byte(tp[36:52])); err != nil {
+		return sc, errBadTraceparent
+	}
+	if _, err = hex.Decode(flags[:], []byte(tp[53:55])); err != nil {
+		return sc, errBadTraceparent
+	}
+	if sc.TraceID == (TraceID{}) || sc.SpanID == (SpanID{}) {
+		return sc, errBadTraceparent
+	}
+	sc.Sampled = flags[0]&1 != 0
+	return sc, nil
+}
+
+//
+// misc
+//
+
+func newTraceID() (id TraceID) {
+	for id == (TraceID{}) {
+		binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	}
+	return
+}
+
+func newSpanID() (id SpanID) {
+	for id == (SpanID{}) {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return
+}
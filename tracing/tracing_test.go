@@ -0,0 +1,188 @@
+// Package tracing_test: unit tests for the package
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tracing"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// (subset of) OTLP JSON
+type (
+	collected struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []attr `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []span `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	span struct {
+		TraceID      string `json:"traceId"`
+		SpanID       string `json:"spanId"`
+		ParentSpanID string `json:"parentSpanId"`
+		Name         string `json:"name"`
+		Attributes   []attr `json:"attributes"`
+		Status       struct {
+			Message string `json:"message"`
+			Code    int    `json:"code"`
+		} `json:"status"`
+		Kind int `json:"kind"`
+	}
+	attr struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+			IntValue    string `json:"intValue"`
+		} `json:"value"`
+	}
+
+	// OTLP collector stub
+	collector struct {
+		spans   map[string]span // by name
+		service string
+		mu      sync.Mutex
+	}
+)
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var req collected
+	if err := jsoniter.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	for _, rs := range req.ResourceSpans {
+		for _, a := range rs.Resource.Attributes {
+			if a.Key == "service.name" {
+				c.service = a.Value.StringValue
+			}
+		}
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				c.spans[s.Name] = s
+			}
+		}
+	}
+	c.mu.Unlock()
+}
+
+func TestTraceparent(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := tracing.ParseTraceparent(tp)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, sc.Sampled, "expected sampled")
+	tassert.Errorf(t, sc.String() == tp, "expected %q, got %q", tp, sc.String())
+
+	for _, bad := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",       // short
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",    // zero trace-id
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",    // zero span-id
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",    // not hex
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",    // invalid version
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-xx", // version 00 w/ extra
+	} {
+		_, err := tracing.ParseTraceparent(bad)
+		tassert.Errorf(t, err != nil, "expected %q to fail", bad)
+	}
+
+	// request ID: from the header, the (query) value, or the context
+	const tid = "4bf92f3577b34da6a3ce929d0e0e4736"
+	hdr := http.Header{}
+	tassert.Errorf(t, tracing.ReqID(context.Background(), hdr) == "", "expected no request ID")
+	hdr.Set(tracing.HdrTraceparent, tp)
+	tassert.Errorf(t, tracing.ReqID(context.Background(), hdr) == tid, "unexpected request ID (header)")
+	tassert.Errorf(t, tracing.ReqID(context.Background(), nil, tp) == tid, "unexpected request ID (value)")
+	ctx := tracing.Extract(context.Background(), nil, tp)
+	tassert.Errorf(t, tracing.ReqID(ctx, nil) == tid, "unexpected request ID (context)")
+}
+
+func TestExport(t *testing.T) {
+	const (
+		traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		spanID  = "00f067aa0ba902b7"
+	)
+	c := &collector{spans: make(map[string]span)}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	config := cmn.GCO.BeginUpdate()
+	config.Tracing = cmn.TracingConf{Enabled: true, ExporterEndpoint: srv.URL, SamplerProbability: 1}
+	cmn.GCO.CommitUpdate(config)
+
+	_, span := tracing.Start(context.Background(), "disabled", tracing.KindInternal)
+	tassert.Errorf(t, span == nil, "expected no spans prior to Init")
+
+	tracing.Init("target", "t1")
+
+	// remote parent (e.g., client or proxy) => server => client (e.g., backend)
+	hdr := http.Header{}
+	hdr.Set(tracing.HdrTraceparent, "00-"+traceID+"-"+spanID+"-01")
+	ctx, srvSpan := tracing.Start(tracing.Extract(context.Background(), hdr), "target.get", tracing.KindServer)
+	tassert.Fatalf(t, srvSpan != nil, "expected sampled span")
+	tassert.Errorf(t, tracing.SpanFromContext(ctx) == srvSpan, "expected current span")
+	srvSpan.SetAttr("ais.object", "ais://bck/obj")
+
+	cctx, cliSpan := tracing.Start(tracing.Detach(ctx), "backend.get", tracing.KindClient)
+	tassert.Fatalf(t, cliSpan != nil, "expected sampled child span")
+	cliSpan.SetAttr("ais.size", int64(1024))
+	out := http.Header{}
+	tracing.Inject(cctx, out)
+	sc, err := tracing.ParseTraceparent(out.Get(tracing.HdrTraceparent))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, sc == cliSpan.Context(), "expected propagated child span context")
+	cliSpan.End(errors.New("backend failure"))
+	srvSpan.End(nil)
+	srvSpan.End(errors.New("ignored")) // (ended)
+
+	// not sampled by the caller
+	hdr.Set(tracing.HdrTraceparent, "00-"+traceID+"-"+spanID+"-00")
+	_, span = tracing.Start(tracing.Extract(context.Background(), hdr), "not-sampled", tracing.KindServer)
+	tassert.Errorf(t, span == nil, "expected parent-based sampling")
+
+	// root, with zero probability
+	config = cmn.GCO.BeginUpdate()
+	config.Tracing.SamplerProbability = 0
+	cmn.GCO.CommitUpdate(config)
+	_, span = tracing.Start(context.Background(), "zero-probability", tracing.KindInternal)
+	tassert.Errorf(t, span == nil, "expected root span not to be sampled")
+
+	tracing.Stop() // flush
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tassert.Fatalf(t, len(c.spans) == 2, "expected 2 exported spans, got %d", len(c.spans))
+	tassert.Errorf(t, c.service == "aistore-target", "unexpected service name %q", c.service)
+
+	s := c.spans["target.get"]
+	tassert.Errorf(t, s.TraceID == traceID && s.ParentSpanID == spanID, "unexpected server span %+v", s)
+	tassert.Errorf(t, s.Kind == tracing.KindServer && s.Status.Code == 0, "unexpected server span %+v", s)
+	tassert.Errorf(t, len(s.Attributes) == 1 && s.Attributes[0].Value.StringValue == "ais://bck/obj",
+		"unexpected attributes %+v", s.Attributes)
+	srvSpanID := s.SpanID
+
+	s = c.spans["backend.get"]
+	tassert.Errorf(t, s.TraceID == traceID && s.ParentSpanID == srvSpanID, "unexpected client span %+v", s)
+	tassert.Errorf(t, s.Status.Code == 2 && s.Status.Message == "backend failure", "unexpected status %+v", s.Status)
+	tassert.Errorf(t, len(s.Attributes) == 1 && s.Attributes[0].Value.IntValue == "1024",
+		"unexpected attributes %+v", s.Attributes)
+	tassert.Errorf(t, tracing.Dropped() == 0, "expected no dropped spans, got %d", tracing.Dropped())
+}
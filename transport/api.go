@@ -17,8 +17,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/hk"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tracing"
 )
 
 ///////////////////
@@ -77,6 +79,8 @@ type (
 		CmplArg  any           // optional context passed to the ObjSentCB callback
 		Callback ObjSentCB     // called when the last byte is sent _or_ when the stream terminates (see term.reason)
 		prc      *atomic.Int64 // private; if present, ref-counts so that we call ObjSentCB only once
+		span     *tracing.Span // private; (sampled) send-to-completion span
+		started  int64         // private; mono-time of Send() (see cos.StreamsOutObjLatency)
 		Hdr      ObjHdr
 	}
 
@@ -153,6 +157,10 @@ func NewObjStream(client Client, dstURL, dstID string, extra *Extra) (s *Stream)
 //     stream(s).
 func (s *Stream) Send(obj *Obj) (err error) {
 	debug.Assertf(len(obj.Hdr.Opaque) < len(s.maxhdr)-sizeofh, "(%d, %d)", len(obj.Hdr.Opaque), len(s.maxhdr))
+	obj.started = mono.NanoTime()
+	if !ReservedOpcode(obj.Hdr.Opcode) && tracing.IsEnabled() {
+		obj.span = s.traceSend(obj)
+	}
 	if err = s.startSend(obj); err != nil {
 		s.doCmpl(obj, err) // take a shortcut
 		return
@@ -6,6 +6,7 @@
 package transport
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"runtime"
@@ -13,8 +14,10 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/pierrec/lz4/v3"
 )
 
@@ -145,6 +148,8 @@ func (s *Stream) doCmpl(obj *Obj, err error) {
 			cos.Close(obj.Reader) // otherwise, always closing
 		}
 	}
+	obj.span.End(err)
+
 	// SCQ completion callback
 	if rc == 0 {
 		if obj.Callback != nil {
@@ -156,6 +161,18 @@ func (s *Stream) doCmpl(obj *Obj, err error) {
 	freeSend(obj)
 }
 
+// (sampled) span: from Send() to completion
+func (s *Stream) traceSend(obj *Obj) *tracing.Span {
+	_, span := tracing.Start(context.Background(), "transport.send", tracing.KindProducer)
+	if span != nil {
+		span.SetAttr("ais.stream", s.trname)
+		span.SetAttr("ais.target", s.dstID)
+		span.SetAttr("ais.object", obj.Hdr.Cname())
+		span.SetAttr("ais.size", obj.Hdr.ObjAttrs.Size)
+	}
+	return span
+}
+
 func (s *Stream) doRequest() error {
 	s.numCur, s.sizeCur = 0, 0
 	if !s.compressed() {
@@ -327,6 +344,7 @@ func (s *Stream) eoObj(err error) {
 	// target stats
 	g.tstats.Inc(cos.StreamsOutObjCount)
 	g.tstats.Add(cos.StreamsOutObjSize, objSize)
+	g.tstats.Add(cos.StreamsOutObjLatency, mono.SinceNano(obj.started))
 exit:
 	if err != nil {
 		nlog.Errorln(err)
@@ -5,6 +5,7 @@
 package xact
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -22,11 +23,13 @@ import (
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/nl"
+	"github.com/NVIDIA/aistore/tracing"
 )
 
 type (
 	Base struct {
 		notif  *NotifXact
+		span   *tracing.Span // (sampled) xaction's lifetime; parent of its work items (see TraceCtx)
 		bck    meta.Bck
 		id     string
 		kind   string
@@ -86,6 +89,19 @@ func (xctn *Base) InitBase(id, kind string, bck *meta.Bck) {
 	if !xctn.bck.IsEmpty() {
 		xctn._nam += "-" + xctn.bck.Cname("")
 	}
+
+	if _, span := tracing.Start(context.Background(), "xaction."+kind, tracing.KindInternal); span != nil {
+		span.SetAttr("ais.xaction", id)
+		if !xctn.bck.IsEmpty() {
+			span.SetAttr("ais.bucket", xctn.bck.Cname(""))
+		}
+		xctn.span = span
+	}
+}
+
+// context for the xaction's (traced) work items
+func (xctn *Base) TraceCtx() context.Context {
+	return tracing.WithSpan(context.Background(), xctn.span)
 }
 
 func (xctn *Base) ID() string   { return xctn.id }
@@ -163,7 +179,7 @@ func (xctn *Base) Abort(err error) bool {
 	close(xctn.abort.ch)
 
 	if xctn.Kind() != apc.ActList {
-		nlog.InfoDepth(1, xctn.Name(), err)
+		nlog.InfoDepthWith(xctn.logFields(), 1, xctn.Name(), err)
 	}
 	return true
 }
@@ -186,13 +202,13 @@ func (xctn *Base) AddErr(err error, logExtra ...int) {
 	// log error
 	level := logExtra[0]
 	if level == 0 {
-		nlog.ErrorDepth(1, err)
+		nlog.ErrorDepthWith(xctn.logFields(), 1, err)
 		return
 	}
 	// finally, FastV
 	module := logExtra[1]
 	if cmn.Rom.FastV(level, module) {
-		nlog.InfoDepth(1, "Warning:", err)
+		nlog.InfoDepthWith(xctn.logFields(), 1, "Warning:", err)
 	}
 }
 
@@ -331,18 +347,32 @@ func (xctn *Base) Finish() {
 		}
 	}
 	xctn.onFinished(err, aborted)
+	if span := xctn.span; span != nil {
+		span.SetAttr("ais.objs", xctn.Objs())
+		span.SetAttr("ais.bytes", xctn.Bytes())
+		span.SetAttr("ais.aborted", aborted)
+		span.End(err)
+	}
 	// log
+	if xctn.Kind() == apc.ActList {
+		return
+	}
+	fields := xctn.logFields()
 	switch {
-	case xctn.Kind() == apc.ActList:
 	case err == nil:
-		nlog.Infoln(xctn.String(), "finished")
+		nlog.InfolnWith(fields, xctn.String(), "finished")
 	case aborted:
-		nlog.Warningln(xctn.String(), "aborted:", err.Error(), info)
+		nlog.WarninglnWith(fields, xctn.String(), "aborted:", err.Error(), info)
 	default:
-		nlog.Infoln("Warning:", xctn.String(), "finished w/err:", err.Error())
+		nlog.InfolnWith(fields, "Warning:", xctn.String(), "finished w/err:", err.Error())
 	}
 }
 
+// structured-logging fields (see nlog.Fields)
+func (xctn *Base) logFields() *nlog.Fields {
+	return &nlog.Fields{Module: cos.SmoduleName(cos.SmoduleXs), Xid: xctn.ID()}
+}
+
 // base stats: locally processed
 func (xctn *Base) Objs() int64  { return xctn.stats.objs.Load() }
 func (xctn *Base) Bytes() int64 { return xctn.stats.bytes.Load() }
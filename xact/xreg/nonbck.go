@@ -6,6 +6,7 @@ package xreg
 
 import (
 	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -44,6 +45,11 @@ func RenewStoreCleanup(id string) RenewRes {
 	return dreg.renew(e, nil)
 }
 
+func RenewLifecycle(id string, bcks []cmn.Bck) RenewRes {
+	e := dreg.nonbckXacts[apc.ActLifecycle].New(Args{UUID: id, Custom: bcks}, nil)
+	return dreg.renew(e, nil)
+}
+
 func RenewDownloader(xid string, bck *meta.Bck) RenewRes {
 	e := dreg.nonbckXacts[apc.ActDownload].New(Args{UUID: xid, Custom: bck}, nil)
 	return dreg.renew(e, nil)
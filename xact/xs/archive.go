@@ -501,7 +501,7 @@ func (wi *archwi) do(lom *core.LOM, lrit *lriterator) {
 		}
 	}
 
-	fh, err := cos.NewFileHandle(lom.FQN)
+	fh, err := lom.NewHandle()
 	if err != nil {
 		wi.r.AddErr(err, 5, cos.SmoduleXs)
 		return
@@ -14,12 +14,15 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/api/apc"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/atomic"
 	"github.com/NVIDIA/aistore/cmn/cos"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/feat"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/cmn/nlog"
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
@@ -28,10 +31,14 @@ import (
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
-// TODO:
-// 1. load, latest-ver, checksum, write, finalize
-// 2. track each chunk reader with 'started' timestamp; abort/retry individual chunks; timeout
-// 3. validate `expCksum`
+// Each chunk reader:
+// - reads its (fixed-size) chunk via backend.GetObjReader(range);
+// - retries failed reads (with backoff) resuming from the last successfully read offset
+//   within the chunk; the object's written offset (`woff`) never goes back;
+// - is being monitored for progress: a reader that makes no progress for the "stall" timeout
+//   gets its current read canceled and retried.
+// Upon completion, the resulting checksum gets validated against the one reported by the remote
+// backend (iff `validate_cold_get` is configured - compare with regular cold GET).
 
 // default tunables (can override via apc.BlobMsg)
 const (
@@ -40,6 +47,14 @@ const (
 	maxChunkSize   = 16 * cos.MiB
 	dfltNumWorkers = 4
 
+	dfltMaxRetries = 3 // per chunk
+	maxMaxRetries  = 16
+	dfltStallTime  = 30 * time.Second
+	minStallTime   = 2 * time.Second
+
+	retryDelay    = 500 * time.Millisecond // initial backoff (doubles with every retry)
+	maxRetryDelay = 8 * time.Second
+
 	maxInitialSizeSGL = 128           // vec length
 	maxTotalChunks    = 128 * cos.MiB // max mem per blob downloader
 )
@@ -54,21 +69,47 @@ type (
 		nextRoff int64
 		woff     int64
 		xact.Base
-		sgls  []*memsys.SGL
-		cksum cos.CksumHash
-		wg    sync.WaitGroup
+		sgls     []*memsys.SGL
+		expCksum *cos.Cksum // as reported by remote backend (nil when not validating)
+		cksum    cos.CksumHash
+		vcksum   cos.CksumHash // to validate `expCksum` of a different type
+		wg       sync.WaitGroup
 		// not necessarily equal user-provided apc.BlobMsg values;
 		// in particular, chunk size and num workers might be adjusted based on resources
 		chunkSize  int64
 		fullSize   int64
 		numWorkers int
+		maxRetries int
+		stallTime  time.Duration
+		chunks     struct {
+			done    atomic.Int64
+			retried atomic.Int64
+			stalled atomic.Int64
+		}
+	}
+	ExtBlobDlStats struct {
+		ChunkSize  int64 `json:"blob.chunk.size,string"`
+		NumWorkers int   `json:"blob.workers"`
+		NumChunks  int64 `json:"blob.chunks.n,string"`
+		DoneChunks int64 `json:"blob.chunks.done.n,string"`
+		Retries    int64 `json:"blob.chunks.retry.n,string"`
+		Stalls     int64 `json:"blob.chunks.stall.n,string"`
 	}
 )
 
 // internal
 type (
 	blobReader struct {
-		parent *XactBlobDl
+		parent  *XactBlobDl
+		cancel  context.CancelFunc // current read, if any
+		tprog   atomic.Int64       // mono-time of the last progress; zero when idle
+		stalled atomic.Bool
+		mu      sync.Mutex
+	}
+	// tracks chunk reader's progress
+	progReader struct {
+		r      io.Reader
+		reader *blobReader
 	}
 	chunkWi struct {
 		sgl  *memsys.SGL
@@ -101,6 +142,8 @@ func RenewBlobDl(xid string, params *core.BlobParams, oa *cmn.ObjAttrs) xreg.Ren
 	)
 	pre.chunkSize = params.Msg.ChunkSize
 	pre.numWorkers = params.Msg.NumWorkers
+	pre.maxRetries = params.Msg.MaxRetries
+	pre.stallTime = params.Msg.StallTimeout.D()
 	if oa == nil {
 		// backend.HeadObj(), unless already done via prior (e.g. latest-ver or prefetch-threshold) check
 		// (in the latter case, oa.Size must be present)
@@ -125,6 +168,23 @@ func RenewBlobDl(xid string, params *core.BlobParams, oa *cmn.ObjAttrs) xreg.Ren
 		return xreg.RenewRes{Err: err}
 	}
 
+	// end-to-end protection (compare with cold GET)
+	if lom.CksumConf().ValidateColdGet {
+		pre.expCksum = _expCksum(oa)
+	}
+
+	// large object: store as chunks (see core/lchunk.go)
+	if params.Wfqn != "" {
+		if csize := lom.Csize(&cmn.GCO.Get().Chunks, pre.fullSize); csize > 0 {
+			lmfh, err := lom.CreateChunked(params.Wfqn, csize)
+			if err != nil {
+				return xreg.RenewRes{Err: err}
+			}
+			cos.Close(params.Lmfh)
+			params.Lmfh = lmfh
+		}
+	}
+
 	// validate, assign defaults (tune-up below)
 	if pre.chunkSize == 0 {
 		pre.chunkSize = dfltChunkSize
@@ -149,9 +209,36 @@ func RenewBlobDl(xid string, params *core.BlobParams, oa *cmn.ObjAttrs) xreg.Ren
 	if a := cmn.MaxParallelism(); a < pre.numWorkers {
 		pre.numWorkers = a
 	}
+	switch {
+	case pre.maxRetries == 0:
+		pre.maxRetries = dfltMaxRetries
+	case pre.maxRetries < 0: // no retries
+		pre.maxRetries = 0
+	case pre.maxRetries > maxMaxRetries:
+		pre.maxRetries = maxMaxRetries
+	}
+	if pre.stallTime == 0 {
+		pre.stallTime = dfltStallTime
+	} else if pre.stallTime < minStallTime {
+		pre.stallTime = minStallTime
+	}
 	return xreg.RenewBucketXact(apc.ActBlobDl, lom.Bck(), xreg.Args{UUID: xid, Custom: pre})
 }
 
+// remote-reported checksum, if any (ditto)
+func _expCksum(oa *cmn.ObjAttrs) *cos.Cksum {
+	if !oa.Cksum.IsEmpty() {
+		return oa.Cksum
+	}
+	if v, ok := oa.GetCustomKey(cmn.MD5ObjMD); ok {
+		return cos.NewCksum(cos.ChecksumMD5, v)
+	}
+	if v, ok := oa.GetCustomKey(cmn.CRC32CObjMD); ok {
+		return cos.NewCksum(cos.ChecksumCRC32C, v)
+	}
+	return nil
+}
+
 //
 // blobFactory
 //
@@ -204,25 +291,48 @@ func (p *blobFactory) Start() error {
 		r.numWorkers++
 	}
 
-	// open channels
+	r.init(mm, cnt*slabSize, slabSize)
+	p.xctn = r
+	return nil
+}
+
+func (*blobFactory) Kind() string     { return apc.ActBlobDl }
+func (p *blobFactory) Get() core.Xact { return p.xctn }
+
+func (p *blobFactory) WhenPrevIsRunning(prev xreg.Renewable) (xreg.WPR, error) {
+	var (
+		xprev   = prev.Get().(*XactBlobDl)
+		lomPrev = xprev.args.Lom
+		xcurr   = p.pre
+		lomCurr = xcurr.args.Lom
+	)
+	if lomPrev.Bucket().Equal(lomCurr.Bucket()) && lomPrev.ObjName == lomCurr.ObjName {
+		return xreg.WprUse, cmn.NewErrXactUsePrev(prev.Get().String())
+	}
+	return xreg.WprKeepAndStartNew, nil
+}
+
+//
+// XactBlobDl
+//
+
+// open channels, allocate readers and their SGLs, and setup the writer
+func (r *XactBlobDl) init(mm *memsys.MMSA, sglSize, slabSize int64) {
 	r.workCh = make(chan chunkWi, r.numWorkers)
 	r.doneCh = make(chan chunkDone, r.numWorkers)
 
-	// init and allocate
 	r.readers = make([]*blobReader, r.numWorkers)
 	r.sgls = make([]*memsys.SGL, r.numWorkers)
 	for i := range r.readers {
 		r.readers[i] = &blobReader{
 			parent: r,
 		}
-		r.sgls[i] = mm.NewSGL(cnt*slabSize, slabSize)
+		r.sgls[i] = mm.NewSGL(sglSize, slabSize)
 	}
 
-	p.xctn = r
-
 	// deliver locally for custom processing
 	if r.args.WriteSGL != nil {
-		return nil
+		return
 	}
 
 	//
@@ -234,6 +344,10 @@ func (p *blobFactory) Start() error {
 		r.cksum.Init(ty)
 		ws = append(ws, r.cksum.H)
 	}
+	if r.expCksum != nil && r.expCksum.Ty() != r.cksum.Ty() {
+		r.vcksum.Init(r.expCksum.Ty())
+		ws = append(ws, r.vcksum.H)
+	}
 	ws = append(ws, r.args.Lmfh)
 	if r.args.RspW != nil {
 		// and transmit concurrently (alternatively,
@@ -248,29 +362,8 @@ func (p *blobFactory) Start() error {
 		}
 	}
 	r.writer = cos.NewWriterMulti(ws...)
-	return nil
 }
 
-func (*blobFactory) Kind() string     { return apc.ActBlobDl }
-func (p *blobFactory) Get() core.Xact { return p.xctn }
-
-func (p *blobFactory) WhenPrevIsRunning(prev xreg.Renewable) (xreg.WPR, error) {
-	var (
-		xprev   = prev.Get().(*XactBlobDl)
-		lomPrev = xprev.args.Lom
-		xcurr   = p.pre
-		lomCurr = xcurr.args.Lom
-	)
-	if lomPrev.Bucket().Equal(lomCurr.Bucket()) && lomPrev.ObjName == lomCurr.ObjName {
-		return xreg.WprUse, cmn.NewErrXactUsePrev(prev.Get().String())
-	}
-	return xreg.WprKeepAndStartNew, nil
-}
-
-//
-// XactBlobDl
-//
-
 func (r *XactBlobDl) Name() string { return r.Base.Name() + "/" + r.args.Lom.ObjName }
 
 func (r *XactBlobDl) Run(*sync.WaitGroup) {
@@ -278,6 +371,7 @@ func (r *XactBlobDl) Run(*sync.WaitGroup) {
 		err     error
 		pending []chunkDone
 		eof     bool
+		ticker  = time.NewTicker(r.stallTime >> 1)
 	)
 	nlog.Infoln(r.Name()+": chunk-size", cos.ToSizeIEC(r.chunkSize, 0)+", num-concurrent-readers", r.numWorkers)
 	r.start()
@@ -285,6 +379,10 @@ outer:
 	for {
 		select {
 		case done := <-r.doneCh:
+			if done.err != nil {
+				err = fmt.Errorf("%s: failed to read chunk at offset %d: %w", r.Name(), done.roff, done.err)
+				goto fin
+			}
 			sgl, sz := done.sgl, done.sgl.Size()
 			if done.code == http.StatusRequestedRangeNotSatisfiable && r.fullSize > done.roff+sz {
 				err = fmt.Errorf("%s: premature eof: expected size %d, have %d", r.Name(), r.fullSize, done.roff+sz)
@@ -352,12 +450,15 @@ outer:
 					nlog.Errorf("   roff %d", pending[i].roff)
 				}
 			}
+		case <-ticker.C:
+			r.checkStalled()
 		case <-r.ChanAbort():
 			err = cmn.ErrXactUserAbort
 			goto fin
 		}
 	}
 fin:
+	ticker.Stop()
 	close(r.workCh)
 
 	if r.args.WriteSGL != nil {
@@ -380,13 +481,15 @@ fin:
 					r.cksum.Finalize()
 					r.args.Lom.SetCksum(r.cksum.Clone())
 				}
-				_, err = core.T.FinalizeObj(r.args.Lom, r.args.Wfqn, r, cmn.OwtGetPrefetchLock)
+				if err = r.validateCksum(); err == nil {
+					_, err = core.T.FinalizeObj(r.args.Lom, r.args.Wfqn, r, cmn.OwtGetPrefetchLock)
+				}
 			}
 		}
 		if err == nil {
 			r.ObjsAdd(1, 0)
 		} else {
-			if errRemove := cos.RemoveFile(r.args.Wfqn); errRemove != nil && !os.IsNotExist(errRemove) {
+			if errRemove := r.args.Lom.RemoveWork(r.args.Wfqn); errRemove != nil && !os.IsNotExist(errRemove) {
 				nlog.Errorln("nested err:", errRemove)
 			}
 			if err != cmn.ErrXactUserAbort {
@@ -434,10 +537,46 @@ func (r *XactBlobDl) write(sgl *memsys.SGL) (err error) {
 
 	r.woff += size
 	r.ObjsAdd(0, size)
+	r.chunks.done.Inc()
 	sgl.Reset()
 	return nil
 }
 
+func (r *XactBlobDl) validateCksum() error {
+	if r.expCksum == nil {
+		return nil
+	}
+	computed := &r.cksum.Cksum
+	if r.vcksum.H != nil {
+		r.vcksum.Finalize()
+		computed = &r.vcksum.Cksum
+	}
+	if computed.Equal(r.expCksum) {
+		return nil
+	}
+	return cos.NewErrDataCksum(r.expCksum, computed, r.args.Lom.Cname())
+}
+
+// cancel current read of a chunk reader that makes no progress
+// (the reader will then retry - see blobReader.retriable)
+func (r *XactBlobDl) checkStalled() {
+	now := mono.NanoTime()
+	for _, reader := range r.readers {
+		tprog := reader.tprog.Load()
+		if tprog == 0 || time.Duration(now-tprog) < r.stallTime || reader.stalled.Load() {
+			continue
+		}
+		reader.stalled.Store(true)
+		r.chunks.stalled.Inc()
+		nlog.Warningln(r.Name(), "chunk reader stalled for", time.Duration(now-tprog), "- canceling")
+		reader.mu.Lock()
+		if reader.cancel != nil {
+			reader.cancel()
+		}
+		reader.mu.Unlock()
+	}
+}
+
 func (r *XactBlobDl) cleanup() {
 	for i := range r.readers {
 		r.sgls[i].Free()
@@ -453,49 +592,130 @@ func (r *XactBlobDl) cleanup() {
 //
 
 func (reader *blobReader) run() {
-	var (
-		err       error
-		written   int64
-		a         = reader.parent.args
-		chunkSize = reader.parent.chunkSize
-		ctx       = context.Background()
-	)
 	for {
 		msg, ok := <-reader.parent.workCh
 		if !ok {
 			break
 		}
 		sgl := msg.sgl
-		res := core.T.Backend(a.Lom.Bck()).GetObjReader(ctx, a.Lom, msg.roff, chunkSize)
+		ecode, err := reader.chunk(sgl, msg.roff)
 		if reader.parent.IsAborted() {
 			break
 		}
-		if res.ErrCode == http.StatusRequestedRangeNotSatisfiable {
-			debug.Assert(res.Size == 0)
+		if ecode == http.StatusRequestedRangeNotSatisfiable && err == nil {
+			debug.Assert(sgl.Size() == 0)
 			reader.parent.doneCh <- chunkDone{nil, sgl, msg.roff, http.StatusRequestedRangeNotSatisfiable}
 			break
 		}
-		if err = res.Err; err == nil {
-			written, err = io.Copy(sgl, res.R)
-		}
 		if err != nil {
-			reader.parent.doneCh <- chunkDone{err, sgl, msg.roff, res.ErrCode}
+			reader.parent.doneCh <- chunkDone{err, sgl, msg.roff, ecode}
 			break
 		}
-		debug.Assert(res.Size == written, res.Size, " ", written)
-		debug.Assert(sgl.Size() == written, sgl.Size(), " ", written)
 		debug.Assert(sgl.Size() == sgl.Len(), sgl.Size(), " ", sgl.Len())
-
-		reader.parent.doneCh <- chunkDone{nil, sgl, msg.roff, res.ErrCode}
+		reader.parent.doneCh <- chunkDone{nil, sgl, msg.roff, ecode}
 	}
 	reader.parent.wg.Done()
 }
 
+// read the entire chunk at a given offset; retry (and resume) upon failure
+func (reader *blobReader) chunk(sgl *memsys.SGL, roff int64) (ecode int, err error) {
+	var (
+		r    = reader.parent
+		size = min(r.chunkSize, r.fullSize-roff) // expected
+	)
+	for retry := 0; ; retry++ {
+		have := sgl.Size()
+		ecode, err = reader.read(sgl, roff+have, size-have)
+		if err == nil {
+			if sgl.Size() == size {
+				return ecode, nil
+			}
+			err = fmt.Errorf("short read at offset %d: expected %d, got %d: %w", roff, size, sgl.Size(), io.ErrUnexpectedEOF)
+		} else if ecode == http.StatusRequestedRangeNotSatisfiable && have == 0 {
+			return ecode, nil // (handled by the caller)
+		}
+		if retry >= r.maxRetries || r.IsAborted() || !reader.retriable(err, ecode) {
+			return ecode, err
+		}
+		r.chunks.retried.Inc()
+		delay := min(retryDelay<<retry, maxRetryDelay)
+		if cmn.Rom.FastV(4, cos.SmoduleXs) {
+			nlog.Infoln(r.Name(), "retrying chunk at offset", roff, "resuming at", roff+sgl.Size(), "in", delay, "[", err, "]")
+		}
+		select {
+		case <-time.After(delay):
+		case <-r.ChanAbort():
+			return 0, cmn.ErrXactUserAbort
+		}
+	}
+}
+
+func (reader *blobReader) read(sgl *memsys.SGL, roff, length int64) (int, error) {
+	var (
+		a           = reader.parent.args
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	reader.stalled.Store(false)
+	reader.tprog.Store(mono.NanoTime())
+	reader.mu.Lock()
+	reader.cancel = cancel
+	reader.mu.Unlock()
+
+	res := core.T.Backend(a.Lom.Bck()).GetObjReader(ctx, a.Lom, roff, length)
+	err := res.Err
+	if err == nil {
+		_, err = io.Copy(sgl, &progReader{r: res.R, reader: reader}) // using sgl.ReadFrom
+		cos.Close(res.R)
+	}
+
+	reader.tprog.Store(0)
+	reader.mu.Lock()
+	reader.cancel = nil
+	reader.mu.Unlock()
+	cancel()
+	return res.ErrCode, err
+}
+
+func (reader *blobReader) retriable(err error, ecode int) bool {
+	if reader.stalled.Load() {
+		return true // canceled via checkStalled
+	}
+	switch {
+	case ecode == http.StatusRequestTimeout || ecode == http.StatusTooManyRequests:
+		return true
+	case ecode >= http.StatusInternalServerError:
+		return true
+	case ecode >= http.StatusBadRequest:
+		return false
+	}
+	return !cmn.IsErrObjNought(err)
+}
+
+func (pr *progReader) Read(b []byte) (n int, err error) {
+	n, err = pr.r.Read(b)
+	if n > 0 {
+		pr.reader.tprog.Store(mono.NanoTime())
+	}
+	return n, err
+}
+
 func (r *XactBlobDl) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
 
 	// HACK shortcut to support progress bar
 	snap.Stats.InBytes = r.fullSize
+
+	ext := &ExtBlobDlStats{
+		ChunkSize:  r.chunkSize,
+		NumWorkers: r.numWorkers,
+		DoneChunks: r.chunks.done.Load(),
+		Retries:    r.chunks.retried.Load(),
+		Stalls:     r.chunks.stalled.Load(),
+	}
+	if r.chunkSize > 0 {
+		ext.NumChunks = (r.fullSize + r.chunkSize - 1) / r.chunkSize
+	}
+	snap.Ext = ext
 	return
 }
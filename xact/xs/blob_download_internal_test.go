@@ -0,0 +1,214 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/core/mock"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tools/tassert"
+	"github.com/NVIDIA/aistore/tools/trand"
+	"github.com/NVIDIA/aistore/xact"
+)
+
+// injected (once) into GetObjReader at a given offset
+const (
+	faultShort = "short" // return half of the requested range (and no error)
+	faultStall = "stall" // block until canceled
+	fault500   = "500"   // retriable
+	fault404   = "404"   // not retriable
+)
+
+const (
+	blobChunkSize = memsys.MaxPageSlabSize
+	blobNumChunks = 4
+)
+
+type (
+	blobBackend struct {
+		core.Backend // (only GetObjReader is implemented)
+		faults       map[int64][]string
+		data         []byte
+		offs         []int64 // requested offsets, in order
+		mu           sync.Mutex
+	}
+	stallReader struct {
+		ctx context.Context
+	}
+	blobTarget struct {
+		*mock.TargetMock
+		bp    core.Backend
+		cksum *cos.Cksum // as finalized
+	}
+)
+
+func (t *blobTarget) Backend(*meta.Bck) core.Backend { return t.bp }
+
+// (the LOM is freed upon completion - record its checksum)
+func (t *blobTarget) FinalizeObj(lom *core.LOM, _ string, _ core.Xact, _ cmn.OWT) (int, error) {
+	t.cksum = lom.Checksum().Clone()
+	return 0, nil
+}
+
+func (be *blobBackend) GetObjReader(ctx context.Context, _ *core.LOM, off, length int64) (res core.GetReaderResult) {
+	var fault string
+	be.mu.Lock()
+	be.offs = append(be.offs, off)
+	if faults := be.faults[off]; len(faults) > 0 {
+		fault, be.faults[off] = faults[0], faults[1:]
+	}
+	be.mu.Unlock()
+
+	switch fault {
+	case faultShort:
+		res.R = io.NopCloser(bytes.NewReader(be.data[off : off+length/2]))
+	case faultStall:
+		res.R = io.NopCloser(&stallReader{ctx})
+	case fault500:
+		res.Err, res.ErrCode = errors.New("internal server error"), http.StatusInternalServerError
+	case fault404:
+		res.Err, res.ErrCode = cos.NewErrNotFound(nil, "blob"), http.StatusNotFound
+	default:
+		res.R = io.NopCloser(bytes.NewReader(be.data[off : off+length]))
+	}
+	res.Size = length
+	return res
+}
+
+func (be *blobBackend) requested(off int64) bool {
+	be.mu.Lock()
+	defer be.mu.Unlock()
+	return slices.Contains(be.offs, off)
+}
+
+func (sr *stallReader) Read([]byte) (int, error) {
+	<-sr.ctx.Done()
+	return 0, sr.ctx.Err()
+}
+
+func newBlobTest(t *testing.T) (be *blobBackend, bck *meta.Bck, bt *blobTarget) {
+	var (
+		bmd   = mock.NewBaseBownerMock()
+		tMock = mock.NewTarget(bmd)
+		mpath = t.TempDir()
+	)
+	bck = meta.NewBck("blob", apc.GCP, cmn.NsGlobal, &cmn.Bprops{Cksum: cmn.CksumConf{Type: cos.ChecksumXXHash}})
+	bmd.Add(bck)
+	be = &blobBackend{data: []byte(trand.String(blobNumChunks * blobChunkSize)), faults: make(map[int64][]string)}
+	bt = &blobTarget{TargetMock: tMock, bp: be}
+	core.T = bt
+
+	fs.TestNew(mock.NewIOS())
+	_, err := fs.Add(mpath, tMock.SID())
+	tassert.CheckFatal(t, err)
+	t.Cleanup(func() { fs.Remove(mpath) })
+	fs.CSM.Reg(fs.ObjectType, &fs.ObjectContentResolver{}, true)
+	fs.CSM.Reg(fs.WorkfileType, &fs.WorkfileContentResolver{}, true)
+	for _, mi := range fs.GetAvail() {
+		tassert.CheckFatal(t, cos.CreateDir(mi.MakePathBck(bck.Bucket())))
+	}
+
+	cos.InitShortID(0)
+	if xact.IncFinished == nil {
+		xact.IncFinished = func() {}
+	}
+	return be, bck, bt
+}
+
+// run blob download to completion; returns the resulting error and the content written
+func runBlobDl(t *testing.T, be *blobBackend, bck *meta.Bck, expCksum *cos.Cksum) (*XactBlobDl, []byte, error) {
+	lom := core.AllocLOM("obj")
+	tassert.CheckFatal(t, lom.InitBck(bck.Bucket()))
+	wfqn := fs.CSM.Gen(lom, fs.WorkfileType, "blob-dl")
+	lmfh, err := lom.CreateWork(wfqn)
+	tassert.CheckFatal(t, err)
+
+	r := &XactBlobDl{
+		args:       &core.BlobParams{Lom: lom, Lmfh: lmfh, Wfqn: wfqn, Msg: &apc.BlobMsg{}},
+		expCksum:   expCksum,
+		chunkSize:  blobChunkSize,
+		fullSize:   int64(len(be.data)),
+		numWorkers: 2,
+		maxRetries: 3,
+		stallTime:  200 * time.Millisecond,
+	}
+	// (compare with blobFactory.Start)
+	r.InitBase(cos.GenUUID(), apc.ActBlobDl, lom.Bck())
+	r.init(memsys.PageMM(), blobChunkSize, memsys.MaxPageSlabSize)
+
+	r.Run(nil)
+
+	// (not renamed: FinalizeObj is mocked)
+	written, _ := os.ReadFile(wfqn)
+	os.Remove(wfqn)
+	return r, written, r.AbortErr()
+}
+
+func cksumOf(ty string, data []byte) *cos.Cksum {
+	ck := cos.NewCksumHash(ty)
+	ck.H.Write(data)
+	ck.Finalize()
+	return ck.Clone()
+}
+
+// per-chunk retry (short read, stall, 5xx) resuming at the last read offset
+func TestBlobDlRetry(t *testing.T) {
+	be, bck, bt := newBlobTest(t)
+	be.faults[0] = []string{faultShort}
+	be.faults[blobChunkSize] = []string{faultStall}
+	be.faults[2*blobChunkSize] = []string{fault500, fault500}
+
+	// (validating MD5 while computing xxhash)
+	r, written, err := runBlobDl(t, be, bck, cksumOf(cos.ChecksumMD5, be.data))
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(written, be.data), "content mismatch (%d vs %d bytes)", len(written), len(be.data))
+
+	tassert.Errorf(t, r.chunks.retried.Load() == 4, "expected 4 retries, got %d", r.chunks.retried.Load())
+	tassert.Errorf(t, r.chunks.stalled.Load() == 1, "expected 1 stall, got %d", r.chunks.stalled.Load())
+	tassert.Errorf(t, r.chunks.done.Load() == blobNumChunks, "expected %d chunks, got %d", blobNumChunks, r.chunks.done.Load())
+
+	// short read: resumed at the offset where it stopped
+	tassert.Errorf(t, be.requested(blobChunkSize/2), "expected resume offset %d, got %v", blobChunkSize/2, be.offs)
+
+	tassert.Errorf(t, bt.cksum.Equal(cksumOf(cos.ChecksumXXHash, be.data)), "unexpected checksum %s", bt.cksum)
+}
+
+func TestBlobDlErrors(t *testing.T) {
+	be, bck, _ := newBlobTest(t)
+
+	// checksum mismatch
+	_, _, err := runBlobDl(t, be, bck, cos.NewCksum(cos.ChecksumXXHash, "0123456789abcdef"))
+	tassert.Fatalf(t, cos.IsErrBadCksum(err), "expected bad checksum, got %v", err)
+
+	// not retriable
+	be.faults[blobChunkSize] = []string{fault404}
+	r, _, err := runBlobDl(t, be, bck, nil)
+	var errNotFound *cos.ErrNotFound
+	tassert.Fatalf(t, errors.As(err, &errNotFound), "expected not-found, got %v", err)
+	tassert.Errorf(t, r.chunks.retried.Load() == 0, "expected no retries, got %d", r.chunks.retried.Load())
+
+	// out of retries
+	be.faults[0] = []string{fault500, fault500, fault500, fault500}
+	r, _, err = runBlobDl(t, be, bck, nil)
+	tassert.Fatalf(t, err != nil, "expected failure")
+	tassert.Errorf(t, r.chunks.retried.Load() == 3, "expected 3 retries, got %d", r.chunks.retried.Load())
+}
@@ -40,4 +40,6 @@ func Xreg(xeleOnly bool) {
 	xreg.RegBckXact(&lsoFactory{streamingF: streamingF{kind: apc.ActList}})
 
 	xreg.RegBckXact(&blobFactory{})
+
+	xreg.RegNonBckXact(&lcyFactory{})
 }
@@ -0,0 +1,191 @@
+// Package xs is a collection of eXtended actions (xactions), including multi-object
+// operations, list-objects, (cluster) rebalance and (target) resilver, ETL, and more.
+/*
+ * Copyright (c) 2024, NVIDIA CORPORATION. All rights reserved.
+ */
+package xs
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/api/apc"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/cos"
+	"github.com/NVIDIA/aistore/cmn/nlog"
+	"github.com/NVIDIA/aistore/core"
+	"github.com/NVIDIA/aistore/core/meta"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xact"
+	"github.com/NVIDIA/aistore/xact/xreg"
+)
+
+// Lifecycle xaction enforces per-bucket lifecycle rules (see cmn.LifecycleConf):
+// - aborts incomplete multipart uploads that were initiated more than N days ago;
+// - walks the buckets (via mountpath joggers) and removes objects created more than N days ago.
+// Objects in remote buckets get evicted, i.e., only their in-cluster copies are removed.
+//
+// The xaction runs periodically on each target (see target's housekeeping) and can be
+// started on demand via api.StartXaction.
+
+type (
+	lcyFactory struct {
+		xreg.RenewBase
+		xctn *XactLcy
+	}
+	XactLcy struct {
+		rules map[string]cmn.LifecycleRules // bucket cname => rules
+		bcks  []cmn.Bck                     // optional: run only these buckets
+		now   time.Time
+		xact.Base
+	}
+)
+
+// aborts incomplete multipart uploads initiated more than `age` ago and returns their number;
+// is set by the target at startup (see ais/s3)
+var AbortStaleMpt func(bck *cmn.Bck, prefix string, age time.Duration) int
+
+// interface guard
+var (
+	_ core.Xact      = (*XactLcy)(nil)
+	_ xreg.Renewable = (*lcyFactory)(nil)
+)
+
+////////////////
+// lcyFactory //
+////////////////
+
+func (*lcyFactory) New(args xreg.Args, _ *meta.Bck) xreg.Renewable {
+	return &lcyFactory{RenewBase: xreg.RenewBase{Args: args}}
+}
+
+func (p *lcyFactory) Start() error {
+	bcks, _ := p.Args.Custom.([]cmn.Bck)
+	p.xctn = &XactLcy{bcks: bcks, rules: make(map[string]cmn.LifecycleRules, 4)}
+	p.xctn.InitBase(p.UUID(), apc.ActLifecycle, nil)
+	return nil
+}
+
+func (*lcyFactory) Kind() string     { return apc.ActLifecycle }
+func (p *lcyFactory) Get() core.Xact { return p.xctn }
+
+func (*lcyFactory) WhenPrevIsRunning(prevEntry xreg.Renewable) (xreg.WPR, error) {
+	return xreg.WprUse, cmn.NewErrXactUsePrev(prevEntry.Get().String())
+}
+
+/////////////
+// XactLcy //
+/////////////
+
+func (r *XactLcy) Run(wg *sync.WaitGroup) {
+	if wg != nil {
+		wg.Done()
+	}
+	nlog.Infoln(r.Name())
+
+	r.now = time.Now()
+	bcks := r.prepare()
+	if len(bcks) == 0 {
+		r.Finish()
+		return
+	}
+	mpopts := &mpather.JgroupOpts{
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Buckets:  bcks,
+		Throttle: true,
+	}
+	jg := mpather.NewJoggerGroup(mpopts, cmn.GCO.Get(), "")
+	jg.Run()
+	select {
+	case <-r.ChanAbort():
+		jg.Stop()
+	case <-jg.ListenFinished():
+		if err := jg.Stop(); err != nil {
+			r.AddErr(err)
+		}
+	}
+	r.Finish()
+}
+
+// abort stale multipart uploads and return the buckets that have expiration rules
+func (r *XactLcy) prepare() (bcks []cmn.Bck) {
+	bmd := core.T.Bowner().Get()
+	bmd.Range(nil, nil, func(bck *meta.Bck) bool {
+		if !bck.Props.Lifecycle.IsActive() || !r.selected(bck) {
+			return false
+		}
+		var expire bool
+		for i := range bck.Props.Lifecycle.Rules {
+			rule := &bck.Props.Lifecycle.Rules[i]
+			if rule.Disabled {
+				continue
+			}
+			if rule.AbortMptDays > 0 && AbortStaleMpt != nil {
+				if n := AbortStaleMpt(bck.Bucket(), rule.Prefix, days(rule.AbortMptDays)); n > 0 {
+					nlog.Infoln(r.Name(), bck.String(), "aborted", n, "incomplete multipart upload(s)")
+				}
+			}
+			expire = expire || rule.ExpirationDays > 0
+		}
+		if expire {
+			r.rules[bck.Cname("")] = bck.Props.Lifecycle.Rules
+			bcks = append(bcks, *bck.Bucket())
+		}
+		return r.IsAborted()
+	})
+	return bcks
+}
+
+func (r *XactLcy) selected(bck *meta.Bck) bool {
+	if len(r.bcks) == 0 {
+		return true
+	}
+	for i := range r.bcks {
+		if bck.Equal((*meta.Bck)(&r.bcks[i]), false /*same BID*/, true /*same backend*/) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *XactLcy) visitObj(lom *core.LOM, _ []byte) error {
+	var (
+		md    = lom.GetCustomMD()
+		rules = r.rules[lom.Bck().Cname("")]
+	)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Disabled || rule.ExpirationDays == 0 || !rule.Match(lom.ObjName, md) {
+			continue
+		}
+		_, _, mtime, err := lom.Fstat(false /*get atime*/)
+		if err != nil {
+			return nil // (removed or renamed in the meantime)
+		}
+		if r.now.Sub(mtime) <= days(rule.ExpirationDays) {
+			continue
+		}
+		size := lom.Lsize()
+		ecode, err := core.T.DeleteObject(lom, lom.Bck().IsRemote() /*evict*/)
+		if err == nil {
+			r.ObjsAdd(1, size)
+		} else if !cos.IsNotExist(err, ecode) {
+			r.AddErr(err)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (r *XactLcy) Snap() (snap *core.Snap) {
+	snap = &core.Snap{}
+	r.ToSnap(snap)
+
+	snap.IdleX = r.IsIdle()
+	return
+}
+
+func days(n int) time.Duration { return time.Duration(n) * 24 * time.Hour }
@@ -6,6 +6,7 @@
 package xs
 
 import (
+	"context"
 	"sync"
 
 	"github.com/NVIDIA/aistore/api/apc"
@@ -15,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/core"
 	"github.com/NVIDIA/aistore/core/meta"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xact/xreg"
 )
 
@@ -46,6 +48,7 @@ type (
 	lrxact interface {
 		IsAborted() bool
 		Finished() bool
+		TraceCtx() context.Context
 	}
 
 	// running concurrency
@@ -60,6 +63,7 @@ type (
 	// common multi-object operation context and list|range|prefix logic
 	lriterator struct {
 		parent lrxact
+		tctx   context.Context // when traced: parent xaction's span (see _do)
 		msg    *apc.ListRange
 		bck    *meta.Bck
 		pt     *cos.ParsedTemplate
@@ -103,6 +107,9 @@ func (r *lriterator) init(xctn lrxact, msg *apc.ListRange, bck *meta.Bck, blocki
 	r.parent = xctn
 	r.msg = msg
 	r.bck = bck
+	if tctx := xctn.TraceCtx(); tracing.SpanFromContext(tctx) != nil {
+		r.tctx = tctx
+	}
 
 	// list is the simplest and always single-threaded
 	if msg.IsList() {
@@ -304,13 +311,25 @@ func (r *lriterator) do(lom *core.LOM, wi lrwi, smap *meta.Smap) (bool /*this lo
 	}
 
 	if r.workers == nil {
-		wi.do(lom, r)
+		r._do(lom, wi)
 		return true, nil
 	}
 	r.workCh <- lrpair{lom, wi} // lom eventually freed below
 	return false, nil
 }
 
+// do the work item (when the xaction is traced, with its own child span)
+func (r *lriterator) _do(lom *core.LOM, wi lrwi) {
+	if r.tctx == nil {
+		wi.do(lom, r)
+		return
+	}
+	_, span := tracing.Start(r.tctx, "xaction.work", tracing.KindInternal)
+	span.SetAttr("ais.object", lom.Cname())
+	wi.do(lom, r)
+	span.End(nil)
+}
+
 //////////////
 // lrworker //
 //////////////
@@ -321,7 +340,7 @@ func (worker *lrworker) run() {
 		if !ok {
 			break
 		}
-		lrpair.wi.do(lrpair.lom, worker.lrit)
+		worker.lrit._do(lrpair.lom, lrpair.wi)
 		core.FreeLOM(lrpair.lom)
 		if worker.lrit.parent.IsAborted() {
 			break
@@ -559,6 +559,12 @@ func (r *LsoXact) shiftLastPage(token string) {
 
 func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
 	r.walk.wi = newWalkInfo(msg, r.LomAdd)
+	if msg.IsFlagSet(apc.LsDeleted) {
+		r.walkDeleted(msg)
+		close(r.walk.pageCh)
+		r.walk.wg.Done()
+		return
+	}
 	opts := &fs.WalkBckOpts{
 		WalkOpts: fs.WalkOpts{CTs: []string{fs.ObjectType}, Callback: r.cb, Prefix: msg.Prefix, Sorted: true},
 	}
@@ -573,6 +579,31 @@ func (r *LsoXact) doWalk(msg *apc.LsoMsg) {
 	r.walk.wg.Done()
 }
 
+// list soft-deleted objects: walk the trash across all mountpaths (see fs.WalkSoftDeleted)
+// and, same as with regular objects, feed the pages one entry at a time
+func (r *LsoXact) walkDeleted(msg *apc.LsoMsg) {
+	cb := func(ent *fs.SoftDelEnt) error {
+		if !r.walk.wi.match(ent.ObjName) || ent.ObjName <= msg.StartAfter {
+			return nil
+		}
+		e := &cmn.LsoEnt{
+			Name:  ent.ObjName,
+			Size:  ent.Size,
+			Atime: cos.FormatNanoTime(ent.Dtime, msg.TimeFormat),
+			Flags: apc.LocOK | apc.EntryIsDeleted,
+		}
+		select {
+		case r.walk.pageCh <- e:
+			return nil
+		case <-r.walk.stopCh.Listen():
+			return errStopped
+		}
+	}
+	if err := fs.WalkSoftDeleted(r.Bck().Bucket(), msg.Prefix, cb); err != nil && err != errStopped {
+		r.AddErr(err, 0)
+	}
+}
+
 func (r *LsoXact) validateCb(fqn string, de fs.DirEntry) error {
 	if !de.IsDir() {
 		return nil
@@ -625,7 +656,7 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 
 	// ls arch
 	// looking only at the file extension - not reading ("detecting") file magic (TODO: add lsmsg flag)
-	archList, err := archive.List(fqn)
+	archList, err := lsarch(fqn)
 	if err != nil {
 		if archive.IsErrUnknownFileExt(err) {
 			// skip and keep going
@@ -650,6 +681,25 @@ func (r *LsoXact) cb(fqn string, de fs.DirEntry) error {
 	return nil
 }
 
+// chunked objects are listed via their (content) reader
+func lsarch(fqn string) ([]*archive.Entry, error) {
+	lom := core.AllocLOM("")
+	defer core.FreeLOM(lom)
+	if err := lom.InitFQN(fqn, nil); err != nil {
+		return archive.List(fqn)
+	}
+	if err := lom.Load(true /*cache it*/, false /*locked*/); err != nil || !lom.IsChunked() {
+		return archive.List(fqn)
+	}
+	fh, err := lom.Open()
+	if err != nil {
+		return nil, err
+	}
+	lst, err := archive.ListReader(fh, lom.Lsize(), fqn)
+	cos.Close(fh)
+	return lst, err
+}
+
 func (r *LsoXact) Snap() (snap *core.Snap) {
 	snap = &core.Snap{}
 	r.ToSnap(snap)
@@ -6,7 +6,6 @@
 package xs
 
 import (
-	"context"
 	"fmt"
 	"strconv"
 	"sync"
@@ -163,7 +162,7 @@ func (r *prefetch) do(lom *core.LOM, lrit *lriterator) {
 	if r.msg.BlobThreshold > 0 && size >= r.msg.BlobThreshold && r.blob.num.Load() < maxNumBlobDls {
 		err = r.blobdl(lom, oa)
 	} else {
-		ecode, err = core.T.GetCold(context.Background(), lom, cmn.OwtGetPrefetchLock)
+		ecode, err = core.T.GetCold(r.TraceCtx(), lom, cmn.OwtGetPrefetchLock)
 		if err == nil { // done
 			r.ObjsAdd(1, lom.Lsize())
 		}